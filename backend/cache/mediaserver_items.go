@@ -12,15 +12,22 @@ import (
 var LibraryStore *MediaServerLibraryCache
 
 type MediaServerLibraryCache struct {
-	sections       map[string]*models.LibrarySection // Key: Library Title
+	sections       map[librarySectionKey]*models.LibrarySection // Key: Server Name + Library Title
 	mu             sync.RWMutex
 	LastFullUpdate int64
 }
 
+// librarySectionKey identifies a library section on a specific media server.
+// The primary media server uses an empty Server name.
+type librarySectionKey struct {
+	Server string
+	Title  string
+}
+
 // NewLibraryCache creates a new LibraryCache instance
 func Cache_NewLibraryCache() *MediaServerLibraryCache {
 	return &MediaServerLibraryCache{
-		sections:       make(map[string]*models.LibrarySection),
+		sections:       make(map[librarySectionKey]*models.LibrarySection),
 		LastFullUpdate: 0,
 	}
 }
@@ -37,9 +44,11 @@ func (c *MediaServerLibraryCache) UpdateSection(section *models.LibrarySection)
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
+	key := librarySectionKey{Server: section.Server, Title: section.Title}
+
 	// Check if section already exists
 	// If it does, we need to update it
-	if existing, exists := c.sections[section.Title]; exists {
+	if existing, exists := c.sections[key]; exists {
 		// Update metadata
 		existing.Type = section.Type
 		existing.ID = section.ID
@@ -68,17 +77,18 @@ func (c *MediaServerLibraryCache) UpdateSection(section *models.LibrarySection)
 		existing.TotalSize = len(existing.MediaItems)
 	} else {
 		// If section does not exist, add it to the cache
-		c.sections[section.Title] = section
+		c.sections[key] = section
 	}
 }
 
 // UpdateMediaItem updates a specific media item in a section
+// The section is looked up on the media server the item belongs to
 func (c *MediaServerLibraryCache) UpdateMediaItem(sectionTitle string, item *models.MediaItem) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
 	// Check if section exists
-	if section, exists := c.sections[sectionTitle]; exists {
+	if section, exists := c.sections[librarySectionKey{Server: item.Server, Title: sectionTitle}]; exists {
 		// Create a map of existing items for O(1) lookup
 		existingItems := make(map[string]*models.MediaItem)
 		for i := range section.MediaItems {
@@ -100,7 +110,7 @@ func (c *MediaServerLibraryCache) UpdateMediaItemDBSavedSets(sectionTitle string
 	defer c.mu.Unlock()
 
 	// Check if section exists
-	if section, exists := c.sections[sectionTitle]; exists {
+	if section, exists := c.sections[librarySectionKey{Server: item.Server, Title: sectionTitle}]; exists {
 		// Create a map of existing items for O(1) lookup
 		existingItems := make(map[string]*models.MediaItem)
 		for i := range section.MediaItems {
@@ -113,19 +123,30 @@ func (c *MediaServerLibraryCache) UpdateMediaItemDBSavedSets(sectionTitle string
 	}
 }
 
-// GetSectionByTitle retrieves a section by Title
+// GetSectionByTitle retrieves a section by Title from the primary media server
 func (c *MediaServerLibraryCache) GetSectionByTitle(title string) (*models.LibrarySection, bool) {
+	return c.GetServerSectionByTitle("", title)
+}
+
+// GetServerSectionByTitle retrieves a section by Title from a specific media server
+func (c *MediaServerLibraryCache) GetServerSectionByTitle(server, title string) (*models.LibrarySection, bool) {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
-	section, exists := c.sections[title]
+	section, exists := c.sections[librarySectionKey{Server: server, Title: title}]
 	return section, exists
 }
 
+// GetRatingKeyByTMDBID retrieves the RatingKey of a media item by TMDB ID from a section of the primary media server
 func (c *MediaServerLibraryCache) GetRatingKeyByTMDBID(libraryTitle, tmdbID string) (string, bool) {
+	return c.GetServerRatingKeyByTMDBID("", libraryTitle, tmdbID)
+}
+
+// GetServerRatingKeyByTMDBID retrieves the RatingKey of a media item by TMDB ID from a section of a specific media server
+func (c *MediaServerLibraryCache) GetServerRatingKeyByTMDBID(server, libraryTitle, tmdbID string) (string, bool) {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
-	section, exists := c.sections[libraryTitle]
+	section, exists := c.sections[librarySectionKey{Server: server, Title: libraryTitle}]
 	if !exists {
 		return "", false
 	}
@@ -139,13 +160,21 @@ func (c *MediaServerLibraryCache) GetRatingKeyByTMDBID(libraryTitle, tmdbID stri
 	return "", false
 }
 
-// GetAllSectionsSortedByTitle returns all sections sorted by Title
+// GetAllSectionsSortedByTitle returns all sections of the primary media server sorted by Title
 func (c *MediaServerLibraryCache) GetAllSectionsSortedByTitle() []*models.LibrarySection {
+	return c.GetAllServerSectionsSortedByTitle("")
+}
+
+// GetAllServerSectionsSortedByTitle returns all sections of a specific media server sorted by Title
+func (c *MediaServerLibraryCache) GetAllServerSectionsSortedByTitle(server string) []*models.LibrarySection {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
 	sections := make([]*models.LibrarySection, 0, len(c.sections))
-	for _, section := range c.sections {
+	for key, section := range c.sections {
+		if key.Server != server {
+			continue
+		}
 		sections = append(sections, section)
 	}
 
@@ -156,26 +185,31 @@ func (c *MediaServerLibraryCache) GetAllSectionsSortedByTitle() []*models.Librar
 	return sections
 }
 
-// RemoveSectionByTitle removes a section from the cache by Title
+// RemoveSectionByTitle removes a section of the primary media server from the cache by Title
 func (c *MediaServerLibraryCache) RemoveSectionByTitle(title string) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	delete(c.sections, title)
+	delete(c.sections, librarySectionKey{Title: title})
 }
 
 // ClearAllSections removes all sections from the cache
 func (c *MediaServerLibraryCache) ClearAllSections() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	c.sections = make(map[string]*models.LibrarySection)
+	c.sections = make(map[librarySectionKey]*models.LibrarySection)
 }
 
-// GetMediaItemFromSectionByTMDBID retrieves a media item by TMDB ID from a specific section
+// GetMediaItemFromSectionByTMDBID retrieves a media item by TMDB ID from a specific section of the primary media server
 func (c *MediaServerLibraryCache) GetMediaItemFromSectionByTMDBID(sectionTitle, tmdbID string) (*models.MediaItem, bool) {
+	return c.GetMediaItemFromServerSectionByTMDBID("", sectionTitle, tmdbID)
+}
+
+// GetMediaItemFromServerSectionByTMDBID retrieves a media item by TMDB ID from a specific section of a specific media server
+func (c *MediaServerLibraryCache) GetMediaItemFromServerSectionByTMDBID(server, sectionTitle, tmdbID string) (*models.MediaItem, bool) {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
-	section, exists := c.sections[sectionTitle]
+	section, exists := c.sections[librarySectionKey{Server: server, Title: sectionTitle}]
 	if !exists {
 		return &models.MediaItem{}, false
 	}
@@ -194,11 +228,21 @@ func (c *MediaServerLibraryCache) GetMediaItemFromSectionByTMDBID(sectionTitle,
 	return &models.MediaItem{}, false
 }
 
+// GetMediaItemByRatingKey retrieves a media item by RatingKey from the primary media server
 func (c *MediaServerLibraryCache) GetMediaItemByRatingKey(ratingKey string) (*models.MediaItem, bool) {
+	return c.GetServerMediaItemByRatingKey("", ratingKey)
+}
+
+// GetServerMediaItemByRatingKey retrieves a media item by RatingKey from a specific media server
+// RatingKeys are only unique per media server, so the other media servers are not searched
+func (c *MediaServerLibraryCache) GetServerMediaItemByRatingKey(server, ratingKey string) (*models.MediaItem, bool) {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
-	for _, section := range c.sections {
+	for key, section := range c.sections {
+		if key.Server != server {
+			continue
+		}
 		for _, item := range section.MediaItems {
 			if item.RatingKey == ratingKey {
 				return &item, true
@@ -209,12 +253,17 @@ func (c *MediaServerLibraryCache) GetMediaItemByRatingKey(ratingKey string) (*mo
 	return &models.MediaItem{}, false
 }
 
-// GetMediaItemFromSectionByTitleAndYear retrieves the TMDB ID from a media item by its title and year
+// GetMediaItemFromSectionByTitleAndYear retrieves a media item by its title and year from a section of the primary media server
 func (c *MediaServerLibraryCache) GetMediaItemFromSectionByTitleAndYear(sectionTitle, itemTitle string, year int) (*models.MediaItem, bool) {
+	return c.GetMediaItemFromServerSectionByTitleAndYear("", sectionTitle, itemTitle, year)
+}
+
+// GetMediaItemFromServerSectionByTitleAndYear retrieves a media item by its title and year from a section of a specific media server
+func (c *MediaServerLibraryCache) GetMediaItemFromServerSectionByTitleAndYear(server, sectionTitle, itemTitle string, year int) (*models.MediaItem, bool) {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
-	section, exists := c.sections[sectionTitle]
+	section, exists := c.sections[librarySectionKey{Server: server, Title: sectionTitle}]
 	if !exists {
 		return &models.MediaItem{}, false
 	}
@@ -230,6 +279,34 @@ func (c *MediaServerLibraryCache) GetMediaItemFromSectionByTitleAndYear(sectionT
 	return &models.MediaItem{}, false
 }
 
+// GetMediaItemsOnOtherServersByTMDBID retrieves all media items with the given TMDB ID and type
+// from every media server except the one given
+func (c *MediaServerLibraryCache) GetMediaItemsOnOtherServersByTMDBID(excludeServer, tmdbID, itemType string) []models.MediaItem {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var items []models.MediaItem
+	for key, section := range c.sections {
+		if key.Server == excludeServer {
+			continue
+		}
+		for _, item := range section.MediaItems {
+			if item.TMDB_ID == tmdbID && item.Type == itemType {
+				items = append(items, item)
+			}
+		}
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].Server != items[j].Server {
+			return items[i].Server < items[j].Server
+		}
+		return items[i].LibraryTitle < items[j].LibraryTitle
+	})
+
+	return items
+}
+
 func (c *MediaServerLibraryCache) GetSectionsCount() int {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
@@ -25,17 +25,24 @@ var (
 )
 
 type Config struct {
-	Auth          Config_Auth              `json:"auth" yaml:"Auth,omitempty"`                     // Authentication settings.
-	Logging       Config_Logging           `json:"logging" yaml:"Logging,omitempty"`               // Logging configuration settings.
-	MediaServer   Config_MediaServer       `json:"media_server" yaml:"MediaServer,omitempty"`      // Media server integration settings.
-	Mediux        Config_Mediux            `json:"mediux" yaml:"Mediux,omitempty"`                 // MediUX integration settings.
-	AutoDownload  Config_AutoDownload      `json:"auto_download" yaml:"AutoDownload,omitempty"`    // Auto-download settings.
-	Images        Config_Images            `json:"images" yaml:"Images,omitempty"`                 // Image settings.
-	TMDB          Config_TMDB              `json:"tmdb" yaml:"TMDB,omitempty"`                     // TMDB (The Movie Database) integration settings.
-	LabelsAndTags Config_LabelsAndTags     `json:"labels_and_tags" yaml:"LabelsAndTags,omitempty"` // Labels and tags settings.
-	Notifications Config_Notifications     `json:"notifications" yaml:"Notifications,omitempty"`   // Notification settings.
-	SonarrRadarr  Config_SonarrRadarr_Apps `json:"sonarr_radarr" yaml:"SonarrRadarr,omitempty"`    // List of Sonarr/Radarr instances to integrate with.
-	Database      Config_Database          `json:"database" yaml:"Database,omitempty"`             // Database configuration settings.
+	Auth                   Config_Auth               `json:"auth" yaml:"Auth,omitempty"`                                                 // Authentication settings.
+	Logging                Config_Logging            `json:"logging" yaml:"Logging,omitempty"`                                           // Logging configuration settings.
+	MediaServer            Config_MediaServer        `json:"media_server" yaml:"MediaServer,omitempty"`                                  // Media server integration settings.
+	AdditionalMediaServers []Config_MediaServer      `json:"additional_media_servers,omitempty" yaml:"AdditionalMediaServers,omitempty"` // Additional named media servers. Saved sets are applied to every server holding the same TMDB ID.
+	Mediux                 Config_Mediux             `json:"mediux" yaml:"Mediux,omitempty"`                                             // MediUX integration settings.
+	AutoDownload           Config_AutoDownload       `json:"auto_download" yaml:"AutoDownload,omitempty"`                                // Auto-download settings.
+	DownloadQueue          Config_DownloadQueue      `json:"download_queue" yaml:"DownloadQueue,omitempty"`                              // Download queue settings.
+	SubscribedCreators     Config_SubscribedCreators `json:"subscribed_creators" yaml:"SubscribedCreators,omitempty"`                    // Settings for picking up new sets from followed MediUX creators.
+	AutoSelect             Config_AutoSelect         `json:"auto_select" yaml:"AutoSelect,omitempty"`                                    // Rules for picking a set for new library items.
+	CoverageReport         Config_CoverageReport     `json:"coverage_report" yaml:"CoverageReport,omitempty"`                            // Settings for the scheduled library coverage report.
+	DriftDetection         Config_DriftDetection     `json:"drift_detection" yaml:"DriftDetection,omitempty"`                            // Settings for checking that applied images are still on the media server.
+	Kometa                 Config_Kometa             `json:"kometa" yaml:"Kometa,omitempty"`                                             // Settings for exporting the saved sets for Kometa.
+	Images                 Config_Images             `json:"images" yaml:"Images,omitempty"`                                             // Image settings.
+	TMDB                   Config_TMDB               `json:"tmdb" yaml:"TMDB,omitempty"`                                                 // TMDB (The Movie Database) integration settings.
+	LabelsAndTags          Config_LabelsAndTags      `json:"labels_and_tags" yaml:"LabelsAndTags,omitempty"`                             // Labels and tags settings.
+	Notifications          Config_Notifications      `json:"notifications" yaml:"Notifications,omitempty"`                               // Notification settings.
+	SonarrRadarr           Config_SonarrRadarr_Apps  `json:"sonarr_radarr" yaml:"SonarrRadarr,omitempty"`                                // List of Sonarr/Radarr instances to integrate with.
+	Database               Config_Database           `json:"database" yaml:"Database,omitempty"`                                         // Database configuration settings.
 }
 
 type Config_Dev struct {
@@ -53,48 +60,135 @@ type Config_Logging struct {
 }
 
 type Config_MediaServer struct {
-	Type                         string                  `json:"type" yaml:"Type"`                                                      // Type of media server (e.g., plex, emby, jellyfin).
-	URL                          string                  `json:"url" yaml:"URL"`                                                        // Base URL of the media server. This is either the IP:Port or the domain name (e.g., plex.domain.com).
-	ApiToken                     string                  `json:"api_token" yaml:"ApiToken"`                                             // Authentication token for accessing the media server.
-	Libraries                    []models.LibrarySection `json:"libraries,omitempty" yaml:"Libraries,omitempty"`                        // List of media server libraries to manage.
-	UserID                       string                  `json:"user_id,omitempty" yaml:"UserID,omitempty"`                             // User ID for accessing the media server. This is used for Emby and Jellyfin servers.
-	EnableSortByEpisodeAddedDate bool                    `json:"enable_sort_by_episode_added_date" yaml:"EnableSortByEpisodeAddedDate"` // Whether to check episodes for added date when getting Media Items. This is only for Plex servers.
-	EnablePlexEventListener      bool                    `json:"enable_plex_event_listener" yaml:"EnablePlexEventListener"`             // Whether to enable the Plex event listener for reapplying images on refresh. Plex exclusive feature.
+	Name                            string                  `json:"name,omitempty" yaml:"Name,omitempty"`                                       // Name used to identify this media server when more than one is configured. Defaults to the Type.
+	Type                            string                  `json:"type" yaml:"Type"`                                                           // Type of media server (e.g., plex, emby, jellyfin).
+	URL                             string                  `json:"url" yaml:"URL"`                                                             // Base URL of the media server. This is either the IP:Port or the domain name (e.g., plex.domain.com).
+	ApiToken                        string                  `json:"api_token" yaml:"ApiToken"`                                                  // Authentication token for accessing the media server.
+	Libraries                       []models.LibrarySection `json:"libraries,omitempty" yaml:"Libraries,omitempty"`                             // List of media server libraries to manage.
+	UserID                          string                  `json:"user_id,omitempty" yaml:"UserID,omitempty"`                                  // User ID for accessing the media server. This is used for Emby and Jellyfin servers.
+	EnableSortByEpisodeAddedDate    bool                    `json:"enable_sort_by_episode_added_date" yaml:"EnableSortByEpisodeAddedDate"`      // Whether to check episodes for added date when getting Media Items. This is only for Plex servers.
+	EnablePlexEventListener         bool                    `json:"enable_plex_event_listener" yaml:"EnablePlexEventListener"`                  // Whether to enable the Plex event listener for reapplying images on refresh. Plex exclusive feature.
+	EnableEmbyJellyfinEventListener bool                    `json:"enable_emby_jellyfin_event_listener" yaml:"EnableEmbyJellyfinEventListener"` // Whether to enable the Emby/Jellyfin event listener for reapplying images that a refresh replaced. Emby and Jellyfin only.
+	EnablePlexWebhook               bool                    `json:"enable_plex_webhook" yaml:"EnablePlexWebhook"`                               // Whether to handle Plex Pass webhooks sent to /api/plex/webhook. Plex exclusive feature.
+	PlexWebhookToken                string                  `json:"plex_webhook_token,omitempty" yaml:"PlexWebhookToken,omitempty"`             // Optional secret that Plex webhooks must send as the token query parameter.
 }
 
 type Config_Mediux struct {
-	ApiToken        string `json:"api_token" yaml:"ApiToken"`               // Authentication token for accessing MediUX services.
-	DownloadQuality string `json:"download_quality" yaml:"DownloadQuality"` // Quality of the media to download from MediUX (Options: "original", "optimized") Defaults to "optimized".
+	ApiToken            string `json:"api_token" yaml:"ApiToken"`                        // Authentication token for accessing MediUX services.
+	DownloadQuality     string `json:"download_quality" yaml:"DownloadQuality"`          // Quality of the media to download from MediUX (Options: "original", "optimized") Defaults to "optimized".
+	EnableEventListener bool   `json:"enable_event_listener" yaml:"EnableEventListener"` // Whether to listen for MediUX set updates and run the AutoDownload check for the saved items that use them.
 }
 
 type Config_AutoDownload struct {
-	Enabled bool   `json:"enabled" yaml:"Enabled"`               // Whether auto-download is enabled.
-	Cron    string `json:"cron,omitempty" yaml:"Cron,omitempty"` // Cron expression for scheduling auto-downloads.
+	Enabled         bool   `json:"enabled" yaml:"Enabled"`                            // Whether auto-download is enabled.
+	Cron            string `json:"cron,omitempty" yaml:"Cron,omitempty"`              // Cron expression for scheduling auto-downloads.
+	DryRun          bool   `json:"dry_run" yaml:"DryRun,omitempty"`                   // Only log which images would be downloaded, without changing the media server or database.
+	RequireApproval bool   `json:"require_approval" yaml:"RequireApproval,omitempty"` // Stage the images that AutoDownload and the Sonarr/Radarr webhooks would apply until they are approved.
+}
+
+type Config_DownloadQueue struct {
+	MaxAttempts                 int `json:"max_attempts" yaml:"MaxAttempts,omitempty"`                                    // Maximum number of attempts for an entry that fails with a transient error (media server down, MediUX 5xx, timeouts). Defaults to 5.
+	RetryDelaySeconds           int `json:"retry_delay_seconds" yaml:"RetryDelaySeconds,omitempty"`                       // Delay before the first retry. The delay doubles after every attempt. Defaults to 60.
+	MaxRetryDelaySeconds        int `json:"max_retry_delay_seconds" yaml:"MaxRetryDelaySeconds,omitempty"`                // Upper limit for the retry delay. Defaults to 3600.
+	Workers                     int `json:"workers" yaml:"Workers,omitempty"`                                             // Number of queue entries processed at the same time. Defaults to 2.
+	ImageWorkers                int `json:"image_workers" yaml:"ImageWorkers,omitempty"`                                  // Number of images of a single entry applied at the same time. Defaults to 4.
+	MediuxRequestsPerSecond     int `json:"mediux_requests_per_second" yaml:"MediuxRequestsPerSecond,omitempty"`          // Maximum MediUX image downloads per second. -1 disables the limit. Defaults to 10.
+	MediaServerUploadsPerSecond int `json:"media_server_uploads_per_second" yaml:"MediaServerUploadsPerSecond,omitempty"` // Maximum image uploads per second to each media server. -1 disables the limit. Defaults to 5.
+}
+
+type Config_SubscribedCreators struct {
+	Enabled       bool                                   `json:"enabled" yaml:"Enabled"`                                    // Whether new sets from followed MediUX creators are picked up.
+	Cron          string                                 `json:"cron,omitempty" yaml:"Cron,omitempty"`                      // Cron expression for checking the followed creators. Defaults to every 6 hours.
+	Mode          string                                 `json:"mode,omitempty" yaml:"Mode,omitempty"`                      // "add" adds new sets to the download queue, "approval" waits for them to be approved. Defaults to "approval".
+	MaxSetAgeDays int                                    `json:"max_set_age_days,omitempty" yaml:"MaxSetAgeDays,omitempty"` // Only sets created within this many days are picked up. Defaults to 7.
+	SelectedTypes Config_SubscribedCreatorsSelectedTypes `json:"selected_types" yaml:"SelectedTypes,omitempty"`             // Image types selected for the sets that are added.
+	AutoDownload  bool                                   `json:"auto_download" yaml:"AutoDownload,omitempty"`               // Whether AutoDownload is turned on for the sets that are added.
+}
+
+type Config_SubscribedCreatorsSelectedTypes struct {
+	Poster              bool `json:"poster" yaml:"Poster"`
+	Backdrop            bool `json:"backdrop" yaml:"Backdrop"`
+	SeasonPoster        bool `json:"season_poster" yaml:"SeasonPoster"`
+	SpecialSeasonPoster bool `json:"special_season_poster" yaml:"SpecialSeasonPoster"`
+	Titlecard           bool `json:"titlecard" yaml:"Titlecard"`
+}
+
+type Config_AutoSelect struct {
+	Enabled bool                    `json:"enabled" yaml:"Enabled"`                 // Whether a set is picked automatically for new library items.
+	Rules   []Config_AutoSelectRule `json:"rules,omitempty" yaml:"Rules,omitempty"` // Rules per library. Libraries without a rule are left alone.
+}
+
+type Config_AutoSelectRule struct {
+	Library               string   `json:"library" yaml:"Library"`                                                   // Title of the library the rule applies to.
+	PreferredCreators     []string `json:"preferred_creators,omitempty" yaml:"PreferredCreators,omitempty"`          // MediUX usernames, in order of priority. Their sets win over more popular sets of other creators.
+	OnlyPreferredCreators bool     `json:"only_preferred_creators,omitempty" yaml:"OnlyPreferredCreators,omitempty"` // Only pick sets of the preferred creators.
+	MinPopularity         int      `json:"min_popularity,omitempty" yaml:"MinPopularity,omitempty"`                  // Minimum popularity of a set.
+	RequiredImageTypes    []string `json:"required_image_types,omitempty" yaml:"RequiredImageTypes,omitempty"`       // Image types a set must contain (poster, backdrop, season_poster, special_season_poster, titlecard).
+	Language              string   `json:"language,omitempty" yaml:"Language,omitempty"`                             // Only use images in this language (e.g. English). Images without a language are always used.
+}
+
+type Config_CoverageReport struct {
+	Enabled bool   `json:"enabled" yaml:"Enabled"`               // Whether the coverage report is built on a schedule and saved to the reports folder.
+	Cron    string `json:"cron,omitempty" yaml:"Cron,omitempty"` // Cron expression for building the coverage report. Defaults to every day at 03:00.
+}
+
+type Config_DriftDetection struct {
+	Enabled   bool   `json:"enabled" yaml:"Enabled"`                         // Whether the applied images are compared with the media server on a schedule.
+	Cron      string `json:"cron,omitempty" yaml:"Cron,omitempty"`           // Cron expression for the drift check. Defaults to every day at 04:00.
+	Mode      string `json:"mode,omitempty" yaml:"Mode,omitempty"`           // "flag" only reports drifted images, "reapply" applies the image of the saved set again. Defaults to "flag".
+	Threshold int    `json:"threshold,omitempty" yaml:"Threshold,omitempty"` // Number of differing perceptual hash bits (1-64) above which an image counts as drifted. Defaults to 10.
+}
+
+type Config_Kometa struct {
+	Enabled           bool   `json:"enabled" yaml:"Enabled"`                                          // Whether the saved sets are exported for Kometa. The export runs again shortly after a saved set changes.
+	AssetDirectory    string `json:"asset_directory,omitempty" yaml:"AssetDirectory,omitempty"`       // Folder the images are saved to in the Kometa asset layout, with a subfolder per library.
+	MetadataDirectory string `json:"metadata_directory,omitempty" yaml:"MetadataDirectory,omitempty"` // Folder a Kometa metadata file with the MediUX image URLs is written to for every library.
+	Cron              string `json:"cron,omitempty" yaml:"Cron,omitempty"`                            // Cron expression for a full export. Defaults to every day at 05:00.
 }
 
 type Config_Images struct {
 	CacheImages       Config_CacheImages       `json:"cache_images" yaml:"CacheImages"`              // Settings for caching images.
 	SaveImagesLocally Config_SaveImagesLocally `json:"save_images_locally" yaml:"SaveImagesLocally"` // Settings for saving images locally alongside content.
+	History           Config_ImageHistory      `json:"history" yaml:"History,omitempty"`             // Settings for keeping the replaced images of the media server.
 }
 
 type Config_CacheImages struct {
 	Enabled bool `json:"enabled" yaml:"Enabled"` // Whether to enable caching of images.
 }
 
+type Config_ImageHistory struct {
+	Enabled     bool `json:"enabled" yaml:"Enabled"`                    // Whether to keep a copy of the media server image before it is replaced.
+	MaxVersions int  `json:"max_versions" yaml:"MaxVersions,omitempty"` // Number of replaced versions kept per image. Older versions are removed.
+}
+
 type Config_SaveImagesLocally struct {
-	Enabled                 bool   `json:"enabled" yaml:"Enabled"`                                                       // Whether to save images next to their content.
-	Path                    string `json:"path,omitempty" yaml:"Path,omitempty"`                                         // By default, this is set to alongside the content. If set, this will override that behavior and save all images to this path.
-	EpisodeNamingConvention string `json:"episode_naming_convention,omitempty" yaml:"EpisodeNamingConvention,omitempty"` // Episode naming convention for the media server. Only needed for Plex. Will default to match
-	RunningOnWindows        bool   `json:"running_on_windows,omitempty" yaml:"RunningOnWindows,omitempty"`               // Whether the application is running on Windows. This affects path formatting.
+	Enabled                 bool                            `json:"enabled" yaml:"Enabled"`                                                       // Whether to save images next to their content.
+	Path                    string                          `json:"path,omitempty" yaml:"Path,omitempty"`                                         // By default, this is set to alongside the content. If set, this will override that behavior and save all images to this path.
+	EpisodeNamingConvention string                          `json:"episode_naming_convention,omitempty" yaml:"EpisodeNamingConvention,omitempty"` // Episode naming convention for the media server. Only needed for Plex. Will default to match
+	NamingProfile           string                          `json:"naming_profile,omitempty" yaml:"NamingProfile,omitempty"`                      // File naming of the saved images: "plex", "kodi", "emby" (Emby/Jellyfin) or "custom". Defaults to the naming of the media server type.
+	NamingTemplate          Config_LocalImageNamingTemplate `json:"naming_template,omitempty" yaml:"NamingTemplate,omitempty"`                    // File names used by the "custom" naming profile.
+	RunningOnWindows        bool                            `json:"running_on_windows,omitempty" yaml:"RunningOnWindows,omitempty"`               // Whether the application is running on Windows. This affects path formatting.
+}
+
+type Config_LocalImageNamingTemplate struct {
+	Poster              string `json:"poster,omitempty" yaml:"Poster,omitempty"`                             // File name of movie and show posters. Defaults to the Plex name.
+	Backdrop            string `json:"backdrop,omitempty" yaml:"Backdrop,omitempty"`                         // File name of movie and show backdrops. Defaults to the Plex name.
+	SeasonPoster        string `json:"season_poster,omitempty" yaml:"SeasonPoster,omitempty"`                // File name of season posters, relative to the show folder. Defaults to the Plex name.
+	SpecialSeasonPoster string `json:"special_season_poster,omitempty" yaml:"SpecialSeasonPoster,omitempty"` // File name of the specials season poster, relative to the show folder. Defaults to the Plex name.
+	Titlecard           string `json:"titlecard,omitempty" yaml:"Titlecard,omitempty"`                       // File name of titlecards, relative to the episode folder. Defaults to the Plex name.
 }
 
 type Config_TMDB struct {
-	ApiToken string `json:"-" yaml:"ApiToken"` // API token for accessing TMDB (The Movie Database) services.
+	ApiToken        string   `json:"-" yaml:"ApiToken"`                                          // API token for accessing TMDB (The Movie Database) services.
+	FallbackEnabled bool     `json:"fallback_enabled" yaml:"FallbackEnabled,omitempty"`          // Whether TMDB images are offered as sets for items that have no MediUX sets.
+	Languages       []string `json:"languages,omitempty" yaml:"Languages,omitempty"`             // ISO 639-1 codes of the poster languages to use, in order of preference. Defaults to ["en"].
+	MinVoteAverage  float64  `json:"min_vote_average,omitempty" yaml:"MinVoteAverage,omitempty"` // Images with a lower TMDB vote average are left out. Defaults to 0.
+	MaxSets         int      `json:"max_sets,omitempty" yaml:"MaxSets,omitempty"`                // Maximum number of TMDB sets offered per item. Defaults to 5.
 }
 
 type Config_LabelsAndTags struct {
 	Applications                           []Config_LabelsAndTagsProvider `json:"applications,omitempty" yaml:"Applications,omitempty"`
-	RemoveOverlayLabelOnlyOnPosterDownload bool                           `json:"remove_overlay_label_only_on_poster_download,omitempty" yaml:"RemoveOverlayLabelOnlyOnPosterDownload,omitempty"` // Whether to remove the "Overlay" label from media items after downloading a poster image. This is to allow Kometa to reprocess the image and apply the overlays. This is a Plex exclusive feature and should be set to true if you have "Overlay" in your "Remove" list for the Plex application under "LabelsAndTags".
+	RemoveOverlayLabelOnlyOnPosterDownload bool                           `json:"remove_overlay_label_only_on_poster_download,omitempty" yaml:"RemoveOverlayLabelOnlyOnPosterDownload,omitempty"` // Whether to remove the "Overlay" label from media items after downloading a poster image. This is to allow Kometa to reprocess the image and apply the overlays. This applies to Plex, Emby and Jellyfin and should be set to true if you have "Overlay" in your "Remove" list for one of these applications under "LabelsAndTags".
 }
 
 type Config_LabelsAndTagsProvider struct {
@@ -158,6 +252,7 @@ type Config_NotificationTemplate struct {
 	NewSetsAvailableForIgnoredItems Config_CustomNotification `json:"new_sets_available_for_ignored_items" yaml:"NewSetsAvailableForIgnoredItems,omitempty"` // Custom notification settings for when new sets become available for ignored items.
 	CheckForMediaItemChangesJob     Config_CustomNotification `json:"check_for_media_item_changes_job" yaml:"CheckForMediaItemChangesJob,omitempty"`         // Custom notification settings for the media item changes job.
 	SonarrNotification              Config_CustomNotification `json:"sonarr_notification" yaml:"SonarrNotification,omitempty"`                               // Custom notification settings for Sonarr events.
+	RadarrNotification              Config_CustomNotification `json:"radarr_notification" yaml:"RadarrNotification,omitempty"`                               // Custom notification settings for Radarr events.
 }
 
 type Config_CustomNotification struct {
@@ -179,7 +274,7 @@ type Config_SonarrRadarrApp struct {
 }
 
 type Config_Database struct {
-	Type     string `json:"type,omitempty" yaml:"Type,omitempty"`         // Type of database (e.g., "sqlite", "mysql", "postgresql").
+	Type     string `json:"type,omitempty" yaml:"Type,omitempty"`         // Type of database ("sqlite3", "mysql", "postgresql").
 	Path     string `json:"path,omitempty" yaml:"Path,omitempty"`         // File path for the database (if applicable, e.g., for SQLite).
 	User     string `json:"user,omitempty" yaml:"User,omitempty"`         // Username for database authentication (if applicable).
 	Password string `json:"password,omitempty" yaml:"Password,omitempty"` // Password for database authentication (if applicable).
@@ -187,4 +282,13 @@ type Config_Database struct {
 	Port     int    `json:"port,omitempty" yaml:"Port,omitempty"`         // Port number of the database server (if applicable).
 	Name     string `json:"name,omitempty" yaml:"Name,omitempty"`         // Name of the database to connect to.
 	DSN      string `json:"dsn,omitempty" yaml:"DSN,omitempty"`           // Data Source Name for the database connection (if applicable).
+
+	Backup Config_DatabaseBackup `json:"backup" yaml:"Backup,omitempty"` // Scheduled backup settings (SQLite only).
+}
+
+type Config_DatabaseBackup struct {
+	Enabled   bool   `json:"enabled" yaml:"Enabled"`                         // Whether scheduled database backups are enabled.
+	Cron      string `json:"cron,omitempty" yaml:"Cron,omitempty"`           // Cron expression for scheduling backups. Defaults to every day at 3 AM.
+	Retention int    `json:"retention,omitempty" yaml:"Retention,omitempty"` // Number of most recent backups to keep. Defaults to 7.
+	Path      string `json:"path,omitempty" yaml:"Path,omitempty"`           // Folder to store the backups in. Defaults to "backups" in the config folder.
 }
@@ -42,6 +42,12 @@ func DefaultNotificationTemplates() Config_NotificationTemplate {
 			Message:      "{{MediaItemTitle}}{{NewLine}}{{ImageName}}{{NewLine}}Set ID: {{SetID}}{{NewLine}}Reason:{{NewLine}}{{Reason}}{{NewLine}}{{Result}}",
 			IncludeImage: true,
 		},
+		RadarrNotification: Config_CustomNotification{
+			Enabled:      true,
+			Title:        "Radarr | {{ReasonTitle}}",
+			Message:      "{{MediaItemTitle}}{{NewLine}}{{ImageName}}{{NewLine}}Set ID: {{SetID}}{{NewLine}}Reason:{{NewLine}}{{Reason}}{{NewLine}}{{Result}}",
+			IncludeImage: true,
+		},
 	}
 }
 
@@ -54,12 +60,60 @@ func DefaultConfig() Config {
 			Level: "INFO",
 		},
 		Mediux: Config_Mediux{
-			DownloadQuality: "optimized",
+			DownloadQuality:     "optimized",
+			EnableEventListener: false,
 		},
 		AutoDownload: Config_AutoDownload{
 			Enabled: false,
 			Cron:    "0 0 * * *",
 		},
+		DownloadQueue: Config_DownloadQueue{
+			MaxAttempts:                 5,
+			RetryDelaySeconds:           60,
+			MaxRetryDelaySeconds:        3600,
+			Workers:                     2,
+			ImageWorkers:                4,
+			MediuxRequestsPerSecond:     10,
+			MediaServerUploadsPerSecond: 5,
+		},
+		SubscribedCreators: Config_SubscribedCreators{
+			Enabled:       false,
+			Cron:          "0 */6 * * *",
+			Mode:          "approval",
+			MaxSetAgeDays: 7,
+			SelectedTypes: Config_SubscribedCreatorsSelectedTypes{
+				Poster:       true,
+				Backdrop:     true,
+				SeasonPoster: true,
+				Titlecard:    true,
+			},
+		},
+		AutoSelect: Config_AutoSelect{
+			Enabled: false,
+			Rules:   []Config_AutoSelectRule{},
+		},
+		CoverageReport: Config_CoverageReport{
+			Enabled: false,
+			Cron:    "0 3 * * *",
+		},
+		DriftDetection: Config_DriftDetection{
+			Enabled:   false,
+			Cron:      "0 4 * * *",
+			Mode:      "flag",
+			Threshold: 10,
+		},
+		Kometa: Config_Kometa{
+			Enabled:           false,
+			AssetDirectory:    "",
+			MetadataDirectory: "",
+			Cron:              "0 5 * * *",
+		},
+		TMDB: Config_TMDB{
+			FallbackEnabled: false,
+			Languages:       []string{"en"},
+			MinVoteAverage:  0,
+			MaxSets:         5,
+		},
 		Images: Config_Images{
 			CacheImages: Config_CacheImages{
 				Enabled: false,
@@ -67,6 +121,10 @@ func DefaultConfig() Config {
 			SaveImagesLocally: Config_SaveImagesLocally{
 				Enabled: false,
 			},
+			History: Config_ImageHistory{
+				Enabled:     false,
+				MaxVersions: 5,
+			},
 		},
 		Notifications: Config_Notifications{
 			Enabled:              false,
@@ -0,0 +1,62 @@
+package config
+
+import (
+	"aura/models"
+	"slices"
+)
+
+// SelectedTypeLabels are the labels/tags added for the selected image types when AddLabelTagForSelectedTypes is enabled
+var SelectedTypeLabels = LabelsForSelectedTypes(models.SelectedTypes{
+	Poster:              true,
+	Backdrop:            true,
+	SeasonPoster:        true,
+	SpecialSeasonPoster: true,
+	Titlecard:           true,
+})
+
+// LabelsForSelectedTypes returns the labels/tags of the selected image types
+func LabelsForSelectedTypes(selectedTypes models.SelectedTypes) []string {
+	labels := []string{}
+	if selectedTypes.Poster {
+		labels = append(labels, "aura-poster")
+	}
+	if selectedTypes.Backdrop {
+		labels = append(labels, "aura-backdrop")
+	}
+	if selectedTypes.SeasonPoster {
+		labels = append(labels, "aura-season-poster")
+	}
+	if selectedTypes.SpecialSeasonPoster {
+		labels = append(labels, "aura-special-season-poster")
+	}
+	if selectedTypes.Titlecard {
+		labels = append(labels, "aura-titlecard")
+	}
+	return labels
+}
+
+// AddedLabels returns every label/tag aura can add to an item for this application
+func (app Config_LabelsAndTagsProvider) AddedLabels() []string {
+	labels := append([]string{}, app.Add...)
+	return append(labels, SelectedTypeLabels...)
+}
+
+// LabelsToAdd returns the labels/tags to add to an item after the selected image types were downloaded
+func (app Config_LabelsAndTagsProvider) LabelsToAdd(selectedTypes models.SelectedTypes) []string {
+	labels := append([]string{}, app.Add...)
+	if app.AddLabelTagForSelectedTypes {
+		labels = append(labels, LabelsForSelectedTypes(selectedTypes)...)
+	}
+	return labels
+}
+
+// LabelsToRemove returns the labels/tags to remove from an item after the selected image types were downloaded.
+// With RemoveOverlayLabelOnlyOnPosterDownload, "Overlay" is only removed when a poster was downloaded.
+func (app Config_LabelsAndTagsProvider) LabelsToRemove(selectedTypes models.SelectedTypes) []string {
+	if !Current.LabelsAndTags.RemoveOverlayLabelOnlyOnPosterDownload || selectedTypes.Poster {
+		return app.Remove
+	}
+	return slices.DeleteFunc(slices.Clone(app.Remove), func(label string) bool {
+		return label == "Overlay"
+	})
+}
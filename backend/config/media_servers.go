@@ -0,0 +1,48 @@
+package config
+
+// Media Items from the primary media server (config.Current.MediaServer) do not carry a server name.
+// Media Items from an additional media server carry the Name of that server.
+
+// AllMediaServers returns the primary media server followed by all additional media servers
+func AllMediaServers() []*Config_MediaServer {
+	servers := []*Config_MediaServer{&Current.MediaServer}
+	for i := range Current.AdditionalMediaServers {
+		servers = append(servers, &Current.AdditionalMediaServers[i])
+	}
+	return servers
+}
+
+// GetMediaServerByName returns the media server config with the given name.
+// An empty name (or the name of the primary media server) resolves to the primary media server.
+func GetMediaServerByName(name string) (*Config_MediaServer, bool) {
+	if name == "" || name == Current.MediaServer.Name {
+		return &Current.MediaServer, true
+	}
+	for i := range Current.AdditionalMediaServers {
+		if Current.AdditionalMediaServers[i].Name == name {
+			return &Current.AdditionalMediaServers[i], true
+		}
+	}
+	return nil, false
+}
+
+// MediaServerKey returns the server name used to key Media Items from this media server.
+// The primary media server is always keyed with an empty name.
+func MediaServerKey(ms *Config_MediaServer) string {
+	if ms == nil || ms == &Current.MediaServer || ms.Name == Current.MediaServer.Name {
+		return ""
+	}
+	return ms.Name
+}
+
+// MediaServerDisplayName returns a human readable name for the media server with the given key
+func MediaServerDisplayName(name string) string {
+	ms, found := GetMediaServerByName(name)
+	if !found {
+		return name
+	}
+	if ms.Name != "" {
+		return ms.Name
+	}
+	return ms.Type
+}
@@ -20,8 +20,15 @@ func (config *Config) PrintDetails() {
 		Interface("Authentication Details", sanitizedConfig.Auth).
 		Interface("Logging", sanitizedConfig.Logging).
 		Interface("Media Server", sanitizedConfig.MediaServer).
+		Interface("Additional Media Servers", sanitizedConfig.AdditionalMediaServers).
 		Interface("MediUX", sanitizedConfig.Mediux).
 		Interface("Auto Download", sanitizedConfig.AutoDownload).
+		Interface("Download Queue", sanitizedConfig.DownloadQueue).
+		Interface("Subscribed Creators", sanitizedConfig.SubscribedCreators).
+		Interface("Auto Select", sanitizedConfig.AutoSelect).
+		Interface("Coverage Report", sanitizedConfig.CoverageReport).
+		Interface("Drift Detection", sanitizedConfig.DriftDetection).
+		Interface("Kometa", sanitizedConfig.Kometa).
 		Interface("Images", sanitizedConfig.Images).
 		Interface("TMDB", sanitizedConfig.TMDB).
 		Interface("Labels and Tags", sanitizedConfig.LabelsAndTags).
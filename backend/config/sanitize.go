@@ -21,6 +21,17 @@ func (config *Config) SanitizeConfig(ctx context.Context) *Config {
 	c.Mediux.ApiToken = MaskToken(c.Mediux.ApiToken)
 	c.TMDB.ApiToken = MaskToken(c.TMDB.ApiToken)
 	c.MediaServer.ApiToken = MaskToken(c.MediaServer.ApiToken)
+	c.MediaServer.PlexWebhookToken = MaskToken(c.MediaServer.PlexWebhookToken)
+	c.Database.Password = MaskToken(c.Database.Password)
+
+	// Deep copy additional media servers slice
+	if len(config.AdditionalMediaServers) > 0 {
+		c.AdditionalMediaServers = make([]Config_MediaServer, len(config.AdditionalMediaServers))
+		for i, ms := range config.AdditionalMediaServers {
+			ms.ApiToken = MaskToken(ms.ApiToken)
+			c.AdditionalMediaServers[i] = ms
+		}
+	}
 
 	// Deep copy notifications.providers slice and nested pointer
 	if len(config.Notifications.Providers) > 0 {
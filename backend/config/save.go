@@ -16,6 +16,9 @@ func (config *Config) Save(ctx context.Context) (Err logging.LogErrorInfo) {
 	// Clear the User ID before saving
 	// This is done so that it is loaded on startup
 	config.MediaServer.UserID = ""
+	for i := range config.AdditionalMediaServers {
+		config.AdditionalMediaServers[i].UserID = ""
+	}
 
 	// Sub-action: Marshal config to YAML
 	subActionMarshal := logAction.AddSubAction("Marshal Config to YAML", logging.LevelTrace)
@@ -8,6 +8,7 @@ const (
 	TemplateTypeNewSetsAvailableForIgnoredItems = "new_sets_available_for_ignored_items"
 	TemplateTypeCheckForMediaItemChangesJob     = "check_for_media_item_changes_job"
 	TemplateTypeSonarrNotification              = "sonarr_notification"
+	TemplateTypeRadarrNotification              = "radarr_notification"
 )
 
 var BaseTemplateVariables = []string{
@@ -92,7 +93,7 @@ func AllowedTemplateVariables(templateType string) []string {
 				"{{MoreInfo}}",
 			},
 		)
-	case TemplateTypeSonarrNotification:
+	case TemplateTypeSonarrNotification, TemplateTypeRadarrNotification:
 		return mergeTemplateVariableGroups(
 			BaseTemplateVariables,
 			MediaItemVariables,
@@ -118,6 +119,7 @@ func GetNotificationTemplateVariableCatalog() NotificationTemplateVariableCatalo
 			TemplateTypeNewSetsAvailableForIgnoredItems: AllowedTemplateVariables(TemplateTypeNewSetsAvailableForIgnoredItems),
 			TemplateTypeCheckForMediaItemChangesJob:     AllowedTemplateVariables(TemplateTypeCheckForMediaItemChangesJob),
 			TemplateTypeSonarrNotification:              AllowedTemplateVariables(TemplateTypeSonarrNotification),
+			TemplateTypeRadarrNotification:              AllowedTemplateVariables(TemplateTypeRadarrNotification),
 		},
 	}
 }
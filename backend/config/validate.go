@@ -27,15 +27,39 @@ func (config *Config) Validate(ctx context.Context) {
 	// // Sub-action: MediaServer Config
 	isMediaServerValid := ValidateMediaServer(ctx, &config.MediaServer)
 
+	// Sub-action: Additional MediaServers Config
+	isAdditionalMediaServersValid := ValidateAdditionalMediaServers(ctx, config.MediaServer, config.AdditionalMediaServers)
+
 	// Sub-action: MediUX Config
 	isMediuxValid := ValidateMediux(ctx, &config.Mediux)
 
 	// Sub-action: AutoDownload Config
 	isAutoDownloadValid := ValidateAutoDownload(ctx, &config.AutoDownload)
 
+	// Sub-action: DownloadQueue Config
+	isDownloadQueueValid := ValidateDownloadQueue(ctx, &config.DownloadQueue)
+
+	// Sub-action: SubscribedCreators Config
+	isSubscribedCreatorsValid := ValidateSubscribedCreators(ctx, &config.SubscribedCreators)
+
+	// Sub-action: AutoSelect Config
+	isAutoSelectValid := ValidateAutoSelect(ctx, &config.AutoSelect)
+
+	// Sub-action: CoverageReport Config
+	isCoverageReportValid := ValidateCoverageReport(ctx, &config.CoverageReport)
+
+	// Sub-action: DriftDetection Config
+	isDriftDetectionValid := ValidateDriftDetection(ctx, &config.DriftDetection)
+
+	// Sub-action: Kometa Config
+	isKometaValid := ValidateKometa(ctx, &config.Kometa)
+
 	// Sub-action: Images Config
 	isImagesValid := ValidateImages(ctx, &config.Images, config.MediaServer)
 
+	// Sub-action: TMDB Config
+	isTMDBValid := ValidateTMDB(ctx, &config.TMDB)
+
 	// Sub-action: Notifications Config
 	isNotificationsValid := ValidateNotifications(ctx, &config.Notifications)
 
@@ -49,9 +73,9 @@ func (config *Config) Validate(ctx context.Context) {
 	isLabelsAndTagsValid := ValidateLabelsAndTags(ctx, &config.LabelsAndTags)
 
 	// If any validation failed, set status to error
-	if !isAuthValid || !isLoggingValid || !isMediaServerValid ||
-		!isMediuxValid || !isAutoDownloadValid ||
-		!isImagesValid || !isNotificationsValid || !isSonarrRadarrValid || !isDatabaseValid || !isLabelsAndTagsValid {
+	if !isAuthValid || !isLoggingValid || !isMediaServerValid || !isAdditionalMediaServersValid ||
+		!isMediuxValid || !isAutoDownloadValid || !isDownloadQueueValid || !isSubscribedCreatorsValid || !isAutoSelectValid ||
+		!isCoverageReportValid || !isDriftDetectionValid || !isKometaValid || !isImagesValid || !isTMDBValid || !isNotificationsValid || !isSonarrRadarrValid || !isDatabaseValid || !isLabelsAndTagsValid {
 		logAction.SetError("Config validation failed", "One or more config sections are invalid", nil)
 		Valid = false
 	} else {
@@ -155,6 +179,42 @@ func ValidateMediaServer(ctx context.Context, MediaServer *Config_MediaServer) b
 	// Now that we know the Media Server config is valid, clean up fields and set defaults
 	// Trim the trailing slash from the URL
 	MediaServer.URL = strings.TrimSuffix(MediaServer.URL, "/")
+	MediaServer.PlexWebhookToken = strings.TrimSpace(MediaServer.PlexWebhookToken)
+
+	return isValid
+}
+
+func ValidateAdditionalMediaServers(ctx context.Context, primary Config_MediaServer, additional []Config_MediaServer) bool {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Validating Additional MediaServers Config", logging.LevelTrace)
+	defer logAction.Complete()
+
+	isValid := true
+
+	seenNames := map[string]bool{}
+	if primary.Name != "" {
+		seenNames[primary.Name] = true
+	}
+
+	for i := range additional {
+		ms := &additional[i]
+
+		// Each additional media server needs a unique name so that items can be keyed by server
+		ms.Name = strings.TrimSpace(ms.Name)
+		if ms.Name == "" {
+			logAction.SetError(fmt.Sprintf("AdditionalMediaServers[%d].Name is not set", i), "Each additional media server must have a unique name", nil)
+			isValid = false
+			continue
+		} else if seenNames[ms.Name] {
+			logAction.SetError(fmt.Sprintf("AdditionalMediaServers[%d].Name: '%s' is already in use", i, ms.Name), "Each media server must have a unique name", nil)
+			isValid = false
+			continue
+		}
+		seenNames[ms.Name] = true
+
+		if !ValidateMediaServer(ctx, ms) {
+			isValid = false
+		}
+	}
 
 	return isValid
 }
@@ -223,9 +283,39 @@ func ValidateImages(ctx context.Context, Images *Config_Images, msConfig Config_
 
 	isValid := true
 
-	// If Images.SaveImagesLocally.Enabled is true, validate the EpisodeNamingConvention
+	if Images.History.MaxVersions < 0 {
+		logAction.SetError("Images.History.MaxVersions is not valid", "Images.History.MaxVersions must be 1 or higher", nil)
+		isValid = false
+	} else if Images.History.MaxVersions == 0 {
+		Images.History.MaxVersions = 5
+		if Images.History.Enabled {
+			logAction.AppendWarning("message", "Images.History.MaxVersions not set, defaulting to 5")
+		}
+	}
+
+	// If Images.SaveImagesLocally.Enabled is true, validate the NamingProfile and EpisodeNamingConvention
 	if Images.SaveImagesLocally.Enabled {
-		if msConfig.Type != "Plex" {
+		validNamingProfiles := []string{"plex", "kodi", "emby", "custom"}
+
+		Images.SaveImagesLocally.NamingProfile = strings.ToLower(Images.SaveImagesLocally.NamingProfile)
+		if Images.SaveImagesLocally.NamingProfile == "jellyfin" {
+			Images.SaveImagesLocally.NamingProfile = "emby"
+		}
+		if !stringSliceContains(validNamingProfiles, Images.SaveImagesLocally.NamingProfile) {
+			defaultNamingProfile := "plex"
+			if msConfig.Type == "Emby" || msConfig.Type == "Jellyfin" {
+				defaultNamingProfile = "emby"
+			}
+			if Images.SaveImagesLocally.NamingProfile == "" {
+				logAction.AppendWarning("message", fmt.Sprintf("Images.SaveImagesLocally.NamingProfile not set, defaulting to '%s'", defaultNamingProfile))
+			} else {
+				logAction.AppendWarning("message", fmt.Sprintf("Images.SaveImagesLocally.NamingProfile invalid, defaulting to '%s'", defaultNamingProfile))
+			}
+			Images.SaveImagesLocally.NamingProfile = defaultNamingProfile
+		}
+
+		// The titlecard naming of the other profiles does not depend on the EpisodeNamingConvention
+		if Images.SaveImagesLocally.NamingProfile != "plex" {
 			return isValid
 		}
 
@@ -233,10 +323,10 @@ func ValidateImages(ctx context.Context, Images *Config_Images, msConfig Config_
 
 		Images.SaveImagesLocally.EpisodeNamingConvention = strings.ToLower(Images.SaveImagesLocally.EpisodeNamingConvention)
 		if !stringSliceContains(validEpisodeNamingConventions, Images.SaveImagesLocally.EpisodeNamingConvention) {
-			if msConfig.Type == "Plex" && Images.SaveImagesLocally.EpisodeNamingConvention == "" {
+			if Images.SaveImagesLocally.EpisodeNamingConvention == "" {
 				Images.SaveImagesLocally.EpisodeNamingConvention = "match"
 				logAction.AppendWarning("message", "Images.SaveImagesLocally.EpisodeNamingConvention not set, defaulting to 'match'")
-			} else if msConfig.Type == "Plex" && Images.SaveImagesLocally.EpisodeNamingConvention != "match" && Images.SaveImagesLocally.EpisodeNamingConvention != "static" {
+			} else {
 				Images.SaveImagesLocally.EpisodeNamingConvention = "match"
 				logAction.AppendWarning("message", "Images.SaveImagesLocally.EpisodeNamingConvention invalid, defaulting to 'match'")
 			}
@@ -246,6 +336,56 @@ func ValidateImages(ctx context.Context, Images *Config_Images, msConfig Config_
 	return isValid
 }
 
+func ValidateTMDB(ctx context.Context, TMDB *Config_TMDB) bool {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Validating TMDB Config", logging.LevelTrace)
+	defer logAction.Complete()
+
+	isValid := true
+
+	if !TMDB.FallbackEnabled {
+		return isValid
+	}
+
+	if TMDB.ApiToken == "" {
+		logAction.SetError("TMDB.ApiToken is not set", "TMDB.FallbackEnabled needs a TMDB API Read Access Token or API Key", nil)
+		isValid = false
+	}
+
+	languages := []string{}
+	for _, lang := range TMDB.Languages {
+		lang = strings.ToLower(strings.TrimSpace(lang))
+		if lang == "" {
+			continue
+		}
+		if len(lang) != 2 && lang != "null" {
+			logAction.SetError(fmt.Sprintf("TMDB.Languages: '%s' is not valid", lang), "Use ISO 639-1 language codes such as 'en' or 'de', or 'null' for images without text", nil)
+			isValid = false
+			continue
+		}
+		languages = append(languages, lang)
+	}
+	if len(languages) == 0 {
+		languages = []string{"en"}
+		logAction.AppendWarning("message", "TMDB.Languages not set, defaulting to ['en']")
+	}
+	TMDB.Languages = languages
+
+	if TMDB.MinVoteAverage < 0 || TMDB.MinVoteAverage > 10 {
+		logAction.SetError("TMDB.MinVoteAverage is not valid", "TMDB.MinVoteAverage must be between 0 and 10", nil)
+		isValid = false
+	}
+
+	if TMDB.MaxSets < 0 {
+		logAction.SetError("TMDB.MaxSets is not valid", "TMDB.MaxSets must be 1 or higher", nil)
+		isValid = false
+	} else if TMDB.MaxSets == 0 {
+		TMDB.MaxSets = 5
+		logAction.AppendWarning("message", "TMDB.MaxSets not set, defaulting to 5")
+	}
+
+	return isValid
+}
+
 func ValidateNotifications(ctx context.Context, Notifications *Config_Notifications) bool {
 	ctx, logAction := logging.AddSubActionToContext(ctx, "Validating Notifications Config", logging.LevelTrace)
 	defer logAction.Complete()
@@ -394,6 +534,24 @@ func ValidateNotifications(ctx context.Context, Notifications *Config_Notificati
 		}
 	}
 
+	if Notifications.NotificationTemplate.RadarrNotification == (Config_CustomNotification{}) {
+		Notifications.NotificationTemplate.RadarrNotification = defaults.RadarrNotification
+		logging.LOGGER.Warn().Timestamp().Msg("Notifications.NotificationTemplate.RadarrNotification not set, defaulting to built-in template")
+		logAction.AppendWarning("message", "Notifications.NotificationTemplate.RadarrNotification not set, defaulting to built-in template")
+	} else {
+		validRadarrNotificationVariables := AllowedTemplateVariables(TemplateTypeRadarrNotification)
+		if !validateTemplateVariables(Notifications.NotificationTemplate.RadarrNotification.Title, validRadarrNotificationVariables) {
+			logging.LOGGER.Warn().Timestamp().Msg("Notifications.NotificationTemplate.RadarrNotification.Title contains invalid variables, please check the config documentation for valid variables")
+			logAction.AppendWarning("message", "Notifications.NotificationTemplate.RadarrNotification.Title contains invalid variables, please check the config documentation for valid variables")
+			Notifications.NotificationTemplate.RadarrNotification.Title = defaults.RadarrNotification.Title
+		}
+		if !validateTemplateVariables(Notifications.NotificationTemplate.RadarrNotification.Message, validRadarrNotificationVariables) {
+			logging.LOGGER.Warn().Timestamp().Msg("Notifications.NotificationTemplate.RadarrNotification.Message contains invalid variables, please check the config documentation for valid variables")
+			logAction.AppendWarning("message", "Notifications.NotificationTemplate.RadarrNotification.Message contains invalid variables, please check the config documentation for valid variables")
+			Notifications.NotificationTemplate.RadarrNotification.Message = defaults.RadarrNotification.Message
+		}
+	}
+
 	return isValid
 }
 
@@ -556,6 +714,302 @@ func ValidateDatabase(ctx context.Context, Database *Config_Database) bool {
 		if Database.Path == "" {
 			Database.Path = "AURA.db"
 		}
+	case "mysql", "postgresql":
+		// Either a full DSN or the individual connection fields must be set
+		if Database.DSN == "" {
+			if Database.Host == "" {
+				logAction.SetError("Database.Host is not set", fmt.Sprintf("Database.Host or Database.DSN must be set when using %s", Database.Type), nil)
+				isValid = false
+			}
+			if Database.User == "" {
+				logAction.SetError("Database.User is not set", fmt.Sprintf("Database.User or Database.DSN must be set when using %s", Database.Type), nil)
+				isValid = false
+			}
+			if Database.Name == "" {
+				logAction.SetError("Database.Name is not set", fmt.Sprintf("Database.Name or Database.DSN must be set when using %s", Database.Type), nil)
+				isValid = false
+			}
+		}
+		if Database.Port < 0 || Database.Port > 65535 {
+			logAction.SetError("Database.Port is not valid", "Database.Port must be between 1 and 65535", nil)
+			isValid = false
+		}
+	}
+
+	// Scheduled backups
+	if Database.Backup.Enabled {
+		if Database.Type != "sqlite3" {
+			logAction.AppendWarning("message", fmt.Sprintf("Database.Backup is only supported for sqlite3, use the backup tools of your %s server instead", Database.Type))
+		}
+
+		if Database.Backup.Cron == "" {
+			Database.Backup.Cron = "0 3 * * *"
+			logAction.AppendWarning("message", "Database.Backup.Cron not set, defaulting to '0 3 * * *' (every day at 3 AM)")
+		}
+		if !ValidateCron(Database.Backup.Cron) {
+			logAction.SetError(fmt.Sprintf("Database.Backup.Cron: '%s' is not a valid cron expression", Database.Backup.Cron), "Please provide a valid cron expression", nil)
+			isValid = false
+		}
+
+		if Database.Backup.Retention <= 0 {
+			Database.Backup.Retention = 7
+			logAction.AppendWarning("message", "Database.Backup.Retention not set, defaulting to 7")
+		}
+	}
+
+	return isValid
+}
+
+func ValidateDownloadQueue(ctx context.Context, DownloadQueue *Config_DownloadQueue) bool {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Validating DownloadQueue Config", logging.LevelTrace)
+	defer logAction.Complete()
+
+	isValid := true
+
+	if DownloadQueue.MaxAttempts < 0 {
+		logAction.SetError("DownloadQueue.MaxAttempts is not valid", "DownloadQueue.MaxAttempts must be 1 or higher", nil)
+		isValid = false
+	} else if DownloadQueue.MaxAttempts == 0 {
+		DownloadQueue.MaxAttempts = 5
+		logAction.AppendWarning("message", "DownloadQueue.MaxAttempts not set, defaulting to 5")
+	}
+
+	if DownloadQueue.RetryDelaySeconds < 0 {
+		logAction.SetError("DownloadQueue.RetryDelaySeconds is not valid", "DownloadQueue.RetryDelaySeconds must be 1 or higher", nil)
+		isValid = false
+	} else if DownloadQueue.RetryDelaySeconds == 0 {
+		DownloadQueue.RetryDelaySeconds = 60
+		logAction.AppendWarning("message", "DownloadQueue.RetryDelaySeconds not set, defaulting to 60")
+	}
+
+	if DownloadQueue.MaxRetryDelaySeconds < 0 {
+		logAction.SetError("DownloadQueue.MaxRetryDelaySeconds is not valid", "DownloadQueue.MaxRetryDelaySeconds must be 1 or higher", nil)
+		isValid = false
+	} else if DownloadQueue.MaxRetryDelaySeconds == 0 {
+		DownloadQueue.MaxRetryDelaySeconds = 3600
+		logAction.AppendWarning("message", "DownloadQueue.MaxRetryDelaySeconds not set, defaulting to 3600")
+	}
+
+	if isValid && DownloadQueue.MaxRetryDelaySeconds < DownloadQueue.RetryDelaySeconds {
+		DownloadQueue.MaxRetryDelaySeconds = DownloadQueue.RetryDelaySeconds
+		logAction.AppendWarning("message", "DownloadQueue.MaxRetryDelaySeconds is lower than DownloadQueue.RetryDelaySeconds, using DownloadQueue.RetryDelaySeconds")
+	}
+
+	if DownloadQueue.Workers < 0 {
+		logAction.SetError("DownloadQueue.Workers is not valid", "DownloadQueue.Workers must be 1 or higher", nil)
+		isValid = false
+	} else if DownloadQueue.Workers == 0 {
+		DownloadQueue.Workers = 2
+		logAction.AppendWarning("message", "DownloadQueue.Workers not set, defaulting to 2")
+	}
+
+	if DownloadQueue.ImageWorkers < 0 {
+		logAction.SetError("DownloadQueue.ImageWorkers is not valid", "DownloadQueue.ImageWorkers must be 1 or higher", nil)
+		isValid = false
+	} else if DownloadQueue.ImageWorkers == 0 {
+		DownloadQueue.ImageWorkers = 4
+		logAction.AppendWarning("message", "DownloadQueue.ImageWorkers not set, defaulting to 4")
+	}
+
+	// -1 disables the rate limits
+	if DownloadQueue.MediuxRequestsPerSecond < -1 {
+		logAction.SetError("DownloadQueue.MediuxRequestsPerSecond is not valid", "DownloadQueue.MediuxRequestsPerSecond must be 1 or higher, or -1 to disable the limit", nil)
+		isValid = false
+	} else if DownloadQueue.MediuxRequestsPerSecond == 0 {
+		DownloadQueue.MediuxRequestsPerSecond = 10
+		logAction.AppendWarning("message", "DownloadQueue.MediuxRequestsPerSecond not set, defaulting to 10")
+	}
+
+	if DownloadQueue.MediaServerUploadsPerSecond < -1 {
+		logAction.SetError("DownloadQueue.MediaServerUploadsPerSecond is not valid", "DownloadQueue.MediaServerUploadsPerSecond must be 1 or higher, or -1 to disable the limit", nil)
+		isValid = false
+	} else if DownloadQueue.MediaServerUploadsPerSecond == 0 {
+		DownloadQueue.MediaServerUploadsPerSecond = 5
+		logAction.AppendWarning("message", "DownloadQueue.MediaServerUploadsPerSecond not set, defaulting to 5")
+	}
+
+	return isValid
+}
+
+func ValidateSubscribedCreators(ctx context.Context, SubscribedCreators *Config_SubscribedCreators) bool {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Validating SubscribedCreators Config", logging.LevelTrace)
+	defer logAction.Complete()
+
+	isValid := true
+
+	if !SubscribedCreators.Enabled {
+		return isValid
+	}
+
+	if SubscribedCreators.Cron == "" {
+		SubscribedCreators.Cron = "0 */6 * * *"
+		logAction.AppendWarning("message", "SubscribedCreators.Cron not set, defaulting to '0 */6 * * *' (every 6 hours)")
+	}
+	if !ValidateCron(SubscribedCreators.Cron) {
+		logAction.SetError(fmt.Sprintf("SubscribedCreators.Cron: '%s' is not a valid cron expression", SubscribedCreators.Cron), "Please provide a valid cron expression", nil)
+		isValid = false
+	}
+
+	switch SubscribedCreators.Mode {
+	case "add", "approval":
+	case "":
+		SubscribedCreators.Mode = "approval"
+		logAction.AppendWarning("message", "SubscribedCreators.Mode not set, defaulting to 'approval'")
+	default:
+		logAction.SetError(fmt.Sprintf("SubscribedCreators.Mode: '%s' is not valid", SubscribedCreators.Mode), "SubscribedCreators.Mode must be 'add' or 'approval'", nil)
+		isValid = false
+	}
+
+	if SubscribedCreators.MaxSetAgeDays < 0 {
+		logAction.SetError("SubscribedCreators.MaxSetAgeDays is not valid", "SubscribedCreators.MaxSetAgeDays must be 1 or higher", nil)
+		isValid = false
+	} else if SubscribedCreators.MaxSetAgeDays == 0 {
+		SubscribedCreators.MaxSetAgeDays = 7
+		logAction.AppendWarning("message", "SubscribedCreators.MaxSetAgeDays not set, defaulting to 7")
+	}
+
+	selected := SubscribedCreators.SelectedTypes
+	if !selected.Poster && !selected.Backdrop && !selected.SeasonPoster && !selected.SpecialSeasonPoster && !selected.Titlecard {
+		logAction.SetError("SubscribedCreators.SelectedTypes is not valid", "Select at least one image type", nil)
+		isValid = false
+	}
+
+	return isValid
+}
+
+func ValidateCoverageReport(ctx context.Context, CoverageReport *Config_CoverageReport) bool {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Validating CoverageReport Config", logging.LevelTrace)
+	defer logAction.Complete()
+
+	isValid := true
+
+	if !CoverageReport.Enabled {
+		return isValid
+	}
+
+	if CoverageReport.Cron == "" {
+		CoverageReport.Cron = "0 3 * * *"
+		logAction.AppendWarning("message", "CoverageReport.Cron not set, defaulting to '0 3 * * *' (every day at 03:00)")
+	}
+	if !ValidateCron(CoverageReport.Cron) {
+		logAction.SetError(fmt.Sprintf("CoverageReport.Cron: '%s' is not a valid cron expression", CoverageReport.Cron), "Please provide a valid cron expression", nil)
+		isValid = false
+	}
+
+	return isValid
+}
+
+func ValidateDriftDetection(ctx context.Context, DriftDetection *Config_DriftDetection) bool {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Validating DriftDetection Config", logging.LevelTrace)
+	defer logAction.Complete()
+
+	isValid := true
+
+	if !DriftDetection.Enabled {
+		return isValid
+	}
+
+	if DriftDetection.Cron == "" {
+		DriftDetection.Cron = "0 4 * * *"
+		logAction.AppendWarning("message", "DriftDetection.Cron not set, defaulting to '0 4 * * *' (every day at 04:00)")
+	}
+	if !ValidateCron(DriftDetection.Cron) {
+		logAction.SetError(fmt.Sprintf("DriftDetection.Cron: '%s' is not a valid cron expression", DriftDetection.Cron), "Please provide a valid cron expression", nil)
+		isValid = false
+	}
+
+	switch DriftDetection.Mode {
+	case "flag", "reapply":
+	case "":
+		DriftDetection.Mode = "flag"
+		logAction.AppendWarning("message", "DriftDetection.Mode not set, defaulting to 'flag'")
+	default:
+		logAction.SetError(fmt.Sprintf("DriftDetection.Mode: '%s' is not valid", DriftDetection.Mode), "DriftDetection.Mode must be 'flag' or 'reapply'", nil)
+		isValid = false
+	}
+
+	if DriftDetection.Threshold < 0 || DriftDetection.Threshold > 64 {
+		logAction.SetError("DriftDetection.Threshold is not valid", "DriftDetection.Threshold must be between 1 and 64", nil)
+		isValid = false
+	} else if DriftDetection.Threshold == 0 {
+		DriftDetection.Threshold = 10
+		logAction.AppendWarning("message", "DriftDetection.Threshold not set, defaulting to 10")
+	}
+
+	return isValid
+}
+
+func ValidateKometa(ctx context.Context, Kometa *Config_Kometa) bool {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Validating Kometa Config", logging.LevelTrace)
+	defer logAction.Complete()
+
+	isValid := true
+
+	if !Kometa.Enabled {
+		return isValid
+	}
+
+	if Kometa.AssetDirectory == "" && Kometa.MetadataDirectory == "" {
+		logAction.SetError("Kometa.AssetDirectory and Kometa.MetadataDirectory are not set", "Set at least one of them to export the saved sets for Kometa", nil)
+		isValid = false
+	}
+
+	if Kometa.Cron == "" {
+		Kometa.Cron = "0 5 * * *"
+		logAction.AppendWarning("message", "Kometa.Cron not set, defaulting to '0 5 * * *' (every day at 05:00)")
+	}
+	if !ValidateCron(Kometa.Cron) {
+		logAction.SetError(fmt.Sprintf("Kometa.Cron: '%s' is not a valid cron expression", Kometa.Cron), "Please provide a valid cron expression", nil)
+		isValid = false
+	}
+
+	return isValid
+}
+
+// AutoSelectImageTypes are the image types that can be required by an AutoSelect rule
+var AutoSelectImageTypes = []string{"poster", "backdrop", "season_poster", "special_season_poster", "titlecard"}
+
+func ValidateAutoSelect(ctx context.Context, AutoSelect *Config_AutoSelect) bool {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Validating AutoSelect Config", logging.LevelTrace)
+	defer logAction.Complete()
+
+	isValid := true
+
+	if !AutoSelect.Enabled {
+		return isValid
+	}
+
+	if len(AutoSelect.Rules) == 0 {
+		logAction.AppendWarning("message", "AutoSelect is enabled, but no rules are set. No sets will be picked.")
+	}
+
+	libraries := map[string]bool{}
+	for i, rule := range AutoSelect.Rules {
+		if rule.Library == "" {
+			logAction.SetError(fmt.Sprintf("AutoSelect.Rules[%d].Library is not set", i), "Set the title of the library the rule applies to", nil)
+			isValid = false
+			continue
+		}
+		if libraries[rule.Library] {
+			logAction.SetError(fmt.Sprintf("AutoSelect.Rules[%d]: more than one rule for library '%s'", i, rule.Library), "Use a single rule per library", nil)
+			isValid = false
+		}
+		libraries[rule.Library] = true
+
+		if rule.MinPopularity < 0 {
+			logAction.SetError(fmt.Sprintf("AutoSelect.Rules[%d].MinPopularity is not valid", i), "MinPopularity must be 0 or higher", nil)
+			isValid = false
+		}
+		if rule.OnlyPreferredCreators && len(rule.PreferredCreators) == 0 {
+			logAction.SetError(fmt.Sprintf("AutoSelect.Rules[%d].OnlyPreferredCreators is set without PreferredCreators", i), "Add at least one preferred creator", nil)
+			isValid = false
+		}
+		for _, imageType := range rule.RequiredImageTypes {
+			if !slices.Contains(AutoSelectImageTypes, imageType) {
+				logAction.SetError(fmt.Sprintf("AutoSelect.Rules[%d].RequiredImageTypes: '%s' is not valid", i, imageType), fmt.Sprintf("Use one or more of: %s", strings.Join(AutoSelectImageTypes, ", ")), nil)
+				isValid = false
+			}
+		}
 	}
 
 	return isValid
@@ -571,18 +1025,20 @@ func ValidateLabelsAndTags(ctx context.Context, LabelsAndTags *Config_LabelsAndT
 		return isValid
 	}
 
-	// If RemoveOverlayLabelOnlyOnPosterDownload is true, then we need to check if "Overlay" is in the remove list for the Plex application
+	// If RemoveOverlayLabelOnlyOnPosterDownload is true, then we need to check if "Overlay" is in the remove list of a media server application
 	if LabelsAndTags.RemoveOverlayLabelOnlyOnPosterDownload {
+		overlayRemoved := false
 		for _, app := range LabelsAndTags.Applications {
-			if app.Application == "Plex" {
-				if !stringSliceContains(app.Remove, "Overlay") {
-					logging.LOGGER.Warn().Timestamp().Msg("LabelsAndTags.RemoveOverlayLabelOnlyOnPosterDownload is true, but 'Overlay' is not in the remove list for the Plex application. This setting will have no effect unless 'Overlay' is added to the remove list for the Plex application.")
-					logAction.AppendWarning("message", "LabelsAndTags.RemoveOverlayLabelOnlyOnPosterDownload is true, but 'Overlay' is not in the remove list for the Plex application. This setting will have no effect unless 'Overlay' is added to the remove list for the Plex application.")
-					LabelsAndTags.RemoveOverlayLabelOnlyOnPosterDownload = false
-					break
-				}
+			if (app.Application == "Plex" || app.Application == "Emby" || app.Application == "Jellyfin") && stringSliceContains(app.Remove, "Overlay") {
+				overlayRemoved = true
+				break
 			}
 		}
+		if !overlayRemoved {
+			logging.LOGGER.Warn().Timestamp().Msg("LabelsAndTags.RemoveOverlayLabelOnlyOnPosterDownload is true, but 'Overlay' is not in the remove list for the Plex, Emby or Jellyfin application. This setting will have no effect unless 'Overlay' is added to one of these remove lists.")
+			logAction.AppendWarning("message", "LabelsAndTags.RemoveOverlayLabelOnlyOnPosterDownload is true, but 'Overlay' is not in the remove list for the Plex, Emby or Jellyfin application. This setting will have no effect unless 'Overlay' is added to one of these remove lists.")
+			LabelsAndTags.RemoveOverlayLabelOnlyOnPosterDownload = false
+		}
 	}
 
 	return isValid
@@ -0,0 +1,224 @@
+package database
+
+import (
+	"aura/config"
+	"aura/logging"
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const scheduledBackupPrefix = "AURA_backup_"
+
+// BackupInfo describes a single scheduled backup file
+type BackupInfo struct {
+	Name      string    `json:"name"`
+	Size      int64     `json:"size"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// backupMu makes sure backups and restores do not run at the same time
+var backupMu sync.Mutex
+
+// GetBackupDir returns the folder the scheduled backups are stored in
+func GetBackupDir() string {
+	dir := config.Current.Database.Backup.Path
+	if dir == "" {
+		return path.Join(config.ConfigPath, "backups")
+	}
+	if !filepath.IsAbs(dir) {
+		return path.Join(config.ConfigPath, dir)
+	}
+	return dir
+}
+
+// CreateScheduledBackup takes a consistent backup of the database and removes
+// the oldest backups so only the configured number of backups is kept.
+func CreateScheduledBackup(ctx context.Context) (backup BackupInfo, Err logging.LogErrorInfo) {
+	if Client == nil {
+		return backup, logging.Error_DBClientNotInitialized()
+	}
+
+	backupMu.Lock()
+	defer backupMu.Unlock()
+
+	return createScheduledBackup(ctx, "")
+}
+
+// createScheduledBackup creates a backup and applies the retention. The backup named keep is never removed.
+func createScheduledBackup(ctx context.Context, keep string) (backup BackupInfo, Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Creating Scheduled Database Backup", logging.LevelInfo)
+	defer logAction.Complete()
+
+	backupDir := GetBackupDir()
+	if err := os.MkdirAll(backupDir, 0755); err != nil {
+		logAction.SetError("Failed to create backup folder", "Ensure the backup path is accessible and writable.", map[string]any{
+			"error": err.Error(),
+			"path":  backupDir,
+		})
+		return backup, *logAction.Error
+	}
+
+	backupName := fmt.Sprintf("%s%s.db", scheduledBackupPrefix, time.Now().Format("20060102_150405"))
+	backupPath := path.Join(backupDir, backupName)
+	if _, err := os.Stat(backupPath); err == nil {
+		logAction.SetError("Backup already exists", "A backup was already created this second, try again later.", map[string]any{
+			"path": backupPath,
+		})
+		return backup, *logAction.Error
+	}
+
+	Err = Client.BackupTo(ctx, backupPath)
+	if Err.Message != "" {
+		return backup, Err
+	}
+
+	info, err := os.Stat(backupPath)
+	if err != nil {
+		logAction.SetError("Failed to stat backup file", "", map[string]any{
+			"error": err.Error(),
+			"path":  backupPath,
+		})
+		return backup, *logAction.Error
+	}
+	backup = BackupInfo{Name: backupName, Size: info.Size(), CreatedAt: info.ModTime()}
+	logAction.AppendResult("backup", backupName)
+
+	// Remove the oldest backups
+	retention := config.Current.Database.Backup.Retention
+	if retention <= 0 {
+		retention = 7
+	}
+	backups, Err := listBackups(ctx)
+	if Err.Message != "" {
+		return backup, Err
+	}
+	for _, old := range backups[min(retention, len(backups)):] {
+		if old.Name == keep {
+			continue
+		}
+		if err := os.Remove(path.Join(backupDir, old.Name)); err != nil {
+			logAction.AppendWarning(old.Name, fmt.Sprintf("Failed to remove old backup: %s", err.Error()))
+			continue
+		}
+		logAction.AppendResult("removed", old.Name)
+	}
+
+	return backup, logging.LogErrorInfo{}
+}
+
+// ListBackups returns the scheduled backups, newest first
+func ListBackups(ctx context.Context) (backups []BackupInfo, Err logging.LogErrorInfo) {
+	backupMu.Lock()
+	defer backupMu.Unlock()
+
+	return listBackups(ctx)
+}
+
+func listBackups(ctx context.Context) (backups []BackupInfo, Err logging.LogErrorInfo) {
+	backups = []BackupInfo{}
+
+	backupDir := GetBackupDir()
+	entries, err := os.ReadDir(backupDir)
+	if os.IsNotExist(err) {
+		return backups, Err
+	} else if err != nil {
+		_, logAction := logging.AddSubActionToContext(ctx, "Listing Database Backups", logging.LevelError)
+		defer logAction.Complete()
+		logAction.SetError("Failed to read backup folder", "Ensure the backup path is accessible.", map[string]any{
+			"error": err.Error(),
+			"path":  backupDir,
+		})
+		return backups, *logAction.Error
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasPrefix(entry.Name(), scheduledBackupPrefix) || !strings.HasSuffix(entry.Name(), ".db") {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		backups = append(backups, BackupInfo{Name: entry.Name(), Size: info.Size(), CreatedAt: info.ModTime()})
+	}
+
+	// The timestamp in the name sorts chronologically
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].Name > backups[j].Name
+	})
+
+	return backups, Err
+}
+
+// RestoreBackup replaces the database with one of the scheduled backups.
+// A backup of the current database is taken first, so the restore itself can be rolled back.
+func RestoreBackup(ctx context.Context, backupName string) (safetyBackup BackupInfo, Err logging.LogErrorInfo) {
+	if Client == nil {
+		return safetyBackup, logging.Error_DBClientNotInitialized()
+	}
+
+	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf("Restoring Database Backup '%s'", backupName), logging.LevelInfo)
+	defer logAction.Complete()
+
+	backupMu.Lock()
+	defer backupMu.Unlock()
+
+	// Only allow restoring files from the list of backups
+	backups, Err := listBackups(ctx)
+	if Err.Message != "" {
+		return safetyBackup, Err
+	}
+	found := false
+	for _, b := range backups {
+		if b.Name == backupName {
+			found = true
+			break
+		}
+	}
+	if !found {
+		logAction.SetError("Backup not found", "Use the list of backups to get a valid backup name", map[string]any{
+			"name": backupName,
+		})
+		return safetyBackup, *logAction.Error
+	}
+
+	safetyBackup, Err = createScheduledBackup(ctx, backupName)
+	if Err.Message != "" {
+		return safetyBackup, Err
+	}
+
+	// Wait for the running queries to finish and keep new ones out until the restored database is up to date
+	clientMu.Lock()
+	defer clientMu.Unlock()
+	restoreCtx := context.WithValue(ctx, restoreCtxKey{}, true)
+
+	Err = Client.RestoreFrom(restoreCtx, path.Join(GetBackupDir(), backupName))
+	if Err.Message != "" {
+		return safetyBackup, Err
+	}
+
+	// Backups of an older database version are migrated like on startup
+	if RestoreMigrations != nil {
+		migrationsPerformed, Err := RestoreMigrations(restoreCtx)
+		if Err.Message != "" {
+			logAction.AppendWarning("safety_backup", safetyBackup.Name)
+			return safetyBackup, Err
+		}
+		logAction.AppendResult("migrations_performed", migrationsPerformed)
+	}
+
+	logging.LOGGER.Info().Timestamp().
+		Str("backup", backupName).
+		Str("safety_backup", safetyBackup.Name).
+		Msg("Database restored from backup")
+	publishSavedSetsChanged("", "")
+
+	return safetyBackup, logging.LogErrorInfo{}
+}
@@ -2,22 +2,52 @@ package database
 
 import (
 	"aura/config"
+	"aura/events"
 	"aura/logging"
 	"aura/models"
 	"context"
 	"database/sql"
 	"fmt"
+	"sync"
+	"time"
 )
 
-const LATEST_DB_VERSION = 5
+const LATEST_DB_VERSION = 12
 
 var Client DB
 
+// clientMu makes sure the connection is not swapped by a restore while it is in use.
+// Every database function takes the read lock, RestoreBackup takes the write lock.
+var clientMu sync.RWMutex
+
+// restoreCtxKey marks the context of a running restore, so the migrations that run
+// after the swap can use the database while the restore holds the write lock
+type restoreCtxKey struct{}
+
+// RestoreMigrations brings a restored backup of an older database version up to date.
+// It is set on startup, as the migrations can not be imported here.
+var RestoreMigrations func(ctx context.Context) (migrationsPerformed int, Err logging.LogErrorInfo)
+
+func lockClient(ctx context.Context) (unlock func()) {
+	if ctx.Value(restoreCtxKey{}) != nil {
+		return func() {}
+	}
+	clientMu.RLock()
+	return clientMu.RUnlock
+}
+
 type SQliteDB struct {
 	Config config.Config_Database
 	conn   *sql.DB
 }
 
+// ServerDB is used for the database servers (PostgreSQL and MySQL).
+// The SQL dialect is selected by Config.Type.
+type ServerDB struct {
+	Config config.Config_Database
+	conn   *sql.DB
+}
+
 type DB interface {
 	// Open Database Connection
 	GetDBConnection(ctx context.Context) (conn *sql.DB, newDB bool, Err logging.LogErrorInfo)
@@ -52,6 +82,12 @@ type DB interface {
 	// Backup Database
 	Backup(ctx context.Context, currentVersion, newVersion int) (Err logging.LogErrorInfo)
 
+	// Write a consistent copy of the database to destPath while it is in use
+	BackupTo(ctx context.Context, destPath string) (Err logging.LogErrorInfo)
+
+	// Replace the database with a copy created by BackupTo
+	RestoreFrom(ctx context.Context, srcPath string) (Err logging.LogErrorInfo)
+
 	// Upsert Converted Saved Item
 	UpsertSavedItem(ctx context.Context, newItem models.DBSavedItem) (Err logging.LogErrorInfo)
 
@@ -94,8 +130,77 @@ type DB interface {
 	// Get Temp Ignored Items
 	GetTempIgnoredItems(ctx context.Context) (items []models.MediaItem, Err logging.LogErrorInfo)
 
+	// Get All Ignored Items (all modes)
+	GetAllIgnoredItems(ctx context.Context) (items []models.DBIgnoredItem, Err logging.LogErrorInfo)
+
 	// Update Media Item on_server flag
 	UpdateMediaItemOnServer(ctx context.Context, tmdbID string, libraryTitle string, onServer bool) (logErr logging.LogErrorInfo)
+
+	// Create DownloadQueue table (if it does not exist yet)
+	CreateDownloadQueueTable(ctx context.Context) (Err logging.LogErrorInfo)
+
+	// Add a pending entry to the Download Queue
+	AddDownloadQueueEntry(ctx context.Context, item models.DBSavedItem) (id int64, Err logging.LogErrorInfo)
+
+	// Update the status, item and results of a Download Queue entry
+	UpdateDownloadQueueEntry(ctx context.Context, entry models.DBDownloadQueueEntry) (Err logging.LogErrorInfo)
+
+	// Get Download Queue entries matching a filter (oldest first unless NewestFirst is set)
+	GetDownloadQueueEntries(ctx context.Context, filter models.DBDownloadQueueFilter) (entries []models.DBDownloadQueueEntry, Err logging.LogErrorInfo)
+
+	// Delete Download Queue entries matching a filter
+	DeleteDownloadQueueEntries(ctx context.Context, filter models.DBDownloadQueueFilter) (deleted int64, Err logging.LogErrorInfo)
+
+	// Create PendingApprovals table (if it does not exist yet)
+	CreatePendingApprovalsTable(ctx context.Context) (Err logging.LogErrorInfo)
+
+	// Stage a change that has to be approved before it is applied
+	AddPendingApproval(ctx context.Context, approval models.DBPendingApproval) (id int64, Err logging.LogErrorInfo)
+
+	// Replace the item and reason of a Pending Approval that is still pending
+	UpdatePendingApprovalItem(ctx context.Context, id int64, item models.DBSavedItem, reason string) (Err logging.LogErrorInfo)
+
+	// Mark a Pending Approval as approved or rejected
+	UpdatePendingApprovalStatus(ctx context.Context, id int64, status string) (Err logging.LogErrorInfo)
+
+	// Get Pending Approvals matching a filter (oldest first unless NewestFirst is set)
+	GetPendingApprovals(ctx context.Context, filter models.DBPendingApprovalFilter) (approvals []models.DBPendingApproval, Err logging.LogErrorInfo)
+
+	// Create ImageHistory table (if it does not exist yet)
+	CreateImageHistoryTable(ctx context.Context) (Err logging.LogErrorInfo)
+
+	// Record an image that was replaced on the media server
+	AddImageHistoryEntry(ctx context.Context, entry models.DBImageHistoryEntry) (id int64, Err logging.LogErrorInfo)
+
+	// Get Image History entries matching a filter (newest first)
+	GetImageHistory(ctx context.Context, filter models.DBImageHistoryFilter) (entries []models.DBImageHistoryEntry, Err logging.LogErrorInfo)
+
+	// Delete Image History entries by ID
+	DeleteImageHistoryEntries(ctx context.Context, ids []int64) (deleted int64, Err logging.LogErrorInfo)
+
+	// Create ImageFingerprints table (if it does not exist yet)
+	CreateImageFingerprintsTable(ctx context.Context) (Err logging.LogErrorInfo)
+
+	// Drop the ImageFingerprints table and create it again with the latest columns
+	RecreateImageFingerprintsTable(ctx context.Context) (Err logging.LogErrorInfo)
+
+	// Get the fingerprint of the image that was last applied in a place of a Media Item
+	GetImageFingerprint(ctx context.Context, server, tmdbID, libraryTitle, imageKey string) (fingerprint models.DBImageFingerprint, found bool, Err logging.LogErrorInfo)
+
+	// Insert or replace the fingerprint of an applied image
+	UpsertImageFingerprint(ctx context.Context, fingerprint models.DBImageFingerprint) (Err logging.LogErrorInfo)
+
+	// Delete the fingerprint of an image
+	DeleteImageFingerprint(ctx context.Context, server, tmdbID, libraryTitle, imageKey string) (Err logging.LogErrorInfo)
+
+	// Create JobCheckpoints table (if it does not exist yet)
+	CreateJobCheckpointsTable(ctx context.Context) (Err logging.LogErrorInfo)
+
+	// Get the time up to which a job has handled everything
+	GetJobCheckpoint(ctx context.Context, job string) (checkedAt time.Time, found bool, Err logging.LogErrorInfo)
+
+	// Save the time up to which a job has handled everything
+	SetJobCheckpoint(ctx context.Context, job string, checkedAt time.Time) (Err logging.LogErrorInfo)
 }
 
 func NewDatabaseClient() (DB, logging.LogErrorInfo) {
@@ -103,6 +208,8 @@ func NewDatabaseClient() (DB, logging.LogErrorInfo) {
 	switch dbConfig.Type {
 	case "sqlite3":
 		return &SQliteDB{Config: dbConfig}, logging.LogErrorInfo{}
+	case "postgresql", "mysql":
+		return &ServerDB{Config: dbConfig}, logging.LogErrorInfo{}
 	default:
 		return nil, logging.LogErrorInfo{
 			Message: fmt.Sprintf("unsupported database type: %s", dbConfig.Type),
@@ -115,12 +222,10 @@ func BuildDSN() (string, logging.LogErrorInfo) {
 	switch dbConfig.Type {
 	case "sqlite3":
 		return dbConfig.Path, logging.LogErrorInfo{}
-	// case "mysql":
-	// 	return fmt.Sprintf("%s:%s@tcp(%s:%d)/%s",
-	// 		dbConfig.User, dbConfig.Password, dbConfig.Host, dbConfig.Port, dbConfig.Name), logging.LogErrorInfo{}
-	// case "postgresql":
-	// 	return fmt.Sprintf("postgres://%s:%s@%s:%d/%s?sslmode=disable",
-	// 		dbConfig.User, dbConfig.Password, dbConfig.Host, dbConfig.Port, dbConfig.Name), logging.LogErrorInfo{}
+	case "mysql":
+		return buildMySQLDSN(dbConfig)
+	case "postgresql":
+		return buildPostgresDSN(dbConfig), logging.LogErrorInfo{}
 	default:
 		return "", logging.LogErrorInfo{
 			Message: fmt.Sprintf("unsupported database type: %s", dbConfig.Type),
@@ -132,6 +237,7 @@ func GetDBConnection(ctx context.Context) (conn *sql.DB, newDB bool, Err logging
 	if Client == nil {
 		return nil, false, logging.Error_DBClientNotInitialized()
 	}
+	defer lockClient(ctx)()
 	return Client.GetDBConnection(ctx)
 }
 
@@ -155,6 +261,7 @@ func CreateVersionTable(ctx context.Context) (Err logging.LogErrorInfo) {
 	if Client == nil {
 		return logging.Error_DBClientNotInitialized()
 	}
+	defer lockClient(ctx)()
 	return Client.CreateVersionTable(ctx)
 }
 
@@ -162,6 +269,7 @@ func GetCurrentVersion(ctx context.Context) (version int, Err logging.LogErrorIn
 	if Client == nil {
 		return 0, logging.Error_DBClientNotInitialized()
 	}
+	defer lockClient(ctx)()
 	return Client.GetCurrentVersion(ctx)
 }
 
@@ -169,6 +277,7 @@ func UpdateVersionTable(ctx context.Context, newVersion int) (Err logging.LogErr
 	if Client == nil {
 		return logging.Error_DBClientNotInitialized()
 	}
+	defer lockClient(ctx)()
 	return Client.UpdateVersionTable(ctx, newVersion)
 }
 
@@ -176,6 +285,7 @@ func CreateAuthTable(ctx context.Context) (Err logging.LogErrorInfo) {
 	if Client == nil {
 		return logging.Error_DBClientNotInitialized()
 	}
+	defer lockClient(ctx)()
 	return Client.CreateAuthTable(ctx)
 }
 
@@ -183,6 +293,7 @@ func GetAuthTokenSecret(ctx context.Context) (secret string, Err logging.LogErro
 	if Client == nil {
 		return "", logging.Error_DBClientNotInitialized()
 	}
+	defer lockClient(ctx)()
 	return Client.GetAuthTokenSecret(ctx)
 }
 
@@ -190,6 +301,7 @@ func Vacuum(ctx context.Context) (Err logging.LogErrorInfo) {
 	if Client == nil {
 		return logging.Error_DBClientNotInitialized()
 	}
+	defer lockClient(ctx)()
 	return Client.Vacuum(ctx)
 }
 
@@ -197,6 +309,7 @@ func CreateTables(ctx context.Context) (Err logging.LogErrorInfo) {
 	if Client == nil {
 		return logging.Error_DBClientNotInitialized()
 	}
+	defer lockClient(ctx)()
 	return Client.CreateTables(ctx)
 }
 
@@ -204,20 +317,33 @@ func Backup(ctx context.Context, currentVersion, newVersion int) (Err logging.Lo
 	if Client == nil {
 		return logging.Error_DBClientNotInitialized()
 	}
+	defer lockClient(ctx)()
 	return Client.Backup(ctx, currentVersion, newVersion)
 }
 
+// UpsertSavedItem saves an item of the primary media server with its poster sets.
+// SavedItems, the DownloadQueue and PendingApprovals have no server column: the copies on the
+// additional media servers are found by TMDB ID when the images are applied.
 func UpsertSavedItem(ctx context.Context, newItem models.DBSavedItem) (Err logging.LogErrorInfo) {
 	if Client == nil {
 		return logging.Error_DBClientNotInitialized()
 	}
-	return Client.UpsertSavedItem(ctx, newItem)
+	if newItem.MediaItem.Server != "" {
+		return logging.Error_AdditionalMediaServerItem()
+	}
+	defer lockClient(ctx)()
+	Err = Client.UpsertSavedItem(ctx, newItem)
+	if Err.Message == "" {
+		publishSavedSetsChanged(newItem.MediaItem.TMDB_ID, newItem.MediaItem.LibraryTitle)
+	}
+	return Err
 }
 
 func CheckIfMediaItemExists(ctx context.Context, TMDB_ID, libraryTitle string) (ignored bool, ignoreMode string, sets []models.DBSavedSet, logErr logging.LogErrorInfo) {
 	if Client == nil {
 		return false, "", []models.DBSavedSet{}, logging.Error_DBClientNotInitialized()
 	}
+	defer lockClient(ctx)()
 	return Client.CheckIfMediaItemExists(ctx, TMDB_ID, libraryTitle)
 }
 
@@ -225,6 +351,7 @@ func GetAllMediaItems(ctx context.Context) (items []models.MediaItem, logErr log
 	if Client == nil {
 		return []models.MediaItem{}, logging.Error_DBClientNotInitialized()
 	}
+	defer lockClient(ctx)()
 	return Client.GetAllMediaItems(ctx)
 }
 
@@ -232,6 +359,7 @@ func GetAllMediaItemsWithFlags(ctx context.Context) (items []MediaItemWithFlags,
 	if Client == nil {
 		return []MediaItemWithFlags{}, logging.Error_DBClientNotInitialized()
 	}
+	defer lockClient(ctx)()
 	return Client.GetAllMediaItemsWithFlags(ctx)
 }
 
@@ -239,6 +367,7 @@ func UpdateMediaItem(ctx context.Context, updatedItem models.MediaItem) (Err log
 	if Client == nil {
 		return logging.Error_DBClientNotInitialized()
 	}
+	defer lockClient(ctx)()
 	return Client.UpdateMediaItem(ctx, updatedItem)
 }
 
@@ -246,13 +375,19 @@ func DeleteMediaItemAndIgnoredStatus(ctx context.Context, TMDB_ID, libraryTitle
 	if Client == nil {
 		return logging.Error_DBClientNotInitialized()
 	}
-	return Client.DeleteMediaItemAndIgnoredStatus(ctx, TMDB_ID, libraryTitle)
+	defer lockClient(ctx)()
+	Err = Client.DeleteMediaItemAndIgnoredStatus(ctx, TMDB_ID, libraryTitle)
+	if Err.Message == "" {
+		publishSavedSetsChanged(TMDB_ID, libraryTitle)
+	}
+	return Err
 }
 
 func GetAllSavedSets(ctx context.Context, dbFilter models.DBFilter) (out PagedSavedItems, logErr logging.LogErrorInfo) {
 	if Client == nil {
 		return PagedSavedItems{}, logging.Error_DBClientNotInitialized()
 	}
+	defer lockClient(ctx)()
 	return Client.GetAllSavedSets(ctx, dbFilter)
 }
 
@@ -260,6 +395,7 @@ func GetAllUniqueUsers(ctx context.Context) (users []string, logErr logging.LogE
 	if Client == nil {
 		return []string{}, logging.Error_DBClientNotInitialized()
 	}
+	defer lockClient(ctx)()
 	return Client.GetAllUniqueUsers(ctx)
 }
 
@@ -274,20 +410,31 @@ func DeletePosterSetForMediaItem(ctx context.Context, tmdbID, libraryTitle, setI
 	if Client == nil {
 		return logging.Error_DBClientNotInitialized()
 	}
-	return Client.DeletePosterSetForMediaItem(ctx, tmdbID, libraryTitle, setID)
+	defer lockClient(ctx)()
+	Err = Client.DeletePosterSetForMediaItem(ctx, tmdbID, libraryTitle, setID)
+	if Err.Message == "" {
+		publishSavedSetsChanged(tmdbID, libraryTitle)
+	}
+	return Err
 }
 
 func DeleteAllPosterSetsForMediaItem(ctx context.Context, tmdbID, libraryTitle string) (Err logging.LogErrorInfo) {
 	if Client == nil {
 		return logging.Error_DBClientNotInitialized()
 	}
-	return Client.DeleteAllPosterSetsForMediaItem(ctx, tmdbID, libraryTitle)
+	defer lockClient(ctx)()
+	Err = Client.DeleteAllPosterSetsForMediaItem(ctx, tmdbID, libraryTitle)
+	if Err.Message == "" {
+		publishSavedSetsChanged(tmdbID, libraryTitle)
+	}
+	return Err
 }
 
 func IgnoreMediaItem(ctx context.Context, tmdbID, libraryTitle, mode, currentSets string) (Err logging.LogErrorInfo) {
 	if Client == nil {
 		return logging.Error_DBClientNotInitialized()
 	}
+	defer lockClient(ctx)()
 	return Client.IgnoreMediaItem(ctx, tmdbID, libraryTitle, mode, currentSets)
 }
 
@@ -295,6 +442,7 @@ func StopIgnoringMediaItem(ctx context.Context, TMDB_ID, libraryTitle string) (E
 	if Client == nil {
 		return logging.Error_DBClientNotInitialized()
 	}
+	defer lockClient(ctx)()
 	return Client.StopIgnoringMediaItem(ctx, TMDB_ID, libraryTitle)
 }
 
@@ -302,12 +450,212 @@ func GetTempIgnoredItems(ctx context.Context) (items []models.MediaItem, Err log
 	if Client == nil {
 		return []models.MediaItem{}, logging.Error_DBClientNotInitialized()
 	}
+	defer lockClient(ctx)()
 	return Client.GetTempIgnoredItems(ctx)
 }
 
+func GetAllIgnoredItems(ctx context.Context) (items []models.DBIgnoredItem, Err logging.LogErrorInfo) {
+	if Client == nil {
+		return []models.DBIgnoredItem{}, logging.Error_DBClientNotInitialized()
+	}
+	defer lockClient(ctx)()
+	return Client.GetAllIgnoredItems(ctx)
+}
+
 func UpdateMediaItemOnServer(ctx context.Context, tmdbID string, libraryTitle string, onServer bool) (logErr logging.LogErrorInfo) {
 	if Client == nil {
 		return logging.Error_DBClientNotInitialized()
 	}
+	defer lockClient(ctx)()
 	return Client.UpdateMediaItemOnServer(ctx, tmdbID, libraryTitle, onServer)
 }
+
+func CreateDownloadQueueTable(ctx context.Context) (Err logging.LogErrorInfo) {
+	if Client == nil {
+		return logging.Error_DBClientNotInitialized()
+	}
+	defer lockClient(ctx)()
+	return Client.CreateDownloadQueueTable(ctx)
+}
+
+func AddDownloadQueueEntry(ctx context.Context, item models.DBSavedItem) (id int64, Err logging.LogErrorInfo) {
+	if Client == nil {
+		return 0, logging.Error_DBClientNotInitialized()
+	}
+	if item.MediaItem.Server != "" {
+		return 0, logging.Error_AdditionalMediaServerItem()
+	}
+	defer lockClient(ctx)()
+	return Client.AddDownloadQueueEntry(ctx, item)
+}
+
+func UpdateDownloadQueueEntry(ctx context.Context, entry models.DBDownloadQueueEntry) (Err logging.LogErrorInfo) {
+	if Client == nil {
+		return logging.Error_DBClientNotInitialized()
+	}
+	defer lockClient(ctx)()
+	return Client.UpdateDownloadQueueEntry(ctx, entry)
+}
+
+func GetDownloadQueueEntries(ctx context.Context, filter models.DBDownloadQueueFilter) (entries []models.DBDownloadQueueEntry, Err logging.LogErrorInfo) {
+	if Client == nil {
+		return nil, logging.Error_DBClientNotInitialized()
+	}
+	defer lockClient(ctx)()
+	return Client.GetDownloadQueueEntries(ctx, filter)
+}
+
+func DeleteDownloadQueueEntries(ctx context.Context, filter models.DBDownloadQueueFilter) (deleted int64, Err logging.LogErrorInfo) {
+	if Client == nil {
+		return 0, logging.Error_DBClientNotInitialized()
+	}
+	defer lockClient(ctx)()
+	return Client.DeleteDownloadQueueEntries(ctx, filter)
+}
+
+func CreatePendingApprovalsTable(ctx context.Context) (Err logging.LogErrorInfo) {
+	if Client == nil {
+		return logging.Error_DBClientNotInitialized()
+	}
+	defer lockClient(ctx)()
+	return Client.CreatePendingApprovalsTable(ctx)
+}
+
+func AddPendingApproval(ctx context.Context, approval models.DBPendingApproval) (id int64, Err logging.LogErrorInfo) {
+	if Client == nil {
+		return 0, logging.Error_DBClientNotInitialized()
+	}
+	if approval.Item.MediaItem.Server != "" {
+		return 0, logging.Error_AdditionalMediaServerItem()
+	}
+	defer lockClient(ctx)()
+	return Client.AddPendingApproval(ctx, approval)
+}
+
+func UpdatePendingApprovalItem(ctx context.Context, id int64, item models.DBSavedItem, reason string) (Err logging.LogErrorInfo) {
+	if Client == nil {
+		return logging.Error_DBClientNotInitialized()
+	}
+	if item.MediaItem.Server != "" {
+		return logging.Error_AdditionalMediaServerItem()
+	}
+	defer lockClient(ctx)()
+	return Client.UpdatePendingApprovalItem(ctx, id, item, reason)
+}
+
+func UpdatePendingApprovalStatus(ctx context.Context, id int64, status string) (Err logging.LogErrorInfo) {
+	if Client == nil {
+		return logging.Error_DBClientNotInitialized()
+	}
+	defer lockClient(ctx)()
+	return Client.UpdatePendingApprovalStatus(ctx, id, status)
+}
+
+func GetPendingApprovals(ctx context.Context, filter models.DBPendingApprovalFilter) (approvals []models.DBPendingApproval, Err logging.LogErrorInfo) {
+	if Client == nil {
+		return nil, logging.Error_DBClientNotInitialized()
+	}
+	defer lockClient(ctx)()
+	return Client.GetPendingApprovals(ctx, filter)
+}
+
+func CreateImageHistoryTable(ctx context.Context) (Err logging.LogErrorInfo) {
+	if Client == nil {
+		return logging.Error_DBClientNotInitialized()
+	}
+	defer lockClient(ctx)()
+	return Client.CreateImageHistoryTable(ctx)
+}
+
+func AddImageHistoryEntry(ctx context.Context, entry models.DBImageHistoryEntry) (id int64, Err logging.LogErrorInfo) {
+	if Client == nil {
+		return 0, logging.Error_DBClientNotInitialized()
+	}
+	defer lockClient(ctx)()
+	return Client.AddImageHistoryEntry(ctx, entry)
+}
+
+func GetImageHistory(ctx context.Context, filter models.DBImageHistoryFilter) (entries []models.DBImageHistoryEntry, Err logging.LogErrorInfo) {
+	if Client == nil {
+		return nil, logging.Error_DBClientNotInitialized()
+	}
+	defer lockClient(ctx)()
+	return Client.GetImageHistory(ctx, filter)
+}
+
+func DeleteImageHistoryEntries(ctx context.Context, ids []int64) (deleted int64, Err logging.LogErrorInfo) {
+	if Client == nil {
+		return 0, logging.Error_DBClientNotInitialized()
+	}
+	defer lockClient(ctx)()
+	return Client.DeleteImageHistoryEntries(ctx, ids)
+}
+
+func CreateImageFingerprintsTable(ctx context.Context) (Err logging.LogErrorInfo) {
+	if Client == nil {
+		return logging.Error_DBClientNotInitialized()
+	}
+	defer lockClient(ctx)()
+	return Client.CreateImageFingerprintsTable(ctx)
+}
+
+func RecreateImageFingerprintsTable(ctx context.Context) (Err logging.LogErrorInfo) {
+	if Client == nil {
+		return logging.Error_DBClientNotInitialized()
+	}
+	defer lockClient(ctx)()
+	return Client.RecreateImageFingerprintsTable(ctx)
+}
+
+func GetImageFingerprint(ctx context.Context, server, tmdbID, libraryTitle, imageKey string) (fingerprint models.DBImageFingerprint, found bool, Err logging.LogErrorInfo) {
+	if Client == nil {
+		return fingerprint, false, logging.Error_DBClientNotInitialized()
+	}
+	defer lockClient(ctx)()
+	return Client.GetImageFingerprint(ctx, server, tmdbID, libraryTitle, imageKey)
+}
+
+func UpsertImageFingerprint(ctx context.Context, fingerprint models.DBImageFingerprint) (Err logging.LogErrorInfo) {
+	if Client == nil {
+		return logging.Error_DBClientNotInitialized()
+	}
+	defer lockClient(ctx)()
+	return Client.UpsertImageFingerprint(ctx, fingerprint)
+}
+
+func DeleteImageFingerprint(ctx context.Context, server, tmdbID, libraryTitle, imageKey string) (Err logging.LogErrorInfo) {
+	if Client == nil {
+		return logging.Error_DBClientNotInitialized()
+	}
+	defer lockClient(ctx)()
+	return Client.DeleteImageFingerprint(ctx, server, tmdbID, libraryTitle, imageKey)
+}
+
+func CreateJobCheckpointsTable(ctx context.Context) (Err logging.LogErrorInfo) {
+	if Client == nil {
+		return logging.Error_DBClientNotInitialized()
+	}
+	defer lockClient(ctx)()
+	return Client.CreateJobCheckpointsTable(ctx)
+}
+
+func GetJobCheckpoint(ctx context.Context, job string) (checkedAt time.Time, found bool, Err logging.LogErrorInfo) {
+	if Client == nil {
+		return checkedAt, false, logging.Error_DBClientNotInitialized()
+	}
+	defer lockClient(ctx)()
+	return Client.GetJobCheckpoint(ctx, job)
+}
+
+func SetJobCheckpoint(ctx context.Context, job string, checkedAt time.Time) (Err logging.LogErrorInfo) {
+	if Client == nil {
+		return logging.Error_DBClientNotInitialized()
+	}
+	defer lockClient(ctx)()
+	return Client.SetJobCheckpoint(ctx, job, checkedAt)
+}
+
+// publishSavedSetsChanged lets listeners (e.g. the Kometa export) know that the saved sets of an item changed
+func publishSavedSetsChanged(tmdbID, libraryTitle string) {
+	events.Publish(events.TypeSavedSetsChanged, events.SavedSetsChange{TMDB_ID: tmdbID, LibraryTitle: libraryTitle})
+}
@@ -0,0 +1,130 @@
+package database
+
+import (
+	"aura/models"
+	"database/sql"
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// downloadQueueColumns are the columns read by scanDownloadQueueEntry, in order
+const downloadQueueColumns = `id, status, attempts, item, results, errors, warnings, created_at, updated_at, started_at, finished_at, next_attempt_at`
+
+// buildDownloadQueueWhere returns the WHERE clause (with "?" placeholders) and its arguments for a Download Queue filter
+func buildDownloadQueueWhere(filter models.DBDownloadQueueFilter) (whereSQL string, args []any) {
+	conds := []string{}
+	args = []any{}
+
+	if filter.ID != 0 {
+		conds = append(conds, "id = ?")
+		args = append(args, filter.ID)
+	}
+	if len(filter.Statuses) > 0 {
+		conds = append(conds, "status IN ("+placeholders(len(filter.Statuses))+")")
+		for _, status := range filter.Statuses {
+			args = append(args, status)
+		}
+	}
+	if filter.TMDB_ID != "" {
+		conds = append(conds, "tmdb_id = ?")
+		args = append(args, filter.TMDB_ID)
+	}
+	if filter.LibraryTitle != "" {
+		conds = append(conds, "library_title = ?")
+		args = append(args, filter.LibraryTitle)
+	}
+	if filter.Server != nil {
+		conds = append(conds, "server = ?")
+		args = append(args, *filter.Server)
+	}
+
+	if filter.DueBy != nil {
+		conds = append(conds, "(next_attempt_at IS NULL OR next_attempt_at <= ?)")
+		args = append(args, filter.DueBy.UTC())
+	}
+
+	if len(conds) == 0 {
+		return "", args
+	}
+	return " WHERE " + strings.Join(conds, " AND "), args
+}
+
+// marshalDownloadQueueEntry encodes the JSON columns of a Download Queue entry
+func marshalDownloadQueueEntry(entry models.DBDownloadQueueEntry) (item, results, errors, warnings string, err error) {
+	if entry.Results == nil {
+		entry.Results = []models.DownloadQueueImageResult{}
+	}
+	if entry.Errors == nil {
+		entry.Errors = []string{}
+	}
+	if entry.Warnings == nil {
+		entry.Warnings = []string{}
+	}
+
+	values := []any{entry.Item, entry.Results, entry.Errors, entry.Warnings}
+	encoded := make([]string, len(values))
+	for i, v := range values {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return "", "", "", "", err
+		}
+		encoded[i] = string(data)
+	}
+	return encoded[0], encoded[1], encoded[2], encoded[3], nil
+}
+
+// scanDownloadQueueEntry reads a row selected with downloadQueueColumns
+func scanDownloadQueueEntry(rows *sql.Rows) (entry models.DBDownloadQueueEntry, err error) {
+	var item, results, errors, warnings string
+	var startedAt, finishedAt, nextAttemptAt sql.NullTime
+
+	err = rows.Scan(
+		&entry.ID,
+		&entry.Status,
+		&entry.Attempts,
+		&item,
+		&results,
+		&errors,
+		&warnings,
+		&entry.CreatedAt,
+		&entry.UpdatedAt,
+		&startedAt,
+		&finishedAt,
+		&nextAttemptAt,
+	)
+	if err != nil {
+		return entry, err
+	}
+
+	if err = json.Unmarshal([]byte(item), &entry.Item); err != nil {
+		return entry, err
+	}
+	if err = json.Unmarshal([]byte(results), &entry.Results); err != nil {
+		return entry, err
+	}
+	if err = json.Unmarshal([]byte(errors), &entry.Errors); err != nil {
+		return entry, err
+	}
+	if err = json.Unmarshal([]byte(warnings), &entry.Warnings); err != nil {
+		return entry, err
+	}
+	if startedAt.Valid {
+		entry.StartedAt = &startedAt.Time
+	}
+	if finishedAt.Valid {
+		entry.FinishedAt = &finishedAt.Time
+	}
+	if nextAttemptAt.Valid {
+		entry.NextAttemptAt = &nextAttemptAt.Time
+	}
+	return entry, nil
+}
+
+// nullTime converts an optional time into a value for a nullable column
+func nullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: t.UTC(), Valid: true}
+}
@@ -0,0 +1,4 @@
+package database
+
+// imageFingerprintColumns are the columns read into a models.DBImageFingerprint, in order
+const imageFingerprintColumns = `server, tmdb_id, library_title, image_key, image_rating_key, image_id, perceptual_hash, applied_at`
@@ -0,0 +1,92 @@
+package database
+
+import (
+	"aura/models"
+	"database/sql"
+	"strings"
+)
+
+// imageHistoryColumns are the columns read by scanImageHistoryEntry, in order
+const imageHistoryColumns = `id, tmdb_id, library_title, server, title, rating_key, image_rating_key, image_type, season_number, episode_number, file_name, replaced_by, created_at`
+
+// buildImageHistoryWhere returns the WHERE clause (with "?" placeholders) and its arguments for an Image History filter
+func buildImageHistoryWhere(filter models.DBImageHistoryFilter) (whereSQL string, args []any) {
+	conds := []string{}
+	args = []any{}
+
+	if filter.ID != 0 {
+		conds = append(conds, "id = ?")
+		args = append(args, filter.ID)
+	}
+	if filter.TMDB_ID != "" {
+		conds = append(conds, "tmdb_id = ?")
+		args = append(args, filter.TMDB_ID)
+	}
+	if filter.LibraryTitle != "" {
+		conds = append(conds, "library_title = ?")
+		args = append(args, filter.LibraryTitle)
+	}
+	if filter.Server != "" {
+		conds = append(conds, "server = ?")
+		args = append(args, filter.Server)
+	}
+	if filter.RatingKey != "" {
+		conds = append(conds, "rating_key = ?")
+		args = append(args, filter.RatingKey)
+	}
+	if filter.ImageRatingKey != "" {
+		conds = append(conds, "image_rating_key = ?")
+		args = append(args, filter.ImageRatingKey)
+	}
+	if filter.ImageType != "" {
+		conds = append(conds, "image_type = ?")
+		args = append(args, filter.ImageType)
+	}
+
+	if len(conds) == 0 {
+		return "", args
+	}
+	return " WHERE " + strings.Join(conds, " AND "), args
+}
+
+// scanImageHistoryEntry reads a row selected with imageHistoryColumns
+func scanImageHistoryEntry(rows *sql.Rows) (entry models.DBImageHistoryEntry, err error) {
+	var seasonNumber, episodeNumber sql.NullInt64
+
+	err = rows.Scan(
+		&entry.ID,
+		&entry.TMDB_ID,
+		&entry.LibraryTitle,
+		&entry.Server,
+		&entry.Title,
+		&entry.RatingKey,
+		&entry.ImageRatingKey,
+		&entry.ImageType,
+		&seasonNumber,
+		&episodeNumber,
+		&entry.FileName,
+		&entry.ReplacedBy,
+		&entry.CreatedAt,
+	)
+	if err != nil {
+		return entry, err
+	}
+
+	if seasonNumber.Valid {
+		n := int(seasonNumber.Int64)
+		entry.SeasonNumber = &n
+	}
+	if episodeNumber.Valid {
+		n := int(episodeNumber.Int64)
+		entry.EpisodeNumber = &n
+	}
+	return entry, nil
+}
+
+// nullableInt stores a nil number as NULL
+func nullableInt(n *int) any {
+	if n == nil {
+		return nil
+	}
+	return *n
+}
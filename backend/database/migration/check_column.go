@@ -15,6 +15,31 @@ func checkColumnExists(ctx context.Context, tableName string, columnName string)
 		return false, getDBConnErr
 	}
 
+	// Database servers list their columns in information_schema
+	if dbType := database.GetConfig().Type; dbType != "sqlite3" {
+		query := `
+			SELECT COUNT(*)
+			FROM information_schema.columns
+			WHERE table_schema = DATABASE() AND LOWER(table_name) = LOWER(?) AND LOWER(column_name) = LOWER(?);
+		`
+		if dbType == "postgresql" {
+			query = `
+			SELECT COUNT(*)
+			FROM information_schema.columns
+			WHERE table_schema = current_schema() AND LOWER(table_name) = LOWER($1) AND LOWER(column_name) = LOWER($2);
+		`
+		}
+		var count int
+		if err := conn.QueryRowContext(ctx, query, tableName, columnName).Scan(&count); err != nil {
+			Err = logging.LogErrorInfo{
+				Message: "Failed to query " + tableName + " table info",
+				Detail:  map[string]any{"error": err.Error()},
+			}
+			return false, Err
+		}
+		return count > 0, Err
+	}
+
 	// Check if the column already exists to avoid duplicate column error
 	checkColumnQuery := `PRAGMA table_info(` + tableName + `);`
 	rows, err := conn.QueryContext(ctx, checkColumnQuery)
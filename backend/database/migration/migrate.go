@@ -13,6 +13,15 @@ func RunMigrations() (migrationsPerformed int, Err logging.LogErrorInfo) {
 	ctx = logging.WithCurrentAction(ctx, logAction)
 	defer logAction.Complete()
 
+	return MigrateToLatest(ctx)
+}
+
+// MigrateToLatest runs the migrations from the current database version up to database.LATEST_DB_VERSION.
+// It is also used after a backup of an older version was restored.
+func MigrateToLatest(ctx context.Context) (migrationsPerformed int, Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Migrating Database to Latest Version", logging.LevelInfo)
+	defer logAction.Complete()
+
 	migrationsPerformed = 0
 	Err = logging.LogErrorInfo{}
 
@@ -27,6 +36,17 @@ func RunMigrations() (migrationsPerformed int, Err logging.LogErrorInfo) {
 		return migrationsPerformed, Err
 	}
 
+	// The migrations up to v5 are written for SQLite.
+	// PostgreSQL and MySQL databases were first created at v5.
+	if dbType := database.GetConfig().Type; dbType != "sqlite3" && currentVersion < 5 {
+		logAction.SetError("No migration path for database type", "Create a new database and import your saved sets", map[string]any{
+			"type":           dbType,
+			"currentVersion": currentVersion,
+			"latestVersion":  database.LATEST_DB_VERSION,
+		})
+		return migrationsPerformed, *logAction.Error
+	}
+
 	// Run migrations as needed
 	for v := currentVersion; v < database.LATEST_DB_VERSION; v++ {
 		migrateErr := logging.LogErrorInfo{}
@@ -61,6 +81,48 @@ func RunMigrations() (migrationsPerformed int, Err logging.LogErrorInfo) {
 				return migrationsPerformed, migrateErr
 			}
 			migrationsPerformed++
+		case 5:
+			migrateErr = migrate_5_to_6(ctx)
+			if migrateErr.Message != "" {
+				return migrationsPerformed, migrateErr
+			}
+			migrationsPerformed++
+		case 6:
+			migrateErr = migrate_6_to_7(ctx)
+			if migrateErr.Message != "" {
+				return migrationsPerformed, migrateErr
+			}
+			migrationsPerformed++
+		case 7:
+			migrateErr = migrate_7_to_8(ctx)
+			if migrateErr.Message != "" {
+				return migrationsPerformed, migrateErr
+			}
+			migrationsPerformed++
+		case 8:
+			migrateErr = migrate_8_to_9(ctx)
+			if migrateErr.Message != "" {
+				return migrationsPerformed, migrateErr
+			}
+			migrationsPerformed++
+		case 9:
+			migrateErr = migrate_9_to_10(ctx)
+			if migrateErr.Message != "" {
+				return migrationsPerformed, migrateErr
+			}
+			migrationsPerformed++
+		case 10:
+			migrateErr = migrate_10_to_11(ctx)
+			if migrateErr.Message != "" {
+				return migrationsPerformed, migrateErr
+			}
+			migrationsPerformed++
+		case 11:
+			migrateErr = migrate_11_to_12(ctx)
+			if migrateErr.Message != "" {
+				return migrationsPerformed, migrateErr
+			}
+			migrationsPerformed++
 		default:
 			logging.LOGGER.Error().Msgf("No migration path for database version %d", v)
 			return migrationsPerformed, logging.LogErrorInfo{Message: "No migration path for database version %d"}
@@ -0,0 +1,30 @@
+package migration
+
+import (
+	"aura/database"
+	"aura/logging"
+	"context"
+)
+
+// migrate_10_to_11 adds the JobCheckpoints table
+func migrate_10_to_11(ctx context.Context) (Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Migrating Database from v10 to v11", logging.LevelInfo)
+	defer logAction.Complete()
+	logging.LOGGER.Info().Timestamp().Int("From Version", 10).Int("To Version", 11).Msg("Starting database migration")
+
+	Err = logging.LogErrorInfo{}
+
+	// Create a backup of the current database
+	backupErr := database.Backup(ctx, 10, 11)
+	if backupErr.Message != "" {
+		return backupErr
+	}
+
+	createErr := database.CreateJobCheckpointsTable(ctx)
+	if createErr.Message != "" {
+		return createErr
+	}
+
+	logging.LOGGER.Info().Timestamp().Msg("Database migration v10.0 to v11.0 completed successfully")
+	return Err
+}
@@ -0,0 +1,32 @@
+package migration
+
+import (
+	"aura/database"
+	"aura/logging"
+	"context"
+)
+
+// migrate_11_to_12 keys the ImageFingerprints table by media server and stores perceptual hashes.
+// The old SHA-256 hashes cannot be compared with perceptual hashes, so the fingerprints are dropped.
+// The next upload of each image records a new fingerprint.
+func migrate_11_to_12(ctx context.Context) (Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Migrating Database from v11 to v12", logging.LevelInfo)
+	defer logAction.Complete()
+	logging.LOGGER.Info().Timestamp().Int("From Version", 11).Int("To Version", 12).Msg("Starting database migration")
+
+	Err = logging.LogErrorInfo{}
+
+	// Create a backup of the current database
+	backupErr := database.Backup(ctx, 11, 12)
+	if backupErr.Message != "" {
+		return backupErr
+	}
+
+	recreateErr := database.RecreateImageFingerprintsTable(ctx)
+	if recreateErr.Message != "" {
+		return recreateErr
+	}
+
+	logging.LOGGER.Info().Timestamp().Msg("Database migration v11.0 to v12.0 completed successfully")
+	return Err
+}
@@ -0,0 +1,31 @@
+package migration
+
+import (
+	"aura/database"
+	"aura/logging"
+	"context"
+)
+
+// migrate_5_to_6 adds the DownloadQueue table.
+// Unlike the earlier migrations this one also runs for PostgreSQL and MySQL.
+func migrate_5_to_6(ctx context.Context) (Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Migrating Database from v5 to v6", logging.LevelInfo)
+	defer logAction.Complete()
+	logging.LOGGER.Info().Timestamp().Int("From Version", 5).Int("To Version", 6).Msg("Starting database migration")
+
+	Err = logging.LogErrorInfo{}
+
+	// Create a backup of the current database
+	backupErr := database.Backup(ctx, 5, 6)
+	if backupErr.Message != "" {
+		return backupErr
+	}
+
+	createErr := database.CreateDownloadQueueTable(ctx)
+	if createErr.Message != "" {
+		return createErr
+	}
+
+	logging.LOGGER.Info().Timestamp().Msg("Database migration v5.0 to v6.0 completed successfully")
+	return Err
+}
@@ -0,0 +1,57 @@
+package migration
+
+import (
+	"aura/database"
+	"aura/logging"
+	"context"
+	"fmt"
+)
+
+// migrate_6_to_7 adds the next_attempt_at column used to schedule retries of Download Queue entries
+func migrate_6_to_7(ctx context.Context) (Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Migrating Database from v6 to v7", logging.LevelInfo)
+	defer logAction.Complete()
+	logging.LOGGER.Info().Timestamp().Int("From Version", 6).Int("To Version", 7).Msg("Starting database migration")
+
+	Err = logging.LogErrorInfo{}
+
+	// Create a backup of the current database
+	backupErr := database.Backup(ctx, 6, 7)
+	if backupErr.Message != "" {
+		return backupErr
+	}
+
+	// Get DB connection
+	conn, _, getDBConnErr := database.GetDBConnection(ctx)
+	if getDBConnErr.Message != "" {
+		return getDBConnErr
+	}
+
+	// Check if the "next_attempt_at" column already exists to avoid duplicate column error
+	columnExists, checkColumnErr := checkColumnExists(ctx, "DownloadQueue", "next_attempt_at")
+	if checkColumnErr.Message != "" {
+		return checkColumnErr
+	}
+
+	if !columnExists {
+		columnType := "DATETIME"
+		switch database.GetConfig().Type {
+		case "postgresql":
+			columnType = "TIMESTAMPTZ"
+		case "mysql":
+			columnType = "DATETIME(6)"
+		}
+
+		query := fmt.Sprintf(`ALTER TABLE DownloadQueue ADD COLUMN next_attempt_at %s NULL;`, columnType)
+		if _, err := conn.ExecContext(ctx, query); err != nil {
+			logAction.SetError("Failed to add next_attempt_at column to DownloadQueue table", "", map[string]any{
+				"error": err.Error(),
+				"query": query,
+			})
+			return *logAction.Error
+		}
+	}
+
+	logging.LOGGER.Info().Timestamp().Msg("Database migration v6.0 to v7.0 completed successfully")
+	return Err
+}
@@ -0,0 +1,30 @@
+package migration
+
+import (
+	"aura/database"
+	"aura/logging"
+	"context"
+)
+
+// migrate_7_to_8 adds the PendingApprovals table
+func migrate_7_to_8(ctx context.Context) (Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Migrating Database from v7 to v8", logging.LevelInfo)
+	defer logAction.Complete()
+	logging.LOGGER.Info().Timestamp().Int("From Version", 7).Int("To Version", 8).Msg("Starting database migration")
+
+	Err = logging.LogErrorInfo{}
+
+	// Create a backup of the current database
+	backupErr := database.Backup(ctx, 7, 8)
+	if backupErr.Message != "" {
+		return backupErr
+	}
+
+	createErr := database.CreatePendingApprovalsTable(ctx)
+	if createErr.Message != "" {
+		return createErr
+	}
+
+	logging.LOGGER.Info().Timestamp().Msg("Database migration v7.0 to v8.0 completed successfully")
+	return Err
+}
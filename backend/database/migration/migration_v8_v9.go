@@ -0,0 +1,30 @@
+package migration
+
+import (
+	"aura/database"
+	"aura/logging"
+	"context"
+)
+
+// migrate_8_to_9 adds the ImageHistory table
+func migrate_8_to_9(ctx context.Context) (Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Migrating Database from v8 to v9", logging.LevelInfo)
+	defer logAction.Complete()
+	logging.LOGGER.Info().Timestamp().Int("From Version", 8).Int("To Version", 9).Msg("Starting database migration")
+
+	Err = logging.LogErrorInfo{}
+
+	// Create a backup of the current database
+	backupErr := database.Backup(ctx, 8, 9)
+	if backupErr.Message != "" {
+		return backupErr
+	}
+
+	createErr := database.CreateImageHistoryTable(ctx)
+	if createErr.Message != "" {
+		return createErr
+	}
+
+	logging.LOGGER.Info().Timestamp().Msg("Database migration v8.0 to v9.0 completed successfully")
+	return Err
+}
@@ -0,0 +1,86 @@
+package database
+
+import (
+	"aura/models"
+	"database/sql"
+	"encoding/json"
+	"strings"
+)
+
+// pendingApprovalColumns are the columns read by scanPendingApproval, in order
+const pendingApprovalColumns = `id, kind, status, item, reason, created_at, decided_at`
+
+// buildPendingApprovalsWhere returns the WHERE clause (with "?" placeholders) and its arguments for a Pending Approvals filter
+func buildPendingApprovalsWhere(filter models.DBPendingApprovalFilter) (whereSQL string, args []any) {
+	conds := []string{}
+	args = []any{}
+
+	if filter.ID != 0 {
+		conds = append(conds, "id = ?")
+		args = append(args, filter.ID)
+	}
+	if len(filter.Kinds) > 0 {
+		conds = append(conds, "kind IN ("+placeholders(len(filter.Kinds))+")")
+		for _, kind := range filter.Kinds {
+			args = append(args, kind)
+		}
+	}
+	if len(filter.Statuses) > 0 {
+		conds = append(conds, "status IN ("+placeholders(len(filter.Statuses))+")")
+		for _, status := range filter.Statuses {
+			args = append(args, status)
+		}
+	}
+	if filter.TMDB_ID != "" {
+		conds = append(conds, "tmdb_id = ?")
+		args = append(args, filter.TMDB_ID)
+	}
+	if filter.LibraryTitle != "" {
+		conds = append(conds, "library_title = ?")
+		args = append(args, filter.LibraryTitle)
+	}
+	if filter.SetID != "" {
+		conds = append(conds, "set_id = ?")
+		args = append(args, filter.SetID)
+	}
+
+	if len(conds) == 0 {
+		return "", args
+	}
+	return " WHERE " + strings.Join(conds, " AND "), args
+}
+
+// pendingApprovalSetID returns the ID of the first Poster Set of an approval, used to find approvals of the same set
+func pendingApprovalSetID(approval models.DBPendingApproval) string {
+	if len(approval.Item.PosterSets) == 0 {
+		return ""
+	}
+	return approval.Item.PosterSets[0].ID
+}
+
+// scanPendingApproval reads a row selected with pendingApprovalColumns
+func scanPendingApproval(rows *sql.Rows) (approval models.DBPendingApproval, err error) {
+	var item string
+	var decidedAt sql.NullTime
+
+	err = rows.Scan(
+		&approval.ID,
+		&approval.Kind,
+		&approval.Status,
+		&item,
+		&approval.Reason,
+		&approval.CreatedAt,
+		&decidedAt,
+	)
+	if err != nil {
+		return approval, err
+	}
+
+	if err = json.Unmarshal([]byte(item), &approval.Item); err != nil {
+		return approval, err
+	}
+	if decidedAt.Valid {
+		approval.DecidedAt = &decidedAt.Time
+	}
+	return approval, nil
+}
@@ -0,0 +1,70 @@
+package database
+
+import (
+	"aura/logging"
+	"context"
+	"database/sql"
+)
+
+func (s *ServerDB) CreateAuthTable(ctx context.Context) (Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Creating AUTH Table", logging.LevelDebug)
+	defer logAction.Complete()
+
+	query := `
+	CREATE TABLE IF NOT EXISTS AUTH (
+		token_secret TEXT NOT NULL
+	);
+	`
+	_, err := s.conn.ExecContext(ctx, query)
+	if err != nil {
+		logAction.SetError("Failed to create AUTH table", err.Error(), map[string]any{
+			"error": err.Error(),
+			"query": query,
+		})
+		return *logAction.Error
+	}
+
+	return logging.LogErrorInfo{}
+}
+
+func (s *ServerDB) GetAuthTokenSecret(ctx context.Context) (secret string, Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Getting Auth Token Secret", logging.LevelDebug)
+	defer logAction.Complete()
+
+	query := `SELECT token_secret FROM AUTH LIMIT 1;`
+	row := s.conn.QueryRowContext(ctx, query)
+
+	var tokenSecret string
+	err := row.Scan(&tokenSecret)
+	if err != nil {
+		// If no secret exists yet, generate + persist one.
+		if err == sql.ErrNoRows {
+			newSecret, genErr := generateTokenAuthSecret()
+			if genErr != nil {
+				logAction.SetError("Failed to generate Auth Token Secret", genErr.Error(), map[string]any{
+					"error": genErr.Error(),
+				})
+				return "", *logAction.Error
+			}
+
+			insertQuery := s.rebind(`INSERT INTO AUTH (token_secret) VALUES (?);`)
+			if _, execErr := s.conn.ExecContext(ctx, insertQuery, newSecret); execErr != nil {
+				logAction.SetError("Failed to persist Auth Token Secret", execErr.Error(), map[string]any{
+					"error": execErr.Error(),
+					"query": insertQuery,
+				})
+				return "", *logAction.Error
+			}
+
+			return newSecret, logging.LogErrorInfo{}
+		}
+
+		logAction.SetError("Failed to get Auth Token Secret", err.Error(), map[string]any{
+			"error": err.Error(),
+			"query": query,
+		})
+		return "", *logAction.Error
+	}
+
+	return tokenSecret, logging.LogErrorInfo{}
+}
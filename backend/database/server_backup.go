@@ -0,0 +1,136 @@
+package database
+
+import (
+	"aura/config"
+	"aura/logging"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"time"
+)
+
+// Backup writes the rows of every table to a JSON file in the config directory.
+// Database servers are usually backed up by their own tooling, this is a safety net before migrations.
+func (s *ServerDB) Backup(ctx context.Context, currentVersion, newVersion int) (Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf("Backing up %s Database", s.Config.Type), logging.LevelInfo)
+	defer logAction.Complete()
+
+	Err = logging.LogErrorInfo{}
+
+	tables := append([]string{"VERSION", "AUTH"}, serverTables...)
+	dump := make(map[string][]map[string]any, len(tables))
+
+	for _, table := range tables {
+		actionDumpTable := logAction.AddSubAction(fmt.Sprintf("Reading %s table", table), logging.LevelTrace)
+
+		// Tables added in a later version do not exist yet when backing up before a migration
+		exists, err := s.tableExists(ctx, s.conn, table)
+		if err == nil && !exists {
+			actionDumpTable.Complete()
+			continue
+		}
+
+		rowsOut, err := s.dumpTable(ctx, table)
+		if err != nil {
+			actionDumpTable.SetError(fmt.Sprintf("Failed to read %s table for backup", table),
+				"Ensure the database is accessible.",
+				map[string]any{
+					"error": err.Error(),
+					"table": table,
+				})
+			return *actionDumpTable.Error
+		}
+		dump[table] = rowsOut
+		actionDumpTable.Complete()
+	}
+
+	data, err := json.MarshalIndent(dump, "", "  ")
+	if err != nil {
+		logAction.SetError("Failed to encode database backup", "", map[string]any{
+			"error": err.Error(),
+		})
+		return *logAction.Error
+	}
+
+	// Create a backup file path with version and timestamp
+	timestamp := time.Now().Format("20060102_150405")
+	backupPath := path.Join(config.ConfigPath, fmt.Sprintf("AURA_%s_backup_v%d_to_v%d_%s.json", s.Config.Type, currentVersion, newVersion, timestamp))
+
+	if err := os.WriteFile(backupPath, data, 0644); err != nil {
+		logAction.SetError("Failed to write database backup file",
+			"Ensure the destination path is accessible and writable.",
+			map[string]any{
+				"error": err.Error(),
+				"path":  backupPath,
+			})
+		return *logAction.Error
+	}
+	logAction.AppendResult("backup_path", backupPath)
+
+	return Err
+}
+
+// dumpTable reads all rows of a table as column -> value maps
+func (s *ServerDB) dumpTable(ctx context.Context, table string) (out []map[string]any, err error) {
+	out = []map[string]any{}
+
+	rows, err := s.conn.QueryContext(ctx, fmt.Sprintf("SELECT * FROM %s;", table))
+	if err != nil {
+		return out, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return out, err
+	}
+
+	for rows.Next() {
+		values := make([]any, len(columns))
+		pointers := make([]any, len(columns))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+		if err := rows.Scan(pointers...); err != nil {
+			return out, err
+		}
+
+		row := make(map[string]any, len(columns))
+		for i, column := range columns {
+			if b, ok := values[i].([]byte); ok {
+				row[column] = string(b)
+			} else {
+				row[column] = values[i]
+			}
+		}
+		out = append(out, row)
+	}
+
+	return out, rows.Err()
+}
+
+func (s *ServerDB) BackupTo(ctx context.Context, destPath string) (Err logging.LogErrorInfo) {
+	_, logAction := logging.AddSubActionToContext(ctx, "Creating Database Backup", logging.LevelInfo)
+	defer logAction.Complete()
+
+	logAction.SetError("Scheduled backups are not supported for this database type",
+		fmt.Sprintf("Use the backup tools of your %s server, or the saved sets export", s.Config.Type),
+		map[string]any{
+			"type": s.Config.Type,
+		})
+	return *logAction.Error
+}
+
+func (s *ServerDB) RestoreFrom(ctx context.Context, srcPath string) (Err logging.LogErrorInfo) {
+	_, logAction := logging.AddSubActionToContext(ctx, "Restoring Database Backup", logging.LevelInfo)
+	defer logAction.Complete()
+
+	logAction.SetError("Restoring backups is not supported for this database type",
+		fmt.Sprintf("Use the backup tools of your %s server, or the saved sets import", s.Config.Type),
+		map[string]any{
+			"type": s.Config.Type,
+		})
+	return *logAction.Error
+}
@@ -0,0 +1,497 @@
+package database
+
+import (
+	"aura/logging"
+	"context"
+	"fmt"
+)
+
+// serverColumnTypes holds the column types that differ between the database servers
+type serverColumnTypes struct {
+	ID       string // Auto-incrementing primary key
+	Key      string // Text column used in a primary key, unique constraint or index
+	DateTime string // Date and time
+	LongText string // Text column for large JSON documents
+}
+
+func (s *ServerDB) columnTypes() serverColumnTypes {
+	if s.Config.Type == "postgresql" {
+		return serverColumnTypes{
+			ID:       "BIGSERIAL PRIMARY KEY",
+			Key:      "TEXT",
+			DateTime: "TIMESTAMPTZ",
+			LongText: "TEXT",
+		}
+	}
+	return serverColumnTypes{
+		ID:       "BIGINT AUTO_INCREMENT PRIMARY KEY",
+		Key:      "VARCHAR(255)",
+		DateTime: "DATETIME(6)",
+		LongText: "MEDIUMTEXT",
+	}
+}
+
+// serverTables lists the main tables in the order they have to be created (parents before children)
+var serverTables = []string{"MediaItems", "Movies", "Series", "Seasons", "Episodes", "PosterSets", "ImageFiles", "SavedItems", "IgnoredItems", "DownloadQueue", "PendingApprovals", "ImageHistory", "ImageFingerprints", "JobCheckpoints"}
+
+func (s *ServerDB) CreateTables(ctx context.Context) (Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Creating Database Tables", logging.LevelInfo)
+	defer logAction.Complete()
+
+	Err = logging.LogErrorInfo{}
+	t := s.columnTypes()
+
+	tableQueries := map[string]string{
+		"MediaItems": fmt.Sprintf(`
+CREATE TABLE MediaItems (
+	id %[1]s,
+	tmdb_id %[2]s NOT NULL,
+	library_title %[2]s NOT NULL,
+	rating_key TEXT NOT NULL,
+	type %[2]s NOT NULL CHECK (type IN ('movie','show')),
+	title TEXT NOT NULL,
+	year INTEGER NOT NULL,
+	on_server INTEGER NOT NULL DEFAULT 0 CHECK (on_server IN (0,1)),
+	UNIQUE (tmdb_id, library_title)
+)`, t.ID, t.Key),
+
+		"Movies": fmt.Sprintf(`
+CREATE TABLE Movies (
+	id %[1]s,
+	media_item_id BIGINT NOT NULL UNIQUE,
+	path TEXT NOT NULL,
+	size BIGINT NOT NULL,
+	duration BIGINT NOT NULL,
+	FOREIGN KEY (media_item_id) REFERENCES MediaItems(id)
+		ON DELETE CASCADE
+		ON UPDATE CASCADE
+)`, t.ID),
+
+		"Series": fmt.Sprintf(`
+CREATE TABLE Series (
+	id %[1]s,
+	media_item_id BIGINT NOT NULL UNIQUE,
+	season_count INTEGER,
+	episode_count INTEGER,
+	location TEXT,
+	FOREIGN KEY (media_item_id) REFERENCES MediaItems(id)
+		ON DELETE CASCADE
+		ON UPDATE CASCADE
+)`, t.ID),
+
+		"Seasons": fmt.Sprintf(`
+CREATE TABLE Seasons (
+	id %[1]s,
+	series_id BIGINT NOT NULL,
+	rating_key TEXT NOT NULL,
+	season_number INTEGER NOT NULL,
+	episode_count INTEGER,
+	FOREIGN KEY (series_id) REFERENCES Series(id)
+		ON DELETE CASCADE
+		ON UPDATE CASCADE,
+	UNIQUE (series_id, season_number)
+)`, t.ID),
+
+		"Episodes": fmt.Sprintf(`
+CREATE TABLE Episodes (
+	id %[1]s,
+	season_id BIGINT NOT NULL,
+	rating_key TEXT NOT NULL,
+	episode_number INTEGER NOT NULL,
+	title TEXT,
+	path TEXT NOT NULL,
+	size BIGINT NOT NULL,
+	duration BIGINT NOT NULL,
+	FOREIGN KEY (season_id) REFERENCES Seasons(id)
+		ON DELETE CASCADE
+		ON UPDATE CASCADE,
+	UNIQUE (season_id, episode_number)
+)`, t.ID),
+
+		"PosterSets": fmt.Sprintf(`
+CREATE TABLE PosterSets (
+	id %[1]s,
+	set_id %[2]s NOT NULL UNIQUE,
+	type %[2]s NOT NULL CHECK (type IN ('show','movie','collection')),
+	title TEXT NOT NULL,
+	"user" %[2]s NOT NULL,
+	date_created %[3]s,
+	date_updated %[3]s
+)`, t.ID, t.Key, t.DateTime),
+
+		"ImageFiles": fmt.Sprintf(`
+CREATE TABLE ImageFiles (
+	id %[1]s,
+	poster_set_id BIGINT NOT NULL,
+	item_tmdb_id %[2]s NOT NULL,
+	image_id %[2]s NOT NULL,
+	image_type %[2]s NOT NULL CHECK (image_type IN ('poster','backdrop','season_poster','titlecard')),
+	image_last_updated %[3]s NOT NULL,
+	image_season_number INTEGER,
+	image_episode_number INTEGER,
+	FOREIGN KEY (poster_set_id) REFERENCES PosterSets(id)
+		ON DELETE CASCADE
+		ON UPDATE CASCADE,
+	UNIQUE (poster_set_id, image_id, item_tmdb_id)
+)`, t.ID, t.Key, t.DateTime),
+
+		"SavedItems": fmt.Sprintf(`
+CREATE TABLE SavedItems (
+	tmdb_id %[1]s NOT NULL,
+	library_title %[1]s NOT NULL,
+	poster_set_id BIGINT NOT NULL,
+	poster_selected INTEGER NOT NULL DEFAULT 0 CHECK (poster_selected IN (0,1)),
+	backdrop_selected INTEGER NOT NULL DEFAULT 0 CHECK (backdrop_selected IN (0,1)),
+	season_poster_selected INTEGER NOT NULL DEFAULT 0 CHECK (season_poster_selected IN (0,1)),
+	special_season_poster_selected INTEGER NOT NULL DEFAULT 0 CHECK (special_season_poster_selected IN (0,1)),
+	titlecard_selected INTEGER NOT NULL DEFAULT 0 CHECK (titlecard_selected IN (0,1)),
+	autodownload INTEGER NOT NULL DEFAULT 0 CHECK (autodownload IN (0,1)),
+	auto_add_new_collection_items INTEGER NOT NULL DEFAULT 0 CHECK (auto_add_new_collection_items IN (0,1)),
+	last_downloaded %[2]s NOT NULL,
+	PRIMARY KEY (tmdb_id, library_title, poster_set_id),
+	FOREIGN KEY (poster_set_id) REFERENCES PosterSets(id)
+		ON DELETE CASCADE
+		ON UPDATE CASCADE,
+	FOREIGN KEY (tmdb_id, library_title) REFERENCES MediaItems(tmdb_id, library_title)
+		ON DELETE CASCADE
+		ON UPDATE CASCADE
+)`, t.Key, t.DateTime),
+
+		"IgnoredItems": fmt.Sprintf(`
+CREATE TABLE IgnoredItems (
+	tmdb_id %[1]s NOT NULL,
+	library_title %[1]s NOT NULL,
+	mode %[1]s NOT NULL CHECK (mode IN ('always','until-set-available','until-new-set-available')),
+	current_sets TEXT NOT NULL,
+	PRIMARY KEY (tmdb_id, library_title)
+)`, t.Key),
+
+		"DownloadQueue":     s.downloadQueueTableQuery(),
+		"PendingApprovals":  s.pendingApprovalsTableQuery(),
+		"ImageHistory":      s.imageHistoryTableQuery(),
+		"ImageFingerprints": s.imageFingerprintsTableQuery(),
+		"JobCheckpoints":    s.jobCheckpointsTableQuery(),
+	}
+
+	for _, table := range serverTables {
+		actionCreateTable := logAction.AddSubAction(fmt.Sprintf("Creating %s Table", table), logging.LevelTrace)
+		query := s.rebind(tableQueries[table])
+		if _, err := s.conn.ExecContext(ctx, query); err != nil {
+			actionCreateTable.SetError(fmt.Sprintf("Failed to create %s table", table), err.Error(), map[string]any{
+				"error": err.Error(),
+				"query": query,
+			})
+			return *actionCreateTable.Error
+		}
+		actionCreateTable.Complete()
+	}
+
+	// Indexes are created one by one, since MySQL does not run multiple statements in a single call
+	indexQueries := []string{
+		`CREATE INDEX idx_seasons_series_id ON Seasons(series_id)`,
+		`CREATE INDEX idx_episodes_season_id ON Episodes(season_id)`,
+		`CREATE INDEX idx_imagefiles_poster_set_id ON ImageFiles(poster_set_id)`,
+		`CREATE INDEX idx_imagefiles_set_type ON ImageFiles(poster_set_id, image_type)`,
+		`CREATE INDEX idx_imagefiles_item_tmdb_id ON ImageFiles(item_tmdb_id)`,
+		`CREATE INDEX idx_imagefiles_item_tmdb_type ON ImageFiles(item_tmdb_id, image_type)`,
+		`CREATE INDEX idx_saveditems_poster_set_id ON SavedItems(poster_set_id)`,
+		`CREATE INDEX idx_saveditems_item ON SavedItems(tmdb_id, library_title)`,
+		`CREATE INDEX idx_ignoreditems_mode ON IgnoredItems(mode)`,
+	}
+	indexQueries = append(indexQueries, downloadQueueIndexQueries...)
+	indexQueries = append(indexQueries, pendingApprovalsIndexQueries...)
+	indexQueries = append(indexQueries, imageHistoryIndexQueries...)
+
+	actionCreateIndexes := logAction.AddSubAction("Adding Indexes to New Tables", logging.LevelTrace)
+	for _, query := range indexQueries {
+		if _, err := s.conn.ExecContext(ctx, query); err != nil {
+			actionCreateIndexes.SetError("Failed to add indexes to new tables", err.Error(), map[string]any{
+				"error": err.Error(),
+				"query": query,
+			})
+			return *actionCreateIndexes.Error
+		}
+	}
+	actionCreateIndexes.Complete()
+
+	return Err
+}
+
+// downloadQueueIndexQueries are the indexes of the DownloadQueue table
+var downloadQueueIndexQueries = []string{
+	`CREATE INDEX idx_downloadqueue_status ON DownloadQueue(status)`,
+	`CREATE INDEX idx_downloadqueue_item ON DownloadQueue(tmdb_id, library_title)`,
+}
+
+func (s *ServerDB) downloadQueueTableQuery() string {
+	t := s.columnTypes()
+	return fmt.Sprintf(`
+CREATE TABLE DownloadQueue (
+	id %[1]s,
+	tmdb_id %[2]s NOT NULL,
+	library_title %[2]s NOT NULL,
+	server %[2]s NOT NULL DEFAULT '',
+	title TEXT NOT NULL,
+	status %[2]s NOT NULL CHECK (status IN ('pending','processing','succeeded','warning','failed')),
+	attempts INTEGER NOT NULL DEFAULT 0,
+	item %[4]s NOT NULL,
+	results %[4]s NOT NULL,
+	errors %[4]s NOT NULL,
+	warnings %[4]s NOT NULL,
+	created_at %[3]s NOT NULL,
+	updated_at %[3]s NOT NULL,
+	started_at %[3]s NULL,
+	finished_at %[3]s NULL,
+	next_attempt_at %[3]s NULL
+)`, t.ID, t.Key, t.DateTime, t.LongText)
+}
+
+// CreateDownloadQueueTable adds the DownloadQueue table to a database that was created before it existed
+func (s *ServerDB) CreateDownloadQueueTable(ctx context.Context) (Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Creating DownloadQueue Table", logging.LevelTrace)
+	defer logAction.Complete()
+
+	Err = logging.LogErrorInfo{}
+
+	exists, err := s.tableExists(ctx, s.conn, "DownloadQueue")
+	if err != nil {
+		logAction.SetError("Failed to check for DownloadQueue table", "", map[string]any{
+			"error": err.Error(),
+		})
+		return *logAction.Error
+	}
+	if exists {
+		return Err
+	}
+
+	queries := append([]string{s.downloadQueueTableQuery()}, downloadQueueIndexQueries...)
+	for _, query := range queries {
+		query = s.rebind(query)
+		if _, err := s.conn.ExecContext(ctx, query); err != nil {
+			logAction.SetError("Failed to create DownloadQueue table", err.Error(), map[string]any{
+				"error": err.Error(),
+				"query": query,
+			})
+			return *logAction.Error
+		}
+	}
+
+	return Err
+}
+
+// pendingApprovalsIndexQueries are the indexes of the PendingApprovals table
+var pendingApprovalsIndexQueries = []string{
+	`CREATE INDEX idx_pendingapprovals_status ON PendingApprovals(status)`,
+	`CREATE INDEX idx_pendingapprovals_item ON PendingApprovals(tmdb_id, library_title)`,
+}
+
+func (s *ServerDB) pendingApprovalsTableQuery() string {
+	t := s.columnTypes()
+	return fmt.Sprintf(`
+CREATE TABLE PendingApprovals (
+	id %[1]s,
+	kind %[2]s NOT NULL,
+	status %[2]s NOT NULL CHECK (status IN ('pending','approved','rejected')),
+	tmdb_id %[2]s NOT NULL,
+	library_title %[2]s NOT NULL,
+	server %[2]s NOT NULL DEFAULT '',
+	title TEXT NOT NULL,
+	set_id %[2]s NOT NULL DEFAULT '',
+	item %[4]s NOT NULL,
+	reason TEXT NOT NULL,
+	created_at %[3]s NOT NULL,
+	decided_at %[3]s NULL
+)`, t.ID, t.Key, t.DateTime, t.LongText)
+}
+
+// CreatePendingApprovalsTable adds the PendingApprovals table to a database that was created before it existed
+func (s *ServerDB) CreatePendingApprovalsTable(ctx context.Context) (Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Creating PendingApprovals Table", logging.LevelTrace)
+	defer logAction.Complete()
+
+	Err = logging.LogErrorInfo{}
+
+	exists, err := s.tableExists(ctx, s.conn, "PendingApprovals")
+	if err != nil {
+		logAction.SetError("Failed to check for PendingApprovals table", "", map[string]any{
+			"error": err.Error(),
+		})
+		return *logAction.Error
+	}
+	if exists {
+		return Err
+	}
+
+	queries := append([]string{s.pendingApprovalsTableQuery()}, pendingApprovalsIndexQueries...)
+	for _, query := range queries {
+		query = s.rebind(query)
+		if _, err := s.conn.ExecContext(ctx, query); err != nil {
+			logAction.SetError("Failed to create PendingApprovals table", err.Error(), map[string]any{
+				"error": err.Error(),
+				"query": query,
+			})
+			return *logAction.Error
+		}
+	}
+
+	return Err
+}
+
+// imageHistoryIndexQueries are the indexes of the ImageHistory table
+var imageHistoryIndexQueries = []string{
+	`CREATE INDEX idx_imagehistory_item ON ImageHistory(tmdb_id, library_title)`,
+	`CREATE INDEX idx_imagehistory_image ON ImageHistory(rating_key, image_rating_key, image_type)`,
+}
+
+func (s *ServerDB) imageHistoryTableQuery() string {
+	t := s.columnTypes()
+	return fmt.Sprintf(`
+CREATE TABLE ImageHistory (
+	id %[1]s,
+	tmdb_id %[2]s NOT NULL,
+	library_title %[2]s NOT NULL,
+	server %[2]s NOT NULL DEFAULT '',
+	title TEXT NOT NULL,
+	rating_key %[2]s NOT NULL,
+	image_rating_key %[2]s NOT NULL,
+	image_type %[2]s NOT NULL,
+	season_number INTEGER NULL,
+	episode_number INTEGER NULL,
+	file_name TEXT NOT NULL,
+	replaced_by %[2]s NOT NULL DEFAULT '',
+	created_at %[3]s NOT NULL
+)`, t.ID, t.Key, t.DateTime)
+}
+
+// CreateImageHistoryTable adds the ImageHistory table to a database that was created before it existed
+func (s *ServerDB) CreateImageHistoryTable(ctx context.Context) (Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Creating ImageHistory Table", logging.LevelTrace)
+	defer logAction.Complete()
+
+	Err = logging.LogErrorInfo{}
+
+	exists, err := s.tableExists(ctx, s.conn, "ImageHistory")
+	if err != nil {
+		logAction.SetError("Failed to check for ImageHistory table", "", map[string]any{
+			"error": err.Error(),
+		})
+		return *logAction.Error
+	}
+	if exists {
+		return Err
+	}
+
+	queries := append([]string{s.imageHistoryTableQuery()}, imageHistoryIndexQueries...)
+	for _, query := range queries {
+		query = s.rebind(query)
+		if _, err := s.conn.ExecContext(ctx, query); err != nil {
+			logAction.SetError("Failed to create ImageHistory table", err.Error(), map[string]any{
+				"error": err.Error(),
+				"query": query,
+			})
+			return *logAction.Error
+		}
+	}
+
+	return Err
+}
+
+func (s *ServerDB) imageFingerprintsTableQuery() string {
+	t := s.columnTypes()
+	return fmt.Sprintf(`
+CREATE TABLE ImageFingerprints (
+	server %[1]s NOT NULL DEFAULT '',
+	tmdb_id %[1]s NOT NULL,
+	library_title %[1]s NOT NULL,
+	image_key %[1]s NOT NULL,
+	image_rating_key %[1]s NOT NULL,
+	image_id %[1]s NOT NULL,
+	perceptual_hash %[1]s NOT NULL,
+	applied_at %[2]s NOT NULL,
+	PRIMARY KEY (server, tmdb_id, library_title, image_key)
+)`, t.Key, t.DateTime)
+}
+
+// CreateImageFingerprintsTable adds the ImageFingerprints table to a database that was created before it existed
+func (s *ServerDB) CreateImageFingerprintsTable(ctx context.Context) (Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Creating ImageFingerprints Table", logging.LevelTrace)
+	defer logAction.Complete()
+
+	Err = logging.LogErrorInfo{}
+
+	exists, err := s.tableExists(ctx, s.conn, "ImageFingerprints")
+	if err != nil {
+		logAction.SetError("Failed to check for ImageFingerprints table", "", map[string]any{
+			"error": err.Error(),
+		})
+		return *logAction.Error
+	}
+	if exists {
+		return Err
+	}
+
+	query := s.rebind(s.imageFingerprintsTableQuery())
+	if _, err := s.conn.ExecContext(ctx, query); err != nil {
+		logAction.SetError("Failed to create ImageFingerprints table", err.Error(), map[string]any{
+			"error": err.Error(),
+			"query": query,
+		})
+		return *logAction.Error
+	}
+
+	return Err
+}
+
+// RecreateImageFingerprintsTable drops the ImageFingerprints table and creates it again with the latest columns
+func (s *ServerDB) RecreateImageFingerprintsTable(ctx context.Context) (Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Recreating ImageFingerprints Table", logging.LevelTrace)
+	defer logAction.Complete()
+
+	if _, err := s.conn.ExecContext(ctx, `DROP TABLE IF EXISTS ImageFingerprints`); err != nil {
+		logAction.SetError("Failed to drop ImageFingerprints table", err.Error(), map[string]any{
+			"error": err.Error(),
+		})
+		return *logAction.Error
+	}
+
+	return s.CreateImageFingerprintsTable(ctx)
+}
+
+func (s *ServerDB) jobCheckpointsTableQuery() string {
+	t := s.columnTypes()
+	return fmt.Sprintf(`
+CREATE TABLE JobCheckpoints (
+	job %[1]s NOT NULL PRIMARY KEY,
+	checked_at %[2]s NOT NULL
+)`, t.Key, t.DateTime)
+}
+
+// CreateJobCheckpointsTable adds the JobCheckpoints table to a database that was created before it existed
+func (s *ServerDB) CreateJobCheckpointsTable(ctx context.Context) (Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Creating JobCheckpoints Table", logging.LevelTrace)
+	defer logAction.Complete()
+
+	Err = logging.LogErrorInfo{}
+
+	exists, err := s.tableExists(ctx, s.conn, "JobCheckpoints")
+	if err != nil {
+		logAction.SetError("Failed to check for JobCheckpoints table", "", map[string]any{
+			"error": err.Error(),
+		})
+		return *logAction.Error
+	}
+	if exists {
+		return Err
+	}
+
+	query := s.rebind(s.jobCheckpointsTableQuery())
+	if _, err := s.conn.ExecContext(ctx, query); err != nil {
+		logAction.SetError("Failed to create JobCheckpoints table", err.Error(), map[string]any{
+			"error": err.Error(),
+			"query": query,
+		})
+		return *logAction.Error
+	}
+
+	return Err
+}
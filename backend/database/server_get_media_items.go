@@ -0,0 +1,86 @@
+package database
+
+import (
+	"aura/logging"
+	"aura/models"
+	"context"
+)
+
+func (s *ServerDB) GetAllMediaItems(ctx context.Context) (items []models.MediaItem, logErr logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Retrieving all MediaItems from database", logging.LevelDebug)
+	defer logAction.Complete()
+
+	items = []models.MediaItem{}
+	logErr = logging.LogErrorInfo{}
+
+	// Query all MediaItems
+	rows, err := s.conn.QueryContext(ctx, `
+		SELECT tmdb_id, library_title, rating_key, type, title, year
+		FROM MediaItems;
+	`)
+	if err != nil {
+		logAction.SetError("Failed to query MediaItems", "", map[string]any{"error": err.Error()})
+		return items, *logAction.Error
+	}
+	defer rows.Close()
+
+	// Iterate through the rows
+	for rows.Next() {
+		var item models.MediaItem
+		if err := rows.Scan(&item.TMDB_ID, &item.LibraryTitle, &item.RatingKey, &item.Type, &item.Title, &item.Year); err != nil {
+			logAction.SetError("Failed to scan MediaItem row", "", map[string]any{"error": err.Error()})
+			return items, *logAction.Error
+		}
+		items = append(items, item)
+	}
+
+	return items, logErr
+}
+
+func (s *ServerDB) GetAllMediaItemsWithFlags(ctx context.Context) ([]MediaItemWithFlags, logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Retrieving all MediaItems with set/ignored flags", logging.LevelDebug)
+	defer logAction.Complete()
+
+	items := []MediaItemWithFlags{}
+	logErr := logging.LogErrorInfo{}
+
+	// EXISTS is used instead of GROUP BY, since PostgreSQL does not allow selecting ungrouped columns
+	rows, err := s.conn.QueryContext(ctx, `
+        SELECT
+            m.tmdb_id,
+            m.library_title,
+            m.rating_key,
+            m.type,
+            m.title,
+            m.year,
+            CASE WHEN EXISTS (
+                SELECT 1 FROM SavedItems s
+                WHERE s.tmdb_id = m.tmdb_id AND s.library_title = m.library_title
+            ) THEN 1 ELSE 0 END AS has_saved_set,
+            CASE WHEN EXISTS (
+                SELECT 1 FROM IgnoredItems i
+                WHERE i.tmdb_id = m.tmdb_id AND i.library_title = m.library_title
+            ) THEN 1 ELSE 0 END AS is_ignored
+        FROM
+            MediaItems m
+    `)
+	if err != nil {
+		logAction.SetError("Failed to query MediaItems with flags", "", map[string]any{"error": err.Error()})
+		return items, *logAction.Error
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item MediaItemWithFlags
+		var hasSavedSet, isIgnored int
+		if err := rows.Scan(&item.TMDB_ID, &item.LibraryTitle, &item.RatingKey, &item.Type, &item.Title, &item.Year, &hasSavedSet, &isIgnored); err != nil {
+			logAction.SetError("Failed to scan row", "", map[string]any{"error": err.Error()})
+			return items, *logAction.Error
+		}
+		item.HasSavedSet = hasSavedSet == 1
+		item.IsIgnored = isIgnored == 1
+		items = append(items, item)
+	}
+
+	return items, logErr
+}
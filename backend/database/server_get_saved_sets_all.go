@@ -0,0 +1,388 @@
+package database
+
+import (
+	"aura/logging"
+	"aura/models"
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// GetAllSavedSets returns a page of saved items.
+// Unlike SQLite (which builds the JSON in SQL), the poster sets, images, seasons and episodes
+// are loaded per media item so the queries stay portable between PostgreSQL and MySQL.
+func (s *ServerDB) GetAllSavedSets(ctx context.Context, filter models.DBFilter) (out PagedSavedItems, Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Getting All Saved Sets from Database", logging.LevelInfo)
+	defer logAction.Complete()
+
+	Err = logging.LogErrorInfo{}
+	out.Items = make([]models.DBSavedItem, 0)
+
+	if s == nil || s.conn == nil {
+		logAction.SetError("Database connection is nil", "", map[string]any{})
+		return out, *logAction.Error
+	}
+
+	whereSQL, baseArgs := buildSavedItemsWhere(filter)
+
+	// Sorting (whitelist only)
+	sortCol := "mi.title"
+	switch strings.ToLower(strings.TrimSpace(filter.SortOption)) {
+	case "", "title":
+		sortCol = "mi.title"
+	case "year":
+		sortCol = "mi.year"
+	case "library":
+		sortCol = "mi.library_title"
+	case "last_downloaded", "date_downloaded":
+		sortCol = "mi.max_last_downloaded"
+	}
+
+	// Sort direction
+	sortDir := "ASC"
+	if strings.EqualFold(filter.SortOrder, "desc") {
+		sortDir = "DESC"
+	}
+
+	pageItems := filter.ItemsPerPage
+	if pageItems == 0 {
+		pageItems = 25
+	}
+	if pageItems > 250 {
+		pageItems = 250
+	}
+	pageNumber := filter.PageNumber
+	if pageNumber <= 0 {
+		pageNumber = 1
+	}
+
+	baseSQL := `
+WITH base AS (
+  SELECT
+    mi.id,
+    mi.tmdb_id,
+    mi.library_title,
+    mi.rating_key,
+    mi.type,
+    mi.title,
+    mi.year,
+    mi.on_server,
+
+    mv.path     AS movie_path,
+    mv.size     AS movie_size,
+    mv.duration AS movie_duration,
+
+    sr.id            AS series_id,
+    sr.season_count  AS season_count,
+    sr.episode_count AS episode_count,
+    sr.location      AS location,
+
+    (SELECT COUNT(*)
+     FROM SavedItems si
+     WHERE si.tmdb_id = mi.tmdb_id AND si.library_title = mi.library_title
+    ) AS set_count,
+
+    (SELECT MAX(si.last_downloaded)
+     FROM SavedItems si
+     WHERE si.tmdb_id = mi.tmdb_id AND si.library_title = mi.library_title
+    ) AS max_last_downloaded
+
+  FROM MediaItems mi
+  LEFT JOIN Movies mv ON mv.media_item_id = mi.id
+  LEFT JOIN Series sr ON sr.media_item_id = mi.id
+)
+`
+
+	// Total count (filtered)
+	countSQL := s.rebind(fmt.Sprintf(`%s
+SELECT COUNT(*)
+FROM base mi
+%s;
+`, baseSQL, whereSQL))
+
+	if err := s.conn.QueryRowContext(ctx, countSQL, baseArgs...).Scan(&out.Total); err != nil {
+		logAction.SetError("Failed to scan total count of saved sets", "", map[string]any{"error": err.Error(), "query": countSQL})
+		return out, *logAction.Error
+	}
+
+	// Data query
+	dataArgs := make([]any, 0, len(baseArgs)+2)
+	dataArgs = append(dataArgs, baseArgs...)
+	limitSQL := ""
+	if pageItems > 0 {
+		// -1 means "all items"
+		limitSQL = "LIMIT ? OFFSET ?"
+		dataArgs = append(dataArgs, pageItems, (pageNumber-1)*pageItems)
+	}
+
+	dataSQL := s.rebind(fmt.Sprintf(`%s
+SELECT
+  mi.tmdb_id,
+  mi.library_title,
+  mi.rating_key,
+  mi.type,
+  mi.title,
+  mi.year,
+  mi.movie_path,
+  mi.movie_size,
+  mi.movie_duration,
+  mi.series_id,
+  mi.season_count,
+  mi.episode_count,
+  mi.location
+FROM base mi
+%s
+ORDER BY %s %s, mi.tmdb_id ASC, mi.library_title ASC
+%s;
+`, baseSQL, whereSQL, sortCol, sortDir, limitSQL))
+
+	rows, err := s.conn.QueryContext(ctx, dataSQL, dataArgs...)
+	if err != nil {
+		logAction.SetError("Failed to query all saved sets", "", map[string]any{"error": err.Error(), "query": dataSQL})
+		return out, *logAction.Error
+	}
+
+	type rowT struct {
+		MediaItem     models.MediaItem
+		MoviePath     sql.NullString
+		MovieSize     sql.NullInt64
+		MovieDuration sql.NullInt64
+		SeriesID      sql.NullInt64
+		SeasonCount   sql.NullInt64
+		EpisodeCount  sql.NullInt64
+		Location      sql.NullString
+	}
+
+	pageRows := []rowT{}
+	for rows.Next() {
+		var r rowT
+		if err := rows.Scan(
+			&r.MediaItem.TMDB_ID, &r.MediaItem.LibraryTitle, &r.MediaItem.RatingKey, &r.MediaItem.Type, &r.MediaItem.Title, &r.MediaItem.Year,
+			&r.MoviePath, &r.MovieSize, &r.MovieDuration,
+			&r.SeriesID, &r.SeasonCount, &r.EpisodeCount, &r.Location,
+		); err != nil {
+			rows.Close()
+			logAction.SetError("Failed to scan saved item row", "", map[string]any{"error": err.Error()})
+			return out, *logAction.Error
+		}
+		pageRows = append(pageRows, r)
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		logAction.SetError("Row iteration error", "", map[string]any{"error": err.Error()})
+		return out, *logAction.Error
+	}
+	rows.Close()
+
+	for _, r := range pageRows {
+		mi := r.MediaItem
+		switch mi.Type {
+		case "movie":
+			mi.Movie = &models.MediaItemMovie{File: models.MediaItemFile{
+				Path:     r.MoviePath.String,
+				Size:     r.MovieSize.Int64,
+				Duration: r.MovieDuration.Int64,
+			}}
+		case "show":
+			mi.Series = &models.MediaItemSeries{
+				SeasonCount:  int(r.SeasonCount.Int64),
+				EpisodeCount: int(r.EpisodeCount.Int64),
+				Location:     r.Location.String,
+			}
+			if r.SeriesID.Valid {
+				seasons, err := s.getSeasonsForSeries(ctx, r.SeriesID.Int64)
+				if err != nil {
+					logAction.SetError("Failed to get seasons for saved item", "", map[string]any{"error": err.Error(), "tmdb_id": mi.TMDB_ID})
+					return out, *logAction.Error
+				}
+				mi.Series.Seasons = seasons
+			}
+		}
+
+		posterSets, err := s.getPosterSetsForMediaItem(ctx, mi.TMDB_ID, mi.LibraryTitle)
+		if err != nil {
+			logAction.SetError("Failed to get poster sets for saved item", "", map[string]any{"error": err.Error(), "tmdb_id": mi.TMDB_ID})
+			return out, *logAction.Error
+		}
+
+		if len(posterSets) == 0 {
+			out.Total -= 1
+			continue
+		}
+		out.Items = append(out.Items, models.DBSavedItem{
+			MediaItem:  mi,
+			PosterSets: posterSets,
+		})
+	}
+
+	return out, Err
+}
+
+// getSeasonsForSeries returns the seasons (with episodes) stored for a series
+func (s *ServerDB) getSeasonsForSeries(ctx context.Context, seriesRowID int64) (seasons []models.MediaItemSeason, err error) {
+	seasons = []models.MediaItemSeason{}
+
+	rows, err := s.conn.QueryContext(ctx, s.rebind(`
+		SELECT sn.id, sn.rating_key, sn.season_number,
+		       ep.rating_key, ep.episode_number, ep.title, ep.path, ep.size, ep.duration
+		FROM Seasons sn
+		LEFT JOIN Episodes ep ON ep.season_id = sn.id
+		WHERE sn.series_id = ?
+		ORDER BY sn.season_number, ep.episode_number;
+	`), seriesRowID)
+	if err != nil {
+		return seasons, err
+	}
+	defer rows.Close()
+
+	lastSeasonRowID := int64(-1)
+	for rows.Next() {
+		var (
+			seasonRowID     int64
+			seasonRatingKey string
+			seasonNumber    int
+			epRatingKey     sql.NullString
+			epNumber        sql.NullInt64
+			epTitle         sql.NullString
+			epPath          sql.NullString
+			epSize          sql.NullInt64
+			epDuration      sql.NullInt64
+		)
+		if err := rows.Scan(&seasonRowID, &seasonRatingKey, &seasonNumber, &epRatingKey, &epNumber, &epTitle, &epPath, &epSize, &epDuration); err != nil {
+			return seasons, err
+		}
+
+		if seasonRowID != lastSeasonRowID {
+			seasons = append(seasons, models.MediaItemSeason{
+				RatingKey:    seasonRatingKey,
+				SeasonNumber: seasonNumber,
+				Episodes:     []models.MediaItemEpisode{},
+			})
+			lastSeasonRowID = seasonRowID
+		}
+
+		if !epNumber.Valid {
+			continue
+		}
+		season := &seasons[len(seasons)-1]
+		season.Episodes = append(season.Episodes, models.MediaItemEpisode{
+			RatingKey:     epRatingKey.String,
+			Title:         epTitle.String,
+			SeasonNumber:  seasonNumber,
+			EpisodeNumber: int(epNumber.Int64),
+			File: models.MediaItemFile{
+				Path:     epPath.String,
+				Size:     epSize.Int64,
+				Duration: epDuration.Int64,
+			},
+		})
+	}
+
+	return seasons, rows.Err()
+}
+
+// getPosterSetsForMediaItem returns the saved poster sets (with item-scoped images) for a media item
+func (s *ServerDB) getPosterSetsForMediaItem(ctx context.Context, tmdbID, libraryTitle string) (posterSets []models.DBPosterSetDetail, err error) {
+	posterSets = []models.DBPosterSetDetail{}
+
+	rows, err := s.conn.QueryContext(ctx, s.rebind(`
+		SELECT
+			ps.id, ps.set_id, ps.title, ps.type, ps."user", ps.date_created, ps.date_updated,
+			si.last_downloaded,
+			si.poster_selected, si.backdrop_selected, si.season_poster_selected, si.special_season_poster_selected, si.titlecard_selected,
+			si.autodownload, si.auto_add_new_collection_items
+		FROM SavedItems si
+		JOIN PosterSets ps ON ps.id = si.poster_set_id
+		WHERE si.tmdb_id = ? AND si.library_title = ?;
+	`), tmdbID, libraryTitle)
+	if err != nil {
+		return posterSets, err
+	}
+
+	posterSetRowIDs := []int64{}
+	for rows.Next() {
+		var (
+			ps                                                                                    models.DBPosterSetDetail
+			posterSetRowID                                                                        int64
+			dateCreated, dateUpdated                                                              sql.NullTime
+			poster, backdrop, seasonPoster, specialSeasonPoster, titlecard, autoDownload, autoAdd int
+		)
+		if err := rows.Scan(
+			&posterSetRowID, &ps.ID, &ps.Title, &ps.Type, &ps.UserCreated, &dateCreated, &dateUpdated,
+			&ps.LastDownloaded,
+			&poster, &backdrop, &seasonPoster, &specialSeasonPoster, &titlecard,
+			&autoDownload, &autoAdd,
+		); err != nil {
+			rows.Close()
+			return posterSets, err
+		}
+		ps.DateCreated = dateCreated.Time
+		ps.DateUpdated = dateUpdated.Time
+		ps.SelectedTypes = models.SelectedTypes{
+			Poster:              poster == 1,
+			Backdrop:            backdrop == 1,
+			SeasonPoster:        seasonPoster == 1,
+			SpecialSeasonPoster: specialSeasonPoster == 1,
+			Titlecard:           titlecard == 1,
+		}
+		ps.AutoDownload = autoDownload == 1
+		ps.AutoAddNewCollectionItems = autoAdd == 1
+		ps.Images = []models.ImageFile{}
+
+		posterSets = append(posterSets, ps)
+		posterSetRowIDs = append(posterSetRowIDs, posterSetRowID)
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return posterSets, err
+	}
+	rows.Close()
+
+	for i := range posterSets {
+		images, err := s.getImagesForPosterSet(ctx, posterSetRowIDs[i], tmdbID)
+		if err != nil {
+			return posterSets, err
+		}
+		posterSets[i].Images = images
+	}
+
+	return posterSets, nil
+}
+
+// getImagesForPosterSet returns the images of a poster set that belong to a single item
+func (s *ServerDB) getImagesForPosterSet(ctx context.Context, posterSetRowID int64, itemTMDBID string) (images []models.ImageFile, err error) {
+	images = []models.ImageFile{}
+
+	rows, err := s.conn.QueryContext(ctx, s.rebind(`
+		SELECT image_id, image_type, image_last_updated, image_season_number, image_episode_number
+		FROM ImageFiles
+		WHERE poster_set_id = ? AND item_tmdb_id = ?;
+	`), posterSetRowID, itemTMDBID)
+	if err != nil {
+		return images, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			im            models.ImageFile
+			seasonNumber  sql.NullInt64
+			episodeNumber sql.NullInt64
+		)
+		if err := rows.Scan(&im.ID, &im.Type, &im.Modified, &seasonNumber, &episodeNumber); err != nil {
+			return images, err
+		}
+		if seasonNumber.Valid {
+			n := int(seasonNumber.Int64)
+			im.SeasonNumber = &n
+		}
+		if episodeNumber.Valid {
+			n := int(episodeNumber.Int64)
+			im.EpisodeNumber = &n
+		}
+		images = append(images, im)
+	}
+
+	return images, rows.Err()
+}
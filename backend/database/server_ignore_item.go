@@ -0,0 +1,225 @@
+package database
+
+import (
+	"aura/cache"
+	"aura/logging"
+	"aura/models"
+	"context"
+	"database/sql"
+	"strings"
+)
+
+func (s *ServerDB) GetTempIgnoredItems(ctx context.Context) (items []models.MediaItem, Err logging.LogErrorInfo) {
+	Err = logging.LogErrorInfo{}
+	if s == nil || s.conn == nil {
+		return nil, logging.LogErrorInfo{Message: "Database connection is nil"}
+	}
+
+	// Query the database for temp ignored items
+	rows, err := s.conn.QueryContext(ctx, `
+        SELECT tmdb_id, library_title, mode, current_sets
+        FROM IgnoredItems
+        WHERE mode = 'until-set-available' OR mode = 'until-new-set-available';
+    `)
+	if err != nil {
+		return nil, logging.LogErrorInfo{
+			Message: "Failed to get temp ignored items",
+			Detail:  map[string]any{"error": err.Error()},
+		}
+	}
+	defer rows.Close()
+
+	var tmdbID string
+	var libraryTitle string
+	var mode string
+	var currentSets string
+	for rows.Next() {
+		if err := rows.Scan(&tmdbID, &libraryTitle, &mode, &currentSets); err != nil {
+			return nil, logging.LogErrorInfo{
+				Message: "Failed to scan temp ignored item",
+				Detail:  map[string]any{"error": err.Error()},
+			}
+		}
+
+		// Get the Media Item from the cache
+		cachedItem, found := cache.LibraryStore.GetMediaItemFromSectionByTMDBID(libraryTitle, tmdbID)
+		if !found {
+			logging.LOGGER.Warn().Timestamp().
+				Str("tmdb_id", tmdbID).
+				Str("library_title", libraryTitle).
+				Msg("Temp ignored item not found in cache")
+			continue
+		}
+		cachedItem.IgnoredMode = mode
+		cachedItem.IgnoredSets = strings.Split(currentSets, ",")
+		items = append(items, *cachedItem)
+	}
+
+	return items, Err
+}
+
+func (s *ServerDB) GetAllIgnoredItems(ctx context.Context) (items []models.DBIgnoredItem, Err logging.LogErrorInfo) {
+	Err = logging.LogErrorInfo{}
+	items = []models.DBIgnoredItem{}
+	if s == nil || s.conn == nil {
+		return items, logging.LogErrorInfo{Message: "Database connection is nil"}
+	}
+
+	rows, err := s.conn.QueryContext(ctx, `
+        SELECT tmdb_id, library_title, mode, current_sets
+        FROM IgnoredItems
+        ORDER BY library_title, tmdb_id;
+    `)
+	if err != nil {
+		return items, logging.LogErrorInfo{
+			Message: "Failed to get ignored items",
+			Detail:  map[string]any{"error": err.Error()},
+		}
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item models.DBIgnoredItem
+		var currentSets string
+		if err := rows.Scan(&item.TMDB_ID, &item.LibraryTitle, &item.Mode, &currentSets); err != nil {
+			return items, logging.LogErrorInfo{
+				Message: "Failed to scan ignored item",
+				Detail:  map[string]any{"error": err.Error()},
+			}
+		}
+		item.CurrentSets = []string{}
+		if currentSets != "" {
+			item.CurrentSets = strings.Split(currentSets, ",")
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return items, logging.LogErrorInfo{
+			Message: "Failed to read ignored items",
+			Detail:  map[string]any{"error": err.Error()},
+		}
+	}
+
+	return items, Err
+}
+
+func (s *ServerDB) IgnoreMediaItem(ctx context.Context, tmdbID, libraryTitle, mode, currentSets string) (Err logging.LogErrorInfo) {
+	Err = logging.LogErrorInfo{}
+
+	if s == nil || s.conn == nil {
+		return logging.LogErrorInfo{Message: "Database connection is nil"}
+	}
+
+	tmdbID = strings.TrimSpace(tmdbID)
+	libraryTitle = strings.TrimSpace(libraryTitle)
+	mode = strings.ToLower(strings.TrimSpace(mode))
+
+	if tmdbID == "" || libraryTitle == "" {
+		return logging.LogErrorInfo{
+			Message: "tmdb_id and library_title are required",
+			Detail:  map[string]any{"tmdb_id": tmdbID, "library_title": libraryTitle},
+		}
+	}
+
+	if mode != "always" && mode != "until-set-available" && mode != "until-new-set-available" {
+		return logging.LogErrorInfo{
+			Message: "Invalid ignore mode",
+			Detail:  map[string]any{"mode": mode, "valid_modes": []string{"always", "until-set-available", "until-new-set-available"}},
+		}
+	} else if mode == "until-new-set-available" && currentSets == "" {
+		return logging.LogErrorInfo{
+			Message: "current_sets is required for 'until-new-set-available' mode",
+			Detail:  map[string]any{"mode": mode, "current_sets": currentSets},
+		}
+	}
+
+	tx, err := s.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return logging.LogErrorInfo{Message: "Failed to start transaction", Detail: map[string]any{"error": err.Error()}}
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// Determine insert vs update for logging
+	var existed int
+	err = tx.QueryRowContext(ctx, s.rebind(`
+        SELECT 1
+        FROM IgnoredItems
+        WHERE tmdb_id = ? AND library_title = ?
+        LIMIT 1;
+    `), tmdbID, libraryTitle).Scan(&existed)
+	if err != nil && err != sql.ErrNoRows {
+		return logging.LogErrorInfo{
+			Message: "Failed to check ignore status of media item",
+			Detail:  map[string]any{"error": err.Error(), "tmdb_id": tmdbID, "library_title": libraryTitle},
+		}
+	}
+	op := "INSERT"
+	if existed == 1 {
+		op = "UPDATE"
+	}
+
+	_, err = s.upsert(ctx, tx, "IgnoredItems",
+		[]string{"tmdb_id", "library_title"},
+		[]string{"tmdb_id", "library_title", "mode", "current_sets"},
+		[]string{"mode", "current_sets"},
+		tmdbID, libraryTitle, mode, currentSets,
+	)
+	if err != nil {
+		return logging.LogErrorInfo{
+			Message: "Failed to ignore media item",
+			Detail:  map[string]any{"error": err.Error(), "tmdb_id": tmdbID, "library_title": libraryTitle, "mode": mode, "current_sets": currentSets},
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return logging.LogErrorInfo{Message: "Failed to commit transaction", Detail: map[string]any{"error": err.Error()}}
+	}
+
+	logging.LOGGER.Debug().Timestamp().
+		Str("op", op).
+		Str("table", "IgnoredItems").
+		Str("tmdb_id", tmdbID).
+		Str("library_title", libraryTitle).
+		Str("mode", mode).
+		Str("current_sets", currentSets).
+		Msg("Ignored media item")
+
+	return Err
+}
+
+func (s *ServerDB) StopIgnoringMediaItem(ctx context.Context, tmdbID, libraryTitle string) (Err logging.LogErrorInfo) {
+	Err = logging.LogErrorInfo{}
+
+	if s == nil || s.conn == nil {
+		return logging.LogErrorInfo{Message: "Database connection is nil"}
+	}
+
+	tmdbID = strings.TrimSpace(tmdbID)
+	libraryTitle = strings.TrimSpace(libraryTitle)
+
+	res, err := s.conn.ExecContext(ctx, s.rebind(`
+        DELETE FROM IgnoredItems
+        WHERE tmdb_id = ? AND library_title = ?;
+    `), tmdbID, libraryTitle)
+	if err != nil {
+		_, logAction := logging.AddSubActionToContext(ctx, "Stopping ignore for media item", logging.LevelError)
+		defer logAction.Complete()
+		logAction.SetError("Failed to delete ignore entry from database", err.Error(), map[string]any{
+			"error":         err.Error(),
+			"tmdb_id":       tmdbID,
+			"library_title": libraryTitle,
+		})
+		return *logAction.Error
+	}
+
+	n, _ := res.RowsAffected()
+	logging.LOGGER.Debug().Timestamp().
+		Str("op", "DELETE").
+		Str("table", "IgnoredItems").
+		Int64("count", n).
+		Str("tmdb_id", tmdbID).
+		Str("library_title", libraryTitle).
+		Msg("Stopped ignoring media item")
+
+	return Err
+}
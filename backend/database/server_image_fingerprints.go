@@ -0,0 +1,111 @@
+package database
+
+import (
+	"aura/logging"
+	"aura/models"
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+func (s *ServerDB) GetImageFingerprint(ctx context.Context, server, tmdbID, libraryTitle, imageKey string) (fingerprint models.DBImageFingerprint, found bool, Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Getting Image Fingerprint from Database", logging.LevelTrace)
+	defer logAction.Complete()
+
+	Err = logging.LogErrorInfo{}
+	if s == nil || s.conn == nil {
+		logAction.SetError("Database connection is nil", "", map[string]any{})
+		return fingerprint, false, *logAction.Error
+	}
+
+	err := s.conn.QueryRowContext(ctx, s.rebind(`
+SELECT `+imageFingerprintColumns+`
+FROM ImageFingerprints
+WHERE server = ? AND tmdb_id = ? AND library_title = ? AND image_key = ?;`),
+		server, tmdbID, libraryTitle, imageKey,
+	).Scan(
+		&fingerprint.Server,
+		&fingerprint.TMDB_ID,
+		&fingerprint.LibraryTitle,
+		&fingerprint.ImageKey,
+		&fingerprint.ImageRatingKey,
+		&fingerprint.ImageID,
+		&fingerprint.PerceptualHash,
+		&fingerprint.AppliedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fingerprint, false, Err
+	} else if err != nil {
+		logAction.SetError("DB: SELECT ImageFingerprints failed", err.Error(), map[string]any{"error": err.Error()})
+		return fingerprint, false, *logAction.Error
+	}
+
+	return fingerprint, true, Err
+}
+
+func (s *ServerDB) UpsertImageFingerprint(ctx context.Context, fingerprint models.DBImageFingerprint) (Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Saving Image Fingerprint to Database", logging.LevelTrace)
+	defer logAction.Complete()
+
+	Err = logging.LogErrorInfo{}
+	if s == nil || s.conn == nil {
+		logAction.SetError("Database connection is nil", "", map[string]any{})
+		return *logAction.Error
+	}
+
+	tx, err := s.conn.BeginTx(ctx, nil)
+	if err != nil {
+		logAction.SetError("Failed to begin transaction", err.Error(), map[string]any{"error": err.Error()})
+		return *logAction.Error
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	_, err = s.upsert(ctx, tx, "ImageFingerprints",
+		[]string{"server", "tmdb_id", "library_title", "image_key"},
+		[]string{"server", "tmdb_id", "library_title", "image_key", "image_rating_key", "image_id", "perceptual_hash", "applied_at"},
+		[]string{"image_rating_key", "image_id", "perceptual_hash", "applied_at"},
+		fingerprint.Server,
+		fingerprint.TMDB_ID,
+		fingerprint.LibraryTitle,
+		fingerprint.ImageKey,
+		fingerprint.ImageRatingKey,
+		fingerprint.ImageID,
+		fingerprint.PerceptualHash,
+		time.Now().UTC(),
+	)
+	if err != nil {
+		logAction.SetError("DB: UPSERT ImageFingerprints failed", err.Error(), map[string]any{"error": err.Error()})
+		return *logAction.Error
+	}
+
+	if err := tx.Commit(); err != nil {
+		logAction.SetError("Failed to commit transaction", err.Error(), map[string]any{"error": err.Error()})
+		return *logAction.Error
+	}
+
+	return Err
+}
+
+func (s *ServerDB) DeleteImageFingerprint(ctx context.Context, server, tmdbID, libraryTitle, imageKey string) (Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Deleting Image Fingerprint from Database", logging.LevelTrace)
+	defer logAction.Complete()
+
+	Err = logging.LogErrorInfo{}
+	if s == nil || s.conn == nil {
+		logAction.SetError("Database connection is nil", "", map[string]any{})
+		return *logAction.Error
+	}
+
+	_, err := s.conn.ExecContext(ctx, s.rebind(`
+DELETE FROM ImageFingerprints
+WHERE server = ? AND tmdb_id = ? AND library_title = ? AND image_key = ?;`),
+		server, tmdbID, libraryTitle, imageKey,
+	)
+	if err != nil {
+		logAction.SetError("DB: DELETE ImageFingerprints failed", err.Error(), map[string]any{"error": err.Error()})
+		return *logAction.Error
+	}
+
+	return Err
+}
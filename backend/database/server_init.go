@@ -0,0 +1,254 @@
+package database
+
+import (
+	"aura/config"
+	"aura/logging"
+	"context"
+	"database/sql"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	_ "github.com/lib/pq"
+)
+
+func (s *ServerDB) Init(ctx context.Context) (newDB bool, Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Initializing Database", logging.LevelInfo)
+	defer logAction.Complete()
+
+	Err = logging.LogErrorInfo{}
+	newDB = false
+
+	// Open DB Connection
+	s.conn, newDB, Err = s.GetDBConnection(ctx)
+	if Err.Message != "" {
+		return newDB, Err
+	}
+
+	// If new DB, create version table, main tables and set version
+	if newDB {
+		Err = s.CreateVersionTable(ctx)
+		if Err.Message != "" {
+			return newDB, Err
+		}
+
+		Err = s.CreateAuthTable(ctx)
+		if Err.Message != "" {
+			return newDB, Err
+		}
+
+		Err = s.CreateTables(ctx)
+		if Err.Message != "" {
+			return newDB, Err
+		}
+
+		Err = s.UpdateVersionTable(ctx, LATEST_DB_VERSION)
+		if Err.Message != "" {
+			return newDB, Err
+		}
+	}
+
+	return newDB, Err
+}
+
+func (s *ServerDB) GetConfig() (config config.Config_Database) {
+	return s.Config
+}
+
+func (s *ServerDB) GetDBConnection(ctx context.Context) (conn *sql.DB, newDB bool, Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Opening Database Connection", logging.LevelInfo)
+	defer logAction.Complete()
+
+	conn = nil
+	newDB = false
+	Err = logging.LogErrorInfo{}
+
+	// Reuse the connection pool once it has been opened
+	if s.conn != nil {
+		return s.conn, newDB, Err
+	}
+
+	// Build DSN
+	dsn, Err := BuildDSN()
+	if Err.Message != "" {
+		return conn, newDB, Err
+	}
+
+	conn, openErr := sql.Open(s.driverName(), dsn)
+	if openErr != nil {
+		logAction.SetError("Failed to open database connection", "Ensure the database connection settings are correct.", map[string]any{
+			"error": openErr.Error(),
+			"type":  s.Config.Type,
+		})
+		return conn, newDB, *logAction.Error
+	}
+
+	// Ping to verify connection
+	if pingErr := conn.PingContext(ctx); pingErr != nil {
+		logAction.SetError("Failed to connect to database server", "Ensure the database server is running and reachable.", map[string]any{
+			"error": pingErr.Error(),
+			"type":  s.Config.Type,
+		})
+		return conn, newDB, *logAction.Error
+	}
+
+	// A database without a VERSION table has not been set up yet
+	versionTableExists, err := s.tableExists(ctx, conn, "VERSION")
+	if err != nil {
+		logAction.SetError("Failed to check for VERSION table", "", map[string]any{
+			"error": err.Error(),
+		})
+		return conn, newDB, *logAction.Error
+	}
+	newDB = !versionTableExists
+	if newDB {
+		logging.LOGGER.Warn().Timestamp().Str("type", s.Config.Type).Msg("Database tables not found. Creating new database.")
+	}
+
+	return conn, newDB, Err
+}
+
+// driverName returns the database/sql driver name for the configured database type
+func (s *ServerDB) driverName() string {
+	if s.Config.Type == "postgresql" {
+		return "postgres"
+	}
+	return "mysql"
+}
+
+// tableExists checks if a table exists in the current database/schema
+func (s *ServerDB) tableExists(ctx context.Context, conn *sql.DB, tableName string) (exists bool, err error) {
+	query := `
+		SELECT COUNT(*)
+		FROM information_schema.tables
+		WHERE table_schema = DATABASE() AND LOWER(table_name) = LOWER(?);
+	`
+	if s.Config.Type == "postgresql" {
+		query = `
+		SELECT COUNT(*)
+		FROM information_schema.tables
+		WHERE table_schema = current_schema() AND LOWER(table_name) = LOWER(?);
+	`
+	}
+
+	var count int
+	if err := conn.QueryRowContext(ctx, s.rebind(query), tableName).Scan(&count); err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// rebind converts a query written with "?" placeholders and double-quoted identifiers
+// into the dialect of the configured database server.
+// PostgreSQL uses numbered placeholders ($1, $2, ...) and MySQL uses backticks for identifiers.
+func (s *ServerDB) rebind(query string) string {
+	var b strings.Builder
+	b.Grow(len(query) + 16)
+
+	inLiteral := false
+	n := 0
+	for _, r := range query {
+		switch {
+		case r == '\'':
+			inLiteral = !inLiteral
+		case inLiteral:
+		case r == '?' && s.Config.Type == "postgresql":
+			n++
+			b.WriteString("$" + strconv.Itoa(n))
+			continue
+		case r == '"' && s.Config.Type == "mysql":
+			b.WriteRune('`')
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// upsert inserts a row into a table, or updates updateCols when a row with the same conflictCols already exists
+func (s *ServerDB) upsert(ctx context.Context, tx *sql.Tx, table string, conflictCols, cols, updateCols []string, args ...any) (sql.Result, error) {
+	return tx.ExecContext(ctx, s.upsertQuery(table, conflictCols, cols, updateCols), args...)
+}
+
+// upsertQuery builds the INSERT ... ON CONFLICT/ON DUPLICATE KEY query of upsert in the dialect of the database server
+func (s *ServerDB) upsertQuery(table string, conflictCols, cols, updateCols []string) string {
+	query := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", table, strings.Join(cols, ", "), placeholders(len(cols)))
+
+	sets := make([]string, 0, len(updateCols))
+	switch s.Config.Type {
+	case "postgresql":
+		for _, c := range updateCols {
+			sets = append(sets, fmt.Sprintf("%s = EXCLUDED.%s", c, c))
+		}
+		if len(sets) == 0 {
+			query += fmt.Sprintf(" ON CONFLICT (%s) DO NOTHING", strings.Join(conflictCols, ", "))
+		} else {
+			query += fmt.Sprintf(" ON CONFLICT (%s) DO UPDATE SET %s", strings.Join(conflictCols, ", "), strings.Join(sets, ", "))
+		}
+	default:
+		for _, c := range updateCols {
+			sets = append(sets, fmt.Sprintf("%s = VALUES(%s)", c, c))
+		}
+		if len(sets) == 0 {
+			// MySQL has no "DO NOTHING", so update the first conflict column to itself
+			sets = append(sets, fmt.Sprintf("%s = %s", conflictCols[0], conflictCols[0]))
+		}
+		query += " ON DUPLICATE KEY UPDATE " + strings.Join(sets, ", ")
+	}
+
+	return s.rebind(query)
+}
+
+// buildPostgresDSN returns the configured DSN or builds a postgres:// URL from the individual fields
+func buildPostgresDSN(dbConfig config.Config_Database) string {
+	if dbConfig.DSN != "" {
+		return dbConfig.DSN
+	}
+
+	port := dbConfig.Port
+	if port == 0 {
+		port = 5432
+	}
+
+	dsn := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(dbConfig.User, dbConfig.Password),
+		Host:     net.JoinHostPort(dbConfig.Host, strconv.Itoa(port)),
+		Path:     "/" + dbConfig.Name,
+		RawQuery: "sslmode=disable",
+	}
+	return dsn.String()
+}
+
+// buildMySQLDSN returns the configured DSN or builds one from the individual fields.
+// Times are always parsed into time.Time and stored as UTC.
+func buildMySQLDSN(dbConfig config.Config_Database) (string, logging.LogErrorInfo) {
+	mysqlConfig := mysql.NewConfig()
+	if dbConfig.DSN != "" {
+		parsed, err := mysql.ParseDSN(dbConfig.DSN)
+		if err != nil {
+			return "", logging.LogErrorInfo{
+				Message: fmt.Sprintf("invalid mysql DSN: %s", err.Error()),
+			}
+		}
+		mysqlConfig = parsed
+	} else {
+		port := dbConfig.Port
+		if port == 0 {
+			port = 3306
+		}
+		mysqlConfig.User = dbConfig.User
+		mysqlConfig.Passwd = dbConfig.Password
+		mysqlConfig.Net = "tcp"
+		mysqlConfig.Addr = net.JoinHostPort(dbConfig.Host, strconv.Itoa(port))
+		mysqlConfig.DBName = dbConfig.Name
+	}
+	mysqlConfig.ParseTime = true
+	mysqlConfig.Loc = time.UTC
+
+	return mysqlConfig.FormatDSN(), logging.LogErrorInfo{}
+}
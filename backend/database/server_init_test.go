@@ -0,0 +1,95 @@
+package database
+
+import (
+	"aura/config"
+	"testing"
+)
+
+func TestRebind(t *testing.T) {
+	tests := []struct {
+		name   string
+		dbType string
+		query  string
+		want   string
+	}{
+		{
+			name:   "postgresql numbers the placeholders",
+			dbType: "postgresql",
+			query:  `SELECT * FROM "Movies" WHERE tmdb_id = ? AND library_title = ?`,
+			want:   `SELECT * FROM "Movies" WHERE tmdb_id = $1 AND library_title = $2`,
+		},
+		{
+			name:   "postgresql keeps question marks in literals",
+			dbType: "postgresql",
+			query:  `SELECT * FROM Movies WHERE title = 'Who?' AND tmdb_id = ?`,
+			want:   `SELECT * FROM Movies WHERE title = 'Who?' AND tmdb_id = $1`,
+		},
+		{
+			name:   "mysql quotes identifiers with backticks",
+			dbType: "mysql",
+			query:  `SELECT "key" FROM JobCheckpoints WHERE job = ?`,
+			want:   "SELECT `key` FROM JobCheckpoints WHERE job = ?",
+		},
+		{
+			name:   "mysql keeps double quotes in literals",
+			dbType: "mysql",
+			query:  `SELECT * FROM Movies WHERE title = 'The "Best" Movie' AND tmdb_id = ?`,
+			want:   `SELECT * FROM Movies WHERE title = 'The "Best" Movie' AND tmdb_id = ?`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &ServerDB{Config: config.Config_Database{Type: tt.dbType}}
+			if got := s.rebind(tt.query); got != tt.want {
+				t.Errorf("rebind() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpsertQuery(t *testing.T) {
+	tests := []struct {
+		name       string
+		dbType     string
+		updateCols []string
+		want       string
+	}{
+		{
+			name:       "postgresql updates the excluded values",
+			dbType:     "postgresql",
+			updateCols: []string{"image_id", "applied_at"},
+			want:       "INSERT INTO ImageFingerprints (server, tmdb_id, image_id, applied_at) VALUES ($1,$2,$3,$4) ON CONFLICT (server, tmdb_id) DO UPDATE SET image_id = EXCLUDED.image_id, applied_at = EXCLUDED.applied_at",
+		},
+		{
+			name:   "postgresql does nothing without update columns",
+			dbType: "postgresql",
+			want:   "INSERT INTO ImageFingerprints (server, tmdb_id, image_id, applied_at) VALUES ($1,$2,$3,$4) ON CONFLICT (server, tmdb_id) DO NOTHING",
+		},
+		{
+			name:       "mysql updates the inserted values",
+			dbType:     "mysql",
+			updateCols: []string{"image_id", "applied_at"},
+			want:       "INSERT INTO ImageFingerprints (server, tmdb_id, image_id, applied_at) VALUES (?,?,?,?) ON DUPLICATE KEY UPDATE image_id = VALUES(image_id), applied_at = VALUES(applied_at)",
+		},
+		{
+			name:   "mysql updates the first conflict column to itself without update columns",
+			dbType: "mysql",
+			want:   "INSERT INTO ImageFingerprints (server, tmdb_id, image_id, applied_at) VALUES (?,?,?,?) ON DUPLICATE KEY UPDATE server = server",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &ServerDB{Config: config.Config_Database{Type: tt.dbType}}
+			got := s.upsertQuery("ImageFingerprints",
+				[]string{"server", "tmdb_id"},
+				[]string{"server", "tmdb_id", "image_id", "applied_at"},
+				tt.updateCols,
+			)
+			if got != tt.want {
+				t.Errorf("upsertQuery() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package database
+
+import (
+	"aura/logging"
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+func (s *ServerDB) GetJobCheckpoint(ctx context.Context, job string) (checkedAt time.Time, found bool, Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Getting Job Checkpoint from Database", logging.LevelTrace)
+	defer logAction.Complete()
+
+	Err = logging.LogErrorInfo{}
+	if s == nil || s.conn == nil {
+		logAction.SetError("Database connection is nil", "", map[string]any{})
+		return checkedAt, false, *logAction.Error
+	}
+
+	err := s.conn.QueryRowContext(ctx, s.rebind(`
+SELECT checked_at
+FROM JobCheckpoints
+WHERE job = ?;`),
+		job,
+	).Scan(&checkedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return checkedAt, false, Err
+	} else if err != nil {
+		logAction.SetError("DB: SELECT JobCheckpoints failed", err.Error(), map[string]any{"error": err.Error()})
+		return checkedAt, false, *logAction.Error
+	}
+
+	return checkedAt, true, Err
+}
+
+func (s *ServerDB) SetJobCheckpoint(ctx context.Context, job string, checkedAt time.Time) (Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Saving Job Checkpoint to Database", logging.LevelTrace)
+	defer logAction.Complete()
+
+	Err = logging.LogErrorInfo{}
+	if s == nil || s.conn == nil {
+		logAction.SetError("Database connection is nil", "", map[string]any{})
+		return *logAction.Error
+	}
+
+	tx, err := s.conn.BeginTx(ctx, nil)
+	if err != nil {
+		logAction.SetError("Failed to begin transaction", err.Error(), map[string]any{"error": err.Error()})
+		return *logAction.Error
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	_, err = s.upsert(ctx, tx, "JobCheckpoints",
+		[]string{"job"},
+		[]string{"job", "checked_at"},
+		[]string{"checked_at"},
+		job,
+		checkedAt.UTC(),
+	)
+	if err != nil {
+		logAction.SetError("DB: UPSERT JobCheckpoints failed", err.Error(), map[string]any{"error": err.Error()})
+		return *logAction.Error
+	}
+
+	if err := tx.Commit(); err != nil {
+		logAction.SetError("Failed to commit transaction", err.Error(), map[string]any{"error": err.Error()})
+		return *logAction.Error
+	}
+
+	return Err
+}
@@ -0,0 +1,65 @@
+package database
+
+import (
+	"aura/logging"
+	"aura/models"
+	"context"
+)
+
+func (s *ServerDB) UpdateMediaItem(ctx context.Context, updatedItem models.MediaItem) (Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Updating MediaItem in database", logging.LevelDebug)
+	defer logAction.Complete()
+
+	Err = logging.LogErrorInfo{}
+	if s == nil || s.conn == nil {
+		logAction.SetError("Database connection is nil", "", map[string]any{})
+		return *logAction.Error
+	}
+
+	res, err := s.conn.ExecContext(ctx, s.rebind(`
+        UPDATE MediaItems
+        SET rating_key = ?, type = ?, title = ?, year = ?
+        WHERE tmdb_id = ? AND library_title = ?;
+    `),
+		updatedItem.RatingKey,
+		updatedItem.Type,
+		updatedItem.Title,
+		updatedItem.Year,
+		updatedItem.TMDB_ID,
+		updatedItem.LibraryTitle,
+	)
+	if err != nil {
+		logAction.SetError("Failed to execute update statement", "", map[string]any{"error": err.Error()})
+		return *logAction.Error
+	}
+
+	affected, _ := res.RowsAffected()
+	logging.LOGGER.Info().Timestamp().
+		Str("op", "UPDATE").
+		Str("table", "MediaItems").
+		Int64("rows", affected).
+		Str("tmdb_id", updatedItem.TMDB_ID).
+		Str("library_title", updatedItem.LibraryTitle).
+		Msg("Updated media item")
+
+	return Err
+}
+
+func (s *ServerDB) UpdateMediaItemOnServer(ctx context.Context, tmdbID string, libraryTitle string, onServer bool) (logErr logging.LogErrorInfo) {
+	logErr = logging.LogErrorInfo{}
+
+	// Update the on_server flag for the specified MediaItem
+	_, err := s.conn.ExecContext(ctx, s.rebind(`
+		UPDATE MediaItems
+		SET on_server = ?
+		WHERE tmdb_id = ? AND library_title = ?;
+	`), boolToInt(onServer), tmdbID, libraryTitle)
+	if err != nil {
+		_, logAction := logging.AddSubActionToContext(ctx, "Updating MediaItem on_server flag in database", logging.LevelDebug)
+		defer logAction.Complete()
+		logAction.SetError("Failed to update MediaItem on_server flag", "", map[string]any{"error": err.Error(), "tmdb_id": tmdbID, "library_title": libraryTitle, "on_server": onServer})
+		return *logAction.Error
+	}
+
+	return logErr
+}
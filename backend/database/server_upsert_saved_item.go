@@ -0,0 +1,492 @@
+package database
+
+import (
+	"aura/logging"
+	"aura/models"
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+func (s *ServerDB) UpsertSavedItem(ctx context.Context, newItem models.DBSavedItem) logging.LogErrorInfo {
+	ctx, logAction := logging.AddSubActionToContext(
+		ctx,
+		fmt.Sprintf(
+			"Upserting SavedItem '%s' (%s | %s | %d)",
+			newItem.MediaItem.Title,
+			newItem.MediaItem.RatingKey,
+			newItem.MediaItem.LibraryTitle,
+			newItem.MediaItem.Year,
+		),
+		logging.LevelDebug,
+	)
+	defer logAction.Complete()
+
+	if s == nil || s.conn == nil {
+		logAction.SetError("DB: connection is nil", "", map[string]any{})
+		return *logAction.Error
+	}
+
+	tx, err := s.conn.BeginTx(ctx, nil)
+	if err != nil {
+		logAction.SetError("DB: TX BEGIN failed", err.Error(), map[string]any{"error": err.Error()})
+		return *logAction.Error
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// Basic counters for logging
+	var (
+		posterSetsDeleted      int64
+		posterSetsUpserted     int
+		imagesUpserted         int
+		emptySavedItemsDeleted int64
+	)
+
+	// 1) Delete Ignore entry for this Media Item if it exists
+	res, err := tx.ExecContext(ctx, s.rebind(`DELETE FROM IgnoredItems WHERE tmdb_id = ? AND library_title = ?;`),
+		newItem.MediaItem.TMDB_ID, newItem.MediaItem.LibraryTitle)
+	if err != nil {
+		logAction.SetError("DB: Failed to delete IgnoredItem", err.Error(), map[string]any{"error": err.Error()})
+		return *logAction.Error
+	}
+	if n, _ := res.RowsAffected(); n > 0 {
+		logAction.AppendResult("delete_ignore_entry", "deleted")
+	}
+
+	mediaItemRowID, errInfo := s.upsertMediaItem(ctx, tx, newItem.MediaItem)
+	if errInfo.Message != "" {
+		logAction.SetError(errInfo.Message, "", errInfo.Detail)
+		return *logAction.Error
+	}
+
+	// Upsert per-type details
+	switch newItem.MediaItem.Type {
+	case "movie":
+		if errInfo := s.upsertMovie(ctx, tx, newItem.MediaItem, mediaItemRowID); errInfo.Message != "" {
+			logAction.SetError(errInfo.Message, "", errInfo.Detail)
+			return *logAction.Error
+		}
+		logAction.AppendResult("action", "upsert_movie")
+	case "show":
+		seriesRowID, errInfo := s.upsertSeries(ctx, tx, newItem.MediaItem, mediaItemRowID)
+		if errInfo.Message != "" {
+			logAction.SetError(errInfo.Message, "", errInfo.Detail)
+			return *logAction.Error
+		}
+		if errInfo := s.reconcileSeasonsAndEpisodes(ctx, tx, newItem.MediaItem, seriesRowID); errInfo.Message != "" {
+			logAction.SetError(errInfo.Message, "", errInfo.Detail)
+			return *logAction.Error
+		}
+		logAction.AppendResult("action", "upsert_show_reconcile")
+	default:
+		logAction.SetError("DB: unsupported media item type", newItem.MediaItem.Type, map[string]any{
+			"type": newItem.MediaItem.Type,
+		})
+		return *logAction.Error
+	}
+
+	// Enforce uniqueness of SelectedTypes across sets for this item:
+	// "last one wins" based on incoming slice order.
+	typeOwnerSetID := map[string]string{} // key: poster/backdrop/season_poster/special_season_poster/titlecard -> set_id
+	for _, ps := range newItem.PosterSets {
+		if ps.ToDelete {
+			continue
+		}
+		if ps.SelectedTypes.Poster {
+			typeOwnerSetID["poster"] = ps.ID
+		}
+		if ps.SelectedTypes.Backdrop {
+			typeOwnerSetID["backdrop"] = ps.ID
+		}
+		if ps.SelectedTypes.SeasonPoster {
+			typeOwnerSetID["season_poster"] = ps.ID
+		}
+		if ps.SelectedTypes.SpecialSeasonPoster {
+			typeOwnerSetID["special_season_poster"] = ps.ID
+		}
+		if ps.SelectedTypes.Titlecard {
+			typeOwnerSetID["titlecard"] = ps.ID
+		}
+	}
+
+	// 2) First, process deletions (so re-adds/upserts in same payload behave predictably)
+	for _, ps := range newItem.PosterSets {
+		if !ps.ToDelete {
+			continue
+		}
+		deletedLinks, _, _, _, errInfo := s.unlinkPosterSetFromMediaItemTx(ctx, tx, newItem.MediaItem.TMDB_ID, newItem.MediaItem.LibraryTitle, ps.ID)
+		if errInfo.Message != "" {
+			logAction.SetError(errInfo.Message, "", errInfo.Detail)
+			return *logAction.Error
+		}
+		posterSetsDeleted += deletedLinks
+	}
+	if posterSetsDeleted > 0 {
+		logAction.AppendResult("poster_sets_deleted", posterSetsDeleted)
+	}
+
+	// 3) Then upsert non-deleted sets
+	for _, ps := range newItem.PosterSets {
+		if ps.ToDelete {
+			continue
+		}
+
+		ps.DateUpdated = time.Now().UTC()
+
+		posterSetRowID, errInfo := s.upsertPosterSet(ctx, tx, ps)
+		if errInfo.Message != "" {
+			logAction.SetError(errInfo.Message, "", errInfo.Detail)
+			return *logAction.Error
+		}
+		posterSetsUpserted++
+
+		// Upsert item+set link (SavedItems)
+		if errInfo := s.upsertSavedItemEntry(ctx, tx, newItem.MediaItem, ps, posterSetRowID); errInfo.Message != "" {
+			logAction.SetError(errInfo.Message, "", errInfo.Detail)
+			return *logAction.Error
+		}
+
+		// Replace images for this set, scoped to this item
+		imagesUpserted += len(ps.Images)
+		if errInfo := s.replaceImageFiles(ctx, tx, ps, posterSetRowID, newItem.MediaItem.TMDB_ID); errInfo.Message != "" {
+			logAction.SetError(errInfo.Message, "", errInfo.Detail)
+			return *logAction.Error
+		}
+	}
+
+	logAction.AppendResult("poster_sets_upserted", posterSetsUpserted)
+	logAction.AppendResult("images_upserted", imagesUpserted)
+
+	// Apply SelectedTypes uniqueness across ALL sets for this media item
+	if errInfo := s.clearSelectedTypesOnOtherSets(ctx, tx, newItem.MediaItem.TMDB_ID, newItem.MediaItem.LibraryTitle, typeOwnerSetID); errInfo.Message != "" {
+		logAction.SetError(errInfo.Message, "", errInfo.Detail)
+		return *logAction.Error
+	}
+	logAction.AppendResult("selected_types_uniqueness", "applied")
+
+	// If no selected types remain, remove that SavedItems row
+	res, err = tx.ExecContext(ctx, s.rebind(`
+DELETE FROM SavedItems
+WHERE tmdb_id = ? AND library_title = ?
+  AND poster_selected = 0
+  AND backdrop_selected = 0
+  AND season_poster_selected = 0
+  AND special_season_poster_selected = 0
+  AND titlecard_selected = 0;
+`), newItem.MediaItem.TMDB_ID, newItem.MediaItem.LibraryTitle)
+	if err != nil {
+		logAction.SetError("DB: delete empty SavedItems links failed", err.Error(), map[string]any{"error": err.Error()})
+		return *logAction.Error
+	}
+	emptySavedItemsDeleted, _ = res.RowsAffected()
+	if emptySavedItemsDeleted > 0 {
+		logAction.AppendResult("saved_items_deleted_empty", emptySavedItemsDeleted)
+	}
+
+	// Cleanup: remove orphan poster sets + their images not referenced by any SavedItems row
+	orphanSetsDeleted, orphanImagesDeleted, errInfo := s.deleteOrphanPosterSetsAndImages(ctx, tx)
+	if errInfo.Message != "" {
+		logAction.SetError(errInfo.Message, "", errInfo.Detail)
+		return *logAction.Error
+	}
+	if orphanSetsDeleted > 0 {
+		logAction.AppendResult("orphan_poster_sets_deleted", orphanSetsDeleted)
+	}
+	if orphanImagesDeleted > 0 {
+		logAction.AppendResult("orphan_images_deleted", orphanImagesDeleted)
+	}
+
+	// Commit the transaction
+	if err := tx.Commit(); err != nil {
+		logAction.SetError("DB: TX COMMIT failed", err.Error(), map[string]any{"error": err.Error()})
+		return *logAction.Error
+	}
+
+	logging.LOGGER.Info().Timestamp().
+		Str("tmdb_id", newItem.MediaItem.TMDB_ID).
+		Str("library_title", newItem.MediaItem.LibraryTitle).
+		Msg("Upserted SavedItem entry for MediaItem")
+
+	return logging.LogErrorInfo{}
+}
+
+// selectRowID looks up the id of a row after it has been upserted
+func (s *ServerDB) selectRowID(ctx context.Context, tx *sql.Tx, query string, args ...any) (rowID int64, err error) {
+	err = tx.QueryRowContext(ctx, s.rebind(query), args...).Scan(&rowID)
+	return rowID, err
+}
+
+func (s *ServerDB) upsertMediaItem(ctx context.Context, tx *sql.Tx, mediaItem models.MediaItem) (rowID int64, Err logging.LogErrorInfo) {
+	_, err := s.upsert(ctx, tx, "MediaItems",
+		[]string{"tmdb_id", "library_title"},
+		[]string{"tmdb_id", "library_title", "rating_key", "type", "title", "year", "on_server"},
+		[]string{"rating_key", "type", "title", "year", "on_server"},
+		mediaItem.TMDB_ID,
+		mediaItem.LibraryTitle,
+		mediaItem.RatingKey,
+		mediaItem.Type,
+		mediaItem.Title,
+		mediaItem.Year,
+		1,
+	)
+	if err != nil {
+		return 0, logging.LogErrorInfo{Message: "DB: UPSERT MediaItems failed", Detail: map[string]any{"error": err.Error()}}
+	}
+
+	rowID, err = s.selectRowID(ctx, tx, `SELECT id FROM MediaItems WHERE tmdb_id = ? AND library_title = ?;`, mediaItem.TMDB_ID, mediaItem.LibraryTitle)
+	if err != nil {
+		return 0, logging.LogErrorInfo{Message: "DB: lookup MediaItems.id failed", Detail: map[string]any{"error": err.Error()}}
+	}
+
+	logging.LOGGER.Debug().Timestamp().
+		Str("tmdb_id", mediaItem.TMDB_ID).
+		Str("library_title", mediaItem.LibraryTitle).
+		Int64("media_item_id", rowID).
+		Msg("Upserted MediaItem")
+	return rowID, logging.LogErrorInfo{}
+}
+
+func (s *ServerDB) upsertMovie(ctx context.Context, tx *sql.Tx, mediaItem models.MediaItem, mediaItemRowID int64) (Err logging.LogErrorInfo) {
+	if mediaItem.Movie == nil {
+		mediaItem.Movie = &models.MediaItemMovie{}
+	}
+
+	_, err := s.upsert(ctx, tx, "Movies",
+		[]string{"media_item_id"},
+		[]string{"media_item_id", "path", "size", "duration"},
+		[]string{"path", "size", "duration"},
+		mediaItemRowID,
+		mediaItem.Movie.File.Path,
+		mediaItem.Movie.File.Size,
+		mediaItem.Movie.File.Duration,
+	)
+	if err != nil {
+		return logging.LogErrorInfo{Message: "DB: UPSERT Movies failed", Detail: map[string]any{"error": err.Error()}}
+	}
+	return logging.LogErrorInfo{}
+}
+
+func (s *ServerDB) upsertSeries(ctx context.Context, tx *sql.Tx, mediaItem models.MediaItem, mediaItemRowID int64) (seriesRowID int64, Err logging.LogErrorInfo) {
+	if mediaItem.Series == nil {
+		mediaItem.Series = &models.MediaItemSeries{}
+	}
+
+	_, err := s.upsert(ctx, tx, "Series",
+		[]string{"media_item_id"},
+		[]string{"media_item_id", "season_count", "episode_count", "location"},
+		[]string{"season_count", "episode_count", "location"},
+		mediaItemRowID,
+		mediaItem.Series.SeasonCount,
+		mediaItem.Series.EpisodeCount,
+		mediaItem.Series.Location,
+	)
+	if err != nil {
+		return 0, logging.LogErrorInfo{Message: "DB: UPSERT Series failed", Detail: map[string]any{"error": err.Error()}}
+	}
+
+	seriesRowID, err = s.selectRowID(ctx, tx, `SELECT id FROM Series WHERE media_item_id = ?;`, mediaItemRowID)
+	if err != nil {
+		return 0, logging.LogErrorInfo{Message: "DB: lookup Series.id failed", Detail: map[string]any{"error": err.Error()}}
+	}
+	return seriesRowID, logging.LogErrorInfo{}
+}
+
+// Reconcile seasons/episodes: delete rows no longer present, upsert present ones.
+func (s *ServerDB) reconcileSeasonsAndEpisodes(ctx context.Context, tx *sql.Tx, mediaItem models.MediaItem, seriesRowID int64) (Err logging.LogErrorInfo) {
+	if mediaItem.Series == nil || mediaItem.Series.Seasons == nil {
+		// if caller doesn't send seasons, do nothing (avoid destructive deletes)
+		return logging.LogErrorInfo{}
+	}
+	seasons := mediaItem.Series.Seasons
+
+	// Delete seasons not in incoming (cascades episodes)
+	if len(seasons) > 0 {
+		keepSeasonNums := make([]any, 0, len(seasons))
+		for _, sn := range seasons {
+			keepSeasonNums = append(keepSeasonNums, sn.SeasonNumber)
+		}
+		qDel := fmt.Sprintf(`DELETE FROM Seasons WHERE series_id = ? AND season_number NOT IN (%s);`, placeholders(len(keepSeasonNums)))
+		args := append([]any{seriesRowID}, keepSeasonNums...)
+		if _, err := tx.ExecContext(ctx, s.rebind(qDel), args...); err != nil {
+			return logging.LogErrorInfo{Message: "DB: delete missing seasons failed", Detail: map[string]any{"error": err.Error()}}
+		}
+	}
+
+	// Upsert seasons and reconcile episodes per season
+	for _, sn := range seasons {
+		_, err := s.upsert(ctx, tx, "Seasons",
+			[]string{"series_id", "season_number"},
+			[]string{"series_id", "rating_key", "season_number", "episode_count"},
+			[]string{"rating_key", "episode_count"},
+			seriesRowID, sn.RatingKey, sn.SeasonNumber, lenOr0(sn.Episodes),
+		)
+		if err != nil {
+			return logging.LogErrorInfo{Message: "DB: UPSERT Seasons failed", Detail: map[string]any{"error": err.Error()}}
+		}
+
+		seasonRowID, err := s.selectRowID(ctx, tx, `SELECT id FROM Seasons WHERE series_id = ? AND season_number = ?;`, seriesRowID, sn.SeasonNumber)
+		if err != nil {
+			return logging.LogErrorInfo{Message: "DB: lookup Seasons.id failed", Detail: map[string]any{"error": err.Error()}}
+		}
+
+		if sn.Episodes == nil {
+			continue
+		}
+
+		if len(sn.Episodes) > 0 {
+			keepEpisodeNums := make([]any, 0, len(sn.Episodes))
+			for _, ep := range sn.Episodes {
+				keepEpisodeNums = append(keepEpisodeNums, ep.EpisodeNumber)
+			}
+			qDelEp := fmt.Sprintf(`DELETE FROM Episodes WHERE season_id = ? AND episode_number NOT IN (%s);`, placeholders(len(keepEpisodeNums)))
+			args := append([]any{seasonRowID}, keepEpisodeNums...)
+			if _, err := tx.ExecContext(ctx, s.rebind(qDelEp), args...); err != nil {
+				return logging.LogErrorInfo{Message: "DB: delete missing episodes failed", Detail: map[string]any{"error": err.Error()}}
+			}
+		}
+
+		for _, ep := range sn.Episodes {
+			_, err := s.upsert(ctx, tx, "Episodes",
+				[]string{"season_id", "episode_number"},
+				[]string{"season_id", "rating_key", "episode_number", "title", "path", "size", "duration"},
+				[]string{"rating_key", "title", "path", "size", "duration"},
+				seasonRowID, ep.RatingKey, ep.EpisodeNumber, ep.Title, ep.File.Path, ep.File.Size, ep.File.Duration,
+			)
+			if err != nil {
+				return logging.LogErrorInfo{Message: "DB: UPSERT Episodes failed", Detail: map[string]any{"error": err.Error()}}
+			}
+		}
+	}
+
+	return logging.LogErrorInfo{}
+}
+
+func (s *ServerDB) upsertPosterSet(ctx context.Context, tx *sql.Tx, ps models.DBPosterSetDetail) (posterSetRowID int64, Err logging.LogErrorInfo) {
+	// date_created is not updated to preserve the original creation time
+	_, err := s.upsert(ctx, tx, "PosterSets",
+		[]string{"set_id"},
+		[]string{"set_id", "type", "title", `"user"`, "date_created", "date_updated"},
+		[]string{"type", "title", `"user"`, "date_updated"},
+		ps.ID, ps.Type, ps.Title, ps.UserCreated, ps.DateCreated.UTC(), ps.DateUpdated.UTC(),
+	)
+	if err != nil {
+		return 0, logging.LogErrorInfo{Message: "DB: UPSERT PosterSets failed", Detail: map[string]any{"error": err.Error(), "set_id": ps.ID}}
+	}
+
+	posterSetRowID, err = s.selectRowID(ctx, tx, `SELECT id FROM PosterSets WHERE set_id = ?;`, ps.ID)
+	if err != nil {
+		return 0, logging.LogErrorInfo{Message: "DB: lookup PosterSets.id failed", Detail: map[string]any{"error": err.Error(), "set_id": ps.ID}}
+	}
+	return posterSetRowID, logging.LogErrorInfo{}
+}
+
+func (s *ServerDB) upsertSavedItemEntry(ctx context.Context, tx *sql.Tx, mediaItem models.MediaItem, ps models.DBPosterSetDetail, posterSetRowID int64) (Err logging.LogErrorInfo) {
+	_, err := s.upsert(ctx, tx, "SavedItems",
+		[]string{"tmdb_id", "library_title", "poster_set_id"},
+		[]string{
+			"tmdb_id", "library_title", "poster_set_id",
+			"poster_selected", "backdrop_selected", "season_poster_selected", "special_season_poster_selected", "titlecard_selected",
+			"autodownload", "auto_add_new_collection_items", "last_downloaded",
+		},
+		[]string{
+			"poster_selected", "backdrop_selected", "season_poster_selected", "special_season_poster_selected", "titlecard_selected",
+			"autodownload", "auto_add_new_collection_items", "last_downloaded",
+		},
+		mediaItem.TMDB_ID,
+		mediaItem.LibraryTitle,
+		posterSetRowID,
+		boolToInt(ps.SelectedTypes.Poster),
+		boolToInt(ps.SelectedTypes.Backdrop),
+		boolToInt(ps.SelectedTypes.SeasonPoster),
+		boolToInt(ps.SelectedTypes.SpecialSeasonPoster),
+		boolToInt(ps.SelectedTypes.Titlecard),
+		boolToInt(ps.AutoDownload),
+		boolToInt(ps.AutoAddNewCollectionItems),
+		ps.LastDownloaded.UTC(),
+	)
+	if err != nil {
+		return logging.LogErrorInfo{Message: "DB: UPSERT SavedItems failed", Detail: map[string]any{"error": err.Error()}}
+	}
+	return logging.LogErrorInfo{}
+}
+
+// replaceImageFiles replaces the images of a set for a single item, so images removed from the set do not linger
+func (s *ServerDB) replaceImageFiles(ctx context.Context, tx *sql.Tx, ps models.DBPosterSetDetail, posterSetRowID int64, itemTMDBID string) (Err logging.LogErrorInfo) {
+	if _, err := tx.ExecContext(ctx, s.rebind(`DELETE FROM ImageFiles WHERE poster_set_id = ? AND item_tmdb_id = ?;`), posterSetRowID, itemTMDBID); err != nil {
+		return logging.LogErrorInfo{Message: "DB: delete ImageFiles (item-scoped) failed", Detail: map[string]any{"error": err.Error(), "set_id": ps.ID}}
+	}
+
+	for _, im := range ps.Images {
+		// Force scope: item_tmdb_id should match the media item being saved
+		_, err := s.upsert(ctx, tx, "ImageFiles",
+			[]string{"poster_set_id", "image_id", "item_tmdb_id"},
+			[]string{"poster_set_id", "item_tmdb_id", "image_id", "image_type", "image_last_updated", "image_season_number", "image_episode_number"},
+			[]string{"image_type", "image_last_updated", "image_season_number", "image_episode_number"},
+			posterSetRowID, itemTMDBID, im.ID, im.Type, im.Modified.UTC(), im.SeasonNumber, im.EpisodeNumber,
+		)
+		if err != nil {
+			return logging.LogErrorInfo{Message: "DB: UPSERT ImageFiles failed", Detail: map[string]any{"error": err.Error(), "set_id": ps.ID}}
+		}
+	}
+	return logging.LogErrorInfo{}
+}
+
+func (s *ServerDB) clearSelectedTypesOnOtherSets(ctx context.Context, tx *sql.Tx, tmdbID, libraryTitle string, owner map[string]string) (Err logging.LogErrorInfo) {
+	// For each type, find owner poster_set_id, then clear that type on all other sets for this item
+	cols := map[string]string{
+		"poster":                "poster_selected",
+		"backdrop":              "backdrop_selected",
+		"season_poster":         "season_poster_selected",
+		"special_season_poster": "special_season_poster_selected",
+		"titlecard":             "titlecard_selected",
+	}
+
+	for key, column := range cols {
+		ownerSetID, ok := owner[key]
+		if !ok || ownerSetID == "" {
+			continue
+		}
+
+		ownerPosterSetRowID, err := s.selectRowID(ctx, tx, `SELECT id FROM PosterSets WHERE set_id = ?;`, ownerSetID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				continue
+			}
+			return logging.LogErrorInfo{Message: "DB: lookup owner poster set id failed", Detail: map[string]any{"error": err.Error(), "set_id": ownerSetID}}
+		}
+
+		q := fmt.Sprintf(`UPDATE SavedItems SET %s = 0 WHERE tmdb_id = ? AND library_title = ? AND poster_set_id <> ?;`, column)
+		if _, err := tx.ExecContext(ctx, s.rebind(q), tmdbID, libraryTitle, ownerPosterSetRowID); err != nil {
+			return logging.LogErrorInfo{Message: "DB: clear selected types failed", Detail: map[string]any{"error": err.Error(), "type": key}}
+		}
+	}
+
+	return logging.LogErrorInfo{}
+}
+
+// deleteOrphanPosterSetsAndImages removes orphan poster sets (not referenced by SavedItems) AND their images.
+// Returns (setsDeleted, imagesDeleted).
+func (s *ServerDB) deleteOrphanPosterSetsAndImages(ctx context.Context, tx *sql.Tx) (setsDeleted int64, imagesDeleted int64, Err logging.LogErrorInfo) {
+	// 1) Delete orphan images first
+	resImg, err := tx.ExecContext(ctx, `
+DELETE FROM ImageFiles
+WHERE NOT EXISTS (SELECT 1 FROM SavedItems si WHERE si.poster_set_id = ImageFiles.poster_set_id);
+`)
+	if err != nil {
+		return 0, 0, logging.LogErrorInfo{Message: "DB: delete orphan ImageFiles failed", Detail: map[string]any{"error": err.Error()}}
+	}
+	imagesDeleted, _ = resImg.RowsAffected()
+
+	// 2) Delete orphan sets
+	resSet, err := tx.ExecContext(ctx, `
+DELETE FROM PosterSets
+WHERE NOT EXISTS (SELECT 1 FROM SavedItems si WHERE si.poster_set_id = PosterSets.id);
+`)
+	if err != nil {
+		return 0, imagesDeleted, logging.LogErrorInfo{Message: "DB: delete orphan PosterSets failed", Detail: map[string]any{"error": err.Error()}}
+	}
+	setsDeleted, _ = resSet.RowsAffected()
+
+	return setsDeleted, imagesDeleted, logging.LogErrorInfo{}
+}
@@ -0,0 +1,82 @@
+package database
+
+import (
+	"aura/logging"
+	"context"
+	"fmt"
+	"strings"
+)
+
+func getServerDBSizeMiB(ctx context.Context, s *ServerDB) (sizeMiB float64, err error) {
+	query := `
+		SELECT COALESCE(SUM(data_length + index_length), 0)
+		FROM information_schema.tables
+		WHERE table_schema = DATABASE();
+	`
+	if s.Config.Type == "postgresql" {
+		query = `SELECT pg_database_size(current_database());`
+	}
+
+	var sizeBytes int64
+	err = s.conn.QueryRowContext(ctx, query).Scan(&sizeBytes)
+	if err != nil {
+		return 0, err
+	}
+
+	return float64(sizeBytes) / (1024.0 * 1024.0), nil
+}
+
+// Vacuum reclaims space and refreshes the planner statistics of the main tables.
+// PostgreSQL uses VACUUM (ANALYZE), MySQL uses OPTIMIZE TABLE.
+func (s *ServerDB) Vacuum(ctx context.Context) (Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Running VACUUM on Database", logging.LevelInfo)
+	defer logAction.Complete()
+
+	dbSizeCurrent, err := getServerDBSizeMiB(ctx, s)
+	if err != nil {
+		logAction.SetError("Failed to get current database size", err.Error(), map[string]any{
+			"error": err.Error(),
+		})
+		return *logAction.Error
+	}
+	logAction.AppendResult("db_size_current", dbSizeCurrent)
+
+	tables := strings.Join(serverTables, ", ")
+	if s.Config.Type == "postgresql" {
+		query := fmt.Sprintf("VACUUM (ANALYZE) %s;", tables)
+		if _, err = s.conn.ExecContext(ctx, query); err != nil {
+			logAction.SetError("Failed to VACUUM database", err.Error(), map[string]any{
+				"error": err.Error(),
+				"query": query,
+			})
+			return *logAction.Error
+		}
+	} else {
+		// OPTIMIZE TABLE returns a status row per table, which has to be read before the connection can be reused
+		query := fmt.Sprintf("OPTIMIZE TABLE %s;", tables)
+		rows, err := s.conn.QueryContext(ctx, query)
+		if err != nil {
+			logAction.SetError("Failed to OPTIMIZE database tables", err.Error(), map[string]any{
+				"error": err.Error(),
+				"query": query,
+			})
+			return *logAction.Error
+		}
+		for rows.Next() {
+		}
+		rows.Close()
+	}
+
+	dbSizeNew, err := getServerDBSizeMiB(ctx, s)
+	if err != nil {
+		logAction.SetError("Failed to get new database size", err.Error(), map[string]any{
+			"error": err.Error(),
+		})
+		return *logAction.Error
+	}
+
+	logAction.AppendResult("db_size_new", dbSizeNew)
+	logAction.AppendResult("vacuum_performed", true)
+	logging.LOGGER.Info().Timestamp().Str("db_size_current", fmt.Sprintf("%.2f MiB", dbSizeCurrent)).Str("db_size_new", fmt.Sprintf("%.2f MiB", dbSizeNew)).Msg("VACUUM completed")
+	return logging.LogErrorInfo{}
+}
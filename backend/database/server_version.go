@@ -0,0 +1,121 @@
+package database
+
+import (
+	"aura/logging"
+	"context"
+	"fmt"
+)
+
+func (s *ServerDB) CreateVersionTable(ctx context.Context) (Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Creating VERSION Table", logging.LevelDebug)
+	defer logAction.Complete()
+
+	query := `
+	CREATE TABLE IF NOT EXISTS VERSION (
+		version INTEGER NOT NULL
+	);
+	`
+	_, err := s.conn.ExecContext(ctx, query)
+	if err != nil {
+		logAction.SetError("Failed to create VERSION table", err.Error(), map[string]any{
+			"error": err.Error(),
+			"query": query,
+		})
+		return *logAction.Error
+	}
+
+	return logging.LogErrorInfo{}
+}
+
+func (s *ServerDB) GetCurrentVersion(ctx context.Context) (version int, Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Getting Current Database Version", logging.LevelDebug)
+	defer logAction.Complete()
+
+	version = 0
+
+	// Check if the VERSION table exists
+	versionTableExists, err := s.tableExists(ctx, s.conn, "VERSION")
+	if err != nil {
+		logAction.SetError("Failed to check for VERSION table", "", map[string]any{
+			"error": err.Error(),
+		})
+		return version, *logAction.Error
+	}
+	if !versionTableExists {
+		logAction.AppendWarning("VERSION table does not exist. Assuming version 0.", nil)
+		return version, logging.LogErrorInfo{}
+	}
+
+	// VERSION table exists, get the current version number
+	getVersionErr := s.conn.QueryRowContext(ctx, "SELECT version FROM VERSION;").Scan(&version)
+	if getVersionErr != nil {
+		logAction.SetError("Failed to get current database version", "", map[string]any{
+			"error": getVersionErr.Error(),
+		})
+		return version, *logAction.Error
+	}
+
+	return version, logging.LogErrorInfo{}
+}
+
+func (s *ServerDB) UpdateVersionTable(ctx context.Context, newVersion int) (Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf("Updating Database Version to %d", newVersion), logging.LevelInfo)
+	defer logAction.Complete()
+
+	query := s.rebind(`UPDATE VERSION SET version = ?;`)
+	res, err := s.conn.ExecContext(ctx, query, newVersion)
+	if err != nil {
+		logAction.SetError(
+			"Failed to update VERSION table",
+			"Ensure the database is accessible and not corrupted.",
+			map[string]any{
+				"error": err.Error(),
+				"query": query,
+			})
+		return *logAction.Error
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		logAction.SetError(
+			"Failed to retrieve rows affected after updating VERSION table",
+			"Ensure the database is accessible and not corrupted.",
+			map[string]any{
+				"error": err.Error(),
+			})
+		return *logAction.Error
+	}
+
+	if rowsAffected == 0 {
+		// MySQL only reports changed rows, so make sure the row is really missing before inserting
+		var count int
+		if err := s.conn.QueryRowContext(ctx, "SELECT COUNT(*) FROM VERSION;").Scan(&count); err != nil {
+			logAction.SetError(
+				"Failed to count rows in VERSION table",
+				"Ensure the database is accessible and not corrupted.",
+				map[string]any{
+					"error": err.Error(),
+				})
+			return *logAction.Error
+		}
+		if count > 0 {
+			return logging.LogErrorInfo{}
+		}
+
+		// No rows updated, insert new version
+		insertQuery := s.rebind(`INSERT INTO VERSION (version) VALUES (?);`)
+		_, err := s.conn.ExecContext(ctx, insertQuery, newVersion)
+		if err != nil {
+			logAction.SetError(
+				"Failed to insert new version into VERSION table",
+				"Ensure the database is accessible and not corrupted.",
+				map[string]any{
+					"error": err.Error(),
+					"query": insertQuery,
+				})
+			return *logAction.Error
+		}
+	}
+
+	return logging.LogErrorInfo{}
+}
@@ -4,6 +4,7 @@ import (
 	"aura/config"
 	"aura/logging"
 	"context"
+	"database/sql"
 	"fmt"
 	"io"
 	"os"
@@ -91,3 +92,145 @@ func (s *SQliteDB) Backup(ctx context.Context, currentVersion, newVersion int) (
 
 	return Err
 }
+
+func (s *SQliteDB) BackupTo(ctx context.Context, destPath string) (Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Creating SQLite Database Backup", logging.LevelInfo)
+	defer logAction.Complete()
+
+	Err = logging.LogErrorInfo{}
+
+	if s == nil || s.conn == nil {
+		logAction.SetError("Database connection is nil", "", nil)
+		return *logAction.Error
+	}
+
+	// VACUUM INTO writes a consistent copy of the database, even while other connections are writing to it
+	if _, err := s.conn.ExecContext(ctx, "VACUUM INTO ?;", destPath); err != nil {
+		logAction.SetError("Failed to create database backup",
+			"Ensure the backup path is accessible and writable, and that there is sufficient disk space.",
+			map[string]any{
+				"error": err.Error(),
+				"path":  destPath,
+			})
+		return *logAction.Error
+	}
+	logAction.AppendResult("path", destPath)
+
+	return Err
+}
+
+func (s *SQliteDB) RestoreFrom(ctx context.Context, srcPath string) (Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Restoring SQLite Database Backup", logging.LevelInfo)
+	defer logAction.Complete()
+
+	Err = logging.LogErrorInfo{}
+
+	// Check the backup before touching the current database
+	actionCheckBackup := logAction.AddSubAction("Checking backup file", logging.LevelDebug)
+	backupConn, err := sql.Open("sqlite3", fmt.Sprintf("file:%s?mode=ro", srcPath))
+	if err != nil {
+		actionCheckBackup.SetError("Failed to open backup file", "Ensure the backup file is accessible.", map[string]any{
+			"error": err.Error(),
+			"path":  srcPath,
+		})
+		return *actionCheckBackup.Error
+	}
+	var integrity string
+	var backupVersion int
+	if err := backupConn.QueryRowContext(ctx, "PRAGMA integrity_check;").Scan(&integrity); err != nil || integrity != "ok" {
+		backupConn.Close()
+		detail := map[string]any{"path": srcPath, "integrity_check": integrity}
+		if err != nil {
+			detail["error"] = err.Error()
+		}
+		actionCheckBackup.SetError("Backup file failed the integrity check", "The backup file is corrupt and can not be restored.", detail)
+		return *actionCheckBackup.Error
+	}
+	if err := backupConn.QueryRowContext(ctx, "SELECT version FROM VERSION;").Scan(&backupVersion); err != nil {
+		backupConn.Close()
+		actionCheckBackup.SetError("Failed to get backup database version", "The backup file is not an AURA database.", map[string]any{
+			"error": err.Error(),
+			"path":  srcPath,
+		})
+		return *actionCheckBackup.Error
+	}
+	backupConn.Close()
+	// Older versions are migrated after the restore
+	if backupVersion < 1 || backupVersion > LATEST_DB_VERSION {
+		actionCheckBackup.SetError("Backup database version is not supported",
+			fmt.Sprintf("Only backups up to database version %d can be restored.", LATEST_DB_VERSION),
+			map[string]any{
+				"backup_version":  backupVersion,
+				"current_version": LATEST_DB_VERSION,
+			})
+		return *actionCheckBackup.Error
+	}
+	actionCheckBackup.Complete()
+
+	dsn, Err := BuildDSN()
+	if Err.Message != "" {
+		return Err
+	}
+	dbPath := path.Join(config.ConfigPath, dsn)
+
+	// Copy the backup next to the database first, so the swap itself is a rename
+	actionRestoreFile := logAction.AddSubAction("Replacing database file", logging.LevelTrace)
+	tmpPath := dbPath + ".restore"
+	if err := copyFile(srcPath, tmpPath); err != nil {
+		os.Remove(tmpPath)
+		actionRestoreFile.SetError("Failed to copy backup file", "Ensure there is sufficient disk space and permissions.", map[string]any{
+			"error":       err.Error(),
+			"source":      srcPath,
+			"destination": tmpPath,
+		})
+		return *actionRestoreFile.Error
+	}
+
+	// Close the current connection before swapping the file
+	if s.conn != nil {
+		s.conn.Close()
+		s.conn = nil
+	}
+	os.Remove(dbPath + "-wal")
+	os.Remove(dbPath + "-shm")
+	if err := os.Rename(tmpPath, dbPath); err != nil {
+		os.Remove(tmpPath)
+		actionRestoreFile.SetError("Failed to replace database file", "Ensure the database path is writable.", map[string]any{
+			"error": err.Error(),
+			"path":  dbPath,
+		})
+		// Reopen the current database so the app keeps working
+		s.conn, _, _ = s.GetDBConnection(ctx)
+		return *actionRestoreFile.Error
+	}
+	actionRestoreFile.Complete()
+
+	// Reopen the connection to the restored database
+	s.conn, _, Err = s.GetDBConnection(ctx)
+	if Err.Message != "" {
+		return Err
+	}
+	logAction.AppendResult("restored_from", srcPath)
+
+	return Err
+}
+
+// copyFile copies the contents of src to dst, replacing dst if it exists
+func copyFile(src, dst string) error {
+	sourceFile, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer sourceFile.Close()
+
+	destFile, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(destFile, sourceFile); err != nil {
+		destFile.Close()
+		return err
+	}
+	return destFile.Close()
+}
@@ -0,0 +1,134 @@
+package database
+
+import (
+	"aura/config"
+	"aura/logging"
+	"context"
+	"database/sql"
+	"os"
+	"path"
+	"path/filepath"
+	"testing"
+)
+
+// newTestSQliteConfigDB opens the configured SQLite database in a temporary config folder,
+// with the VERSION table at the latest version and a table holding a single value
+func newTestSQliteConfigDB(t *testing.T) (context.Context, *SQliteDB) {
+	t.Helper()
+
+	previousPath, previousDatabase := config.ConfigPath, config.Current.Database
+	t.Cleanup(func() {
+		config.ConfigPath = previousPath
+		config.Current.Database = previousDatabase
+	})
+	config.ConfigPath = t.TempDir()
+	config.Current.Database = config.Config_Database{Type: "sqlite3", Path: "aura.db"}
+
+	conn, err := sql.Open("sqlite3", path.Join(config.ConfigPath, "aura.db"))
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	s := &SQliteDB{conn: conn}
+	t.Cleanup(func() {
+		if s.conn != nil {
+			s.conn.Close()
+		}
+	})
+
+	ctx := newTestContext()
+	for _, step := range []func(context.Context) logging.LogErrorInfo{
+		s.CreateVersionTable,
+		func(ctx context.Context) logging.LogErrorInfo { return s.UpdateVersionTable(ctx, LATEST_DB_VERSION) },
+		func(ctx context.Context) logging.LogErrorInfo {
+			if _, err := s.conn.ExecContext(ctx, "CREATE TABLE BackupTest (value TEXT NOT NULL);"); err != nil {
+				return logging.LogErrorInfo{Message: err.Error()}
+			}
+			return logging.LogErrorInfo{}
+		},
+	} {
+		if Err := step(ctx); Err.Message != "" {
+			t.Fatalf("failed to set up database: %s", Err.Message)
+		}
+	}
+	return ctx, s
+}
+
+// setTestValue replaces the value held by the BackupTest table
+func setTestValue(t *testing.T, s *SQliteDB, value string) error {
+	t.Helper()
+	if _, err := s.conn.Exec("DELETE FROM BackupTest;"); err != nil {
+		return err
+	}
+	_, err := s.conn.Exec("INSERT INTO BackupTest (value) VALUES (?);", value)
+	return err
+}
+
+func TestSQliteBackupAndRestore(t *testing.T) {
+	ctx, s := newTestSQliteConfigDB(t)
+
+	if err := setTestValue(t, s, "before"); err != nil {
+		t.Fatalf("failed to set value: %v", err)
+	}
+	backupPath := filepath.Join(t.TempDir(), "backup.db")
+	if Err := s.BackupTo(ctx, backupPath); Err.Message != "" {
+		t.Fatalf("BackupTo() failed: %s", Err.Message)
+	}
+
+	// Changes after the backup are undone by the restore
+	if err := setTestValue(t, s, "after"); err != nil {
+		t.Fatalf("failed to set value: %v", err)
+	}
+	if Err := s.RestoreFrom(ctx, backupPath); Err.Message != "" {
+		t.Fatalf("RestoreFrom() failed: %s", Err.Message)
+	}
+
+	var value string
+	if err := s.conn.QueryRow("SELECT value FROM BackupTest;").Scan(&value); err != nil || value != "before" {
+		t.Errorf("value after restore = %q, error = %v, want %q", value, err, "before")
+	}
+	if version, Err := s.GetCurrentVersion(ctx); Err.Message != "" || version != LATEST_DB_VERSION {
+		t.Errorf("GetCurrentVersion() after restore = %d, error = %q", version, Err.Message)
+	}
+}
+
+func TestSQliteRestoreRejectsNewerVersions(t *testing.T) {
+	ctx, s := newTestSQliteConfigDB(t)
+
+	backupPath := filepath.Join(t.TempDir(), "backup.db")
+	if Err := s.BackupTo(ctx, backupPath); Err.Message != "" {
+		t.Fatalf("BackupTo() failed: %s", Err.Message)
+	}
+	backupConn, err := sql.Open("sqlite3", backupPath)
+	if err != nil {
+		t.Fatalf("failed to open backup: %v", err)
+	}
+	if _, err := backupConn.Exec("UPDATE VERSION SET version = ?;", LATEST_DB_VERSION+1); err != nil {
+		t.Fatalf("failed to change backup version: %v", err)
+	}
+	backupConn.Close()
+
+	if Err := s.RestoreFrom(ctx, backupPath); Err.Message == "" {
+		t.Fatal("RestoreFrom() of a newer database version returned no error")
+	}
+
+	// The current database is still open and unchanged
+	if err := setTestValue(t, s, "after"); err != nil {
+		t.Errorf("failed to set value after the rejected restore: %v", err)
+	}
+}
+
+func TestSQliteRestoreRejectsCorruptFiles(t *testing.T) {
+	ctx, s := newTestSQliteConfigDB(t)
+
+	backupPath := filepath.Join(t.TempDir(), "backup.db")
+	if err := os.WriteFile(backupPath, []byte("not a database"), 0o644); err != nil {
+		t.Fatalf("failed to write backup: %v", err)
+	}
+
+	if Err := s.RestoreFrom(ctx, backupPath); Err.Message == "" {
+		t.Fatal("RestoreFrom() of a corrupt file returned no error")
+	}
+	if s.conn == nil {
+		t.Error("RestoreFrom() closed the current database")
+	}
+}
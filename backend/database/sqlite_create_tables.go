@@ -23,6 +23,11 @@ func (s *SQliteDB) CreateTables(ctx context.Context) (Err logging.LogErrorInfo)
 		v2_CreateSavedItemsTable,
 		v2_CreateIgnoredItemsTable,
 		v2_AddIndexesToNewTables,
+		v6_CreateDownloadQueueTable,
+		v8_CreatePendingApprovalsTable,
+		v9_CreateImageHistoryTable,
+		v12_CreateImageFingerprintsTable,
+		v11_CreateJobCheckpointsTable,
 	}
 
 	for _, step := range steps {
@@ -361,3 +366,220 @@ CREATE INDEX idx_ignoreditems_mode ON IgnoredItems(mode);
 
 	return Err
 }
+
+func (s *SQliteDB) CreateDownloadQueueTable(ctx context.Context) (Err logging.LogErrorInfo) {
+	return v6_CreateDownloadQueueTable(ctx, s.conn)
+}
+
+func v6_CreateDownloadQueueTable(ctx context.Context, conn *sql.DB) (Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Creating DownloadQueue Table", logging.LevelTrace)
+	defer logAction.Complete()
+	Err = logging.LogErrorInfo{}
+
+	query := `
+CREATE TABLE IF NOT EXISTS DownloadQueue (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	tmdb_id TEXT NOT NULL,
+	library_title TEXT NOT NULL,
+	server TEXT NOT NULL DEFAULT '',
+	title TEXT NOT NULL,
+	status TEXT NOT NULL CHECK (status IN ('pending','processing','succeeded','warning','failed')),
+	attempts INTEGER NOT NULL DEFAULT 0,
+
+	-- Saved Item, per-image results, errors and warnings (stored as JSON strings)
+	item TEXT NOT NULL,
+	results TEXT NOT NULL DEFAULT '[]',
+	errors TEXT NOT NULL DEFAULT '[]',
+	warnings TEXT NOT NULL DEFAULT '[]',
+
+	created_at DATETIME NOT NULL,
+	updated_at DATETIME NOT NULL,
+	started_at DATETIME,
+	finished_at DATETIME,
+	next_attempt_at DATETIME
+);
+
+CREATE INDEX IF NOT EXISTS idx_downloadqueue_status ON DownloadQueue(status);
+CREATE INDEX IF NOT EXISTS idx_downloadqueue_item ON DownloadQueue(tmdb_id, library_title);
+`
+	_, err := conn.ExecContext(ctx, query)
+	if err != nil {
+		logAction.SetError("Failed to create DownloadQueue table", err.Error(), map[string]any{
+			"error": err.Error(),
+			"query": query,
+		})
+		return *logAction.Error
+	}
+
+	return Err
+}
+
+func (s *SQliteDB) CreatePendingApprovalsTable(ctx context.Context) (Err logging.LogErrorInfo) {
+	return v8_CreatePendingApprovalsTable(ctx, s.conn)
+}
+
+func v8_CreatePendingApprovalsTable(ctx context.Context, conn *sql.DB) (Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Creating PendingApprovals Table", logging.LevelTrace)
+	defer logAction.Complete()
+	Err = logging.LogErrorInfo{}
+
+	query := `
+CREATE TABLE IF NOT EXISTS PendingApprovals (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	kind TEXT NOT NULL,
+	status TEXT NOT NULL CHECK (status IN ('pending','approved','rejected')),
+	tmdb_id TEXT NOT NULL,
+	library_title TEXT NOT NULL,
+	server TEXT NOT NULL DEFAULT '',
+	title TEXT NOT NULL,
+	set_id TEXT NOT NULL DEFAULT '',
+
+	-- Saved Item that is added to the Download Queue when approved (stored as a JSON string)
+	item TEXT NOT NULL,
+	reason TEXT NOT NULL DEFAULT '',
+
+	created_at DATETIME NOT NULL,
+	decided_at DATETIME
+);
+
+CREATE INDEX IF NOT EXISTS idx_pendingapprovals_status ON PendingApprovals(status);
+CREATE INDEX IF NOT EXISTS idx_pendingapprovals_item ON PendingApprovals(tmdb_id, library_title);
+`
+	_, err := conn.ExecContext(ctx, query)
+	if err != nil {
+		logAction.SetError("Failed to create PendingApprovals table", err.Error(), map[string]any{
+			"error": err.Error(),
+			"query": query,
+		})
+		return *logAction.Error
+	}
+
+	return Err
+}
+
+func (s *SQliteDB) CreateImageHistoryTable(ctx context.Context) (Err logging.LogErrorInfo) {
+	return v9_CreateImageHistoryTable(ctx, s.conn)
+}
+
+func v9_CreateImageHistoryTable(ctx context.Context, conn *sql.DB) (Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Creating ImageHistory Table", logging.LevelTrace)
+	defer logAction.Complete()
+	Err = logging.LogErrorInfo{}
+
+	query := `
+CREATE TABLE IF NOT EXISTS ImageHistory (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	tmdb_id TEXT NOT NULL,
+	library_title TEXT NOT NULL,
+	server TEXT NOT NULL DEFAULT '',
+	title TEXT NOT NULL,
+	rating_key TEXT NOT NULL,
+	image_rating_key TEXT NOT NULL,
+	image_type TEXT NOT NULL,
+	season_number INTEGER,
+	episode_number INTEGER,
+
+	-- Name of the snapshot file in the image-history folder
+	file_name TEXT NOT NULL,
+	replaced_by TEXT NOT NULL DEFAULT '',
+
+	created_at DATETIME NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_imagehistory_item ON ImageHistory(tmdb_id, library_title);
+CREATE INDEX IF NOT EXISTS idx_imagehistory_image ON ImageHistory(rating_key, image_rating_key, image_type);
+`
+	_, err := conn.ExecContext(ctx, query)
+	if err != nil {
+		logAction.SetError("Failed to create ImageHistory table", err.Error(), map[string]any{
+			"error": err.Error(),
+			"query": query,
+		})
+		return *logAction.Error
+	}
+
+	return Err
+}
+
+func (s *SQliteDB) CreateImageFingerprintsTable(ctx context.Context) (Err logging.LogErrorInfo) {
+	return v12_CreateImageFingerprintsTable(ctx, s.conn)
+}
+
+func (s *SQliteDB) RecreateImageFingerprintsTable(ctx context.Context) (Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Recreating ImageFingerprints Table", logging.LevelTrace)
+	defer logAction.Complete()
+
+	if _, err := s.conn.ExecContext(ctx, `DROP TABLE IF EXISTS ImageFingerprints;`); err != nil {
+		logAction.SetError("Failed to drop ImageFingerprints table", err.Error(), map[string]any{
+			"error": err.Error(),
+		})
+		return *logAction.Error
+	}
+
+	return v12_CreateImageFingerprintsTable(ctx, s.conn)
+}
+
+func v12_CreateImageFingerprintsTable(ctx context.Context, conn *sql.DB) (Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Creating ImageFingerprints Table", logging.LevelTrace)
+	defer logAction.Complete()
+	Err = logging.LogErrorInfo{}
+
+	query := `
+CREATE TABLE IF NOT EXISTS ImageFingerprints (
+	-- Name of the media server, empty for the primary media server
+	server TEXT NOT NULL DEFAULT '',
+	tmdb_id TEXT NOT NULL,
+	library_title TEXT NOT NULL,
+
+	-- Place of the image: poster, backdrop, season_poster:S01 or titlecard:S01E01
+	image_key TEXT NOT NULL,
+	image_rating_key TEXT NOT NULL,
+	image_id TEXT NOT NULL,
+
+	-- Perceptual hash (dHash) of the applied image data as 16 hex digits
+	perceptual_hash TEXT NOT NULL,
+	applied_at DATETIME NOT NULL,
+
+	PRIMARY KEY (server, tmdb_id, library_title, image_key)
+);
+`
+	_, err := conn.ExecContext(ctx, query)
+	if err != nil {
+		logAction.SetError("Failed to create ImageFingerprints table", err.Error(), map[string]any{
+			"error": err.Error(),
+			"query": query,
+		})
+		return *logAction.Error
+	}
+
+	return Err
+}
+
+func (s *SQliteDB) CreateJobCheckpointsTable(ctx context.Context) (Err logging.LogErrorInfo) {
+	return v11_CreateJobCheckpointsTable(ctx, s.conn)
+}
+
+func v11_CreateJobCheckpointsTable(ctx context.Context, conn *sql.DB) (Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Creating JobCheckpoints Table", logging.LevelTrace)
+	defer logAction.Complete()
+	Err = logging.LogErrorInfo{}
+
+	query := `
+CREATE TABLE IF NOT EXISTS JobCheckpoints (
+	job TEXT PRIMARY KEY,
+
+	-- Everything up to this time has been handled by the job
+	checked_at DATETIME NOT NULL
+);
+`
+	_, err := conn.ExecContext(ctx, query)
+	if err != nil {
+		logAction.SetError("Failed to create JobCheckpoints table", err.Error(), map[string]any{
+			"error": err.Error(),
+			"query": query,
+		})
+		return *logAction.Error
+	}
+
+	return Err
+}
@@ -0,0 +1,167 @@
+package database
+
+import (
+	"aura/logging"
+	"aura/models"
+	"context"
+	"fmt"
+	"time"
+)
+
+func (s *SQliteDB) AddDownloadQueueEntry(ctx context.Context, item models.DBSavedItem) (id int64, Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Adding Download Queue Entry to Database", logging.LevelDebug)
+	defer logAction.Complete()
+
+	Err = logging.LogErrorInfo{}
+	if s == nil || s.conn == nil {
+		logAction.SetError("Database connection is nil", "", map[string]any{})
+		return 0, *logAction.Error
+	}
+
+	itemJSON, results, errors, warnings, err := marshalDownloadQueueEntry(models.DBDownloadQueueEntry{Item: item})
+	if err != nil {
+		logAction.SetError("Failed to encode Download Queue entry", "Ensure that the Save Item can be converted to JSON", map[string]any{
+			"error": err.Error(),
+		})
+		return 0, *logAction.Error
+	}
+
+	now := time.Now().UTC()
+	res, err := s.conn.ExecContext(ctx, `
+INSERT INTO DownloadQueue (tmdb_id, library_title, server, title, status, attempts, item, results, errors, warnings, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?, 0, ?, ?, ?, ?, ?, ?);`,
+		item.MediaItem.TMDB_ID,
+		item.MediaItem.LibraryTitle,
+		item.MediaItem.Server,
+		item.MediaItem.Title,
+		models.DownloadQueueStatusPending,
+		itemJSON, results, errors, warnings,
+		now, now,
+	)
+	if err != nil {
+		logAction.SetError("DB: INSERT DownloadQueue failed", err.Error(), map[string]any{"error": err.Error()})
+		return 0, *logAction.Error
+	}
+
+	id, err = res.LastInsertId()
+	if err != nil {
+		logAction.SetError("DB: lookup DownloadQueue.id failed", err.Error(), map[string]any{"error": err.Error()})
+		return 0, *logAction.Error
+	}
+
+	logAction.AppendResult("id", id)
+	return id, Err
+}
+
+func (s *SQliteDB) UpdateDownloadQueueEntry(ctx context.Context, entry models.DBDownloadQueueEntry) (Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf("Updating Download Queue Entry %d in Database", entry.ID), logging.LevelTrace)
+	defer logAction.Complete()
+
+	Err = logging.LogErrorInfo{}
+	if s == nil || s.conn == nil {
+		logAction.SetError("Database connection is nil", "", map[string]any{})
+		return *logAction.Error
+	}
+
+	itemJSON, results, errors, warnings, err := marshalDownloadQueueEntry(entry)
+	if err != nil {
+		logAction.SetError("Failed to encode Download Queue entry", "", map[string]any{
+			"error": err.Error(),
+			"id":    entry.ID,
+		})
+		return *logAction.Error
+	}
+
+	_, err = s.conn.ExecContext(ctx, `
+UPDATE DownloadQueue
+SET status = ?, attempts = ?, item = ?, results = ?, errors = ?, warnings = ?, updated_at = ?, started_at = ?, finished_at = ?, next_attempt_at = ?
+WHERE id = ?;`,
+		entry.Status,
+		entry.Attempts,
+		itemJSON, results, errors, warnings,
+		time.Now().UTC(),
+		nullTime(entry.StartedAt),
+		nullTime(entry.FinishedAt),
+		nullTime(entry.NextAttemptAt),
+		entry.ID,
+	)
+	if err != nil {
+		logAction.SetError("DB: UPDATE DownloadQueue failed", err.Error(), map[string]any{
+			"error": err.Error(),
+			"id":    entry.ID,
+		})
+		return *logAction.Error
+	}
+
+	return Err
+}
+
+func (s *SQliteDB) GetDownloadQueueEntries(ctx context.Context, filter models.DBDownloadQueueFilter) (entries []models.DBDownloadQueueEntry, Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Getting Download Queue Entries from Database", logging.LevelTrace)
+	defer logAction.Complete()
+
+	Err = logging.LogErrorInfo{}
+	entries = []models.DBDownloadQueueEntry{}
+	if s == nil || s.conn == nil {
+		logAction.SetError("Database connection is nil", "", map[string]any{})
+		return entries, *logAction.Error
+	}
+
+	whereSQL, args := buildDownloadQueueWhere(filter)
+	query := fmt.Sprintf("SELECT %s FROM DownloadQueue%s ORDER BY id", downloadQueueColumns, whereSQL)
+	if filter.NewestFirst {
+		query += " DESC"
+	}
+	if filter.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, filter.Limit)
+	}
+
+	rows, err := s.conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		logAction.SetError("DB: SELECT DownloadQueue failed", err.Error(), map[string]any{
+			"error": err.Error(),
+			"query": query,
+		})
+		return entries, *logAction.Error
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		entry, err := scanDownloadQueueEntry(rows)
+		if err != nil {
+			logAction.SetError("Failed to read Download Queue entry", err.Error(), map[string]any{"error": err.Error()})
+			return entries, *logAction.Error
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, Err
+}
+
+func (s *SQliteDB) DeleteDownloadQueueEntries(ctx context.Context, filter models.DBDownloadQueueFilter) (deleted int64, Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Deleting Download Queue Entries from Database", logging.LevelDebug)
+	defer logAction.Complete()
+
+	Err = logging.LogErrorInfo{}
+	if s == nil || s.conn == nil {
+		logAction.SetError("Database connection is nil", "", map[string]any{})
+		return 0, *logAction.Error
+	}
+
+	whereSQL, args := buildDownloadQueueWhere(filter)
+	if whereSQL == "" {
+		logAction.SetError("Refusing to delete Download Queue entries without a filter", "", map[string]any{})
+		return 0, *logAction.Error
+	}
+
+	res, err := s.conn.ExecContext(ctx, "DELETE FROM DownloadQueue"+whereSQL, args...)
+	if err != nil {
+		logAction.SetError("DB: DELETE DownloadQueue failed", err.Error(), map[string]any{"error": err.Error()})
+		return 0, *logAction.Error
+	}
+
+	deleted, _ = res.RowsAffected()
+	logAction.AppendResult("deleted", deleted)
+	return deleted, Err
+}
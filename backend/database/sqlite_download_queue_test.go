@@ -0,0 +1,77 @@
+package database
+
+import (
+	"aura/models"
+	"testing"
+	"time"
+)
+
+func TestSQliteDownloadQueueStates(t *testing.T) {
+	ctx, s := newTestSQliteDB(t)
+	if Err := s.CreateDownloadQueueTable(ctx); Err.Message != "" {
+		t.Fatalf("CreateDownloadQueueTable() failed: %s", Err.Message)
+	}
+
+	item := models.DBSavedItem{MediaItem: models.MediaItem{TMDB_ID: "603", LibraryTitle: "Movies", Title: "The Matrix", Type: "movie"}}
+	id, Err := s.AddDownloadQueueEntry(ctx, item)
+	if Err.Message != "" {
+		t.Fatalf("AddDownloadQueueEntry() failed: %s", Err.Message)
+	}
+
+	entries, Err := s.GetDownloadQueueEntries(ctx, models.DBDownloadQueueFilter{ID: id})
+	if Err.Message != "" || len(entries) != 1 {
+		t.Fatalf("GetDownloadQueueEntries() = %d entries, error = %q", len(entries), Err.Message)
+	}
+	entry := entries[0]
+	if entry.Status != models.DownloadQueueStatusPending || entry.Attempts != 0 || entry.Item.MediaItem.Title != "The Matrix" {
+		t.Fatalf("new entry = %+v, want a pending entry without attempts", entry)
+	}
+
+	// A transient failure puts the entry back to pending with a retry time
+	nextAttempt := time.Now().Add(time.Hour).UTC()
+	entry.Status = models.DownloadQueueStatusPending
+	entry.Attempts = 1
+	entry.Errors = []string{"timeout"}
+	entry.NextAttemptAt = &nextAttempt
+	if Err := s.UpdateDownloadQueueEntry(ctx, entry); Err.Message != "" {
+		t.Fatalf("UpdateDownloadQueueEntry() failed: %s", Err.Message)
+	}
+
+	now := time.Now()
+	due, Err := s.GetDownloadQueueEntries(ctx, models.DBDownloadQueueFilter{Statuses: []string{models.DownloadQueueStatusPending}, DueBy: &now})
+	if Err.Message != "" || len(due) != 0 {
+		t.Errorf("entries due now = %d, error = %q, want none before the retry time", len(due), Err.Message)
+	}
+	later := nextAttempt.Add(time.Minute)
+	due, Err = s.GetDownloadQueueEntries(ctx, models.DBDownloadQueueFilter{Statuses: []string{models.DownloadQueueStatusPending}, DueBy: &later})
+	if Err.Message != "" || len(due) != 1 {
+		t.Fatalf("entries due after the retry time = %d, error = %q, want 1", len(due), Err.Message)
+	}
+	if due[0].Attempts != 1 || len(due[0].Errors) != 1 || due[0].Errors[0] != "timeout" {
+		t.Errorf("retried entry = %+v", due[0])
+	}
+
+	// A finished entry is no longer pending
+	finished := time.Now().UTC()
+	entry.Status = models.DownloadQueueStatusSucceeded
+	entry.Attempts = 2
+	entry.Errors = nil
+	entry.NextAttemptAt = nil
+	entry.FinishedAt = &finished
+	if Err := s.UpdateDownloadQueueEntry(ctx, entry); Err.Message != "" {
+		t.Fatalf("UpdateDownloadQueueEntry() failed: %s", Err.Message)
+	}
+	pending, _ := s.GetDownloadQueueEntries(ctx, models.DBDownloadQueueFilter{Statuses: []string{models.DownloadQueueStatusPending}})
+	if len(pending) != 0 {
+		t.Errorf("pending entries = %d, want 0 after the entry succeeded", len(pending))
+	}
+
+	// Deleting requires a filter
+	if _, Err := s.DeleteDownloadQueueEntries(ctx, models.DBDownloadQueueFilter{}); Err.Message == "" {
+		t.Error("DeleteDownloadQueueEntries() without a filter returned no error")
+	}
+	deleted, Err := s.DeleteDownloadQueueEntries(ctx, models.DBDownloadQueueFilter{Statuses: []string{models.DownloadQueueStatusSucceeded}})
+	if Err.Message != "" || deleted != 1 {
+		t.Errorf("DeleteDownloadQueueEntries() deleted = %d, error = %q, want 1", deleted, Err.Message)
+	}
+}
@@ -329,7 +329,7 @@ func buildSavedItemsWhere(filter models.DBFilter) (whereSQL string, args []any)
 		add("mi.year = ?", filter.ItemYear)
 	}
 	if strings.TrimSpace(filter.ItemTitle) != "" {
-		add("mi.title LIKE ?", "%"+strings.TrimSpace(filter.ItemTitle)+"%")
+		add("LOWER(mi.title) LIKE LOWER(?)", "%"+strings.TrimSpace(filter.ItemTitle)+"%")
 	}
 	if len(filter.LibraryTitles) > 0 {
 		// Preserve exact library title values (including intentional leading spaces).
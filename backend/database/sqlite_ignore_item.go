@@ -57,6 +57,51 @@ func (s *SQliteDB) GetTempIgnoredItems(ctx context.Context) (items []models.Medi
 	return items, Err
 }
 
+func (s *SQliteDB) GetAllIgnoredItems(ctx context.Context) (items []models.DBIgnoredItem, Err logging.LogErrorInfo) {
+	Err = logging.LogErrorInfo{}
+	items = []models.DBIgnoredItem{}
+	if s == nil || s.conn == nil {
+		return items, logging.LogErrorInfo{Message: "Database connection is nil"}
+	}
+
+	rows, err := s.conn.QueryContext(ctx, `
+        SELECT tmdb_id, library_title, mode, current_sets
+        FROM IgnoredItems
+        ORDER BY library_title, tmdb_id;
+    `)
+	if err != nil {
+		return items, logging.LogErrorInfo{
+			Message: "Failed to get ignored items",
+			Detail:  map[string]any{"error": err.Error()},
+		}
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item models.DBIgnoredItem
+		var currentSets string
+		if err := rows.Scan(&item.TMDB_ID, &item.LibraryTitle, &item.Mode, &currentSets); err != nil {
+			return items, logging.LogErrorInfo{
+				Message: "Failed to scan ignored item",
+				Detail:  map[string]any{"error": err.Error()},
+			}
+		}
+		item.CurrentSets = []string{}
+		if currentSets != "" {
+			item.CurrentSets = strings.Split(currentSets, ",")
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return items, logging.LogErrorInfo{
+			Message: "Failed to read ignored items",
+			Detail:  map[string]any{"error": err.Error()},
+		}
+	}
+
+	return items, Err
+}
+
 func (s *SQliteDB) IgnoreMediaItem(ctx context.Context, tmdbID, libraryTitle, mode, currentSets string) (Err logging.LogErrorInfo) {
 	Err = logging.LogErrorInfo{}
 
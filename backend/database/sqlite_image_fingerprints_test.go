@@ -0,0 +1,112 @@
+package database
+
+import (
+	"aura/models"
+	"context"
+	"testing"
+)
+
+// newTestFingerprintsDB opens an empty SQLite database with the ImageFingerprints table
+func newTestFingerprintsDB(t *testing.T) (context.Context, *SQliteDB) {
+	t.Helper()
+
+	ctx, s := newTestSQliteDB(t)
+	if Err := s.CreateImageFingerprintsTable(ctx); Err.Message != "" {
+		t.Fatalf("failed to create ImageFingerprints table: %s", Err.Message)
+	}
+	return ctx, s
+}
+
+func TestSQliteImageFingerprintsAreKeyedByServer(t *testing.T) {
+	ctx, s := newTestFingerprintsDB(t)
+
+	for _, fingerprint := range []models.DBImageFingerprint{
+		{Server: "", TMDB_ID: "603", LibraryTitle: "Movies", ImageKey: "poster", ImageRatingKey: "100", ImageID: "a", PerceptualHash: "00000000000000ff"},
+		{Server: "Jellyfin", TMDB_ID: "603", LibraryTitle: "Movies", ImageKey: "poster", ImageRatingKey: "abc", ImageID: "a", PerceptualHash: "ff00000000000000"},
+	} {
+		if Err := s.UpsertImageFingerprint(ctx, fingerprint); Err.Message != "" {
+			t.Fatalf("UpsertImageFingerprint() failed: %s", Err.Message)
+		}
+	}
+
+	primary, found, Err := s.GetImageFingerprint(ctx, "", "603", "Movies", "poster")
+	if Err.Message != "" || !found {
+		t.Fatalf("GetImageFingerprint() primary found = %v, error = %q", found, Err.Message)
+	}
+	if primary.ImageRatingKey != "100" || primary.PerceptualHash != "00000000000000ff" {
+		t.Errorf("primary fingerprint = %+v", primary)
+	}
+
+	jellyfin, found, Err := s.GetImageFingerprint(ctx, "Jellyfin", "603", "Movies", "poster")
+	if Err.Message != "" || !found {
+		t.Fatalf("GetImageFingerprint() Jellyfin found = %v, error = %q", found, Err.Message)
+	}
+	if jellyfin.Server != "Jellyfin" || jellyfin.ImageRatingKey != "abc" {
+		t.Errorf("Jellyfin fingerprint = %+v", jellyfin)
+	}
+
+	// Deleting the fingerprint of one server keeps the other
+	if Err := s.DeleteImageFingerprint(ctx, "", "603", "Movies", "poster"); Err.Message != "" {
+		t.Fatalf("DeleteImageFingerprint() failed: %s", Err.Message)
+	}
+	if _, found, _ := s.GetImageFingerprint(ctx, "", "603", "Movies", "poster"); found {
+		t.Error("primary fingerprint still found after delete")
+	}
+	if _, found, _ := s.GetImageFingerprint(ctx, "Jellyfin", "603", "Movies", "poster"); !found {
+		t.Error("Jellyfin fingerprint removed by the delete of the primary fingerprint")
+	}
+}
+
+func TestSQliteUpsertImageFingerprintReplacesTheFingerprint(t *testing.T) {
+	ctx, s := newTestFingerprintsDB(t)
+
+	fingerprint := models.DBImageFingerprint{TMDB_ID: "1399", LibraryTitle: "Shows", ImageKey: "titlecard:S01E01", ImageRatingKey: "10", ImageID: "a", PerceptualHash: "0000000000000001"}
+	if Err := s.UpsertImageFingerprint(ctx, fingerprint); Err.Message != "" {
+		t.Fatalf("UpsertImageFingerprint() failed: %s", Err.Message)
+	}
+	fingerprint.ImageID = "b"
+	fingerprint.PerceptualHash = "0000000000000002"
+	if Err := s.UpsertImageFingerprint(ctx, fingerprint); Err.Message != "" {
+		t.Fatalf("UpsertImageFingerprint() failed: %s", Err.Message)
+	}
+
+	got, found, Err := s.GetImageFingerprint(ctx, "", "1399", "Shows", "titlecard:S01E01")
+	if Err.Message != "" || !found {
+		t.Fatalf("GetImageFingerprint() found = %v, error = %q", found, Err.Message)
+	}
+	if got.ImageID != "b" || got.PerceptualHash != "0000000000000002" {
+		t.Errorf("fingerprint = %+v, want the second upsert", got)
+	}
+}
+
+func TestSQliteRecreateImageFingerprintsTable(t *testing.T) {
+	ctx, s := newTestFingerprintsDB(t)
+
+	// A table from before the server column
+	if _, err := s.conn.ExecContext(ctx, `DROP TABLE ImageFingerprints;
+CREATE TABLE ImageFingerprints (
+	tmdb_id TEXT NOT NULL,
+	library_title TEXT NOT NULL,
+	image_key TEXT NOT NULL,
+	image_rating_key TEXT NOT NULL,
+	image_id TEXT NOT NULL,
+	content_hash TEXT NOT NULL,
+	applied_at DATETIME NOT NULL,
+	PRIMARY KEY (tmdb_id, library_title, image_key)
+);
+INSERT INTO ImageFingerprints VALUES ('603', 'Movies', 'poster', '100', 'a', 'sha', CURRENT_TIMESTAMP);`); err != nil {
+		t.Fatalf("failed to create old table: %v", err)
+	}
+
+	if Err := s.RecreateImageFingerprintsTable(ctx); Err.Message != "" {
+		t.Fatalf("RecreateImageFingerprintsTable() failed: %s", Err.Message)
+	}
+
+	if _, found, Err := s.GetImageFingerprint(ctx, "", "603", "Movies", "poster"); Err.Message != "" || found {
+		t.Errorf("GetImageFingerprint() found = %v, error = %q, want the old fingerprint dropped", found, Err.Message)
+	}
+	fingerprint := models.DBImageFingerprint{Server: "Jellyfin", TMDB_ID: "603", LibraryTitle: "Movies", ImageKey: "poster", ImageRatingKey: "abc", ImageID: "a", PerceptualHash: "00000000000000ff"}
+	if Err := s.UpsertImageFingerprint(ctx, fingerprint); Err.Message != "" {
+		t.Errorf("UpsertImageFingerprint() after recreate failed: %s", Err.Message)
+	}
+}
@@ -0,0 +1,118 @@
+package database
+
+import (
+	"aura/logging"
+	"aura/models"
+	"context"
+	"fmt"
+	"time"
+)
+
+func (s *SQliteDB) AddImageHistoryEntry(ctx context.Context, entry models.DBImageHistoryEntry) (id int64, Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Adding Image History Entry to Database", logging.LevelTrace)
+	defer logAction.Complete()
+
+	Err = logging.LogErrorInfo{}
+	if s == nil || s.conn == nil {
+		logAction.SetError("Database connection is nil", "", map[string]any{})
+		return 0, *logAction.Error
+	}
+
+	res, err := s.conn.ExecContext(ctx, `
+INSERT INTO ImageHistory (tmdb_id, library_title, server, title, rating_key, image_rating_key, image_type, season_number, episode_number, file_name, replaced_by, created_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);`,
+		entry.TMDB_ID,
+		entry.LibraryTitle,
+		entry.Server,
+		entry.Title,
+		entry.RatingKey,
+		entry.ImageRatingKey,
+		entry.ImageType,
+		nullableInt(entry.SeasonNumber),
+		nullableInt(entry.EpisodeNumber),
+		entry.FileName,
+		entry.ReplacedBy,
+		time.Now().UTC(),
+	)
+	if err != nil {
+		logAction.SetError("DB: INSERT ImageHistory failed", err.Error(), map[string]any{"error": err.Error()})
+		return 0, *logAction.Error
+	}
+
+	id, err = res.LastInsertId()
+	if err != nil {
+		logAction.SetError("DB: lookup ImageHistory.id failed", err.Error(), map[string]any{"error": err.Error()})
+		return 0, *logAction.Error
+	}
+
+	logAction.AppendResult("id", id)
+	return id, Err
+}
+
+func (s *SQliteDB) GetImageHistory(ctx context.Context, filter models.DBImageHistoryFilter) (entries []models.DBImageHistoryEntry, Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Getting Image History from Database", logging.LevelTrace)
+	defer logAction.Complete()
+
+	Err = logging.LogErrorInfo{}
+	entries = []models.DBImageHistoryEntry{}
+	if s == nil || s.conn == nil {
+		logAction.SetError("Database connection is nil", "", map[string]any{})
+		return entries, *logAction.Error
+	}
+
+	whereSQL, args := buildImageHistoryWhere(filter)
+	query := fmt.Sprintf("SELECT %s FROM ImageHistory%s ORDER BY id DESC", imageHistoryColumns, whereSQL)
+	if filter.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, filter.Limit)
+	}
+
+	rows, err := s.conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		logAction.SetError("DB: SELECT ImageHistory failed", err.Error(), map[string]any{
+			"error": err.Error(),
+			"query": query,
+		})
+		return entries, *logAction.Error
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		entry, err := scanImageHistoryEntry(rows)
+		if err != nil {
+			logAction.SetError("Failed to read Image History entry", err.Error(), map[string]any{"error": err.Error()})
+			return entries, *logAction.Error
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, Err
+}
+
+func (s *SQliteDB) DeleteImageHistoryEntries(ctx context.Context, ids []int64) (deleted int64, Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Deleting Image History Entries from Database", logging.LevelTrace)
+	defer logAction.Complete()
+
+	Err = logging.LogErrorInfo{}
+	if s == nil || s.conn == nil {
+		logAction.SetError("Database connection is nil", "", map[string]any{})
+		return 0, *logAction.Error
+	}
+	if len(ids) == 0 {
+		return 0, Err
+	}
+
+	args := make([]any, 0, len(ids))
+	for _, id := range ids {
+		args = append(args, id)
+	}
+	res, err := s.conn.ExecContext(ctx, "DELETE FROM ImageHistory WHERE id IN ("+placeholders(len(ids))+")", args...)
+	if err != nil {
+		logAction.SetError("DB: DELETE ImageHistory failed", err.Error(), map[string]any{"error": err.Error()})
+		return 0, *logAction.Error
+	}
+
+	deleted, _ = res.RowsAffected()
+	logAction.AppendResult("deleted", deleted)
+	return deleted, Err
+}
@@ -0,0 +1,167 @@
+package database
+
+import (
+	"aura/logging"
+	"aura/models"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+func (s *SQliteDB) AddPendingApproval(ctx context.Context, approval models.DBPendingApproval) (id int64, Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Adding Pending Approval to Database", logging.LevelDebug)
+	defer logAction.Complete()
+
+	Err = logging.LogErrorInfo{}
+	if s == nil || s.conn == nil {
+		logAction.SetError("Database connection is nil", "", map[string]any{})
+		return 0, *logAction.Error
+	}
+
+	itemJSON, err := json.Marshal(approval.Item)
+	if err != nil {
+		logAction.SetError("Failed to encode Pending Approval", "Ensure that the Save Item can be converted to JSON", map[string]any{
+			"error": err.Error(),
+		})
+		return 0, *logAction.Error
+	}
+
+	res, err := s.conn.ExecContext(ctx, `
+INSERT INTO PendingApprovals (kind, status, tmdb_id, library_title, server, title, set_id, item, reason, created_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?);`,
+		approval.Kind,
+		models.PendingApprovalStatusPending,
+		approval.Item.MediaItem.TMDB_ID,
+		approval.Item.MediaItem.LibraryTitle,
+		approval.Item.MediaItem.Server,
+		approval.Item.MediaItem.Title,
+		pendingApprovalSetID(approval),
+		string(itemJSON),
+		approval.Reason,
+		time.Now().UTC(),
+	)
+	if err != nil {
+		logAction.SetError("DB: INSERT PendingApprovals failed", err.Error(), map[string]any{"error": err.Error()})
+		return 0, *logAction.Error
+	}
+
+	id, err = res.LastInsertId()
+	if err != nil {
+		logAction.SetError("DB: lookup PendingApprovals.id failed", err.Error(), map[string]any{"error": err.Error()})
+		return 0, *logAction.Error
+	}
+
+	logAction.AppendResult("id", id)
+	return id, Err
+}
+
+func (s *SQliteDB) UpdatePendingApprovalItem(ctx context.Context, id int64, item models.DBSavedItem, reason string) (Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf("Updating Item of Pending Approval %d in Database", id), logging.LevelTrace)
+	defer logAction.Complete()
+
+	Err = logging.LogErrorInfo{}
+	if s == nil || s.conn == nil {
+		logAction.SetError("Database connection is nil", "", map[string]any{})
+		return *logAction.Error
+	}
+
+	itemJSON, err := json.Marshal(item)
+	if err != nil {
+		logAction.SetError("Failed to encode Pending Approval", "Ensure that the Save Item can be converted to JSON", map[string]any{
+			"error": err.Error(),
+		})
+		return *logAction.Error
+	}
+
+	_, err = s.conn.ExecContext(ctx, `
+UPDATE PendingApprovals
+SET item = ?, reason = ?
+WHERE id = ? AND status = ?;`,
+		string(itemJSON),
+		reason,
+		id,
+		models.PendingApprovalStatusPending,
+	)
+	if err != nil {
+		logAction.SetError("DB: UPDATE PendingApprovals failed", err.Error(), map[string]any{
+			"error": err.Error(),
+			"id":    id,
+		})
+		return *logAction.Error
+	}
+
+	return Err
+}
+
+func (s *SQliteDB) UpdatePendingApprovalStatus(ctx context.Context, id int64, status string) (Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf("Updating Pending Approval %d in Database", id), logging.LevelTrace)
+	defer logAction.Complete()
+
+	Err = logging.LogErrorInfo{}
+	if s == nil || s.conn == nil {
+		logAction.SetError("Database connection is nil", "", map[string]any{})
+		return *logAction.Error
+	}
+
+	_, err := s.conn.ExecContext(ctx, `
+UPDATE PendingApprovals
+SET status = ?, decided_at = ?
+WHERE id = ?;`,
+		status,
+		time.Now().UTC(),
+		id,
+	)
+	if err != nil {
+		logAction.SetError("DB: UPDATE PendingApprovals failed", err.Error(), map[string]any{
+			"error": err.Error(),
+			"id":    id,
+		})
+		return *logAction.Error
+	}
+
+	return Err
+}
+
+func (s *SQliteDB) GetPendingApprovals(ctx context.Context, filter models.DBPendingApprovalFilter) (approvals []models.DBPendingApproval, Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Getting Pending Approvals from Database", logging.LevelTrace)
+	defer logAction.Complete()
+
+	Err = logging.LogErrorInfo{}
+	approvals = []models.DBPendingApproval{}
+	if s == nil || s.conn == nil {
+		logAction.SetError("Database connection is nil", "", map[string]any{})
+		return approvals, *logAction.Error
+	}
+
+	whereSQL, args := buildPendingApprovalsWhere(filter)
+	query := fmt.Sprintf("SELECT %s FROM PendingApprovals%s ORDER BY id", pendingApprovalColumns, whereSQL)
+	if filter.NewestFirst {
+		query += " DESC"
+	}
+	if filter.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, filter.Limit)
+	}
+
+	rows, err := s.conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		logAction.SetError("DB: SELECT PendingApprovals failed", err.Error(), map[string]any{
+			"error": err.Error(),
+			"query": query,
+		})
+		return approvals, *logAction.Error
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		approval, err := scanPendingApproval(rows)
+		if err != nil {
+			logAction.SetError("Failed to read Pending Approval", err.Error(), map[string]any{"error": err.Error()})
+			return approvals, *logAction.Error
+		}
+		approvals = append(approvals, approval)
+	}
+
+	return approvals, Err
+}
@@ -0,0 +1,28 @@
+package database
+
+import (
+	"aura/logging"
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+)
+
+// newTestSQliteDB opens an empty SQLite database in a temporary folder
+func newTestSQliteDB(t *testing.T) (context.Context, *SQliteDB) {
+	t.Helper()
+
+	conn, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "aura.db"))
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return newTestContext(), &SQliteDB{conn: conn}
+}
+
+// newTestContext returns a context with a logging action for the database functions
+func newTestContext() context.Context {
+	ctx, ld := logging.CreateLoggingContext(context.Background(), "test")
+	return logging.WithCurrentAction(ctx, ld.AddAction("test", logging.LevelTrace))
+}
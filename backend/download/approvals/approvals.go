@@ -0,0 +1,244 @@
+package approvals
+
+import (
+	"aura/database"
+	downloadqueue "aura/download/queue"
+	"aura/logging"
+	"aura/models"
+	"aura/utils"
+	"context"
+	"fmt"
+	"time"
+)
+
+// Stage adds a change that has to be approved before it is applied.
+// A change for the same item and set that is still waiting for approval is not staged again,
+// the images of an image update are added to the pending one instead.
+// Images that were rejected before (same image ID and modified date) are not staged again.
+// When every image was rejected before, nothing is staged and the returned id is 0.
+func Stage(ctx context.Context, kind string, item models.DBSavedItem, reason string) (id int64, Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx,
+		fmt.Sprintf("Stage Approval for %s", utils.MediaItemInfo(item.MediaItem)),
+		logging.LevelDebug)
+	defer logAction.Complete()
+
+	approval := models.DBPendingApproval{Kind: kind, Item: item, Reason: reason}
+
+	setID := ""
+	if len(item.PosterSets) > 0 {
+		setID = item.PosterSets[0].ID
+	}
+	existing, Err := database.GetPendingApprovals(ctx, models.DBPendingApprovalFilter{
+		Kinds:        []string{kind},
+		Statuses:     []string{models.PendingApprovalStatusPending},
+		TMDB_ID:      item.MediaItem.TMDB_ID,
+		LibraryTitle: item.MediaItem.LibraryTitle,
+		SetID:        setID,
+	})
+	if Err.Message != "" {
+		return 0, Err
+	}
+
+	if kind == models.PendingApprovalKindImageUpdate && len(item.PosterSets) > 0 {
+		images, Err := withoutRejectedImages(ctx, item.MediaItem, setID, item.PosterSets[0].Images)
+		if Err.Message != "" {
+			return 0, Err
+		}
+		if len(images) == 0 {
+			logAction.AppendResult("skipped", "All images were rejected before")
+			return 0, Err
+		}
+		item.PosterSets[0].Images = images
+		approval.Item = item
+	}
+
+	if len(existing) > 0 {
+		logAction.AppendResult("already_pending", existing[0].ID)
+		if kind == models.PendingApprovalKindImageUpdate {
+			merged := existing[0].Item
+			merged.MediaItem = item.MediaItem
+			if len(merged.PosterSets) > 0 && len(item.PosterSets) > 0 {
+				merged.PosterSets[0].Images = mergeImages(merged.PosterSets[0].Images, item.PosterSets[0].Images)
+			}
+			Err = database.UpdatePendingApprovalItem(ctx, existing[0].ID, merged, reason)
+		}
+		return existing[0].ID, Err
+	}
+
+	id, Err = database.AddPendingApproval(ctx, approval)
+	if Err.Message != "" {
+		return 0, Err
+	}
+
+	logAction.AppendResult("id", id)
+	return id, Err
+}
+
+// Approve applies a pending approval.
+// A new set is added to the Download Queue, the images of an image update are applied to the media server right away.
+func Approve(ctx context.Context, id int64) (approval models.DBPendingApproval, Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf("Approve Pending Approval %d", id), logging.LevelInfo)
+	defer logAction.Complete()
+
+	approval, Err = getPending(ctx, id)
+	if Err.Message != "" {
+		return approval, Err
+	}
+
+	switch approval.Kind {
+	case models.PendingApprovalKindImageUpdate:
+		Err = applyImageUpdate(ctx, approval)
+	default:
+		Err = downloadqueue.AddToQueue(ctx, approval.Item)
+	}
+	if Err.Message != "" {
+		return approval, Err
+	}
+
+	Err = database.UpdatePendingApprovalStatus(ctx, id, models.PendingApprovalStatusApproved)
+	if Err.Message != "" {
+		return approval, Err
+	}
+
+	approval.Status = models.PendingApprovalStatusApproved
+	logAction.AppendResult("status", approval.Status)
+	return approval, Err
+}
+
+// Reject marks a pending approval as rejected, its change is not suggested again.
+// The saved set is left as it is. The rejected approval keeps its images, so Stage can skip them
+// until MediUX has a newer version of the image.
+func Reject(ctx context.Context, id int64) (approval models.DBPendingApproval, Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf("Reject Pending Approval %d", id), logging.LevelInfo)
+	defer logAction.Complete()
+
+	approval, Err = getPending(ctx, id)
+	if Err.Message != "" {
+		return approval, Err
+	}
+
+	Err = database.UpdatePendingApprovalStatus(ctx, id, models.PendingApprovalStatusRejected)
+	if Err.Message != "" {
+		return approval, Err
+	}
+
+	approval.Status = models.PendingApprovalStatusRejected
+	logAction.AppendResult("status", approval.Status)
+	return approval, Err
+}
+
+// ApproveAll approves every pending approval, oldest first.
+// Approvals that fail stay pending and are counted in failed.
+func ApproveAll(ctx context.Context) (approved, failed int, Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Approve All Pending Approvals", logging.LevelInfo)
+	defer logAction.Complete()
+
+	pending, Err := database.GetPendingApprovals(ctx, models.DBPendingApprovalFilter{
+		Statuses: []string{models.PendingApprovalStatusPending},
+	})
+	if Err.Message != "" {
+		return 0, 0, Err
+	}
+
+	for _, approval := range pending {
+		_, approveErr := Approve(ctx, approval.ID)
+		if approveErr.Message != "" {
+			logAction.AppendWarning(fmt.Sprintf("approval_%d", approval.ID), approveErr.Message)
+			failed++
+			continue
+		}
+		approved++
+	}
+
+	logAction.AppendResult("approved", approved)
+	logAction.AppendResult("failed", failed)
+	return approved, failed, Err
+}
+
+// withoutRejectedImages removes the images that were part of a rejected image update of the same item and set.
+// An image is only skipped while its modified date is the same, a newer version of it is staged again.
+func withoutRejectedImages(ctx context.Context, mediaItem models.MediaItem, setID string, images []models.ImageFile) (remaining []models.ImageFile, Err logging.LogErrorInfo) {
+	rejected, Err := database.GetPendingApprovals(ctx, models.DBPendingApprovalFilter{
+		Kinds:        []string{models.PendingApprovalKindImageUpdate},
+		Statuses:     []string{models.PendingApprovalStatusRejected},
+		TMDB_ID:      mediaItem.TMDB_ID,
+		LibraryTitle: mediaItem.LibraryTitle,
+		SetID:        setID,
+	})
+	if Err.Message != "" {
+		return images, Err
+	}
+
+	rejectedVersions := map[string]bool{}
+	for _, approval := range rejected {
+		for _, set := range approval.Item.PosterSets {
+			for _, image := range set.Images {
+				rejectedVersions[imageVersion(image)] = true
+			}
+		}
+	}
+
+	remaining = []models.ImageFile{}
+	for _, image := range images {
+		if !rejectedVersions[imageVersion(image)] {
+			remaining = append(remaining, image)
+		}
+	}
+	return remaining, Err
+}
+
+func imageVersion(image models.ImageFile) string {
+	return image.ID + "|" + image.Modified.UTC().Format(time.RFC3339)
+}
+
+// getPending returns an approval that is still waiting for a decision
+func getPending(ctx context.Context, id int64) (approval models.DBPendingApproval, Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf("Get Pending Approval %d", id), logging.LevelTrace)
+	defer logAction.Complete()
+
+	found, Err := database.GetPendingApprovals(ctx, models.DBPendingApprovalFilter{ID: id})
+	if Err.Message != "" {
+		return approval, Err
+	}
+	if len(found) == 0 {
+		logAction.SetError("Pending Approval not found", "Ensure that the ID belongs to an entry in the approval list", map[string]any{
+			"id": id,
+		})
+		return approval, *logAction.Error
+	}
+
+	approval = found[0]
+	if approval.Status != models.PendingApprovalStatusPending {
+		logAction.SetError("Only pending approvals can be approved or rejected", fmt.Sprintf("The approval is already '%s'", approval.Status), map[string]any{
+			"id":     id,
+			"status": approval.Status,
+		})
+		return approval, *logAction.Error
+	}
+
+	return approval, Err
+}
+
+// List returns the approvals (newest first) with the given statuses and kinds, including the current and new version of their images.
+// All statuses and kinds are returned when they are empty.
+func List(ctx context.Context, statuses, kinds []string, limit int) (list []models.DBPendingApproval, Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "List Pending Approvals", logging.LevelInfo)
+	defer logAction.Complete()
+
+	list, Err = database.GetPendingApprovals(ctx, models.DBPendingApprovalFilter{
+		Statuses:    statuses,
+		Kinds:       kinds,
+		NewestFirst: true,
+		Limit:       limit,
+	})
+	if Err.Message != "" {
+		return list, Err
+	}
+
+	for i := range list {
+		list[i].Images = previewImages(ctx, list[i])
+	}
+
+	logAction.AppendResult("approvals", len(list))
+	return list, Err
+}
@@ -0,0 +1,151 @@
+package approvals
+
+import (
+	"aura/database"
+	"aura/logging"
+	"aura/mediaserver"
+	"aura/models"
+	"aura/utils"
+	"context"
+	"fmt"
+	"time"
+)
+
+// applyImageUpdate applies the images of an image update to the media item and its copies on the other media servers.
+// The applied images are stored with the saved set, so AutoDownload does not suggest them again.
+func applyImageUpdate(ctx context.Context, approval models.DBPendingApproval) (Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx,
+		fmt.Sprintf("Applying Image Update for %s", utils.MediaItemInfo(approval.Item.MediaItem)),
+		logging.LevelInfo)
+	defer logAction.Complete()
+
+	mediaItem := approval.Item.MediaItem
+	images := approvalImages(approval)
+	if len(images) == 0 {
+		logAction.SetError("No images to apply", "The approval does not contain any images of the selected types", map[string]any{
+			"id": approval.ID,
+		})
+		return *logAction.Error
+	}
+
+	serverCopies := mediaserver.GetMediaItemCopiesOnOtherServers(ctx, mediaItem)
+
+	applied := []models.ImageFile{}
+	for _, image := range images {
+		imageName := utils.GetFileDownloadName(mediaItem.Title, image)
+		applyErr := mediaserver.DownloadApplyImageToMediaItem(ctx, &mediaItem, image)
+		if applyErr.Message != "" {
+			logAction.AppendWarning(imageName, applyErr.Message)
+			continue
+		}
+		applied = append(applied, image)
+
+		if len(serverCopies) > 0 {
+			_, copiesErr := mediaserver.DownloadApplyImageToServerCopies(ctx, serverCopies, image)
+			if copiesErr.Message != "" {
+				logAction.AppendWarning(imageName+"_server_copies", copiesErr.Message)
+			}
+		}
+	}
+	logAction.AppendResult("applied", len(applied))
+
+	if len(applied) == 0 {
+		logAction.SetError("Failed to apply any image", "Check the warnings for the error of each image", map[string]any{
+			"id":     approval.ID,
+			"images": len(images),
+		})
+		return *logAction.Error
+	}
+
+	return saveImageUpdate(ctx, approval, applied)
+}
+
+// saveImageUpdate stores the images with the saved set of the approval, together with the latest Media Item details
+func saveImageUpdate(ctx context.Context, approval models.DBPendingApproval, images []models.ImageFile) (Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx,
+		fmt.Sprintf("Saving Image Update for %s", utils.MediaItemInfo(approval.Item.MediaItem)),
+		logging.LevelDebug)
+	defer logAction.Complete()
+
+	if len(approval.Item.PosterSets) == 0 {
+		logAction.SetError("Approval has no set", "The approval does not contain the set the images belong to", map[string]any{
+			"id": approval.ID,
+		})
+		return *logAction.Error
+	}
+	setID := approval.Item.PosterSets[0].ID
+
+	out, Err := database.GetAllSavedSets(ctx, models.DBFilter{
+		ItemTMDB_ID:      approval.Item.MediaItem.TMDB_ID,
+		ItemLibraryTitle: approval.Item.MediaItem.LibraryTitle,
+	})
+	if Err.Message != "" {
+		return Err
+	}
+	if len(out.Items) == 0 {
+		logAction.SetError("Saved item not found", "The item was removed from the saved sets after the change was staged", map[string]any{
+			"tmdb_id":       approval.Item.MediaItem.TMDB_ID,
+			"library_title": approval.Item.MediaItem.LibraryTitle,
+		})
+		return *logAction.Error
+	}
+
+	dbItem := out.Items[0]
+	found := false
+	for i, dbSet := range dbItem.PosterSets {
+		if dbSet.ID != setID {
+			continue
+		}
+		dbItem.PosterSets[i].Images = mergeImages(dbSet.Images, images)
+		dbItem.PosterSets[i].LastDownloaded = time.Now()
+		found = true
+		break
+	}
+	if !found {
+		logAction.SetError("Saved set not found", "The set was removed from the item after the change was staged", map[string]any{
+			"set_id": setID,
+		})
+		return *logAction.Error
+	}
+
+	dbItem.MediaItem = approval.Item.MediaItem
+	return database.UpsertSavedItem(ctx, dbItem)
+}
+
+// approvalImages returns the images of the approval that belong to the item and match the selected types of the set
+func approvalImages(approval models.DBPendingApproval) []models.ImageFile {
+	if len(approval.Item.PosterSets) == 0 {
+		return []models.ImageFile{}
+	}
+	return approval.Item.PosterSets[0].SelectedImages(approval.Item.MediaItem)
+}
+
+// mergeImages replaces the images that have the same place on the item (type, season and episode) and adds the others
+func mergeImages(current, updates []models.ImageFile) []models.ImageFile {
+	merged := append([]models.ImageFile{}, current...)
+	for _, update := range updates {
+		replaced := false
+		for i, image := range merged {
+			if imagePlace(image) == imagePlace(update) {
+				merged[i] = update
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			merged = append(merged, update)
+		}
+	}
+	return merged
+}
+
+func imagePlace(image models.ImageFile) string {
+	place := image.ItemTMDB_ID + "|" + image.Type
+	if image.SeasonNumber != nil {
+		place += fmt.Sprintf("|S%d", *image.SeasonNumber)
+	}
+	if image.EpisodeNumber != nil {
+		place += fmt.Sprintf("|E%d", *image.EpisodeNumber)
+	}
+	return place
+}
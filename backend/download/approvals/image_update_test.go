@@ -0,0 +1,46 @@
+package approvals
+
+import (
+	"aura/models"
+	"testing"
+)
+
+func TestMergeImages(t *testing.T) {
+	number := func(n int) *int { return &n }
+	current := []models.ImageFile{
+		{ID: "poster-old", Type: "poster", ItemTMDB_ID: "1399"},
+		{ID: "season1-old", Type: "season_poster", ItemTMDB_ID: "1399", SeasonNumber: number(1)},
+		{ID: "titlecard-old", Type: "titlecard", ItemTMDB_ID: "1399", SeasonNumber: number(1), EpisodeNumber: number(1)},
+	}
+	updates := []models.ImageFile{
+		{ID: "season1-new", Type: "season_poster", ItemTMDB_ID: "1399", SeasonNumber: number(1)},
+		{ID: "season2-new", Type: "season_poster", ItemTMDB_ID: "1399", SeasonNumber: number(2)},
+		{ID: "titlecard-new", Type: "titlecard", ItemTMDB_ID: "1399", SeasonNumber: number(1), EpisodeNumber: number(2)},
+	}
+
+	merged := mergeImages(current, updates)
+
+	want := []string{"poster-old", "season1-new", "titlecard-old", "season2-new", "titlecard-new"}
+	if len(merged) != len(want) {
+		t.Fatalf("mergeImages() returned %d images, want %d", len(merged), len(want))
+	}
+	for i, id := range want {
+		if merged[i].ID != id {
+			t.Errorf("merged[%d] = %s, want %s", i, merged[i].ID, id)
+		}
+	}
+	if current[1].ID != "season1-old" {
+		t.Error("mergeImages() changed the current images")
+	}
+}
+
+func TestMergeImagesKeepsImagesOfOtherItems(t *testing.T) {
+	current := []models.ImageFile{{ID: "movie-a", Type: "poster", ItemTMDB_ID: "603"}}
+	updates := []models.ImageFile{{ID: "movie-b", Type: "poster", ItemTMDB_ID: "604"}}
+
+	merged := mergeImages(current, updates)
+
+	if len(merged) != 2 || merged[0].ID != "movie-a" || merged[1].ID != "movie-b" {
+		t.Errorf("mergeImages() = %+v, want the posters of both movies", merged)
+	}
+}
@@ -0,0 +1,48 @@
+package approvals
+
+import (
+	"aura/logging"
+	"aura/mediaserver"
+	"aura/mediux"
+	"aura/models"
+	"context"
+	"net/url"
+)
+
+// previewImages lists the images of an approval with a link to the current image on the media server and the new image on MediUX
+func previewImages(ctx context.Context, approval models.DBPendingApproval) []models.PendingApprovalImage {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Building Image Previews", logging.LevelTrace)
+	defer logAction.Complete()
+
+	previews := []models.PendingApprovalImage{}
+	for _, image := range approvalImages(approval) {
+		afterURL, Err := mediux.ConstructImageUrl(ctx, image.ID, image.Modified.String(), mediux.ImageQualityThumb)
+		if Err.Message != "" {
+			logAction.AppendWarning(image.ID, Err.Message)
+		}
+		previews = append(previews, models.PendingApprovalImage{
+			ImageID:       image.ID,
+			Type:          image.Type,
+			SeasonNumber:  image.SeasonNumber,
+			EpisodeNumber: image.EpisodeNumber,
+			BeforeURL:     beforeImageURL(approval.Item.MediaItem, image),
+			AfterURL:      afterURL,
+		})
+	}
+	return previews
+}
+
+// beforeImageURL links to the image the media server currently has in the place of the image (GET /api/images/media/item).
+// It is empty when the season or episode of the image is not on the media server.
+func beforeImageURL(item models.MediaItem, image models.ImageFile) string {
+	imageRatingKey, imageType := mediaserver.ImageRatingKeyAndType(item, image)
+	if item.RatingKey == "" || imageRatingKey == "" {
+		return ""
+	}
+
+	query := url.Values{}
+	query.Set("rating_key", item.RatingKey)
+	query.Set("image_rating_key", imageRatingKey)
+	query.Set("image_type", imageType)
+	return "/api/images/media/item?" + query.Encode()
+}
@@ -3,6 +3,8 @@ package autodownload
 import (
 	"aura/cache"
 	"aura/database"
+	"aura/download/approvals"
+	"aura/events"
 	"aura/logging"
 	"aura/mediaserver"
 	"aura/models"
@@ -10,7 +12,9 @@ import (
 	"context"
 	"fmt"
 	"runtime/debug"
+	"slices"
 	"sort"
+	"strings"
 	"time"
 )
 
@@ -19,14 +23,27 @@ type AutoDownloadResult struct {
 	Sets           []AutoDownloadSetResult `json:"sets"`
 	OverallResult  string                  `json:"overall_result"`
 	OverallMessage string                  `json:"overall_message"`
+	DryRun         bool                    `json:"dry_run"` // Nothing was downloaded, Sets only contain the plan
 }
 
 type AutoDownloadSetResult struct {
-	ID          string `json:"id"`
-	Title       string `json:"title"`
-	UserCreated string `json:"user_created"`
-	Result      string `json:"result"`
-	Reason      string `json:"reason"`
+	ID          string                  `json:"id"`
+	Title       string                  `json:"title"`
+	UserCreated string                  `json:"user_created"`
+	Result      string                  `json:"result"`
+	Reason      string                  `json:"reason"`
+	Images      []AutoDownloadImagePlan `json:"images,omitempty"` // Images that need to be redownloaded
+}
+
+// AutoDownloadImagePlan is an image that needs to be redownloaded and the reason why
+type AutoDownloadImagePlan struct {
+	Image         string `json:"image"`
+	ImageID       string `json:"image_id"`
+	Type          string `json:"type"`
+	SeasonNumber  *int   `json:"season_number,omitempty"`
+	EpisodeNumber *int   `json:"episode_number,omitempty"`
+	ReasonTitle   string `json:"reason_title"`
+	Reason        string `json:"reason"`
 }
 
 type ImageFileWithReason struct {
@@ -35,7 +52,9 @@ type ImageFileWithReason struct {
 	Reason      string
 }
 
-func CheckAllItems(ctx context.Context) (Err logging.LogErrorInfo) {
+// CheckAllItems checks every saved item for updated images.
+// With dryRun set, the images that would be downloaded are only logged.
+func CheckAllItems(ctx context.Context, dryRun bool) (Err logging.LogErrorInfo) {
 	ctx, getAllItemAction := logging.AddSubActionToContext(ctx, " Getting all saved sets for AutoDownload Check", logging.LevelInfo)
 	out, Err := database.GetAllSavedSets(ctx, models.DBFilter{ItemsPerPage: -1})
 	if Err.Message != "" {
@@ -46,40 +65,53 @@ func CheckAllItems(ctx context.Context) (Err logging.LogErrorInfo) {
 
 	mediaserver.GetAllLibrarySectionsAndItems(ctx, true)
 
-	errorCount := 0
-	warningCount := 0
-	successCount := 0
-	skippedCount := 0
+	// Progress counters, streamed to /api/events after every item
+	progress := events.JobProgress{Job: "AutoDownload", Total: len(out.Items)}
+	events.Publish(events.TypeJobStarted, progress)
+
 	for _, item := range out.Items {
 		itemCtx, ld := logging.CreateLoggingContext(context.Background(), "AutoDownload - Check For Updates")
 		itemAction := ld.AddAction(fmt.Sprintf("Checking Item %s", utils.MediaItemInfo(item.MediaItem)), logging.LevelInfo)
 		itemCtx = logging.WithCurrentAction(itemCtx, itemAction)
-		result := CheckItem(itemCtx, item)
+		result := CheckItem(itemCtx, item, dryRun)
 		switch result.OverallResult {
 		case "error":
-			errorCount++
+			progress.ErrorCount++
 		case "warning":
-			warningCount++
+			progress.WarningCount++
 		case "success":
-			successCount++
+			progress.SuccessCount++
 		case "skipped":
-			skippedCount++
+			progress.SkippedCount++
 		}
 		itemAction.AppendResult("outcomes", result)
 		ld.Log()
+
+		progress.Processed++
+		progress.Item = result.Item
+		progress.Result = result.OverallResult
+		events.Publish(events.TypeJobProgress, progress)
 	}
 
-	logging.LOGGER.Info().Timestamp().Int("error_count", errorCount).
-		Int("warning_count", warningCount).
-		Int("success_count", successCount).
-		Int("skipped_count", skippedCount).
+	progress.Item = ""
+	progress.Result = ""
+	events.Publish(events.TypeJobFinished, progress)
+
+	logging.LOGGER.Info().Timestamp().Int("error_count", progress.ErrorCount).
+		Int("warning_count", progress.WarningCount).
+		Int("success_count", progress.SuccessCount).
+		Int("skipped_count", progress.SkippedCount).
+		Bool("dry_run", dryRun).
 		Msg("Completed AutoDownload Check for all items")
 	return logging.LogErrorInfo{}
 }
 
-func CheckItem(ctx context.Context, dbItem models.DBSavedItem) (result AutoDownloadResult) {
+// CheckItem checks the sets of a saved item for updated images and redownloads them.
+// With dryRun set, the result only contains the plan and nothing on the media server or in the database is changed.
+func CheckItem(ctx context.Context, dbItem models.DBSavedItem, dryRun bool) (result AutoDownloadResult) {
 	result = AutoDownloadResult{}
 	result.Item = utils.MediaItemInfo(dbItem.MediaItem)
+	defer func() { result.DryRun = dryRun }()
 
 	defer func() {
 		if r := recover(); r != nil {
@@ -120,7 +152,7 @@ func CheckItem(ctx context.Context, dbItem models.DBSavedItem) (result AutoDownl
 
 	// Get the base Show Media Item from the cache
 	_, actionGetFromCache := logging.AddSubActionToContext(ctx, fmt.Sprintf("Getting %s Item from cache", utils.MediaItemInfo(dbItem.MediaItem)), logging.LevelTrace)
-	mediaItem, found := cache.LibraryStore.GetMediaItemFromSectionByTMDBID(dbItem.MediaItem.LibraryTitle, dbItem.MediaItem.TMDB_ID)
+	mediaItem, found := cache.LibraryStore.GetMediaItemFromServerSectionByTMDBID(dbItem.MediaItem.Server, dbItem.MediaItem.LibraryTitle, dbItem.MediaItem.TMDB_ID)
 	if !found || mediaItem == nil {
 		result.OverallResult = "error"
 		result.OverallMessage = "Media Item not found in cache"
@@ -144,9 +176,9 @@ func CheckItem(ctx context.Context, dbItem models.DBSavedItem) (result AutoDownl
 
 	switch dbItem.MediaItem.Type {
 	case "movie":
-		result = handleMovie(ctx, *mediaItem, dbItem)
+		result = handleMovie(ctx, *mediaItem, dbItem, dryRun)
 	case "show":
-		result = handleShow(ctx, *mediaItem, dbItem)
+		result = handleShow(ctx, *mediaItem, dbItem, dryRun)
 	default:
 		result.OverallResult = "error"
 		result.OverallMessage = "Unknown media type"
@@ -154,6 +186,50 @@ func CheckItem(ctx context.Context, dbItem models.DBSavedItem) (result AutoDownl
 	return result
 }
 
+// planImages lists the images that need to be redownloaded for the result of a set
+func planImages(mediaItemTitle string, images []ImageFileWithReason) []AutoDownloadImagePlan {
+	plan := make([]AutoDownloadImagePlan, 0, len(images))
+	for _, image := range images {
+		plan = append(plan, AutoDownloadImagePlan{
+			Image:         utils.GetFileDownloadName(mediaItemTitle, image.ImageFile),
+			ImageID:       image.ID,
+			Type:          image.Type,
+			SeasonNumber:  image.SeasonNumber,
+			EpisodeNumber: image.EpisodeNumber,
+			ReasonTitle:   image.ReasonTitle,
+			Reason:        image.Reason,
+		})
+	}
+	return plan
+}
+
+// stageImageUpdate stages the images that need to be redownloaded for approval instead of applying them
+func stageImageUpdate(ctx context.Context, mediaItem models.MediaItem, dbSet models.DBPosterSetDetail, mediuxSet models.PosterSet, images []ImageFileWithReason) (id int64, Err logging.LogErrorInfo) {
+	reasons := []string{}
+	imageFiles := make([]models.ImageFile, 0, len(images))
+	for _, image := range images {
+		imageFiles = append(imageFiles, image.ImageFile)
+		if !slices.Contains(reasons, image.ReasonTitle) {
+			reasons = append(reasons, image.ReasonTitle)
+		}
+	}
+
+	item := models.DBSavedItem{
+		MediaItem: mediaItem,
+		PosterSets: []models.DBPosterSetDetail{{
+			PosterSet: models.PosterSet{
+				BaseSetInfo: mediuxSet.BaseSetInfo,
+				Images:      imageFiles,
+			},
+			SelectedTypes:             dbSet.SelectedTypes,
+			AutoDownload:              dbSet.AutoDownload,
+			AutoAddNewCollectionItems: dbSet.AutoAddNewCollectionItems,
+		}},
+	}
+	reason := fmt.Sprintf("AutoDownload: %s", strings.Join(reasons, ", "))
+	return approvals.Stage(ctx, models.PendingApprovalKindImageUpdate, item, reason)
+}
+
 func seasonExists(mediaItem models.MediaItem, seasonNumber int) bool {
 	if mediaItem.Series == nil {
 		return false
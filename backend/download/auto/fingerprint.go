@@ -0,0 +1,36 @@
+package autodownload
+
+import (
+	"aura/logging"
+	"aura/mediaserver"
+	"aura/models"
+	"aura/utils"
+	"context"
+	"fmt"
+)
+
+// skipUnchangedImages drops the images whose content is the same as the image that was last applied in their place.
+// A new MediUX Modified date or a changed media file does not always mean a different image, and uploading
+// the same image again only causes needless media server uploads and notifications.
+func skipUnchangedImages(ctx context.Context, mediaItem models.MediaItem, images []ImageFileWithReason) (changed []ImageFileWithReason, unchanged int) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Comparing images with the last applied images", logging.LevelDebug)
+	defer logAction.Complete()
+
+	changed = []ImageFileWithReason{}
+	for _, image := range images {
+		if mediaserver.ImageMatchesFingerprint(ctx, mediaItem, image.ImageFile) {
+			unchanged++
+			logAction.AppendResult(utils.GetFileDownloadName(mediaItem.Title, image.ImageFile), "unchanged")
+			continue
+		}
+		changed = append(changed, image)
+	}
+
+	logAction.AppendResult("unchanged_images", unchanged)
+	return changed, unchanged
+}
+
+// unchangedImagesReason is the set result reason when every image was the same as the last applied image
+func unchangedImagesReason(unchanged int) string {
+	return fmt.Sprintf("%d images are the same as the images that were last applied, skipping upload", unchanged)
+}
@@ -1,6 +1,7 @@
 package autodownload
 
 import (
+	"aura/config"
 	"aura/database"
 	"aura/logging"
 	"aura/mediaserver"
@@ -14,7 +15,7 @@ import (
 	"time"
 )
 
-func handleMovie(ctx context.Context, mediaItem models.MediaItem, dbItem models.DBSavedItem) (result AutoDownloadResult) {
+func handleMovie(ctx context.Context, mediaItem models.MediaItem, dbItem models.DBSavedItem, dryRun bool) (result AutoDownloadResult) {
 	result = AutoDownloadResult{}
 	result.Item = utils.MediaItemInfo(dbItem.MediaItem)
 
@@ -39,6 +40,9 @@ func handleMovie(ctx context.Context, mediaItem models.MediaItem, dbItem models.
 	// If none of these are true, we will compare get the latest set and check the dates to see if there has been an update to an image
 	changes := MovieChangeDetails{}
 
+	// Copies of this item held by the other media servers are only looked up once an image needs to be redownloaded
+	var serverCopies []models.MediaItem
+
 	_, actionCheckChanges := logging.AddSubActionToContext(ctx, "Checking if Media Item has changed", logging.LevelTrace)
 	// Check to see if the Rating Key has changed
 	if mediaItem.RatingKey != dbItem.MediaItem.RatingKey {
@@ -208,10 +212,12 @@ func handleMovie(ctx context.Context, mediaItem models.MediaItem, dbItem models.
 		actionCheckChanges.AppendResult("images_to_redownload_count", len(imagesToRedownload))
 		actionCheckChanges.Complete()
 
-		defer func() {
-			logging.DevMsgf("Checking if we need to add new collection items for set %s (ID: %s)", dbSet.Title, dbSet.ID)
-			handleCollectionAutoAddNewItems(ctx, dbSet, includedItems, mediuxSet)
-		}()
+		if !dryRun {
+			defer func() {
+				logging.DevMsgf("Checking if we need to add new collection items for set %s (ID: %s)", dbSet.Title, dbSet.ID)
+				handleCollectionAutoAddNewItems(ctx, dbSet, includedItems, mediuxSet)
+			}()
+		}
 
 		// If no images need to be redownloaded, we will skip the redownload process and move on to the next set
 		if len(imagesToRedownload) == 0 {
@@ -220,10 +226,57 @@ func handleMovie(ctx context.Context, mediaItem models.MediaItem, dbItem models.
 			result.Sets = append(result.Sets, setResult)
 			continue
 		}
+
+		// Images that are the same as the last applied images are not uploaded again.
+		// A moved file still needs its locally saved images, so nothing is skipped then.
+		// A dry run does not download the images from MediUX to compare them.
+		if !dryRun && (!changes.PathChanged || !config.Current.Images.SaveImagesLocally.Enabled) {
+			var unchanged int
+			imagesToRedownload, unchanged = skipUnchangedImages(ctx, mediaItem, imagesToRedownload)
+			if len(imagesToRedownload) == 0 {
+				// Store the latest image info so the same dates are not checked again
+				mediuxSet.PosterSet.Images = possibleImages
+				insertRedownloadedSetIntoDB(ctx, mediaItem, mediuxSet.PosterSet, dbItem, dbSet)
+				setResult.Result = "skipped"
+				setResult.Reason = unchangedImagesReason(unchanged)
+				result.Sets = append(result.Sets, setResult)
+				continue
+			}
+		}
 		logging.Dev().Timestamp().
 			Int("total_images_in_set", len(mediuxSet.Images)).
 			Int("images_to_redownload", len(imagesToRedownload)).
 			Msgf("Image check results for set %s", dbSet.ID)
+		setResult.Images = planImages(mediaItem.Title, imagesToRedownload)
+
+		// A dry run stops at the plan, nothing is downloaded or stored
+		if dryRun {
+			setResult.Result = "success"
+			setResult.Reason = fmt.Sprintf("Dry run: %d images would be redownloaded", len(imagesToRedownload))
+			result.Sets = append(result.Sets, setResult)
+			continue
+		}
+
+		// When approvals are required, the images wait in the approval list instead of being downloaded
+		if config.Current.AutoDownload.RequireApproval {
+			approvalID, Err := stageImageUpdate(ctx, mediaItem, dbSet, mediuxSet.PosterSet, imagesToRedownload)
+			if Err.Message != "" {
+				setResult.Result = "error"
+				setResult.Reason = fmt.Sprintf("Failed to stage images for approval: %s", Err.Message)
+			} else if approvalID == 0 {
+				setResult.Result = "skipped"
+				setResult.Reason = "The updated images were rejected before"
+			} else {
+				setResult.Result = "success"
+				setResult.Reason = fmt.Sprintf("%d images are waiting for approval (ID: %d)", len(imagesToRedownload), approvalID)
+			}
+			result.Sets = append(result.Sets, setResult)
+			continue
+		}
+
+		if serverCopies == nil {
+			serverCopies = mediaserver.GetMediaItemCopiesOnOtherServers(ctx, mediaItem)
+		}
 
 		_, imageRedownloadsAction := logging.AddSubActionToContext(ctx, fmt.Sprintf("Downloading %d updated images for set %s (ID: %s)", len(imagesToRedownload), dbSet.Title, dbSet.ID), logging.LevelInfo)
 		for idx, image := range imagesToRedownload {
@@ -244,6 +297,14 @@ func handleMovie(ctx context.Context, mediaItem models.MediaItem, dbItem models.
 				logging.LOGGER.Error().Timestamp().Str("item", utils.MediaItemInfo(mediaItem)).Str("set_id", dbSet.ID).Str("image", utils.GetFileDownloadName(mediaItem.Title, image.ImageFile)).Str("error", Err.Message).Msg("Failed to redownload image for AutoDownload Check")
 				continue
 			} else {
+				// Apply the same image to the copies of this item on the other media servers
+				if len(serverCopies) > 0 {
+					_, copiesErr := mediaserver.DownloadApplyImageToServerCopies(ctx, serverCopies, image.ImageFile)
+					if copiesErr.Message != "" {
+						imageRedownloadsAction.AppendWarning(fmt.Sprintf("image_redownload_%d_server_copies", idx+1), copiesErr.Message)
+					}
+				}
+
 				// Send a notification to all configured notification services
 				// We do this asynchronously and don't wait for the result
 				go func(image ImageFileWithReason) {
@@ -362,6 +423,9 @@ func handleCollectionAutoAddNewItems(ctx context.Context, dbSet models.DBPosterS
 			continue
 		}
 
+		// Copies of this item held by the other media servers get the same images
+		serverCopies := mediaserver.GetMediaItemCopiesOnOtherServers(ctx, item)
+
 		for _, image := range itemImages {
 			downloadErr := mediaserver.DownloadApplyImageToMediaItem(ctx, &item, image)
 			if downloadErr.Message != "" {
@@ -375,6 +439,18 @@ func handleCollectionAutoAddNewItems(ctx context.Context, dbSet models.DBPosterS
 				continue
 			}
 
+			if len(serverCopies) > 0 {
+				_, copiesErr := mediaserver.DownloadApplyImageToServerCopies(ctx, serverCopies, image)
+				if copiesErr.Message != "" {
+					action.AppendWarning("collection_auto_add_server_copies_failed", map[string]any{
+						"tmdb_id":    item.TMDB_ID,
+						"image_type": image.Type,
+						"image_id":   image.ID,
+						"error":      copiesErr.Message,
+					})
+				}
+			}
+
 			go func(mediaItem models.MediaItem, imageFile models.ImageFile) {
 				sendFileDownloadNotification(mediaItem, dbSet, ImageFileWithReason{
 					ImageFile:   imageFile,
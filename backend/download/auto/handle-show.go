@@ -1,6 +1,7 @@
 package autodownload
 
 import (
+	"aura/config"
 	"aura/logging"
 	"aura/mediaserver"
 	"aura/mediux"
@@ -12,7 +13,7 @@ import (
 	"strings"
 )
 
-func handleShow(ctx context.Context, mediaItem models.MediaItem, dbItem models.DBSavedItem) (result AutoDownloadResult) {
+func handleShow(ctx context.Context, mediaItem models.MediaItem, dbItem models.DBSavedItem, dryRun bool) (result AutoDownloadResult) {
 	result = AutoDownloadResult{}
 	result.Item = utils.MediaItemInfo(dbItem.MediaItem)
 
@@ -37,6 +38,9 @@ func handleShow(ctx context.Context, mediaItem models.MediaItem, dbItem models.D
 	// If none of these are true, we will compare get the latest set and check the dates to see if there has been an update to an image
 	changes := ShowChangeDetails{}
 
+	// Copies of this item held by the other media servers are only looked up once an image needs to be redownloaded
+	var serverCopies []models.MediaItem
+
 	_, actionCheckChanges := logging.AddSubActionToContext(ctx, "Checking if Media Item has changed", logging.LevelTrace)
 	// Rating key change
 	if mediaItem.RatingKey != dbItem.MediaItem.RatingKey {
@@ -394,10 +398,56 @@ func handleShow(ctx context.Context, mediaItem models.MediaItem, dbItem models.D
 			result.Sets = append(result.Sets, setResult)
 			continue
 		}
+
+		// Images that are the same as the last applied images are not uploaded again.
+		// Moved files still need their locally saved images, so nothing is skipped then.
+		// A dry run does not download the images from MediUX to compare them.
+		if !dryRun && (!showFilesMoved(changes) || !config.Current.Images.SaveImagesLocally.Enabled) {
+			var unchanged int
+			imagesToRedownload, unchanged = skipUnchangedImages(ctx, mediaItem, imagesToRedownload)
+			if len(imagesToRedownload) == 0 {
+				// Store the latest image info so the same dates are not checked again
+				insertRedownloadedSetIntoDB(ctx, mediaItem, mediuxSet.PosterSet, dbItem, dbSet)
+				setResult.Result = "skipped"
+				setResult.Reason = unchangedImagesReason(unchanged)
+				result.Sets = append(result.Sets, setResult)
+				continue
+			}
+		}
 		logging.Dev().Timestamp().
 			Int("total_images_in_set", len(mediuxSet.Images)).
 			Int("images_to_redownload", len(imagesToRedownload)).
 			Msgf("Image check results for set %s", dbSet.ID)
+		setResult.Images = planImages(mediaItem.Title, imagesToRedownload)
+
+		// A dry run stops at the plan, nothing is downloaded or stored
+		if dryRun {
+			setResult.Result = "success"
+			setResult.Reason = fmt.Sprintf("Dry run: %d images would be redownloaded", len(imagesToRedownload))
+			result.Sets = append(result.Sets, setResult)
+			continue
+		}
+
+		// When approvals are required, the images wait in the approval list instead of being downloaded
+		if config.Current.AutoDownload.RequireApproval {
+			approvalID, Err := stageImageUpdate(ctx, mediaItem, dbSet, mediuxSet.PosterSet, imagesToRedownload)
+			if Err.Message != "" {
+				setResult.Result = "error"
+				setResult.Reason = fmt.Sprintf("Failed to stage images for approval: %s", Err.Message)
+			} else if approvalID == 0 {
+				setResult.Result = "skipped"
+				setResult.Reason = "The updated images were rejected before"
+			} else {
+				setResult.Result = "success"
+				setResult.Reason = fmt.Sprintf("%d images are waiting for approval (ID: %d)", len(imagesToRedownload), approvalID)
+			}
+			result.Sets = append(result.Sets, setResult)
+			continue
+		}
+
+		if serverCopies == nil {
+			serverCopies = mediaserver.GetMediaItemCopiesOnOtherServers(ctx, mediaItem)
+		}
 
 		_, imageRedownloadsAction := logging.AddSubActionToContext(ctx, fmt.Sprintf("Downloading %d updated images for set %s (ID: %s)", len(imagesToRedownload), dbSet.Title, dbSet.ID), logging.LevelInfo)
 		for idx, image := range imagesToRedownload {
@@ -418,6 +468,14 @@ func handleShow(ctx context.Context, mediaItem models.MediaItem, dbItem models.D
 				logging.LOGGER.Error().Timestamp().Str("item", utils.MediaItemInfo(mediaItem)).Str("set_id", dbSet.ID).Str("image", utils.GetFileDownloadName(mediaItem.Title, image.ImageFile)).Str("error", Err.Message).Msg("Failed to redownload image for AutoDownload Check")
 				continue
 			} else {
+				// Apply the same image to the copies of this item on the other media servers
+				if len(serverCopies) > 0 {
+					_, copiesErr := mediaserver.DownloadApplyImageToServerCopies(ctx, serverCopies, image.ImageFile)
+					if copiesErr.Message != "" {
+						imageRedownloadsAction.AppendWarning(fmt.Sprintf("image_redownload_%d_server_copies", idx+1), copiesErr.Message)
+					}
+				}
+
 				// Send a notification to all configured notification services
 				// We do this asynchronously and don't wait for the result
 				go func(image ImageFileWithReason) {
@@ -498,6 +556,19 @@ func formatChangedPath(oldPath, newPath string) string {
 	return fmt.Sprintf("Path changed:\n- old: %s\n- new: %s", oldPath, newPath)
 }
 
+// showFilesMoved reports whether the show folder or any episode file moved
+func showFilesMoved(changes ShowChangeDetails) bool {
+	if changes.PathChanged {
+		return true
+	}
+	for _, episode := range changes.ChangedEpisodes {
+		if episode.PathChanged {
+			return true
+		}
+	}
+	return false
+}
+
 type ShowChangeDetails struct {
 	RatingKeyChanged bool                   `json:"rating_key_changed"`
 	OldRatingKey     string                 `json:"old_rating_key,omitempty"`
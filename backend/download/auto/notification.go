@@ -3,11 +3,11 @@ package autodownload
 import (
 	"aura/config"
 	"aura/logging"
+	"aura/mediux"
 	"aura/models"
 	"aura/notification"
 	"aura/utils"
 	"context"
-	"fmt"
 )
 
 func sendFileDownloadNotification(mediaItem models.MediaItem, set models.DBPosterSetDetail, imageWithReason ImageFileWithReason) {
@@ -34,11 +34,7 @@ func sendFileDownloadNotification(mediaItem models.MediaItem, set models.DBPoste
 	message := utils.RenderTemplate(config.Current.Notifications.NotificationTemplate.Autodownload.Message, vars)
 	imageURL := ""
 	if config.Current.Notifications.NotificationTemplate.Autodownload.IncludeImage {
-		imageURL = fmt.Sprintf("%s/%s?v=%s&key=jpg",
-			"https://images.mediux.io/assets",
-			imageWithReason.ID,
-			imageWithReason.Modified.Format("20060102150405"),
-		)
+		imageURL = mediux.GetImageURLFromSrc(imageWithReason.Src)
 	}
 
 	ctx, ld := logging.CreateLoggingContext(context.Background(), "Notification - Send File Download Message")
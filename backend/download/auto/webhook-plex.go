@@ -0,0 +1,172 @@
+package autodownload
+
+import (
+	"aura/cache"
+	"aura/download/autoselect"
+	"aura/logging"
+	"aura/mediaserver"
+	"aura/models"
+	"aura/utils"
+	"context"
+	"sync"
+	"time"
+)
+
+// plexWebhookDelay gives Plex time to finish matching a new item and fetching its own artwork.
+// Events for the same movie or show that arrive within the delay (e.g. every episode of a new season)
+// are handled together.
+const plexWebhookDelay = 15 * time.Second
+
+// The webhook endpoint is public, so the number of pending movies and shows
+// and the number of new items per movie or show are capped
+const (
+	plexWebhookMaxPendingItems    = 100
+	plexWebhookMaxPendingNewItems = 1000
+)
+
+type PlexWebhookPayload struct {
+	Event    string              `json:"event"`
+	Owner    bool                `json:"owner"`
+	Server   PlexWebhookServer   `json:"Server"`
+	Metadata PlexWebhookMetadata `json:"Metadata"`
+}
+
+type PlexWebhookServer struct {
+	Title string `json:"title"`
+	UUID  string `json:"uuid"`
+}
+
+type PlexWebhookMetadata struct {
+	LibrarySectionType   string `json:"librarySectionType"`
+	LibrarySectionTitle  string `json:"librarySectionTitle"`
+	RatingKey            string `json:"ratingKey"`
+	ParentRatingKey      string `json:"parentRatingKey"`
+	GrandparentRatingKey string `json:"grandparentRatingKey"`
+	Type                 string `json:"type"` // movie, show, season or episode
+	Title                string `json:"title"`
+	ParentTitle          string `json:"parentTitle"`
+	GrandparentTitle     string `json:"grandparentTitle"`
+	ParentIndex          int    `json:"parentIndex"`
+	Index                int    `json:"index"`
+}
+
+type pendingPlexWebhookItem struct {
+	libraryTitle string
+	itemTypes    map[string]string // Rating key of the new movie, show, season or episode -> its type
+}
+
+var plexWebhookPending = struct {
+	mu    sync.Mutex
+	items map[string]*pendingPlexWebhookItem
+}{
+	items: make(map[string]*pendingPlexWebhookItem),
+}
+
+// QueuePlexLibraryNewEvent schedules the handling of a library.new webhook event.
+// It returns false and the reason when the event is not for an item that aura manages.
+func QueuePlexLibraryNewEvent(metadata PlexWebhookMetadata) (queued bool, reason string) {
+	if _, found := cache.LibraryStore.GetSectionByTitle(metadata.LibrarySectionTitle); !found {
+		return false, "library is not managed by aura"
+	}
+
+	// Seasons and episodes are handled through the show they belong to
+	topRatingKey := ""
+	switch metadata.Type {
+	case "movie", "show":
+		topRatingKey = metadata.RatingKey
+	case "season":
+		topRatingKey = metadata.ParentRatingKey
+	case "episode":
+		topRatingKey = metadata.GrandparentRatingKey
+	default:
+		return false, "unsupported item type"
+	}
+	if topRatingKey == "" || metadata.RatingKey == "" {
+		return false, "missing rating key"
+	}
+
+	plexWebhookPending.mu.Lock()
+	defer plexWebhookPending.mu.Unlock()
+
+	if pending, exists := plexWebhookPending.items[topRatingKey]; exists {
+		if _, seen := pending.itemTypes[metadata.RatingKey]; !seen && len(pending.itemTypes) >= plexWebhookMaxPendingNewItems {
+			return false, "too many new items are pending for this movie or show"
+		}
+		pending.itemTypes[metadata.RatingKey] = metadata.Type
+		return true, ""
+	}
+	if len(plexWebhookPending.items) >= plexWebhookMaxPendingItems {
+		return false, "too many movies and shows are pending"
+	}
+
+	plexWebhookPending.items[topRatingKey] = &pendingPlexWebhookItem{
+		libraryTitle: metadata.LibrarySectionTitle,
+		itemTypes:    map[string]string{metadata.RatingKey: metadata.Type},
+	}
+	time.AfterFunc(plexWebhookDelay, func() {
+		defer func() {
+			if r := recover(); r != nil {
+				logging.LOGGER.Error().Timestamp().Msgf("PANIC: in Plex webhook processing: %v", r)
+			}
+		}()
+		processPlexWebhookItem(topRatingKey)
+	})
+	return true, ""
+}
+
+// processPlexWebhookItem re-applies the saved images of the new parts of a movie or show.
+// Items that were not in the library cache yet are also passed to AutoSelect.
+func processPlexWebhookItem(topRatingKey string) {
+	plexWebhookPending.mu.Lock()
+	pending, exists := plexWebhookPending.items[topRatingKey]
+	delete(plexWebhookPending.items, topRatingKey)
+	plexWebhookPending.mu.Unlock()
+	if !exists {
+		return
+	}
+
+	ctx, ld := logging.CreateLoggingContext(context.Background(), "Plex Webhook")
+	logAction := ld.AddAction("Handle New Items from Plex Webhook", logging.LevelInfo)
+	ctx = logging.WithCurrentAction(ctx, logAction)
+
+	item := models.MediaItem{RatingKey: topRatingKey, LibraryTitle: pending.libraryTitle}
+	cachedItem, inCache := cache.LibraryStore.GetMediaItemByRatingKey(topRatingKey)
+	if inCache {
+		item = *cachedItem
+	}
+
+	// The cached details do not have the new seasons and episodes yet.
+	// GetMediaItemDetails also adds new items to the library cache.
+	found, Err := mediaserver.GetMediaItemDetails(ctx, &item)
+	if Err.Message != "" || !found {
+		ld.Log()
+		return
+	}
+	setCachedRefreshedMediaItem(item)
+
+	logging.LOGGER.Info().Timestamp().
+		Str("item_title", item.Title).
+		Int("new_items", len(pending.itemTypes)).
+		Bool("new_to_aura", !inCache).
+		Msgf("Plex Webhook: Received new items for %s", utils.MediaItemInfo(item))
+
+	// A movie or show that aura did not know yet may have been removed and added again,
+	// so its own poster and backdrop are re-applied as well
+	if !inCache {
+		pending.itemTypes[item.RatingKey] = item.Type
+	}
+	for ratingKey, itemType := range pending.itemTypes {
+		reApplySavedImages(PlexRefreshedItem{
+			MediaItem:     item,
+			ItemRatingKey: ratingKey,
+			ItemType:      itemType,
+		})
+	}
+
+	if !inCache {
+		autoselect.CheckNewItems(ctx)
+	}
+
+	logAction.AppendResult("new_items", len(pending.itemTypes))
+	logAction.Complete()
+}
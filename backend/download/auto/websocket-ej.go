@@ -0,0 +1,399 @@
+package autodownload
+
+import (
+	"aura/cache"
+	"aura/config"
+	"aura/database"
+	"aura/download/drift"
+	"aura/logging"
+	"aura/mediaserver"
+	"aura/mediaserver/ej"
+	"aura/models"
+	"aura/utils"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	ejReconnectDelay    = 10 * time.Second
+	ejCheckCoolDown     = 30 * time.Second
+	ejKeepAliveInterval = 30 * time.Second
+	ejDedupWindow       = 30 * time.Second
+)
+
+// ejRefreshEventDeduper drops events for items that were handled within ejDedupWindow.
+// A refresh is reported by RefreshProgress and LibraryChanged, and uploading an image
+// makes the server send a LibraryChanged event for the item again.
+var ejRefreshEventDeduper = struct {
+	mu       sync.Mutex
+	lastSeen map[string]time.Time
+}{
+	lastSeen: make(map[string]time.Time),
+}
+
+// ejReApplyMu makes sure the same item is never checked and reapplied twice at the same time
+var ejReApplyMu sync.Mutex
+
+var (
+	ejWSControlMu sync.Mutex
+	ejWSStopChan  chan struct{}
+)
+
+type ejSocketMessage struct {
+	MessageType string          `json:"MessageType"`
+	Data        json.RawMessage `json:"Data,omitempty"`
+}
+
+type ejLibraryChangedData struct {
+	ItemsAdded   []string `json:"ItemsAdded"`
+	ItemsUpdated []string `json:"ItemsUpdated"`
+	ItemsRemoved []string `json:"ItemsRemoved"`
+}
+
+type ejRefreshProgressData struct {
+	ItemID   string `json:"ItemId"`
+	Progress any    `json:"Progress"`
+}
+
+// StartOrRestartEJWebSocketClient stops any running Emby/Jellyfin WebSocket goroutine and starts a new one.
+func StartOrRestartEJWebSocketClient() {
+	ejWSControlMu.Lock()
+	defer ejWSControlMu.Unlock()
+
+	// Stop previous goroutine if running
+	if ejWSStopChan != nil {
+		close(ejWSStopChan)
+		ejWSStopChan = nil
+	}
+
+	stopChan := make(chan struct{})
+	ejWSStopChan = stopChan
+
+	go func(stop <-chan struct{}) {
+		for {
+			if (config.Current.MediaServer.Type != "Emby" && config.Current.MediaServer.Type != "Jellyfin") ||
+				!config.Current.MediaServer.EnableEmbyJellyfinEventListener {
+				select {
+				case <-stop:
+					return
+				case <-time.After(ejCheckCoolDown):
+				}
+				continue
+			}
+
+			err := connectAndListenEJWithStop(stop)
+			if err != nil {
+				logging.LOGGER.Error().Timestamp().Err(err).
+					Msgf("%s WebSocket connection error", config.Current.MediaServer.Type)
+			}
+
+			logging.LOGGER.Warn().Timestamp().
+				Msgf("Reconnecting to %s WebSocket in %s...", config.Current.MediaServer.Type, ejReconnectDelay)
+			select {
+			case <-stop:
+				return
+			case <-time.After(ejReconnectDelay):
+			}
+		}
+	}(stopChan)
+}
+
+// connectAndListenEJWithStop listens to the /socket of the server until the connection fails or stop is closed.
+func connectAndListenEJWithStop(stop <-chan struct{}) (err error) {
+	msConfig := config.Current.MediaServer
+	ejClient := &ej.EJ{Config: msConfig}
+
+	wsURL, wsURLForLog, err := buildEJWebSocketURL(msConfig)
+	if err != nil {
+		return err
+	}
+
+	logging.LOGGER.Info().Timestamp().Str("url", wsURLForLog).
+		Msgf("%s Event Listener: Connecting to %s WebSocket", msConfig.Type, msConfig.Type)
+
+	conn, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to connect to %s WebSocket at %s: %w", msConfig.Type, wsURLForLog, err)
+	}
+	defer conn.Close()
+
+	logging.LOGGER.Info().Timestamp().
+		Msgf("%s Event Listener: Connected — watching for metadata refresh events", msConfig.Type)
+
+	// The server closes connections that do not send a KeepAlive message in time.
+	// Only this goroutine writes to the connection. Closing the connection also ends the read loop below.
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		ticker := time.NewTicker(ejKeepAliveInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				conn.Close()
+				return
+			case <-done:
+				return
+			case <-ticker.C:
+				conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
+				if err := conn.WriteJSON(ejSocketMessage{MessageType: "KeepAlive"}); err != nil {
+					conn.Close()
+					return
+				}
+			}
+		}
+	}()
+
+	for {
+		_, message, err := conn.ReadMessage()
+		if err != nil {
+			select {
+			case <-stop:
+				return nil
+			default:
+			}
+			return fmt.Errorf("error reading from %s WebSocket: %w", msConfig.Type, err)
+		}
+		handleEJMessage(ejClient, message)
+	}
+}
+
+func buildEJWebSocketURL(msConfig config.Config_MediaServer) (wsURL string, wsURLForLog string, err error) {
+	u, err := url.Parse(strings.TrimRight(msConfig.URL, "/"))
+	if err != nil {
+		return "", "", fmt.Errorf("failed to parse %s URL: %w", msConfig.Type, err)
+	}
+
+	// Determine the ws/wss scheme from the http/https URL
+	if u.Scheme == "https" {
+		u.Scheme = "wss"
+	} else {
+		u.Scheme = "ws"
+	}
+	u.Path = strings.TrimRight(u.Path, "/") + "/socket"
+
+	query := url.Values{}
+	query.Set("api_key", msConfig.ApiToken)
+	query.Set("deviceId", "aura")
+	u.RawQuery = query.Encode()
+	wsURL = u.String()
+
+	query.Set("api_key", config.MaskToken(msConfig.ApiToken))
+	u.RawQuery = query.Encode()
+	wsURLForLog = u.String()
+
+	return wsURL, wsURLForLog, nil
+}
+
+// handleEJMessage picks the refreshed items out of LibraryChanged and RefreshProgress messages.
+// Other message types (KeepAlive, Sessions, UserDataChanged, ...) are ignored.
+func handleEJMessage(ejClient *ej.EJ, message []byte) {
+	var msg ejSocketMessage
+	if err := json.Unmarshal(message, &msg); err != nil {
+		return
+	}
+
+	itemIDs := []string{}
+	switch msg.MessageType {
+	case "LibraryChanged":
+		var data ejLibraryChangedData
+		if err := json.Unmarshal(msg.Data, &data); err != nil {
+			return
+		}
+		itemIDs = data.ItemsUpdated
+	case "RefreshProgress":
+		var data ejRefreshProgressData
+		if err := json.Unmarshal(msg.Data, &data); err != nil {
+			return
+		}
+		// Progress is sent as "100" by Jellyfin and as 100 by Emby
+		if asString(data.Progress) != "100" || data.ItemID == "" {
+			return
+		}
+		itemIDs = []string{data.ItemID}
+	default:
+		return
+	}
+
+	itemIDs = filterNewEJRefreshEvents(itemIDs)
+	if len(itemIDs) == 0 {
+		return
+	}
+
+	go processEJRefreshedItems(ejClient, itemIDs)
+}
+
+// filterNewEJRefreshEvents returns the item IDs that were not seen within ejDedupWindow and marks them as seen
+func filterNewEJRefreshEvents(itemIDs []string) []string {
+	now := time.Now()
+
+	ejRefreshEventDeduper.mu.Lock()
+	defer ejRefreshEventDeduper.mu.Unlock()
+
+	for key, ts := range ejRefreshEventDeduper.lastSeen {
+		if now.Sub(ts) > ejDedupWindow {
+			delete(ejRefreshEventDeduper.lastSeen, key)
+		}
+	}
+
+	newIDs := []string{}
+	for _, itemID := range itemIDs {
+		itemID = strings.TrimSpace(itemID)
+		if itemID == "" {
+			continue
+		}
+		if _, exists := ejRefreshEventDeduper.lastSeen[itemID]; exists {
+			continue
+		}
+		ejRefreshEventDeduper.lastSeen[itemID] = now
+		newIDs = append(newIDs, itemID)
+	}
+	return newIDs
+}
+
+// markEJItemHandled keeps the LibraryChanged event of an upload by aura from starting another check
+func markEJItemHandled(itemID string) {
+	ejRefreshEventDeduper.mu.Lock()
+	defer ejRefreshEventDeduper.mu.Unlock()
+	ejRefreshEventDeduper.lastSeen[itemID] = time.Now()
+}
+
+// processEJRefreshedItems groups the refreshed items by their movie or show and reapplies the replaced images of each.
+func processEJRefreshedItems(ejClient *ej.EJ, itemIDs []string) {
+	ctx, ld := logging.CreateLoggingContext(context.Background(), fmt.Sprintf("%s Event Listener", ejClient.Config.Type))
+	logAction := ld.AddAction(fmt.Sprintf("Resolve Refreshed Items on %s", ejClient.Config.Type), logging.LevelDebug)
+	ctx = logging.WithCurrentAction(ctx, logAction)
+
+	// Movies and shows are in the cache, seasons and episodes have to be looked up on the server
+	updatedIDsByItem := map[string][]string{}
+	unknownIDs := []string{}
+	for _, itemID := range itemIDs {
+		if _, found := cache.LibraryStore.GetMediaItemByRatingKey(itemID); found {
+			updatedIDsByItem[itemID] = append(updatedIDsByItem[itemID], itemID)
+			continue
+		}
+		unknownIDs = append(unknownIDs, itemID)
+	}
+	if len(unknownIDs) > 0 {
+		seriesIDs, Err := ejClient.GetItemsSeriesIDs(ctx, unknownIDs)
+		if Err.Message != "" {
+			ld.Log()
+			return
+		}
+		for itemID, seriesID := range seriesIDs {
+			if _, found := cache.LibraryStore.GetMediaItemByRatingKey(seriesID); !found {
+				continue
+			}
+			updatedIDsByItem[seriesID] = append(updatedIDsByItem[seriesID], itemID)
+		}
+	}
+	logAction.Complete()
+
+	for ratingKey, updatedIDs := range updatedIDsByItem {
+		reApplyReplacedEJImages(ejClient.Config.Type, ratingKey, updatedIDs)
+	}
+}
+
+// reApplyReplacedEJImages compares the saved images of the refreshed parts of an item with the images on the server,
+// and applies the saved images again where a refresh replaced them.
+// When the movie or show itself was refreshed, all of its saved images are compared.
+func reApplyReplacedEJImages(serverType string, ratingKey string, updatedIDs []string) {
+	ejReApplyMu.Lock()
+	defer ejReApplyMu.Unlock()
+
+	cachedItem, found := cache.LibraryStore.GetMediaItemByRatingKey(ratingKey)
+	if !found {
+		return
+	}
+	item := *cachedItem
+
+	ctx, ld := logging.CreateLoggingContext(context.Background(), fmt.Sprintf("%s Event Listener", serverType))
+	logAction := ld.AddAction("Re-Apply Replaced Images After Metadata Refresh", logging.LevelInfo)
+	ctx = logging.WithCurrentAction(ctx, logAction)
+
+	savedItems, Err := database.GetAllSavedSets(ctx, models.DBFilter{
+		ItemTMDB_ID:      item.TMDB_ID,
+		ItemLibraryTitle: item.LibraryTitle,
+	})
+	if Err.Message != "" {
+		logAction.SetError("Failed to fetch saved sets", Err.Message, map[string]any{
+			"title":      item.Title,
+			"rating_key": item.RatingKey,
+		})
+		ld.Log()
+		return
+	}
+	if len(savedItems.Items) == 0 {
+		logging.DevMsgf("%s Event Listener: No saved sets found for refreshed item %s, skipping", serverType, utils.MediaItemInfo(item))
+		return
+	}
+
+	// Seasons and episodes are only present in the full details of a show
+	if item.Type == "show" {
+		if found, Err := mediaserver.GetMediaItemDetails(ctx, &item); Err.Message != "" || !found {
+			ld.Log()
+			return
+		}
+	}
+
+	itemRefreshed := slices.Contains(updatedIDs, item.RatingKey)
+	replaced := []models.ImageFile{}
+	applied := 0
+	for _, savedItem := range savedItems.Items {
+		for _, posterSet := range savedItem.PosterSets {
+			if !posterSet.AutoDownload {
+				continue
+			}
+			for _, image := range posterSet.SelectedImages(item) {
+				imageRatingKey, _ := mediaserver.ImageRatingKeyAndType(item, image)
+				if imageRatingKey == "" {
+					continue
+				}
+				if !itemRefreshed && !slices.Contains(updatedIDs, imageRatingKey) {
+					continue
+				}
+				if !drift.ImageReplaced(ctx, item, image) {
+					continue
+				}
+
+				replaced = append(replaced, image)
+				applyErr := mediaserver.DownloadApplyImageToMediaItem(ctx, &item, image)
+				markEJItemHandled(imageRatingKey)
+				if applyErr.Message != "" {
+					logging.LOGGER.Error().Timestamp().
+						Str("error", applyErr.Message).
+						Str("image_id", image.ID).
+						Str("image_type", image.Type).
+						Str("item_title", item.Title).
+						Str("item_rating_key", item.RatingKey).
+						Msgf("%s Event Listener: Failed to re-apply saved image to refreshed item", serverType)
+					continue
+				}
+				applied++
+				logging.LOGGER.Info().Timestamp().
+					Str("image_type", image.Type).
+					Str("item_title", item.Title).
+					Str("item_rating_key", item.RatingKey).
+					Msgf("%s Event Listener: Re-applied saved image that a metadata refresh replaced", serverType)
+			}
+		}
+	}
+
+	if len(replaced) == 0 {
+		logging.DevMsgf("%s Event Listener: Saved images of %s are still applied after the refresh", serverType, utils.MediaItemInfo(item))
+		return
+	}
+
+	logAction.AppendResult("replaced_images", replaced)
+	logAction.AppendResult("images_reapplied", applied)
+	logAction.Complete()
+	ld.Log()
+}
@@ -10,9 +10,12 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
 	"net/url"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -22,10 +25,99 @@ const (
 	mediuxReconnectDelay = 5 * time.Second
 )
 
-func StartMediuxWebSocketClient() {
+// mediuxSetCollections maps the MediUX collections we subscribe to onto the set type stored in the database
+var mediuxSetCollections = map[string]string{
+	"show_sets":       "show",
+	"movie_sets":      "movie",
+	"collection_sets": "collection",
+}
+
+// MediuxWebSocketStatus is the connection state of the MediUX WebSocket listener
+type MediuxWebSocketStatus struct {
+	Running     bool       `json:"running"`
+	Connected   bool       `json:"connected"`
+	Collections []string   `json:"collections"`
+	ConnectedAt *time.Time `json:"connected_at,omitempty"`
+	LastEventAt *time.Time `json:"last_event_at,omitempty"`
+	LastError   string     `json:"last_error,omitempty"`
+	Reconnects  int        `json:"reconnects"`
+}
+
+var (
+	mediuxWSStatus   MediuxWebSocketStatus
+	mediuxWSStatusMu sync.RWMutex
+
+	// mediuxUpdateMu processes set updates one at a time, so a burst of updates does not check the same item in parallel
+	mediuxUpdateMu sync.Mutex
+
+	// mediuxWSConn is the open connection, it is closed to stop the listener
+	mediuxWSConn   *websocket.Conn
+	mediuxWSConnMu sync.Mutex
+)
+
+// GetMediuxWebSocketStatus returns the current connection state of the MediUX WebSocket listener
+func GetMediuxWebSocketStatus() MediuxWebSocketStatus {
+	mediuxWSStatusMu.RLock()
+	defer mediuxWSStatusMu.RUnlock()
+	status := mediuxWSStatus
+	status.Collections = slices.Clone(mediuxWSStatus.Collections)
+	return status
+}
+
+func updateMediuxWebSocketStatus(update func(status *MediuxWebSocketStatus)) {
+	mediuxWSStatusMu.Lock()
+	defer mediuxWSStatusMu.Unlock()
+	update(&mediuxWSStatus)
+}
+
+// StartOrStopMediuxWebSocketClient starts the MediUX WebSocket listener when Mediux.EnableEventListener is set,
+// and stops it when it is not
+func StartOrStopMediuxWebSocketClient() {
+	if config.Current.Mediux.EnableEventListener {
+		startMediuxWebSocketClient()
+		return
+	}
+
+	// Closing the connection ends the listener, it does not reconnect while it is disabled
+	mediuxWSConnMu.Lock()
+	if mediuxWSConn != nil {
+		mediuxWSConn.Close()
+	}
+	mediuxWSConnMu.Unlock()
+}
+
+func startMediuxWebSocketClient() {
+	// The listener reconnects on its own, only start it once
+	alreadyRunning := false
+	updateMediuxWebSocketStatus(func(status *MediuxWebSocketStatus) {
+		alreadyRunning = status.Running
+		status.Running = true
+	})
+	if alreadyRunning {
+		return
+	}
+
 	go func() {
 		for {
 			err := connectAndSubscribeMediux()
+			stopped := false
+			updateMediuxWebSocketStatus(func(status *MediuxWebSocketStatus) {
+				status.Connected = false
+				status.Collections = []string{}
+				if !config.Current.Mediux.EnableEventListener {
+					status.Running = false
+					stopped = true
+					return
+				}
+				status.Reconnects++
+				if err != nil {
+					status.LastError = err.Error()
+				}
+			})
+			if stopped {
+				logging.LOGGER.Info().Timestamp().Msg("Mediux Event Listener: Stopped")
+				return
+			}
 			if err != nil {
 				logging.LOGGER.Error().Timestamp().Err(err).Msg("Mediux WebSocket connection error")
 			}
@@ -55,16 +147,42 @@ func connectAndSubscribeMediux() error {
 	}
 	defer c.Close()
 
-	collectionType := "show_sets"
-	subscribeMsg := map[string]any{
-		"type":       "subscribe",
-		"collection": collectionType,
+	mediuxWSConnMu.Lock()
+	mediuxWSConn = c
+	mediuxWSConnMu.Unlock()
+	defer func() {
+		mediuxWSConnMu.Lock()
+		mediuxWSConn = nil
+		mediuxWSConnMu.Unlock()
+	}()
+	// The listener may have been disabled while connecting
+	if !config.Current.Mediux.EnableEventListener {
+		return nil
 	}
 
-	if err := c.WriteJSON(subscribeMsg); err != nil {
-		return err
+	connectedAt := time.Now()
+	updateMediuxWebSocketStatus(func(status *MediuxWebSocketStatus) {
+		status.Connected = true
+		status.ConnectedAt = &connectedAt
+		status.LastError = ""
+	})
+
+	// Subscribe to every set collection, the uid tells the messages of the subscriptions apart
+	for _, collectionType := range slices.Sorted(maps.Keys(mediuxSetCollections)) {
+		subscribeMsg := map[string]any{
+			"type":       "subscribe",
+			"collection": collectionType,
+			"uid":        collectionType,
+		}
+
+		if err := c.WriteJSON(subscribeMsg); err != nil {
+			return err
+		}
+		updateMediuxWebSocketStatus(func(status *MediuxWebSocketStatus) {
+			status.Collections = append(status.Collections, collectionType)
+		})
+		logging.LOGGER.Debug().Timestamp().Str("collection", collectionType).Msg("Subscribed to Mediux WebSocket collection")
 	}
-	logging.LOGGER.Debug().Timestamp().Str("collection", collectionType).Msg("Subscribed to Mediux WebSocket collection")
 
 	// Listen for messages until error/close
 	for {
@@ -95,58 +213,17 @@ func connectAndSubscribeMediux() error {
 		if msg.Event == "init" {
 			continue
 		} else if msg.Event == "update" {
-			for set := range msg.UpdatedSets {
-				ctx, ld := logging.CreateLoggingContext(context.Background(), "Mediux Update - Processing Set")
-				logAction := ld.AddAction("Fetching All Items from DB", logging.LevelDebug)
-				ctx = logging.WithCurrentAction(ctx, logAction)
-
-				setID := msg.UpdatedSets[set].ID
-				showID := msg.UpdatedSets[set].ShowID
-				showTitle := msg.UpdatedSets[set].Title
-
-				logAction.AppendResult("set_id", setID)
-				logAction.AppendResult("set_show_id", showID)
-				logAction.AppendResult("set_title", showTitle)
-
-				logging.LOGGER.Info().Timestamp().Int("set_id", msg.UpdatedSets[set].ID).Str("set_title", msg.UpdatedSets[set].Title).Msg("Show set updated")
+			setType, ok := mediuxSetCollections[msg.UID]
+			if !ok {
+				logging.LOGGER.Warn().Timestamp().Str("uid", msg.UID).Msg("Received set update for an unknown Mediux WebSocket subscription")
+				continue
+			}
 
-				// Get all items from the database that match this Set
-				dbFilter := models.DBFilter{
-					ItemTMDB_ID: showID,
-					SetID:       strconv.Itoa(setID),
-				}
-				out, Err := database.GetAllSavedSets(ctx, dbFilter)
-				if Err.Message != "" {
-					logging.LOGGER.Error().Timestamp().Str("error", Err.Message).Msg("Failed to fetch items from DB for updated set")
-					continue
-				}
-				if len(out.Items) == 0 {
-					continue // No items found in database for this set, skip processing
-				}
+			eventAt := time.Now()
+			updateMediuxWebSocketStatus(func(status *MediuxWebSocketStatus) { status.LastEventAt = &eventAt })
 
-				logging.LOGGER.Debug().Timestamp().Int("num_items", len(out.Items)).Msg("Found Items in DB for updated set")
-
-				// Process each item in the set
-				for _, dbItem := range out.Items {
-					logging.LOGGER.Debug().Timestamp().Msgf("Processing %s since a set it contains was updated", utils.MediaItemInfo(dbItem.MediaItem))
-					result := CheckItem(ctx, dbItem)
-					switch result.OverallResult {
-					case "Success":
-						logging.LOGGER.Info().Timestamp().Msgf("Auto-download success for item %s", utils.MediaItemInfo(dbItem.MediaItem))
-						logAction.AppendResult("auto_download_result", result)
-					case "Warn":
-						logging.LOGGER.Warn().Timestamp().Msgf("Auto-download warning for item %s", utils.MediaItemInfo(dbItem.MediaItem))
-						logAction.AppendResult("auto_download_result", result)
-					case "Error":
-						logging.LOGGER.Error().Timestamp().Msgf("Auto-download error for item %s", utils.MediaItemInfo(dbItem.MediaItem))
-						logAction.AppendResult("auto_download_result", result)
-					case "Skipped":
-						logging.LOGGER.Debug().Timestamp().Msgf("Auto-download skipped for item %s", utils.MediaItemInfo(dbItem.MediaItem))
-						logAction.AppendResult("auto_download_result", result)
-					}
-				}
-				ld.Log()
-			}
+			// Checking the items can take a while, keep reading so pings are still answered
+			go handleMediuxSetUpdates(setType, msg.UpdatedSets)
 		} else {
 			logging.LOGGER.Warn().Timestamp().
 				Str("type", msg.Type).
@@ -158,6 +235,81 @@ func connectAndSubscribeMediux() error {
 	}
 }
 
+// handleMediuxSetUpdates runs the AutoDownload check for every saved item that uses one of the updated sets
+func handleMediuxSetUpdates(setType string, updatedSets []MediuxWebSocketUpdateData) {
+	mediuxUpdateMu.Lock()
+	defer mediuxUpdateMu.Unlock()
+
+	if !config.Current.AutoDownload.Enabled {
+		logging.LOGGER.Debug().Timestamp().Str("set_type", setType).Msg("AutoDownload is disabled, ignoring Mediux set updates")
+		return
+	}
+
+	for _, updatedSet := range updatedSets {
+		ctx, ld := logging.CreateLoggingContext(context.Background(), "Mediux Update - Processing Set")
+		logAction := ld.AddAction("Fetching All Items from DB", logging.LevelDebug)
+		ctx = logging.WithCurrentAction(ctx, logAction)
+
+		setID := strconv.Itoa(updatedSet.ID)
+		logAction.AppendResult("set_id", setID)
+		logAction.AppendResult("set_type", setType)
+		logAction.AppendResult("set_title", updatedSet.Title)
+
+		logging.LOGGER.Info().Timestamp().Int("set_id", updatedSet.ID).Str("set_type", setType).Str("set_title", updatedSet.Title).Msg("Mediux set updated")
+
+		// Get all items from the database that use this Set
+		dbFilter := models.DBFilter{
+			SetID:        setID,
+			ItemsPerPage: -1,
+		}
+		if setType == "show" {
+			dbFilter.ItemTMDB_ID = updatedSet.ShowID
+		}
+		out, Err := database.GetAllSavedSets(ctx, dbFilter)
+		if Err.Message != "" {
+			logging.LOGGER.Error().Timestamp().Str("error", Err.Message).Msg("Failed to fetch items from DB for updated set")
+			ld.Log()
+			continue
+		}
+
+		// Set IDs are only unique per collection, so only keep the items that saved a set of this type
+		items := []models.DBSavedItem{}
+		for _, dbItem := range out.Items {
+			if slices.ContainsFunc(dbItem.PosterSets, func(posterSet models.DBPosterSetDetail) bool {
+				return posterSet.ID == setID && posterSet.Type == setType
+			}) {
+				items = append(items, dbItem)
+			}
+		}
+		if len(items) == 0 {
+			// No items found in database for this set, skip processing
+			logAction.AppendResult("saved_items", 0)
+			ld.Log()
+			continue
+		}
+
+		logging.LOGGER.Debug().Timestamp().Int("num_items", len(items)).Msg("Found Items in DB for updated set")
+
+		// Process each item in the set
+		for _, dbItem := range items {
+			logging.LOGGER.Debug().Timestamp().Msgf("Processing %s since a set it contains was updated", utils.MediaItemInfo(dbItem.MediaItem))
+			result := CheckItem(ctx, dbItem, config.Current.AutoDownload.DryRun)
+			switch result.OverallResult {
+			case "success":
+				logging.LOGGER.Info().Timestamp().Msgf("Auto-download success for item %s", utils.MediaItemInfo(dbItem.MediaItem))
+			case "warning":
+				logging.LOGGER.Warn().Timestamp().Msgf("Auto-download warning for item %s", utils.MediaItemInfo(dbItem.MediaItem))
+			case "error":
+				logging.LOGGER.Error().Timestamp().Msgf("Auto-download error for item %s", utils.MediaItemInfo(dbItem.MediaItem))
+			case "skipped":
+				logging.LOGGER.Debug().Timestamp().Msgf("Auto-download skipped for item %s", utils.MediaItemInfo(dbItem.MediaItem))
+			}
+			logAction.AppendResult("auto_download_result", result)
+		}
+		ld.Log()
+	}
+}
+
 func buildMediuxWebSocketURL() (string, error) {
 	u, err := url.Parse(mediux.MediuxApiURL)
 	if err != nil {
@@ -174,12 +326,13 @@ func buildMediuxWebSocketURL() (string, error) {
 type MediuxWebSocketResponseMessage struct {
 	Type        string                      `json:"type"`  // Always "subscription" for responses
 	Event       string                      `json:"event"` // Example: "init", "ping", "update"
+	UID         string                      `json:"uid"`   // Collection of the subscription the message belongs to
 	UpdatedSets []MediuxWebSocketUpdateData `json:"data"`
 }
 
 type MediuxWebSocketUpdateData struct {
 	ID          int    `json:"id"`
 	Title       string `json:"set_title"`
-	ShowID      string `json:"show_id"`
+	ShowID      string `json:"show_id"` // Only set for show sets
 	DateUpdated string `json:"date_updated"`
 }
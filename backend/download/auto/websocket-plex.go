@@ -364,36 +364,7 @@ func reApplySavedImages(item PlexRefreshedItem) {
 			if !posterSet.AutoDownload {
 				continue
 			}
-			for _, image := range posterSet.Images {
-				switch image.Type {
-				case "poster":
-					if !posterSet.SelectedTypes.Poster {
-						continue
-					}
-				case "backdrop":
-					if !posterSet.SelectedTypes.Backdrop {
-						continue
-					}
-				case "season_poster":
-					if !posterSet.SelectedTypes.SeasonPoster && !posterSet.SelectedTypes.SpecialSeasonPoster {
-						continue
-					}
-					// If the season number is 0, and we dont have special season poster selected, then skip
-					if image.SeasonNumber != nil && *image.SeasonNumber == 0 && !posterSet.SelectedTypes.SpecialSeasonPoster {
-						continue
-					}
-					// If the season number is greater than 0, and we dont have regular season poster selected, then skip
-					if image.SeasonNumber != nil && *image.SeasonNumber > 0 && !posterSet.SelectedTypes.SeasonPoster {
-						continue
-					}
-				case "titlecard":
-					if !posterSet.SelectedTypes.Titlecard {
-						continue
-					}
-				default:
-					continue
-				}
-
+			for _, image := range posterSet.SelectedImages(item.MediaItem) {
 				if !shouldReApplyImage(item, image) {
 					continue
 				}
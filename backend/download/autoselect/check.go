@@ -0,0 +1,296 @@
+package autoselect
+
+import (
+	"aura/cache"
+	"aura/config"
+	"aura/database"
+	downloadqueue "aura/download/queue"
+	"aura/events"
+	"aura/logging"
+	"aura/mediux"
+	"aura/models"
+	"aura/utils"
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// firstRunWindow is how far back the very first check looks for new items
+const firstRunWindow = 24 * time.Hour
+
+// checkpointJob is the name the time of the last complete check is saved under
+const checkpointJob = "autoselect"
+
+var (
+	seenMu sync.Mutex
+	seen   = map[string]bool{} // Items already handled, keyed by type:tmdb_id|library_title
+)
+
+func itemKey(item models.MediaItem) string {
+	return item.Type + ":" + item.TMDB_ID + "|" + item.LibraryTitle
+}
+
+// CheckNewItems picks a MediUX set for items that appeared in the library cache since the last check.
+// Only items in a library with an AutoSelect rule are handled. The best set according to the rule is added
+// to the Download Queue with AutoDownload enabled.
+func CheckNewItems(ctx context.Context) (Err logging.LogErrorInfo) {
+	if !config.Current.AutoSelect.Enabled {
+		return Err
+	}
+
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Auto Selecting Sets for New Items", logging.LevelInfo)
+	defer logAction.Complete()
+
+	rules := map[string]config.Config_AutoSelectRule{}
+	for _, rule := range config.Current.AutoSelect.Rules {
+		rules[rule.Library] = rule
+	}
+
+	// After a restart, the items added since the last complete check are new
+	checkStarted := time.Now()
+	since, found, Err := database.GetJobCheckpoint(ctx, checkpointJob)
+	if Err.Message != "" {
+		return Err
+	}
+	if !found {
+		since = checkStarted.Add(-firstRunWindow)
+	}
+
+	newItems := findNewItems(since)
+	logAction.AppendResult("new_items", len(newItems))
+	if len(newItems) == 0 {
+		saveCheckpoint(ctx, checkStarted)
+		return Err
+	}
+
+	progress := events.JobProgress{Job: "AutoSelect", Total: len(newItems)}
+	events.Publish(events.TypeJobStarted, progress)
+
+	for _, item := range newItems {
+		result := "skipped"
+		if rule, ok := rules[item.LibraryTitle]; ok {
+			result = handleNewItem(ctx, item, rule)
+		}
+		switch result {
+		case "added":
+			progress.SuccessCount++
+		case "error":
+			progress.ErrorCount++
+		default:
+			progress.SkippedCount++
+		}
+		progress.Item = utils.MediaItemInfo(item)
+		progress.Result = result
+		progress.Processed++
+		events.Publish(events.TypeJobProgress, progress)
+
+		if result == "error" {
+			// Checked again on the next run. The checkpoint stays before the item, so it is also checked again after a restart.
+			if itemAdded := addedAt(item); itemAdded.After(since) && itemAdded.Before(checkStarted) {
+				checkStarted = itemAdded.Add(-time.Second)
+			}
+			continue
+		}
+		seenMu.Lock()
+		seen[itemKey(item)] = true
+		seenMu.Unlock()
+	}
+	saveCheckpoint(ctx, checkStarted)
+
+	progress.Item = ""
+	progress.Result = ""
+	events.Publish(events.TypeJobFinished, progress)
+
+	logAction.AppendResult("added", progress.SuccessCount)
+	logAction.AppendResult("errors", progress.ErrorCount)
+	return Err
+}
+
+// saveCheckpoint saves the time up to which every new item was handled.
+// When it can not be saved, the items of the last check are checked again after a restart.
+func saveCheckpoint(ctx context.Context, checkedAt time.Time) {
+	if Err := database.SetJobCheckpoint(ctx, checkpointJob, checkedAt); Err.Message != "" {
+		logging.LOGGER.Warn().Timestamp().Str("error", Err.Message).Msg("Failed to save the time of the last AutoSelect check")
+	}
+}
+
+// findNewItems returns the items of the primary media server that have not been handled yet.
+// On the first check after startup, only the items added after since are new.
+// Items that failed are returned again until they were handled.
+func findNewItems(since time.Time) []models.MediaItem {
+	seenMu.Lock()
+	defer seenMu.Unlock()
+
+	firstRun := len(seen) == 0
+
+	newItems := []models.MediaItem{}
+	for _, item := range cache.LibraryStore.GetAllMediaItems() {
+		// Copies on the additional media servers are handled by the Download Queue
+		if item.Server != "" || item.TMDB_ID == "" {
+			continue
+		}
+		key := itemKey(item)
+		if seen[key] {
+			continue
+		}
+		if firstRun && !addedAt(item).After(since) {
+			seen[key] = true
+			continue
+		}
+		newItems = append(newItems, item)
+	}
+	return newItems
+}
+
+// addedAt converts the AddedAt timestamp of an item to a time.
+// Plex reports seconds, Emby and Jellyfin report milliseconds.
+func addedAt(item models.MediaItem) time.Time {
+	if item.AddedAt > 1e12 {
+		return time.UnixMilli(item.AddedAt)
+	}
+	return time.Unix(item.AddedAt, 0)
+}
+
+// handleNewItem adds the best set for an item to the Download Queue.
+// It returns "added", "skipped" or "error".
+func handleNewItem(ctx context.Context, item models.MediaItem, rule config.Config_AutoSelectRule) string {
+	ctx, logAction := logging.AddSubActionToContext(ctx,
+		fmt.Sprintf("Auto Selecting Set for %s", utils.MediaItemInfo(item)),
+		logging.LevelDebug)
+	defer logAction.Complete()
+
+	ignored, _, savedSets, Err := database.CheckIfMediaItemExists(ctx, item.TMDB_ID, item.LibraryTitle)
+	if Err.Message != "" {
+		return "error"
+	}
+	if ignored || len(savedSets) > 0 {
+		logAction.AppendResult("skipped", "Item is ignored or already has a saved set")
+		return "skipped"
+	}
+
+	queued, Err := database.GetDownloadQueueEntries(ctx, models.DBDownloadQueueFilter{
+		TMDB_ID:      item.TMDB_ID,
+		LibraryTitle: item.LibraryTitle,
+		Statuses:     []string{models.DownloadQueueStatusPending, models.DownloadQueueStatusProcessing},
+	})
+	if Err.Message != "" {
+		return "error"
+	}
+	if len(queued) > 0 {
+		logAction.AppendResult("skipped", "Item is already in the Download Queue")
+		return "skipped"
+	}
+
+	sets, _, Err := mediux.GetItemSets(ctx, item.TMDB_ID, item.Type, item.LibraryTitle)
+	if Err.Message != "" {
+		return "error"
+	}
+
+	set, ok := selectBestSet(item, sets, rule)
+	if !ok {
+		logAction.AppendResult("skipped", "No set matches the AutoSelect rule")
+		return "skipped"
+	}
+	logAction.AppendResult("selected_set", set.ID)
+	logAction.AppendResult("selected_set_creator", set.UserCreated)
+
+	Err = downloadqueue.AddToQueue(ctx, models.DBSavedItem{
+		MediaItem: item,
+		PosterSets: []models.DBPosterSetDetail{{
+			PosterSet:     set,
+			SelectedTypes: availableTypes(item.Type, set),
+			AutoDownload:  true,
+		}},
+	})
+	if Err.Message != "" {
+		return "error"
+	}
+	return "added"
+}
+
+// selectBestSet returns the set that matches the rule, preferring the creators in the order of the rule,
+// then the most popular and then the most recently updated set
+func selectBestSet(item models.MediaItem, sets []models.SetRef, rule config.Config_AutoSelectRule) (models.PosterSet, bool) {
+	candidates := []models.PosterSet{}
+	for _, setRef := range sets {
+		set := setRef.PosterSet
+		set.Images = []models.ImageFile{}
+		for _, image := range setRef.Images {
+			// Collection sets contain the images of every movie in the collection
+			if image.ItemTMDB_ID != "" && image.ItemTMDB_ID != item.TMDB_ID {
+				continue
+			}
+			// Images without a language (e.g. textless) match every language
+			if rule.Language != "" && image.Language != "" && !strings.EqualFold(image.Language, rule.Language) {
+				continue
+			}
+			set.Images = append(set.Images, image)
+		}
+		if len(set.Images) == 0 || set.Popularity < rule.MinPopularity {
+			continue
+		}
+		if rule.OnlyPreferredCreators && creatorRank(set.UserCreated, rule.PreferredCreators) == len(rule.PreferredCreators) {
+			continue
+		}
+		if !hasRequiredTypes(set, rule.RequiredImageTypes) {
+			continue
+		}
+		candidates = append(candidates, set)
+	}
+	if len(candidates) == 0 {
+		return models.PosterSet{}, false
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		rankI := creatorRank(candidates[i].UserCreated, rule.PreferredCreators)
+		rankJ := creatorRank(candidates[j].UserCreated, rule.PreferredCreators)
+		if rankI != rankJ {
+			return rankI < rankJ
+		}
+		if candidates[i].Popularity != candidates[j].Popularity {
+			return candidates[i].Popularity > candidates[j].Popularity
+		}
+		return candidates[i].DateUpdated.After(candidates[j].DateUpdated)
+	})
+	return candidates[0], true
+}
+
+// creatorRank returns the position of the creator in the preferred creators.
+// Creators that are not preferred are ranked after all preferred creators.
+func creatorRank(creator string, preferred []string) int {
+	for i, username := range preferred {
+		if strings.EqualFold(username, creator) {
+			return i
+		}
+	}
+	return len(preferred)
+}
+
+// hasRequiredTypes reports whether the set has at least one image of every required type
+func hasRequiredTypes(set models.PosterSet, required []string) bool {
+	present := []string{}
+	for _, image := range set.Images {
+		present = append(present, image.SelectedTypeKey())
+	}
+	for _, imageType := range required {
+		if !slices.Contains(present, imageType) {
+			return false
+		}
+	}
+	return true
+}
+
+// availableTypes selects every image type that the set contains
+func availableTypes(itemType string, set models.PosterSet) models.SelectedTypes {
+	selected := set.ContainedTypes()
+	if itemType == "movie" {
+		selected.SeasonPoster = false
+		selected.SpecialSeasonPoster = false
+		selected.Titlecard = false
+	}
+	return selected
+}
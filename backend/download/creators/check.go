@@ -0,0 +1,283 @@
+package creators
+
+import (
+	"aura/cache"
+	"aura/config"
+	"aura/database"
+	"aura/download/approvals"
+	downloadqueue "aura/download/queue"
+	"aura/events"
+	"aura/logging"
+	"aura/mediaserver"
+	"aura/mediux"
+	"aura/models"
+	"aura/utils"
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// checkpointJobPrefix is followed by the username of the creator, the time of the newest handled set is saved under it
+const checkpointJobPrefix = "subscribed_creators:"
+
+// creatorSet is a new set of a followed creator for a single item
+type creatorSet struct {
+	set      models.PosterSet
+	tmdbID   string
+	typ      string // "movie" or "show"
+	username string
+}
+
+// CheckFollowedCreators looks for new sets of the MediUX creators we follow.
+// When a set belongs to an item in our libraries that has no saved set yet, it is added to the Download Queue
+// or staged for approval, depending on SubscribedCreators.Mode.
+func CheckFollowedCreators(ctx context.Context) (Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Checking Followed Creators for New Sets", logging.LevelInfo)
+	defer logAction.Complete()
+
+	cfg := config.Current.SubscribedCreators
+
+	users, Err := mediux.GetUserFollowingAndHiding(ctx)
+	if Err.Message != "" {
+		return Err
+	}
+	following := []string{}
+	for _, user := range users {
+		if user.Follow {
+			following = append(following, user.Username)
+		}
+	}
+	logAction.AppendResult("following", following)
+	if len(following) == 0 {
+		return Err
+	}
+
+	// Collect the new sets of every followed creator, one entry per item in the set.
+	// A set is new when it was created after the newest set handled by the last check, and within MaxSetAgeDays.
+	maxAgeCutoff := time.Now().AddDate(0, 0, -cfg.MaxSetAgeDays)
+	candidates := []creatorSet{}
+	checkpoints := map[string]time.Time{}
+	for _, username := range following {
+		cutoff := maxAgeCutoff
+		since, found, Err := database.GetJobCheckpoint(ctx, checkpointJobPrefix+username)
+		if Err.Message != "" {
+			logAction.AppendWarning(username, Err.Message)
+			continue
+		}
+		if found && since.After(cutoff) {
+			cutoff = since
+		}
+
+		userSets, userErr := mediux.GetAllUserSets(ctx, username)
+		if userErr.Message != "" {
+			logAction.AppendWarning(username, userErr.Message)
+			continue
+		}
+		checkpoints[username] = cutoff
+		candidates = append(candidates, newCreatorSets(userSets.ShowSets, "show", username, cutoff)...)
+		candidates = append(candidates, newCreatorSets(userSets.MovieSets, "movie", username, cutoff)...)
+		candidates = append(candidates, newCreatorSets(userSets.CollectionSets, "movie", username, cutoff)...)
+	}
+	logAction.AppendResult("new_sets", len(candidates))
+	if len(candidates) == 0 {
+		return Err
+	}
+
+	// The checkpoint of a creator moves to the newest handled set.
+	// It stays before a set that failed, so that set is checked again on the next run.
+	failedSets := map[string]time.Time{}
+	for _, candidate := range candidates {
+		if candidate.set.DateCreated.After(checkpoints[candidate.username]) {
+			checkpoints[candidate.username] = candidate.set.DateCreated
+		}
+	}
+
+	// The newest set wins when more than one creator published a set for the same item
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].set.DateCreated.After(candidates[j].set.DateCreated)
+	})
+
+	mediaserver.GetAllLibrarySectionsAndItems(ctx, false)
+	libraryItems := map[string][]models.MediaItem{}
+	for _, item := range cache.LibraryStore.GetAllMediaItems() {
+		// Copies on the additional media servers are handled by the Download Queue
+		if item.Server != "" {
+			continue
+		}
+		key := item.Type + ":" + item.TMDB_ID
+		libraryItems[key] = append(libraryItems[key], item)
+	}
+
+	progress := events.JobProgress{Job: "Subscribed Creators", Total: len(candidates)}
+	events.Publish(events.TypeJobStarted, progress)
+
+	handled := map[string]bool{}
+	for _, candidate := range candidates {
+		for _, item := range libraryItems[candidate.typ+":"+candidate.tmdbID] {
+			itemKey := item.TMDB_ID + "|" + item.LibraryTitle
+			if handled[itemKey] {
+				continue
+			}
+
+			result := handleCreatorSet(ctx, item, candidate.set)
+			if result != "skipped" {
+				handled[itemKey] = true
+			}
+			if failedAt, failed := failedSets[candidate.username]; result == "error" && (!failed || candidate.set.DateCreated.Before(failedAt)) {
+				failedSets[candidate.username] = candidate.set.DateCreated
+			}
+			switch result {
+			case "added", "staged":
+				progress.SuccessCount++
+			case "error":
+				progress.ErrorCount++
+			default:
+				progress.SkippedCount++
+			}
+			progress.Item = fmt.Sprintf("%s - %s", utils.MediaItemInfo(item), candidate.set.ID)
+			progress.Result = result
+		}
+		progress.Processed++
+		events.Publish(events.TypeJobProgress, progress)
+	}
+
+	for username, checkedUpTo := range checkpoints {
+		if failedAt, failed := failedSets[username]; failed {
+			checkedUpTo = failedAt.Add(-time.Second)
+		}
+		if Err := database.SetJobCheckpoint(ctx, checkpointJobPrefix+username, checkedUpTo); Err.Message != "" {
+			logAction.AppendWarning(username, "Failed to save the time of the newest handled set, the sets are checked again on the next run")
+		}
+	}
+
+	progress.Item = ""
+	progress.Result = ""
+	events.Publish(events.TypeJobFinished, progress)
+
+	logAction.AppendResult("added_or_staged", progress.SuccessCount)
+	logAction.AppendResult("errors", progress.ErrorCount)
+	return Err
+}
+
+// newCreatorSets splits the sets created after the cutoff into one entry per item
+func newCreatorSets(sets []models.SetRef, itemType string, username string, cutoff time.Time) []creatorSet {
+	out := []creatorSet{}
+	for _, setRef := range sets {
+		if !setRef.DateCreated.After(cutoff) {
+			continue
+		}
+		for _, tmdbID := range setRef.ItemIDs {
+			set := setRef.PosterSet
+			set.Images = []models.ImageFile{}
+			for _, image := range setRef.Images {
+				if image.ItemTMDB_ID == tmdbID {
+					set.Images = append(set.Images, image)
+				}
+			}
+			if len(set.Images) == 0 {
+				continue
+			}
+			out = append(out, creatorSet{set: set, tmdbID: tmdbID, typ: itemType, username: username})
+		}
+	}
+	return out
+}
+
+// handleCreatorSet adds or stages a set for an item without a saved set.
+// It returns "added", "staged", "skipped" or "error".
+func handleCreatorSet(ctx context.Context, item models.MediaItem, set models.PosterSet) string {
+	ctx, logAction := logging.AddSubActionToContext(ctx,
+		fmt.Sprintf("Handling Set %s by %s for %s", set.ID, set.UserCreated, utils.MediaItemInfo(item)),
+		logging.LevelDebug)
+	defer logAction.Complete()
+
+	cfg := config.Current.SubscribedCreators
+
+	ignored, _, savedSets, Err := database.CheckIfMediaItemExists(ctx, item.TMDB_ID, item.LibraryTitle)
+	if Err.Message != "" {
+		return "error"
+	}
+	if ignored || len(savedSets) > 0 {
+		logAction.AppendResult("skipped", "Item is ignored or already has a saved set")
+		return "skipped"
+	}
+
+	queued, Err := database.GetDownloadQueueEntries(ctx, models.DBDownloadQueueFilter{
+		TMDB_ID:      item.TMDB_ID,
+		LibraryTitle: item.LibraryTitle,
+		Statuses:     []string{models.DownloadQueueStatusPending, models.DownloadQueueStatusProcessing},
+	})
+	if Err.Message != "" {
+		return "error"
+	}
+	if len(queued) > 0 {
+		logAction.AppendResult("skipped", "Item is already in the Download Queue")
+		return "skipped"
+	}
+
+	// Do not suggest a second set while one is waiting for approval, and never suggest a rejected set again
+	previous, Err := database.GetPendingApprovals(ctx, models.DBPendingApprovalFilter{
+		Kinds:        []string{models.PendingApprovalKindCreatorSet},
+		Statuses:     []string{models.PendingApprovalStatusPending, models.PendingApprovalStatusRejected},
+		TMDB_ID:      item.TMDB_ID,
+		LibraryTitle: item.LibraryTitle,
+	})
+	if Err.Message != "" {
+		return "error"
+	}
+	for _, approval := range previous {
+		rejectedSet := len(approval.Item.PosterSets) > 0 && approval.Item.PosterSets[0].ID == set.ID
+		if approval.Status == models.PendingApprovalStatusPending || rejectedSet {
+			logAction.AppendResult("skipped", fmt.Sprintf("A set for this item is already %s", approval.Status))
+			return "skipped"
+		}
+	}
+
+	selectedTypes := selectedTypesForSet(item.Type, set)
+	if selectedTypes == (models.SelectedTypes{}) {
+		logAction.AppendResult("skipped", "The set has none of the selected image types")
+		return "skipped"
+	}
+
+	saveItem := models.DBSavedItem{
+		MediaItem: item,
+		PosterSets: []models.DBPosterSetDetail{{
+			PosterSet:     set,
+			SelectedTypes: selectedTypes,
+			AutoDownload:  cfg.AutoDownload,
+		}},
+	}
+
+	if cfg.Mode == "add" {
+		Err = downloadqueue.AddToQueue(ctx, saveItem)
+		if Err.Message != "" {
+			return "error"
+		}
+		return "added"
+	}
+
+	reason := fmt.Sprintf("%s published a new set '%s' on %s", set.UserCreated, set.Title, set.DateCreated.Format("2006-01-02"))
+	_, Err = approvals.Stage(ctx, models.PendingApprovalKindCreatorSet, saveItem, reason)
+	if Err.Message != "" {
+		return "error"
+	}
+	return "staged"
+}
+
+// selectedTypesForSet returns the configured image types that the set actually contains
+func selectedTypesForSet(itemType string, set models.PosterSet) models.SelectedTypes {
+	cfg := config.Current.SubscribedCreators.SelectedTypes
+
+	available := set.ContainedTypes()
+	selected := models.SelectedTypes{
+		Poster:   cfg.Poster && available.Poster,
+		Backdrop: cfg.Backdrop && available.Backdrop,
+	}
+	if itemType == "show" {
+		selected.SeasonPoster = cfg.SeasonPoster && available.SeasonPoster
+		selected.SpecialSeasonPoster = cfg.SpecialSeasonPoster && available.SpecialSeasonPoster
+		selected.Titlecard = cfg.Titlecard && available.Titlecard
+	}
+	return selected
+}
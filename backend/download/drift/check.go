@@ -0,0 +1,213 @@
+package drift
+
+import (
+	"aura/cache"
+	"aura/config"
+	"aura/database"
+	"aura/events"
+	"aura/logging"
+	"aura/mediaserver"
+	"aura/mediux"
+	"aura/models"
+	"aura/utils"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+var (
+	checkMu     sync.Mutex
+	latestMu    sync.RWMutex
+	latestDrift *models.DriftReport
+)
+
+// LatestDriftReport returns the result of the last Drift Detection check since the app started
+func LatestDriftReport() (models.DriftReport, bool) {
+	latestMu.RLock()
+	defer latestMu.RUnlock()
+	if latestDrift == nil {
+		return models.DriftReport{}, false
+	}
+	return *latestDrift, true
+}
+
+// CheckImageDrift compares every applied image of the saved sets with the image the primary media server shows now.
+// Images are compared by their perceptual hash, so resizing and recompression by the media server are not seen as drift.
+// Drifted images are flagged, and applied again when DriftDetection.Mode is "reapply".
+func CheckImageDrift(ctx context.Context) (report models.DriftReport, Err logging.LogErrorInfo) {
+	checkMu.Lock()
+	defer checkMu.Unlock()
+
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Checking Applied Images for Drift", logging.LevelInfo)
+	defer logAction.Complete()
+
+	cfg := config.Current.DriftDetection
+	cfg.Threshold = driftThreshold()
+	report.Drifted = []models.DriftedImage{}
+
+	mediaserver.GetAllLibrarySectionsAndItems(ctx, false)
+
+	savedSets, Err := database.GetAllSavedSets(ctx, models.DBFilter{ItemsPerPage: -1})
+	if Err.Message != "" {
+		return report, Err
+	}
+
+	progress := events.JobProgress{Job: "Drift Detection", Total: len(savedSets.Items)}
+	events.Publish(events.TypeJobStarted, progress)
+
+	for _, savedItem := range savedSets.Items {
+		progress.Item = utils.MediaItemInfo(savedItem.MediaItem)
+
+		cachedItem, found := cache.LibraryStore.GetMediaItemFromServerSectionByTMDBID("", savedItem.MediaItem.LibraryTitle, savedItem.MediaItem.TMDB_ID)
+		if !found {
+			progress.Processed++
+			progress.SkippedCount++
+			progress.Result = "skipped"
+			events.Publish(events.TypeJobProgress, progress)
+			continue
+		}
+		item := *cachedItem
+
+		// Seasons and episodes are only present in the full details of a show
+		if item.Type == "show" {
+			if found, Err := mediaserver.GetMediaItemDetails(ctx, &item); Err.Message != "" || !found {
+				progress.Processed++
+				progress.ErrorCount++
+				progress.Result = "error"
+				events.Publish(events.TypeJobProgress, progress)
+				continue
+			}
+		}
+
+		itemDrifted := 0
+		itemErrors := 0
+		for _, set := range savedItem.PosterSets {
+			for _, image := range set.SelectedImages(item) {
+				drifted, checked := checkImage(ctx, item, set.ID, image, cfg.Threshold)
+				if !checked {
+					itemErrors++
+					continue
+				}
+				report.Checked++
+				if drifted == nil {
+					continue
+				}
+
+				itemDrifted++
+				if cfg.Mode == "reapply" {
+					applyErr := mediaserver.DownloadApplyImageToMediaItem(ctx, &item, image)
+					if applyErr.Message != "" {
+						drifted.Error = applyErr.Message
+					} else {
+						drifted.Reapplied = true
+					}
+				}
+				logging.LOGGER.Warn().Timestamp().
+					Str("item", utils.MediaItemInfo(item)).
+					Str("image", utils.GetFileDownloadName(item.Title, image)).
+					Int("distance", drifted.Distance).
+					Bool("reapplied", drifted.Reapplied).
+					Msg("Applied image no longer matches the saved set")
+				report.Drifted = append(report.Drifted, *drifted)
+			}
+		}
+
+		progress.Processed++
+		switch {
+		case itemDrifted > 0:
+			progress.WarningCount++
+			progress.Result = fmt.Sprintf("%d drifted", itemDrifted)
+		case itemErrors > 0:
+			progress.ErrorCount++
+			progress.Result = "error"
+		default:
+			progress.SuccessCount++
+			progress.Result = "unchanged"
+		}
+		events.Publish(events.TypeJobProgress, progress)
+	}
+
+	progress.Item = ""
+	progress.Result = ""
+	events.Publish(events.TypeJobFinished, progress)
+
+	report.CheckedAt = time.Now()
+	latestMu.Lock()
+	latestDrift = &report
+	latestMu.Unlock()
+
+	logAction.AppendResult("checked", report.Checked)
+	logAction.AppendResult("drifted", len(report.Drifted))
+	return report, logging.LogErrorInfo{}
+}
+
+// ImageReplaced reports whether the media server shows a different image than the applied MediUX image,
+// e.g. because a metadata refresh replaced it. Images that could not be compared are not seen as replaced.
+func ImageReplaced(ctx context.Context, item models.MediaItem, image models.ImageFile) bool {
+	drifted, checked := checkImage(ctx, item, "", image, driftThreshold())
+	return checked && drifted != nil
+}
+
+func driftThreshold() int {
+	if config.Current.DriftDetection.Threshold <= 0 {
+		return 10
+	}
+	return config.Current.DriftDetection.Threshold
+}
+
+// checkImage compares the image on the media server with the MediUX image.
+// It returns the drifted image when the distance of their perceptual hashes is above the threshold,
+// and checked is false when the images could not be compared.
+func checkImage(ctx context.Context, item models.MediaItem, setID string, image models.ImageFile, threshold int) (drifted *models.DriftedImage, checked bool) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf(
+		"Comparing %s of %s with MediUX", utils.GetFileDownloadName(item.Title, image), utils.MediaItemInfo(item)),
+		logging.LevelDebug)
+	defer logAction.Complete()
+
+	imageRatingKey, imageType := mediaserver.ImageRatingKeyAndType(item, image)
+	if imageRatingKey == "" {
+		// The season or episode of the image is not on the media server
+		return nil, true
+	}
+
+	currentData, Err := mediaserver.GetMediaItemImage(ctx, &item, imageRatingKey, imageType)
+	if Err.Message != "" || len(currentData) == 0 {
+		logAction.AppendWarning("message", "Failed to get the current image from the media server")
+		return nil, false
+	}
+	currentHash, err := utils.PerceptualHash(currentData)
+	if err != nil {
+		logAction.AppendWarning("message", fmt.Sprintf("Failed to read the current image: %s", err.Error()))
+		return nil, false
+	}
+
+	expectedData, _, Err := mediux.GetImage(ctx, image.ID, image.Modified.Format("20060102150405"), mediux.ImageQualityOptimized)
+	if Err.Message != "" {
+		return nil, false
+	}
+	expectedHash, err := utils.PerceptualHash(expectedData)
+	if err != nil {
+		logAction.AppendWarning("message", fmt.Sprintf("Failed to read the MediUX image: %s", err.Error()))
+		return nil, false
+	}
+
+	distance := utils.PerceptualHashDistance(currentHash, expectedHash)
+	logAction.AppendResult("distance", distance)
+	if distance <= threshold {
+		return nil, true
+	}
+
+	return &models.DriftedImage{
+		TMDB_ID:       item.TMDB_ID,
+		LibraryTitle:  item.LibraryTitle,
+		Title:         item.Title,
+		RatingKey:     item.RatingKey,
+		SetID:         setID,
+		ImageID:       image.ID,
+		ImageType:     image.Type,
+		SeasonNumber:  image.SeasonNumber,
+		EpisodeNumber: image.EpisodeNumber,
+		Distance:      distance,
+	}, true
+}
@@ -0,0 +1,60 @@
+package downloadqueue
+
+import (
+	"aura/config"
+	"aura/logging"
+	"strings"
+	"time"
+)
+
+// transientErrorPatterns are parts of error messages that point to a temporary problem
+// (service down, network issue or timeout) rather than a problem with the entry itself
+var transientErrorPatterns = []string{
+	"failed to send",
+	"timeout",
+	"timed out",
+	"deadline exceeded",
+	"connection refused",
+	"connection reset",
+	"no such host",
+	"network is unreachable",
+	"server misbehaving",
+	"eof",
+}
+
+// isTransientError reports whether a failure is likely to go away when the entry is retried later.
+// HTTP 408, 429 and 5xx responses, and network errors are transient, everything else is permanent.
+func isTransientError(Err logging.LogErrorInfo) bool {
+	if Err.Message == "" {
+		return false
+	}
+
+	if statusCode, ok := Err.Detail["status_code"].(int); ok {
+		return statusCode == 408 || statusCode == 429 || statusCode >= 500
+	}
+
+	texts := []string{Err.Message}
+	if detailErr, ok := Err.Detail["error"].(string); ok {
+		texts = append(texts, detailErr)
+	}
+	for _, text := range texts {
+		text = strings.ToLower(text)
+		for _, pattern := range transientErrorPatterns {
+			if strings.Contains(text, pattern) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// retryDelay returns how long to wait before the next attempt of an entry that has been attempted `attempts` times.
+// The delay starts at DownloadQueue.RetryDelaySeconds and doubles after every attempt, up to DownloadQueue.MaxRetryDelaySeconds.
+func retryDelay(attempts int) time.Duration {
+	delay := time.Duration(config.Current.DownloadQueue.RetryDelaySeconds) * time.Second
+	maxDelay := time.Duration(config.Current.DownloadQueue.MaxRetryDelaySeconds) * time.Second
+	for i := 1; i < attempts && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, maxDelay)
+}
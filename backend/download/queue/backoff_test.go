@@ -0,0 +1,60 @@
+package downloadqueue
+
+import (
+	"aura/config"
+	"aura/logging"
+	"testing"
+	"time"
+)
+
+func TestIsTransientError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  logging.LogErrorInfo
+		want bool
+	}{
+		{name: "no error", err: logging.LogErrorInfo{}, want: false},
+		{name: "server error", err: logging.LogErrorInfo{Message: "Failed to upload image", Detail: map[string]any{"status_code": 502}}, want: true},
+		{name: "too many requests", err: logging.LogErrorInfo{Message: "Rate limited", Detail: map[string]any{"status_code": 429}}, want: true},
+		{name: "request timeout", err: logging.LogErrorInfo{Message: "Request timeout", Detail: map[string]any{"status_code": 408}}, want: true},
+		{name: "not found", err: logging.LogErrorInfo{Message: "Image not found", Detail: map[string]any{"status_code": 404}}, want: false},
+		{name: "status code wins over the message", err: logging.LogErrorInfo{Message: "timeout", Detail: map[string]any{"status_code": 401}}, want: false},
+		{name: "timeout in message", err: logging.LogErrorInfo{Message: "Request Timed Out"}, want: true},
+		{name: "network error in detail", err: logging.LogErrorInfo{Message: "Failed to get image", Detail: map[string]any{"error": "dial tcp: connection refused"}}, want: true},
+		{name: "unexpected eof", err: logging.LogErrorInfo{Message: "Failed to read body", Detail: map[string]any{"error": "unexpected EOF"}}, want: true},
+		{name: "permanent error", err: logging.LogErrorInfo{Message: "Media item not found on the media server"}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isTransientError(tt.err); got != tt.want {
+				t.Errorf("isTransientError() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	previous := config.Current.DownloadQueue
+	t.Cleanup(func() { config.Current.DownloadQueue = previous })
+	config.Current.DownloadQueue.RetryDelaySeconds = 60
+	config.Current.DownloadQueue.MaxRetryDelaySeconds = 300
+
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{attempts: 0, want: time.Minute},
+		{attempts: 1, want: time.Minute},
+		{attempts: 2, want: 2 * time.Minute},
+		{attempts: 3, want: 4 * time.Minute},
+		{attempts: 4, want: 5 * time.Minute},
+		{attempts: 20, want: 5 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		if got := retryDelay(tt.attempts); got != tt.want {
+			t.Errorf("retryDelay(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
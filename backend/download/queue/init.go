@@ -1,12 +1,12 @@
 package downloadqueue
 
 import (
-	"aura/config"
+	"aura/database"
+	"aura/events"
 	"aura/logging"
-	"aura/utils"
+	"aura/models"
 	"context"
-	"os"
-	"path"
+	"sync"
 	"time"
 )
 
@@ -20,35 +20,68 @@ const (
 	LAST_STATUS_PROCESSING Status = "Processing"
 )
 
+type QueueInfo struct {
+	Time     time.Time
+	Status   Status
+	Message  string
+	Errors   []string
+	Warnings []string
+}
+
 var (
-	LatestInfo = struct {
-		Time     time.Time
-		Status   Status
-		Message  string
-		Errors   []string
-		Warnings []string
-	}{}
-
-	FolderPath string = ""
+	// LatestInfo is written by the queue workers, use GetLatestInfo and SetLatestInfo to access it
+	LatestInfo   QueueInfo
+	latestInfoMu sync.RWMutex
 )
 
+func GetLatestInfo() QueueInfo {
+	latestInfoMu.RLock()
+	defer latestInfoMu.RUnlock()
+	return LatestInfo
+}
+
+func SetLatestInfo(info QueueInfo) {
+	updateLatestInfo(func(latest *QueueInfo) { *latest = info })
+}
+
+func updateLatestInfo(update func(latest *QueueInfo)) {
+	latestInfoMu.Lock()
+	update(&LatestInfo)
+	info := LatestInfo
+	latestInfoMu.Unlock()
+
+	events.Publish(events.TypeDownloadQueueStatus, info)
+}
+
 type FileIssues struct {
 	Errors   []string
 	Warnings []string
 }
 
-func init() {
-	ctx, ld := logging.CreateLoggingContext(context.Background(), "Download Queue Init")
-	defer ld.Log()
-	logAction := ld.AddAction("Initializing Download Queue", logging.LevelTrace)
-	ctx = logging.WithCurrentAction(ctx, logAction)
+// Init prepares the Download Queue after the database has been initialized.
+// Entries left in "processing" by an interrupted run are queued again,
+// and entries from the old file based queue are moved into the database.
+func Init(ctx context.Context) (Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Initializing Download Queue", logging.LevelInfo)
 	defer logAction.Complete()
 
-	FolderPath = path.Join(config.ConfigPath, "download-queue")
+	Err = logging.LogErrorInfo{}
 
-	// Create the download queue folder if it doesn't exist
-	Err := utils.CreateFolderIfNotExists(ctx, FolderPath)
+	interrupted, Err := database.GetDownloadQueueEntries(ctx, models.DBDownloadQueueFilter{
+		Statuses: []string{models.DownloadQueueStatusProcessing},
+	})
 	if Err.Message != "" {
-		os.Exit(1)
+		return Err
+	}
+	for _, entry := range interrupted {
+		entry.Status = models.DownloadQueueStatusPending
+		Err = database.UpdateDownloadQueueEntry(ctx, entry)
+		if Err.Message != "" {
+			return Err
+		}
 	}
+	logAction.AppendResult("requeued_entries", len(interrupted))
+
+	importLegacyQueueFiles(ctx)
+	return Err
 }
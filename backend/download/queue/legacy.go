@@ -0,0 +1,91 @@
+package downloadqueue
+
+import (
+	"aura/config"
+	"aura/database"
+	"aura/logging"
+	"aura/models"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+)
+
+// importLegacyQueueFiles moves the entries of the old file based download queue into the database.
+// Files prefixed with "error_" or "warning_" keep their state, every other file is queued again.
+// Imported files are removed, and the folder is removed once it is empty.
+func importLegacyQueueFiles(ctx context.Context) {
+	folderPath := path.Join(config.ConfigPath, "download-queue")
+	files, err := os.ReadDir(folderPath)
+	if err != nil {
+		// No folder means there is nothing to import
+		return
+	}
+
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Importing Download Queue Files", logging.LevelInfo)
+	defer logAction.Complete()
+
+	imported := 0
+	for _, file := range files {
+		if file.IsDir() || path.Ext(file.Name()) != ".json" {
+			continue
+		}
+		filePath := path.Join(folderPath, file.Name())
+
+		data, err := os.ReadFile(filePath)
+		if err != nil {
+			logAction.AppendWarning(file.Name(), fmt.Sprintf("Failed to read file: %s", err.Error()))
+			continue
+		}
+
+		var item models.DBSavedItem
+		if err := json.Unmarshal(data, &item); err != nil {
+			logAction.AppendWarning(file.Name(), fmt.Sprintf("Failed to parse file: %s", err.Error()))
+			continue
+		}
+
+		id, Err := database.AddDownloadQueueEntry(ctx, item)
+		if Err.Message != "" {
+			logAction.AppendWarning(file.Name(), fmt.Sprintf("Failed to add entry to database: %s", Err.Message))
+			continue
+		}
+
+		status := ""
+		switch {
+		case strings.HasPrefix(file.Name(), "error_"):
+			status = models.DownloadQueueStatusFailed
+		case strings.HasPrefix(file.Name(), "warning_"):
+			status = models.DownloadQueueStatusWarning
+		}
+		if status != "" {
+			entry := models.DBDownloadQueueEntry{
+				ID:       id,
+				Status:   status,
+				Attempts: 1,
+				Item:     item,
+				Errors:   []string{},
+				Warnings: []string{},
+			}
+			message := "Imported from the file based download queue, details of the last attempt are not available"
+			if status == models.DownloadQueueStatusFailed {
+				entry.Errors = append(entry.Errors, message)
+			} else {
+				entry.Warnings = append(entry.Warnings, message)
+			}
+			if Err := database.UpdateDownloadQueueEntry(ctx, entry); Err.Message != "" {
+				logAction.AppendWarning(file.Name(), fmt.Sprintf("Failed to set entry status: %s", Err.Message))
+			}
+		}
+
+		if err := os.Remove(filePath); err != nil {
+			logAction.AppendWarning(file.Name(), fmt.Sprintf("Failed to remove imported file: %s", err.Error()))
+		}
+		imported++
+	}
+	logAction.AppendResult("imported_files", imported)
+
+	// os.Remove only removes empty folders
+	os.Remove(folderPath)
+}
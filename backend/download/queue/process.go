@@ -1,7 +1,9 @@
 package downloadqueue
 
 import (
+	"aura/config"
 	"aura/database"
+	"aura/events"
 	"aura/logging"
 	"aura/mediaserver"
 	"aura/mediux"
@@ -9,244 +11,362 @@ import (
 	sonarr_radarr "aura/sonarr-radarr"
 	"aura/utils"
 	"context"
-	"encoding/json"
 	"fmt"
-	"os"
-	"path"
+	"slices"
+	"sync"
+	"time"
 )
 
-func finalizeQueueFile(filePath, fileName string, hasErrors, hasWarnings bool) error {
-	if hasErrors {
-		return os.Rename(filePath, path.Join(FolderPath, fmt.Sprintf("error_%s", fileName)))
-	}
-	if hasWarnings {
-		return os.Rename(filePath, path.Join(FolderPath, fmt.Sprintf("warning_%s", fileName)))
-	}
-	return os.Remove(filePath)
-}
+// processMu prevents overlapping runs of the Download Queue job from processing the same entries
+var processMu sync.Mutex
 
 func ProcessQueueItems() {
+	if !processMu.TryLock() {
+		logging.LOGGER.Debug().Timestamp().Msg("Download Queue is already being processed, skipping this run")
+		return
+	}
+	defer processMu.Unlock()
+
 	ctx, ld := logging.CreateLoggingContext(context.Background(), "Download Queue Processing")
 	logAction := ld.AddAction("Processing Download Queue", logging.LevelInfo)
 	defer logAction.Complete()
 	ctx = logging.WithCurrentAction(ctx, logAction)
 
-	// Read all JSON files in the download-queue directory
-	files, err := os.ReadDir(FolderPath)
-	if err != nil {
-		logging.LOGGER.Warn().Timestamp().Err(err).Msg("Failed to read download queue directory")
-		logAction.SetError("Failed to read download queue directory", "Ensure the directory exists and is accessible",
-			map[string]any{
-				"error":      err.Error(),
-				"folderPath": FolderPath,
-			})
+	// Get all pending entries that are due, oldest first
+	now := time.Now()
+	entries, Err := database.GetDownloadQueueEntries(ctx, models.DBDownloadQueueFilter{
+		Statuses: []string{models.DownloadQueueStatusPending},
+		DueBy:    &now,
+	})
+	if Err.Message != "" {
+		logging.LOGGER.Warn().Timestamp().Str("error", Err.Message).Msg("Failed to get pending download queue entries")
 		return
 	}
 
-	if len(files) == 0 {
+	if len(entries) == 0 {
 		logAction.AppendResult("result", "queue is empty")
 		return
 	}
 
-	// Process each file in the directory
-	for _, file := range files {
-		if file.IsDir() || path.Ext(file.Name()) != ".json" {
-			continue
-		}
+	// Process the entries with a pool of workers, the oldest entries are picked up first
+	workers := min(max(config.Current.DownloadQueue.Workers, 1), len(entries))
+	logAction.AppendResult("entries", len(entries))
+	logAction.AppendResult("workers", workers)
+
+	entriesChan := make(chan models.DBDownloadQueueEntry)
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Go(func() {
+			for entry := range entriesChan {
+				processEntry(entry)
+			}
+		})
+	}
+	for _, entry := range entries {
+		entriesChan <- entry
+	}
+	close(entriesChan)
+	wg.Wait()
+}
 
-		// If a file starts with "error_" or "warning_", skip it
-		if len(file.Name()) > 6 && (file.Name()[:6] == "error_" || file.Name()[:8] == "warning_") {
-			continue
+// processEntry downloads and applies all images of a single Download Queue entry
+func processEntry(entry models.DBDownloadQueueEntry) {
+	ctx, ld := logging.CreateLoggingContext(context.Background(), "Download Queue - Processing")
+	subAction := ld.AddAction(fmt.Sprintf("Processing entry %d: %s", entry.ID, utils.MediaItemInfo(entry.Item.MediaItem)), logging.LevelInfo)
+	ctx = logging.WithCurrentAction(ctx, subAction)
+
+	// Reset the Latest Info for this entry
+	updateLatestInfo(func(latest *QueueInfo) {
+		latest.Status = LAST_STATUS_PROCESSING
+		latest.Message = fmt.Sprintf("Processing: %s", entry.Item.MediaItem.Title)
+		latest.Errors = []string{}
+		latest.Warnings = []string{}
+	})
+
+	// Images that were applied in an earlier attempt are not applied again, only the failed ones are retried
+	previousResults := map[string]models.DownloadQueueImageResult{}
+	for _, result := range entry.Results {
+		if result.Success {
+			previousResults[result.SetID+"|"+result.ImageID] = result
 		}
+	}
 
-		ctx, ld := logging.CreateLoggingContext(context.Background(), "Download Queue - Processing")
-		subAction := ld.AddAction(fmt.Sprintf("Processing file: %s", file.Name()), logging.LevelInfo)
-		ctx = logging.WithCurrentAction(ctx, subAction)
+	// Mark the entry as processing, this also clears the errors of the previous attempt.
+	// The image results are kept until this attempt gets to the images.
+	startedAt := time.Now().UTC()
+	entry.Status = models.DownloadQueueStatusProcessing
+	entry.Attempts++
+	entry.StartedAt = &startedAt
+	entry.FinishedAt = nil
+	entry.NextAttemptAt = nil
+	entry.Errors = []string{}
+	entry.Warnings = []string{}
+	if Err := database.UpdateDownloadQueueEntry(ctx, entry); Err.Message != "" {
+		ld.Log()
+		return
+	}
+	events.Publish(events.TypeDownloadQueueItemStarted, newQueueItemEvent(entry))
+
+	// Create an array of errors and warnings for this entry
+	fileErrors := []string{}
+	fileWarnings := []string{}
 
-		// Reset the Latest Info for this file
-		LatestInfo.Status = LAST_STATUS_PROCESSING
-		LatestInfo.Message = fmt.Sprintf("Processing file: %s", file.Name())
-		LatestInfo.Errors = []string{}
-		LatestInfo.Warnings = []string{}
+	queueItem := entry.Item
 
-		// Create an array of errors and warnings for this file
-		fileErrors := []string{}
-		fileWarnings := []string{}
+	// Set when an error might go away on its own (media server down, MediUX 5xx, timeouts)
+	transientFailure := false
+	willRetry := func() bool {
+		return transientFailure && entry.Attempts < config.Current.DownloadQueue.MaxAttempts
+	}
 
-		filePath := path.Join(FolderPath, file.Name())
+	// finalizeEntry stores the outcome of this attempt in the database.
+	// Entries that failed with a transient error are queued again with exponential backoff.
+	finalizeEntry := func() {
+		finishedAt := time.Now().UTC()
+		entry.Errors = fileErrors
+		entry.Warnings = fileWarnings
+		entry.FinishedAt = &finishedAt
+		switch {
+		case len(fileErrors) > 0 && willRetry():
+			nextAttemptAt := finishedAt.Add(retryDelay(entry.Attempts))
+			entry.Status = models.DownloadQueueStatusPending
+			entry.NextAttemptAt = &nextAttemptAt
+			subAction.AppendResult("next_attempt_at", nextAttemptAt)
+			logging.LOGGER.Warn().Timestamp().
+				Int64("id", entry.ID).
+				Int("attempt", entry.Attempts).
+				Int("max_attempts", config.Current.DownloadQueue.MaxAttempts).
+				Time("next_attempt_at", nextAttemptAt).
+				Msgf("Download queue entry for %s failed with a transient error, retrying later", utils.MediaItemInfo(queueItem.MediaItem))
+		case len(fileErrors) > 0:
+			entry.Status = models.DownloadQueueStatusFailed
+		case len(fileWarnings) > 0:
+			entry.Status = models.DownloadQueueStatusWarning
+		default:
+			entry.Status = models.DownloadQueueStatusSucceeded
+		}
+		if Err := database.UpdateDownloadQueueEntry(ctx, entry); Err.Message != "" {
+			subAction.AppendWarning(fmt.Sprintf("entry_%d", entry.ID), "Failed to store the result of the download queue entry")
+		}
+		events.Publish(events.TypeDownloadQueueItemFinished, newQueueItemEvent(entry))
+	}
 
-		finalizeAndNotify := func(
-			mediaItem models.MediaItem,
-			set models.DBPosterSetDetail,
-			tmdbPoster string,
-			tmdbBackdrop string,
-		) {
+	finalizeAndNotify := func(
+		mediaItem models.MediaItem,
+		set models.DBPosterSetDetail,
+		tmdbPoster string,
+		tmdbBackdrop string,
+	) {
+		// Only notify once the entry will not be retried anymore
+		if !(len(fileErrors) > 0 && willRetry()) {
 			issues := FileIssues{Errors: fileErrors, Warnings: fileWarnings}
 			SendNotification(issues, mediaItem, set, tmdbPoster, tmdbBackdrop)
-
-			if err := finalizeQueueFile(filePath, file.Name(), len(fileErrors) > 0, len(fileWarnings) > 0); err != nil {
-				subAction.AppendWarning(fmt.Sprintf("file_%s", file.Name()), "Failed to move or delete processed file")
-			}
-			ld.Log()
 		}
+		finalizeEntry()
+		ld.Log()
+	}
 
-		// Read and parse the JSON file
-		data, err := os.ReadFile(filePath)
-		if err != nil {
-			fileErrors = append(fileErrors, fmt.Sprintf("read file failed: %v", err))
-			finalizeAndNotify(models.MediaItem{}, models.DBPosterSetDetail{}, "", "")
-			continue
-		}
+	if queueItem.MediaItem.RatingKey == "" || queueItem.MediaItem.Title == "" || queueItem.MediaItem.LibraryTitle == "" || queueItem.MediaItem.TMDB_ID == "" {
+		fileErrors = append(fileErrors, "media item missing required fields: ratingKey/title/libraryTitle/tmdbId")
+		finalizeAndNotify(queueItem.MediaItem, models.DBPosterSetDetail{}, "", "")
+		return
+	}
 
-		var queueItem models.DBSavedItem
-		if err := json.Unmarshal(data, &queueItem); err != nil {
-			fileErrors = append(fileErrors, fmt.Sprintf("parse json failed: %v", err))
-			finalizeAndNotify(models.MediaItem{}, models.DBPosterSetDetail{}, "", "")
-			continue
-		}
+	if len(queueItem.PosterSets) == 0 {
+		fileWarnings = append(fileWarnings, "no poster sets found")
+		finalizeAndNotify(queueItem.MediaItem, models.DBPosterSetDetail{}, "", "")
+		return
+	}
 
-		if queueItem.MediaItem.RatingKey == "" || queueItem.MediaItem.Title == "" || queueItem.MediaItem.LibraryTitle == "" || queueItem.MediaItem.TMDB_ID == "" {
-			fileErrors = append(fileErrors, "media item missing required fields: ratingKey/title/libraryTitle/tmdbId")
-			finalizeAndNotify(queueItem.MediaItem, models.DBPosterSetDetail{}, "", "")
-			continue
-		}
+	mediuxItemInfo, mErr := mediux.GetBaseItemInfoByTMDB_ID(queueItem.MediaItem.TMDB_ID, queueItem.MediaItem.Type)
+	if mErr.Message != "" {
+		fileWarnings = append(fileWarnings, fmt.Sprintf("mediux lookup failed: %s", mErr.Message))
+	}
 
-		if len(queueItem.PosterSets) == 0 {
-			fileWarnings = append(fileWarnings, "no poster sets found")
-			finalizeAndNotify(queueItem.MediaItem, models.DBPosterSetDetail{}, "", "")
-			continue
-		}
+	found, mediaErr := mediaserver.GetMediaItemDetails(ctx, &queueItem.MediaItem)
+	if mediaErr.Message != "" || !found {
+		fileErrors = append(fileErrors, fmt.Sprintf("media server lookup failed for '%s' in '%s': %s", queueItem.MediaItem.Title, queueItem.MediaItem.LibraryTitle, mediaErr.Message))
+		// An unreachable media server is retried with backoff, an item that no longer exists fails immediately
+		transientFailure = isTransientError(mediaErr)
+		finalizeAndNotify(
+			queueItem.MediaItem,
+			models.DBPosterSetDetail{},
+			mediuxItemInfo.TMDB_PosterPath,
+			mediuxItemInfo.TMDB_BackdropPath,
+		)
+		return
+	}
 
-		mediuxItemInfo, mErr := mediux.GetBaseItemInfoByTMDB_ID(queueItem.MediaItem.TMDB_ID, queueItem.MediaItem.Type)
-		if mErr.Message != "" {
-			fileWarnings = append(fileWarnings, fmt.Sprintf("mediux lookup failed: %s", mErr.Message))
-		}
+	// Saved sets are applied to every media server holding the same TMDB ID
+	serverCopies := mediaserver.GetMediaItemCopiesOnOtherServers(ctx, queueItem.MediaItem)
+
+	attemptResults := []models.DownloadQueueImageResult{}
+	for _, posterSet := range queueItem.PosterSets {
+		setErrors := []string{}
+		setWarnings := []string{}
 
-		found, mediaErr := mediaserver.GetMediaItemDetails(ctx, &queueItem.MediaItem)
-		if mediaErr.Message != "" || !found {
-			fileErrors = append(fileErrors, fmt.Sprintf("media server lookup failed for '%s' in '%s': %s", queueItem.MediaItem.Title, queueItem.MediaItem.LibraryTitle, mediaErr.Message))
-			// Stop retry flood: mark file as error_ immediately
-			finalizeAndNotify(
+		if posterSet.ID == "" || posterSet.Type == "" || posterSet.Title == "" {
+			setErrors = append(setErrors, "poster set missing required fields: id/type/title")
+			fileErrors = append(fileErrors, setErrors...)
+			SendNotification(
+				FileIssues{Errors: setErrors, Warnings: setWarnings},
 				queueItem.MediaItem,
-				models.DBPosterSetDetail{},
+				posterSet,
 				mediuxItemInfo.TMDB_PosterPath,
 				mediuxItemInfo.TMDB_BackdropPath,
 			)
 			continue
 		}
 
-		for _, posterSet := range queueItem.PosterSets {
-			setErrors := []string{}
-			setWarnings := []string{}
-
-			if posterSet.ID == "" || posterSet.Type == "" || posterSet.Title == "" {
-				setErrors = append(setErrors, "poster set missing required fields: id/type/title")
-				fileErrors = append(fileErrors, setErrors...)
-				SendNotification(
-					FileIssues{Errors: setErrors, Warnings: setWarnings},
-					queueItem.MediaItem,
-					posterSet,
-					mediuxItemInfo.TMDB_PosterPath,
-					mediuxItemInfo.TMDB_BackdropPath,
-				)
-				continue
-			}
-
-			if !posterSet.SelectedTypes.Poster &&
-				!posterSet.SelectedTypes.Backdrop &&
-				!posterSet.SelectedTypes.SeasonPoster &&
-				!posterSet.SelectedTypes.SpecialSeasonPoster &&
-				!posterSet.SelectedTypes.Titlecard {
-				setWarnings = append(setWarnings, "poster set has no selected image types")
-				fileWarnings = append(fileWarnings, setWarnings...)
-				SendNotification(
-					FileIssues{Errors: setErrors, Warnings: setWarnings},
-					queueItem.MediaItem,
-					posterSet,
-					mediuxItemInfo.TMDB_PosterPath,
-					mediuxItemInfo.TMDB_BackdropPath,
-				)
-				continue
-			}
-
-			LatestInfo.Message = fmt.Sprintf("%s (Set: %s)", queueItem.MediaItem.Title, posterSet.ID)
+		if !posterSet.SelectedTypes.Any() {
+			setWarnings = append(setWarnings, "poster set has no selected image types")
+			fileWarnings = append(fileWarnings, setWarnings...)
+			SendNotification(
+				FileIssues{Errors: setErrors, Warnings: setWarnings},
+				queueItem.MediaItem,
+				posterSet,
+				mediuxItemInfo.TMDB_PosterPath,
+				mediuxItemInfo.TMDB_BackdropPath,
+			)
+			continue
+		}
 
-			for idx, image := range posterSet.Images {
-				switch image.Type {
-				case "poster":
-					if !posterSet.SelectedTypes.Poster {
-						continue
-					}
-				case "backdrop":
-					if !posterSet.SelectedTypes.Backdrop {
-						continue
-					}
-				case "season_poster":
-					if image.SeasonNumber == nil {
-						continue
-					}
-					// Check if the Media Item contains the season number for this image, if not skip it
-					mediaItemHasSeason := false
-					if queueItem.MediaItem.Series != nil {
-						for _, season := range queueItem.MediaItem.Series.Seasons {
-							if *image.SeasonNumber == season.SeasonNumber {
-								mediaItemHasSeason = true
-								break
-							}
-						}
-					}
-					if !mediaItemHasSeason {
-						continue
-					}
-					if *image.SeasonNumber == 0 {
-						if !posterSet.SelectedTypes.SpecialSeasonPoster {
-							continue
-						}
-					} else {
-						if !posterSet.SelectedTypes.SeasonPoster {
-							continue
+		updateLatestInfo(func(latest *QueueInfo) {
+			latest.Message = fmt.Sprintf("%s (Set: %s)", queueItem.MediaItem.Title, posterSet.ID)
+		})
+
+		// Select the images of this set that apply to the Media Item
+		images := []models.ImageFile{}
+		for idx, image := range posterSet.Images {
+			switch image.Type {
+			case "poster", "backdrop":
+				// Every Media Item has a poster and a backdrop
+			case "season_poster":
+				if image.SeasonNumber == nil {
+					continue
+				}
+				// Check if the Media Item contains the season number for this image, if not skip it
+				mediaItemHasSeason := false
+				if queueItem.MediaItem.Series != nil {
+					for _, season := range queueItem.MediaItem.Series.Seasons {
+						if *image.SeasonNumber == season.SeasonNumber {
+							mediaItemHasSeason = true
+							break
 						}
 					}
-				case "titlecard":
-					// Check if the Media Item contains the Season and Episode numbers for this image, if not skip it
-					mediaItemHasEpisode := false
-					if queueItem.MediaItem.Series != nil {
-						for _, season := range queueItem.MediaItem.Series.Seasons {
-							for _, episode := range season.Episodes {
-								if image.SeasonNumber != nil && *image.SeasonNumber != season.SeasonNumber {
-									continue
-								}
-								if image.EpisodeNumber != nil && *image.EpisodeNumber != episode.EpisodeNumber {
-									continue
-								}
-								mediaItemHasEpisode = true
-								break
+				}
+				if !mediaItemHasSeason {
+					continue
+				}
+			case "titlecard":
+				// Check if the Media Item contains the Season and Episode numbers for this image, if not skip it
+				mediaItemHasEpisode := false
+				if queueItem.MediaItem.Series != nil {
+					for _, season := range queueItem.MediaItem.Series.Seasons {
+						for _, episode := range season.Episodes {
+							if image.SeasonNumber != nil && *image.SeasonNumber != season.SeasonNumber {
+								continue
 							}
-							if mediaItemHasEpisode {
-								break
+							if image.EpisodeNumber != nil && *image.EpisodeNumber != episode.EpisodeNumber {
+								continue
 							}
+							mediaItemHasEpisode = true
+							break
+						}
+						if mediaItemHasEpisode {
+							break
 						}
 					}
-					if !mediaItemHasEpisode {
-						continue
+				}
+				if !mediaItemHasEpisode {
+					continue
+				}
+			default:
+				subAction.AppendWarning(fmt.Sprintf("entry_%d_image_%d", entry.ID, idx), fmt.Sprintf("Image has unrecognized type '%s'", image.Type))
+				fileWarnings = append(fileWarnings, fmt.Sprintf("Image '%s' has unrecognized type '%s'", image.Src, image.Type))
+				continue
+			}
+			if !posterSet.SelectedTypes.Includes(image) {
+				continue
+			}
+			images = append(images, image)
+		}
+
+		// Apply the selected images in parallel, the outcome of every image is stored at its own index
+		results := make([]models.DownloadQueueImageResult, len(images))
+		imageErrors := make([]string, len(images))
+		imageWarnings := make([]string, len(images))
+		imageTransient := make([]bool, len(images))
+
+		imageSlots := make(chan struct{}, max(config.Current.DownloadQueue.ImageWorkers, 1))
+		var wg sync.WaitGroup
+		for i, image := range images {
+			imageSlots <- struct{}{}
+			wg.Go(func() {
+				defer func() { <-imageSlots }()
+				defer func() {
+					events.Publish(events.TypeDownloadQueueImageResult, events.DownloadQueueImage{
+						EntryID: entry.ID,
+						Title:   queueItem.MediaItem.Title,
+						Result:  results[i],
+					})
+				}()
+
+				results[i] = models.DownloadQueueImageResult{
+					SetID:         posterSet.ID,
+					ImageID:       image.ID,
+					ImageType:     image.Type,
+					SeasonNumber:  image.SeasonNumber,
+					EpisodeNumber: image.EpisodeNumber,
+				}
+
+				// Every worker gets its own copy, the media server clients may fill in missing details
+				mediaItem := queueItem.MediaItem
+				downloadFileName := utils.GetFileDownloadName(mediaItem.Title, image)
+				if previous, applied := previousResults[posterSet.ID+"|"+image.ID]; applied {
+					// Applied in an earlier attempt, only the server copies are tried again when they failed then
+					results[i] = previous
+					if previous.Message == "" {
+						return
 					}
-					if !posterSet.SelectedTypes.Titlecard {
-						continue
+					results[i].Message = ""
+				} else {
+					Err := mediaserver.DownloadApplyImageToMediaItem(ctx, &mediaItem, image)
+					if Err.Message != "" {
+						imageErrors[i] = fmt.Sprintf("%s: %s", downloadFileName, Err.Message)
+						imageTransient[i] = isTransientError(Err)
+						results[i].Message = Err.Message
+						return
 					}
-				default:
-					subAction.AppendWarning(fmt.Sprintf("file_%s_image_%d", file.Name(), idx), fmt.Sprintf("Image has unrecognized type '%s'", image.Type))
-					fileWarnings = append(fileWarnings, fmt.Sprintf("Image '%s' has unrecognized type '%s'", image.Src, image.Type))
-					continue
+					results[i].Success = true
 				}
 
-				downloadFileName := utils.GetFileDownloadName(queueItem.MediaItem.Title, image)
-				Err := mediaserver.DownloadApplyImageToMediaItem(ctx, &queueItem.MediaItem, image)
-				if Err.Message != "" {
-					setErrors = append(setErrors, fmt.Sprintf("%s: %s", downloadFileName, Err.Message))
+				// Apply the same image to the copies of this item on the other media servers
+				if len(serverCopies) > 0 {
+					applied, copiesErr := mediaserver.DownloadApplyImageToServerCopies(ctx, slices.Clone(serverCopies), image)
+					results[i].ServerCopiesApplied = applied
+					if copiesErr.Message != "" {
+						imageWarnings[i] = fmt.Sprintf("%s: %s", downloadFileName, copiesErr.Message)
+						results[i].Message = copiesErr.Message
+					}
 				}
+			})
+		}
+		wg.Wait()
+
+		for i := range images {
+			if imageErrors[i] != "" {
+				setErrors = append(setErrors, imageErrors[i])
+			}
+			if imageWarnings[i] != "" {
+				setWarnings = append(setWarnings, imageWarnings[i])
 			}
+			transientFailure = transientFailure || imageTransient[i]
+		}
+		attemptResults = append(attemptResults, results...)
 
-			// Per-set notification (success/warning/error)
+		// Per-set notification (success/warning/error), skipped when the failed images are retried later
+		if !(len(setErrors) > 0 && willRetry()) {
 			SendNotification(
 				FileIssues{Errors: setErrors, Warnings: setWarnings},
 				queueItem.MediaItem,
@@ -254,46 +374,71 @@ func ProcessQueueItems() {
 				mediuxItemInfo.TMDB_PosterPath,
 				mediuxItemInfo.TMDB_BackdropPath,
 			)
-
-			fileErrors = append(fileErrors, setErrors...)
-			fileWarnings = append(fileWarnings, setWarnings...)
 		}
 
-		Err := database.UpsertSavedItem(ctx, queueItem)
-		if Err.Message != "" {
-			fileErrors = append(fileErrors, fmt.Sprintf("db upsert failed: %s", Err.Message))
-			finalizeAndNotify(
-				queueItem.MediaItem,
-				models.DBPosterSetDetail{},
-				mediuxItemInfo.TMDB_PosterPath,
-				mediuxItemInfo.TMDB_BackdropPath,
-			)
-			continue
-		}
+		fileErrors = append(fileErrors, setErrors...)
+		fileWarnings = append(fileWarnings, setWarnings...)
+	}
+	entry.Results = attemptResults
+
+	// The set is only saved and labelled once its images are on the media server,
+	// a later attempt does this when the failed images are retried
+	if len(fileErrors) > 0 && willRetry() {
+		finalizeEntry()
+		ld.Log()
+		return
+	}
 
-		if err := finalizeQueueFile(filePath, file.Name(), len(fileErrors) > 0, len(fileWarnings) > 0); err != nil {
-			fileWarnings = append(fileWarnings, fmt.Sprintf("finalize file failed: %v", err))
+	Err := database.UpsertSavedItem(ctx, queueItem)
+	if Err.Message != "" {
+		fileErrors = append(fileErrors, fmt.Sprintf("db upsert failed: %s", Err.Message))
+		finalizeAndNotify(
+			queueItem.MediaItem,
+			models.DBPosterSetDetail{},
+			mediuxItemInfo.TMDB_PosterPath,
+			mediuxItemInfo.TMDB_BackdropPath,
+		)
+		return
+	}
+
+	finalizeEntry()
+
+	// Handle any labels and tags asynchronously
+	go func() {
+		ctx, ld := logging.CreateLoggingContext(context.Background(), "Download Queue - Labels and Tags Handling")
+		logAction := ld.AddAction("Handle Labels and Tags for Added Item", logging.LevelInfo)
+		ctx = logging.WithCurrentAction(ctx, logAction)
+		defer ld.Log()
+		selectedTypes := models.SelectedTypes{}
+		for _, posterSet := range queueItem.PosterSets {
+			selectedTypes.Poster = selectedTypes.Poster || posterSet.SelectedTypes.Poster
+			selectedTypes.Backdrop = selectedTypes.Backdrop || posterSet.SelectedTypes.Backdrop
+			selectedTypes.SeasonPoster = selectedTypes.SeasonPoster || posterSet.SelectedTypes.SeasonPoster
+			selectedTypes.SpecialSeasonPoster = selectedTypes.SpecialSeasonPoster || posterSet.SelectedTypes.SpecialSeasonPoster
+			selectedTypes.Titlecard = selectedTypes.Titlecard || posterSet.SelectedTypes.Titlecard
 		}
 
-		// Handle any labels and tags asynchronously
-		go func() {
-			ctx, ld := logging.CreateLoggingContext(context.Background(), "Download Queue - Labels and Tags Handling")
-			logAction := ld.AddAction("Handle Labels and Tags for Added Item", logging.LevelInfo)
-			ctx = logging.WithCurrentAction(ctx, logAction)
-			defer ld.Log()
-			selectedTypes := models.SelectedTypes{}
-			for _, posterSet := range queueItem.PosterSets {
-				selectedTypes.Poster = selectedTypes.Poster || posterSet.SelectedTypes.Poster
-				selectedTypes.Backdrop = selectedTypes.Backdrop || posterSet.SelectedTypes.Backdrop
-				selectedTypes.SeasonPoster = selectedTypes.SeasonPoster || posterSet.SelectedTypes.SeasonPoster
-				selectedTypes.SpecialSeasonPoster = selectedTypes.SpecialSeasonPoster || posterSet.SelectedTypes.SpecialSeasonPoster
-				selectedTypes.Titlecard = selectedTypes.Titlecard || posterSet.SelectedTypes.Titlecard
-			}
+		mediaserver.AddLabelToMediaItem(ctx, queueItem.MediaItem, selectedTypes)
+		for _, serverCopy := range serverCopies {
+			mediaserver.AddLabelToMediaItem(ctx, serverCopy, selectedTypes)
+		}
+		sonarr_radarr.HandleTags(ctx, queueItem.MediaItem, selectedTypes)
+	}()
 
-			mediaserver.AddLabelToMediaItem(ctx, queueItem.MediaItem, selectedTypes)
-			sonarr_radarr.HandleTags(ctx, queueItem.MediaItem, selectedTypes)
-		}()
+	ld.Log()
+}
 
-		ld.Log()
+// newQueueItemEvent returns the data of the Download Queue item events
+func newQueueItemEvent(entry models.DBDownloadQueueEntry) events.DownloadQueueItem {
+	return events.DownloadQueueItem{
+		ID:            entry.ID,
+		Title:         entry.Item.MediaItem.Title,
+		LibraryTitle:  entry.Item.MediaItem.LibraryTitle,
+		Server:        entry.Item.MediaItem.Server,
+		Status:        entry.Status,
+		Attempts:      entry.Attempts,
+		Errors:        entry.Errors,
+		Warnings:      entry.Warnings,
+		NextAttemptAt: entry.NextAttemptAt,
 	}
 }
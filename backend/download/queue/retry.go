@@ -0,0 +1,67 @@
+package downloadqueue
+
+import (
+	"aura/database"
+	"aura/logging"
+	"aura/models"
+	"context"
+	"fmt"
+)
+
+// RetryQueueEntry queues a failed or warning entry again, or moves a scheduled retry forward.
+// The entry is picked up by the next run of the Download Queue job.
+func RetryQueueEntry(ctx context.Context, id int64) (entry models.DBDownloadQueueEntry, Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf("Retry Download Queue Entry %d", id), logging.LevelInfo)
+	defer logAction.Complete()
+
+	entries, Err := database.GetDownloadQueueEntries(ctx, models.DBDownloadQueueFilter{ID: id})
+	if Err.Message != "" {
+		return entry, Err
+	}
+	if len(entries) == 0 {
+		logAction.SetError("Download Queue entry not found", "Ensure that the ID belongs to an entry in the download queue", map[string]any{
+			"id": id,
+		})
+		return entry, *logAction.Error
+	}
+
+	entry = entries[0]
+	scheduledRetry := entry.Status == models.DownloadQueueStatusPending && entry.NextAttemptAt != nil
+	if entry.Status != models.DownloadQueueStatusFailed && entry.Status != models.DownloadQueueStatusWarning && !scheduledRetry {
+		logAction.SetError("Only failed, warning or scheduled retry entries can be retried", fmt.Sprintf("The entry is currently '%s'", entry.Status), map[string]any{
+			"id":     id,
+			"status": entry.Status,
+		})
+		return entry, *logAction.Error
+	}
+
+	// A manual retry does not wait for the backoff delay
+	entry.Status = models.DownloadQueueStatusPending
+	entry.NextAttemptAt = nil
+	Err = database.UpdateDownloadQueueEntry(ctx, entry)
+	if Err.Message != "" {
+		return entry, Err
+	}
+
+	logAction.AppendResult("status", entry.Status)
+	return entry, Err
+}
+
+// GetScheduledRetries returns the pending entries that are waiting for a retry after a transient failure
+func GetScheduledRetries(ctx context.Context) (entries []models.DBDownloadQueueEntry, Err logging.LogErrorInfo) {
+	entries = []models.DBDownloadQueueEntry{}
+
+	pending, Err := database.GetDownloadQueueEntries(ctx, models.DBDownloadQueueFilter{
+		Statuses: []string{models.DownloadQueueStatusPending},
+	})
+	if Err.Message != "" {
+		return entries, Err
+	}
+
+	for _, entry := range pending {
+		if entry.NextAttemptAt != nil {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, Err
+}
@@ -74,11 +74,13 @@ func SendNotification(
 	}
 
 	// Update the Global LatestInfo
-	LatestInfo.Time = time.Now()
-	LatestInfo.Status = result
-	LatestInfo.Message = fmt.Sprintf("%s (Set: %s)", mediaItem.Title, posterSet.ID)
-	LatestInfo.Errors = fileIssues.Errors
-	LatestInfo.Warnings = fileIssues.Warnings
+	SetLatestInfo(QueueInfo{
+		Time:     time.Now(),
+		Status:   result,
+		Message:  fmt.Sprintf("%s (Set: %s)", mediaItem.Title, posterSet.ID),
+		Errors:   fileIssues.Errors,
+		Warnings: fileIssues.Warnings,
+	})
 
 	ctx, ld := logging.CreateLoggingContext(context.Background(), "Notification - Send Download Queue Update")
 	logAction := ld.AddAction("Sending Download Queue Notification", logging.LevelInfo)
@@ -0,0 +1,134 @@
+package events
+
+import (
+	"aura/models"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Event types streamed by /api/events
+const (
+	TypeDownloadQueueStatus       = "download_queue.status"        // Latest status of the Download Queue changed
+	TypeDownloadQueueItemStarted  = "download_queue.item_started"  // A queue entry started processing
+	TypeDownloadQueueImageResult  = "download_queue.image_result"  // A single image of a queue entry was applied or failed
+	TypeDownloadQueueItemFinished = "download_queue.item_finished" // A queue entry finished processing
+	TypeJobStarted                = "job.started"                  // A job started running
+	TypeJobProgress               = "job.progress"                 // A job finished one of its items
+	TypeJobFinished               = "job.finished"                 // A job finished running
+	TypeSavedSetsChanged          = "saved_sets.changed"           // A saved set was added, updated or removed
+)
+
+// subscriberBufferSize is the number of events a slow subscriber can fall behind before events are dropped
+const subscriberBufferSize = 64
+
+type Event struct {
+	Type string    `json:"type"`
+	Time time.Time `json:"time"`
+	Data any       `json:"data"`
+}
+
+// DownloadQueueItem is the data of the download_queue.item_started and download_queue.item_finished events
+type DownloadQueueItem struct {
+	ID            int64      `json:"id"`
+	Title         string     `json:"title"`
+	LibraryTitle  string     `json:"library_title"`
+	Server        string     `json:"server,omitempty"`
+	Status        string     `json:"status"`
+	Attempts      int        `json:"attempts"`
+	Errors        []string   `json:"errors,omitempty"`
+	Warnings      []string   `json:"warnings,omitempty"`
+	NextAttemptAt *time.Time `json:"next_attempt_at,omitempty"`
+}
+
+// DownloadQueueImage is the data of the download_queue.image_result event
+type DownloadQueueImage struct {
+	EntryID int64                           `json:"entry_id"`
+	Title   string                          `json:"title"`
+	Result  models.DownloadQueueImageResult `json:"result"`
+}
+
+// JobProgress is the data of the job.started, job.progress and job.finished events
+type JobProgress struct {
+	Job          string `json:"job"`
+	Item         string `json:"item,omitempty"`   // Item that was just processed
+	Result       string `json:"result,omitempty"` // Result of the item that was just processed
+	Total        int    `json:"total"`
+	Processed    int    `json:"processed"`
+	SuccessCount int    `json:"success_count"`
+	WarningCount int    `json:"warning_count"`
+	ErrorCount   int    `json:"error_count"`
+	SkippedCount int    `json:"skipped_count"`
+}
+
+// SavedSetsChange is the data of the saved_sets.changed event.
+// Both fields are empty when the whole database changed (e.g. a restored backup).
+type SavedSetsChange struct {
+	TMDB_ID      string `json:"tmdb_id,omitempty"`
+	LibraryTitle string `json:"library_title,omitempty"`
+}
+
+type subscriber struct {
+	ch       chan Event
+	prefixes []string
+}
+
+var (
+	subscribersMu sync.RWMutex
+	subscribers   = map[*subscriber]struct{}{}
+)
+
+// Subscribe returns a channel that receives every published event whose type starts with one of the prefixes.
+// No prefixes subscribes to all events. The returned function must be called to stop the subscription.
+//
+// Events are dropped for subscribers that do not keep up, publishing never blocks.
+func Subscribe(prefixes ...string) (<-chan Event, func()) {
+	sub := &subscriber{
+		ch:       make(chan Event, subscriberBufferSize),
+		prefixes: prefixes,
+	}
+
+	subscribersMu.Lock()
+	subscribers[sub] = struct{}{}
+	subscribersMu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			subscribersMu.Lock()
+			delete(subscribers, sub)
+			close(sub.ch)
+			subscribersMu.Unlock()
+		})
+	}
+	return sub.ch, unsubscribe
+}
+
+// Publish sends an event to all matching subscribers
+func Publish(eventType string, data any) {
+	event := Event{Type: eventType, Time: time.Now(), Data: data}
+
+	subscribersMu.RLock()
+	defer subscribersMu.RUnlock()
+	for sub := range subscribers {
+		if !sub.matches(eventType) {
+			continue
+		}
+		select {
+		case sub.ch <- event:
+		default:
+		}
+	}
+}
+
+func (s *subscriber) matches(eventType string) bool {
+	if len(s.prefixes) == 0 {
+		return true
+	}
+	for _, prefix := range s.prefixes {
+		if strings.HasPrefix(eventType, prefix) {
+			return true
+		}
+	}
+	return false
+}
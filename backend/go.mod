@@ -2,9 +2,15 @@ module aura
 
 go 1.25.5
 
-require github.com/rs/zerolog v1.34.0
+require (
+	github.com/go-sql-driver/mysql v1.10.1
+	github.com/lib/pq v1.12.3
+	github.com/rs/zerolog v1.34.0
+	golang.org/x/time v0.15.0
+)
 
 require (
+	filippo.io/edwards25519 v1.2.0 // indirect
 	github.com/KyleBanks/depth v1.2.1 // indirect
 	github.com/decred/dcrd/dcrec/secp256k1/v4 v4.4.1 // indirect
 	github.com/go-openapi/jsonpointer v0.22.5 // indirect
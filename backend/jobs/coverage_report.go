@@ -0,0 +1,81 @@
+package jobs
+
+import (
+	"aura/config"
+	"aura/logging"
+	"aura/report"
+	"context"
+	"runtime/debug"
+)
+
+func StartCoverageReportJob() error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if c == nil {
+		logging.LOGGER.Error().Timestamp().Msg("Cron Jobs Scheduler is not initialized")
+		return nil
+	}
+
+	if coverageReportJobID != 0 {
+		c.Remove(coverageReportJobID)
+		coverageReportJobID = 0
+	}
+
+	if !config.Current.CoverageReport.Enabled {
+		logging.LOGGER.Info().Timestamp().Msg("Coverage Report Job Stopped")
+		return nil
+	}
+
+	spec := config.Current.CoverageReport.Cron
+	if spec == "" {
+		spec = "0 3 * * *" // Default to every day at 03:00
+	}
+
+	var err error
+	coverageReportJobID, err = c.AddFunc(spec, func() {
+		defer func() {
+			if r := recover(); r != nil {
+				logging.LOGGER.Error().
+					Timestamp().
+					Interface("recover", r).
+					Str("stack", string(debug.Stack())).
+					Msg("PANIC: in scheduled Coverage Report Job")
+			}
+		}()
+		ctx, ld := logging.CreateLoggingContext(context.Background(), "Cron Job")
+		action := ld.AddAction("Coverage Report", logging.LevelInfo)
+		ctx = logging.WithCurrentAction(ctx, action)
+		coverage, Err := report.BuildCoverageReport(ctx)
+		if Err.Message == "" {
+			fileName, err := report.SaveCoverageReport(coverage)
+			if err != nil {
+				action.SetError("Failed to save the Coverage Report", "Make sure the config folder is writable", map[string]any{
+					"error": err.Error(),
+				})
+				Err = *action.Error
+			} else {
+				action.AppendResult("file_name", fileName)
+			}
+		}
+		if Err.Message != "" {
+			logging.LOGGER.Error().Timestamp().Str("error", Err.Message).
+				Str("next_run", c.Entry(coverageReportJobID).Next.String()).
+				Msg("Error running Coverage Report Job")
+		} else {
+			logging.LOGGER.Info().Timestamp().
+				Str("next_run", c.Entry(coverageReportJobID).Next.String()).
+				Msg("Coverage Report Job Completed")
+		}
+		ld.Log()
+	})
+	if err != nil {
+		return err
+	}
+	jobSpecs[coverageReportJobID] = spec
+
+	logging.LOGGER.Info().Timestamp().
+		Str("cron", spec).
+		Msg("Coverage Report Job Started")
+	return nil
+}
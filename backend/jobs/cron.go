@@ -24,7 +24,12 @@ var (
 	handleTempIgnoredItemsJobID          cron.EntryID = 0
 
 	// Configurable
-	autodownloadJobID cron.EntryID = 0
+	autodownloadJobID       cron.EntryID = 0
+	databaseBackupJobID     cron.EntryID = 0
+	subscribedCreatorsJobID cron.EntryID = 0
+	coverageReportJobID     cron.EntryID = 0
+	driftDetectionJobID     cron.EntryID = 0
+	kometaExportJobID       cron.EntryID = 0
 )
 
 var manualPrevRun = map[cron.EntryID]string{}
@@ -96,6 +101,16 @@ func GetListOfJobs() []JobInfo {
 				jobInfo.JobName = "Check for Media Item Changes Job"
 			case handleTempIgnoredItemsJobID:
 				jobInfo.JobName = "Handle Temp Ignored Items Job"
+			case databaseBackupJobID:
+				jobInfo.JobName = "Database Backup Job"
+			case subscribedCreatorsJobID:
+				jobInfo.JobName = "Subscribed Creators Job"
+			case coverageReportJobID:
+				jobInfo.JobName = "Coverage Report Job"
+			case driftDetectionJobID:
+				jobInfo.JobName = "Drift Detection Job"
+			case kometaExportJobID:
+				jobInfo.JobName = "Kometa Export Job"
 			default:
 				jobInfo.JobName = "Unknown Job"
 			}
@@ -125,6 +140,16 @@ func TriggerJob(jobName string, jobID string) error {
 		entryID = checkForMediaItemChangesJobID
 	case "Handle Temp Ignored Items Job":
 		entryID = handleTempIgnoredItemsJobID
+	case "Database Backup Job":
+		entryID = databaseBackupJobID
+	case "Subscribed Creators Job":
+		entryID = subscribedCreatorsJobID
+	case "Coverage Report Job":
+		entryID = coverageReportJobID
+	case "Drift Detection Job":
+		entryID = driftDetectionJobID
+	case "Kometa Export Job":
+		entryID = kometaExportJobID
 	default:
 		return fmt.Errorf("unknown job name: %s", jobName)
 	}
@@ -0,0 +1,81 @@
+package jobs
+
+import (
+	"aura/config"
+	"aura/database"
+	"aura/logging"
+	"context"
+	"runtime/debug"
+)
+
+func StartDatabaseBackupJob() error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if c == nil {
+		logging.LOGGER.Error().Timestamp().Msg("Cron Jobs Scheduler is not initialized")
+		return nil
+	}
+
+	if databaseBackupJobID != 0 {
+		c.Remove(databaseBackupJobID)
+		delete(jobSpecs, databaseBackupJobID)
+		databaseBackupJobID = 0
+	}
+
+	backupConfig := config.Current.Database.Backup
+	if !backupConfig.Enabled {
+		logging.LOGGER.Info().Timestamp().Msg("Database Backup Job Stopped")
+		return nil
+	}
+
+	if config.Current.Database.Type != "sqlite3" {
+		logging.LOGGER.Warn().Timestamp().
+			Str("type", config.Current.Database.Type).
+			Msg("Database Backup Job is only supported for sqlite3, not starting")
+		return nil
+	}
+
+	spec := backupConfig.Cron
+	if spec == "" {
+		spec = "0 3 * * *" // Default to daily at 3 AM
+	}
+
+	var err error
+	databaseBackupJobID, err = c.AddFunc(spec, func() {
+		defer func() {
+			if r := recover(); r != nil {
+				logging.LOGGER.Error().
+					Timestamp().
+					Interface("recover", r).
+					Str("stack", string(debug.Stack())).
+					Msg("PANIC: in scheduled Database Backup Job")
+			}
+		}()
+		ctx, ld := logging.CreateLoggingContext(context.Background(), "Cron Job")
+		action := ld.AddAction("Database Backup", logging.LevelInfo)
+		ctx = logging.WithCurrentAction(ctx, action)
+		backup, Err := database.CreateScheduledBackup(ctx)
+		if Err.Message != "" {
+			logging.LOGGER.Error().Timestamp().Str("error", Err.Message).
+				Str("next_run", c.Entry(databaseBackupJobID).Next.String()).
+				Msg("Error running Database Backup Job")
+		} else {
+			logging.LOGGER.Info().Timestamp().
+				Str("backup", backup.Name).
+				Str("next_run", c.Entry(databaseBackupJobID).Next.String()).
+				Msg("Database Backup Job Completed")
+		}
+		ld.Log()
+	})
+	if err != nil {
+		return err
+	}
+	jobSpecs[databaseBackupJobID] = spec
+
+	logging.LOGGER.Info().Timestamp().
+		Str("cron", spec).
+		Int("retention", backupConfig.Retention).
+		Msg("Database Backup Job Started")
+	return nil
+}
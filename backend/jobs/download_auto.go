@@ -48,7 +48,7 @@ func StartAutoDownloadJob() error {
 		ctx, ld := logging.CreateLoggingContext(context.Background(), "Cron Job")
 		action := ld.AddAction("AutoDownload Check", logging.LevelInfo)
 		ctx = logging.WithCurrentAction(ctx, action)
-		Err := autodownload.CheckAllItems(ctx)
+		Err := autodownload.CheckAllItems(ctx, config.Current.AutoDownload.DryRun)
 		if Err.Message != "" {
 			logging.LOGGER.Error().Timestamp().Str("error", Err.Message).
 				Str("next_run", c.Entry(autodownloadJobID).Next.String()).
@@ -98,7 +98,7 @@ func RunAutoDownloadJobNow() {
 		ctx, ld := logging.CreateLoggingContext(context.Background(), "Manual Job Run")
 		action := ld.AddAction("AutoDownload Check", logging.LevelInfo)
 		ctx = logging.WithCurrentAction(ctx, action)
-		Err := autodownload.CheckAllItems(ctx)
+		Err := autodownload.CheckAllItems(ctx, config.Current.AutoDownload.DryRun)
 		if Err.Message != "" {
 			logging.LOGGER.Error().Timestamp().Str("error", Err.Message).
 				Str("next_run", c.Entry(autodownloadJobID).Next.String()).
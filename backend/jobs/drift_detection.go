@@ -0,0 +1,70 @@
+package jobs
+
+import (
+	"aura/config"
+	"aura/download/drift"
+	"aura/logging"
+	"context"
+	"runtime/debug"
+)
+
+func StartDriftDetectionJob() error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if c == nil {
+		logging.LOGGER.Error().Timestamp().Msg("Cron Jobs Scheduler is not initialized")
+		return nil
+	}
+
+	if driftDetectionJobID != 0 {
+		c.Remove(driftDetectionJobID)
+		driftDetectionJobID = 0
+	}
+
+	if !config.Current.DriftDetection.Enabled {
+		logging.LOGGER.Info().Timestamp().Msg("Drift Detection Job Stopped")
+		return nil
+	}
+
+	spec := config.Current.DriftDetection.Cron
+	if spec == "" {
+		spec = "0 4 * * *" // Default to every day at 04:00
+	}
+
+	var err error
+	driftDetectionJobID, err = c.AddFunc(spec, func() {
+		defer func() {
+			if r := recover(); r != nil {
+				logging.LOGGER.Error().
+					Timestamp().
+					Interface("recover", r).
+					Str("stack", string(debug.Stack())).
+					Msg("PANIC: in scheduled Drift Detection Job")
+			}
+		}()
+		ctx, ld := logging.CreateLoggingContext(context.Background(), "Cron Job")
+		action := ld.AddAction("Drift Detection", logging.LevelInfo)
+		ctx = logging.WithCurrentAction(ctx, action)
+		_, Err := drift.CheckImageDrift(ctx)
+		if Err.Message != "" {
+			logging.LOGGER.Error().Timestamp().Str("error", Err.Message).
+				Str("next_run", c.Entry(driftDetectionJobID).Next.String()).
+				Msg("Error running Drift Detection Job")
+		} else {
+			logging.LOGGER.Info().Timestamp().
+				Str("next_run", c.Entry(driftDetectionJobID).Next.String()).
+				Msg("Drift Detection Job Completed")
+		}
+		ld.Log()
+	})
+	if err != nil {
+		return err
+	}
+	jobSpecs[driftDetectionJobID] = spec
+
+	logging.LOGGER.Info().Timestamp().
+		Str("cron", spec).
+		Msg("Drift Detection Job Started")
+	return nil
+}
@@ -0,0 +1,70 @@
+package jobs
+
+import (
+	"aura/config"
+	"aura/kometa"
+	"aura/logging"
+	"context"
+	"runtime/debug"
+)
+
+func StartKometaExportJob() error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if c == nil {
+		logging.LOGGER.Error().Timestamp().Msg("Cron Jobs Scheduler is not initialized")
+		return nil
+	}
+
+	if kometaExportJobID != 0 {
+		c.Remove(kometaExportJobID)
+		kometaExportJobID = 0
+	}
+
+	if !config.Current.Kometa.Enabled {
+		logging.LOGGER.Info().Timestamp().Msg("Kometa Export Job Stopped")
+		return nil
+	}
+
+	spec := config.Current.Kometa.Cron
+	if spec == "" {
+		spec = "0 5 * * *" // Default to every day at 05:00
+	}
+
+	var err error
+	kometaExportJobID, err = c.AddFunc(spec, func() {
+		defer func() {
+			if r := recover(); r != nil {
+				logging.LOGGER.Error().
+					Timestamp().
+					Interface("recover", r).
+					Str("stack", string(debug.Stack())).
+					Msg("PANIC: in scheduled Kometa Export Job")
+			}
+		}()
+		ctx, ld := logging.CreateLoggingContext(context.Background(), "Cron Job")
+		action := ld.AddAction("Kometa Export", logging.LevelInfo)
+		ctx = logging.WithCurrentAction(ctx, action)
+		_, Err := kometa.Export(ctx)
+		if Err.Message != "" {
+			logging.LOGGER.Error().Timestamp().Str("error", Err.Message).
+				Str("next_run", c.Entry(kometaExportJobID).Next.String()).
+				Msg("Error running Kometa Export Job")
+		} else {
+			logging.LOGGER.Info().Timestamp().
+				Str("next_run", c.Entry(kometaExportJobID).Next.String()).
+				Msg("Kometa Export Job Completed")
+		}
+		ld.Log()
+	})
+	if err != nil {
+		return err
+	}
+	jobSpecs[kometaExportJobID] = spec
+
+	logging.LOGGER.Info().Timestamp().
+		Str("cron", spec).
+		Msg("Kometa Export Job Started")
+	return nil
+}
@@ -1,6 +1,7 @@
 package jobs
 
 import (
+	"aura/download/autoselect"
 	"aura/logging"
 	"aura/mediaserver"
 	"context"
@@ -32,6 +33,7 @@ func StartRefreshMediaItemsAndCollectionsJob() error {
 		action := ld.AddAction("Refresh Media Items and Collections", logging.LevelInfo)
 		ctx = logging.WithCurrentAction(ctx, action)
 		mediaserver.GetAllLibrarySectionsAndItems(ctx, true)
+		autoselect.CheckNewItems(ctx)
 		ld.Log()
 	})
 	if err != nil {
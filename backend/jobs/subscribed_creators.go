@@ -0,0 +1,70 @@
+package jobs
+
+import (
+	"aura/config"
+	"aura/download/creators"
+	"aura/logging"
+	"context"
+	"runtime/debug"
+)
+
+func StartSubscribedCreatorsJob() error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if c == nil {
+		logging.LOGGER.Error().Timestamp().Msg("Cron Jobs Scheduler is not initialized")
+		return nil
+	}
+
+	if subscribedCreatorsJobID != 0 {
+		c.Remove(subscribedCreatorsJobID)
+		subscribedCreatorsJobID = 0
+	}
+
+	if !config.Current.SubscribedCreators.Enabled {
+		logging.LOGGER.Info().Timestamp().Msg("Subscribed Creators Job Stopped")
+		return nil
+	}
+
+	spec := config.Current.SubscribedCreators.Cron
+	if spec == "" {
+		spec = "0 */6 * * *" // Default to every 6 hours
+	}
+
+	var err error
+	subscribedCreatorsJobID, err = c.AddFunc(spec, func() {
+		defer func() {
+			if r := recover(); r != nil {
+				logging.LOGGER.Error().
+					Timestamp().
+					Interface("recover", r).
+					Str("stack", string(debug.Stack())).
+					Msg("PANIC: in scheduled Subscribed Creators Job")
+			}
+		}()
+		ctx, ld := logging.CreateLoggingContext(context.Background(), "Cron Job")
+		action := ld.AddAction("Subscribed Creators Check", logging.LevelInfo)
+		ctx = logging.WithCurrentAction(ctx, action)
+		Err := creators.CheckFollowedCreators(ctx)
+		if Err.Message != "" {
+			logging.LOGGER.Error().Timestamp().Str("error", Err.Message).
+				Str("next_run", c.Entry(subscribedCreatorsJobID).Next.String()).
+				Msg("Error running Subscribed Creators Job")
+		} else {
+			logging.LOGGER.Info().Timestamp().
+				Str("next_run", c.Entry(subscribedCreatorsJobID).Next.String()).
+				Msg("Subscribed Creators Job Completed")
+		}
+		ld.Log()
+	})
+	if err != nil {
+		return err
+	}
+	jobSpecs[subscribedCreatorsJobID] = spec
+
+	logging.LOGGER.Info().Timestamp().
+		Str("cron", spec).
+		Msg("Subscribed Creators Job Started")
+	return nil
+}
@@ -0,0 +1,279 @@
+package kometa
+
+import (
+	"aura/config"
+	"aura/database"
+	"aura/logging"
+	"aura/mediux"
+	"aura/models"
+	"aura/utils"
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+var exportMu sync.Mutex
+
+// ExportSummary is the result of a Kometa export
+type ExportSummary struct {
+	Items          int      `json:"items"`
+	ImagesWritten  int      `json:"images_written"`
+	ImagesRemoved  int      `json:"images_removed"`
+	ImagesFailed   int      `json:"images_failed"`
+	MetadataFiles  []string `json:"metadata_files"`
+	AssetDirectory string   `json:"asset_directory,omitempty"`
+}
+
+// exportItem is a saved item with the images Kometa should use, keyed by their asset file name
+type exportItem struct {
+	item   models.MediaItem
+	images map[string]models.ImageFile
+}
+
+// Export writes the applied images of all saved sets for Kometa.
+// When Kometa.AssetDirectory is set, the images are saved in the Kometa asset layout
+// (<Library>/<Folder>/poster.jpg, background.jpg, Season01.jpg, S01E01.jpg).
+// When Kometa.MetadataDirectory is set, a metadata file with the MediUX image URLs is written per library.
+// Files that aura wrote before and that are no longer part of a saved set are removed.
+func Export(ctx context.Context) (summary ExportSummary, Err logging.LogErrorInfo) {
+	exportMu.Lock()
+	defer exportMu.Unlock()
+
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Exporting Saved Sets for Kometa", logging.LevelInfo)
+	defer logAction.Complete()
+
+	cfg := config.Current.Kometa
+	summary.MetadataFiles = []string{}
+	summary.AssetDirectory = cfg.AssetDirectory
+
+	if !cfg.Enabled {
+		logAction.AppendWarning("outcome", "skipped")
+		logAction.AppendWarning("reason", "Kometa export is disabled")
+		return summary, logging.LogErrorInfo{}
+	}
+
+	savedSets, Err := database.GetAllSavedSets(ctx, models.DBFilter{ItemsPerPage: -1})
+	if Err.Message != "" {
+		return summary, Err
+	}
+
+	items := make([]exportItem, 0, len(savedSets.Items))
+	for _, savedItem := range savedSets.Items {
+		images := selectedImages(savedItem)
+		if len(images) == 0 {
+			continue
+		}
+		items = append(items, exportItem{item: savedItem.MediaItem, images: images})
+	}
+	summary.Items = len(items)
+
+	previous := loadManifest()
+	current := exportManifest{Assets: map[string]string{}, MetadataFiles: []string{}}
+
+	if cfg.AssetDirectory != "" {
+		exportAssets(ctx, cfg.AssetDirectory, items, previous, &current, &summary)
+	}
+
+	if cfg.MetadataDirectory != "" {
+		Err = exportMetadataFiles(ctx, cfg.MetadataDirectory, items, &current, &summary)
+		if Err.Message != "" {
+			return summary, Err
+		}
+	}
+
+	// Remove the files of the last export that are not part of this one
+	for filePath := range previous.Assets {
+		if _, exists := current.Assets[filePath]; exists {
+			continue
+		}
+		// The item and library folders are removed as well when they are empty
+		if removeExportedFile(filePath, 2) {
+			summary.ImagesRemoved++
+		}
+	}
+	for _, filePath := range previous.MetadataFiles {
+		if !slices.Contains(current.MetadataFiles, filePath) {
+			removeExportedFile(filePath, 0)
+		}
+	}
+
+	if err := saveManifest(current); err != nil {
+		logAction.AppendWarning("manifest", "Failed to save the list of exported files, they will be written again on the next export")
+		logAction.AppendWarning("error", err.Error())
+	}
+
+	logAction.AppendResult("items", summary.Items)
+	logAction.AppendResult("images_written", summary.ImagesWritten)
+	logAction.AppendResult("images_removed", summary.ImagesRemoved)
+	logAction.AppendResult("images_failed", summary.ImagesFailed)
+	logAction.AppendResult("metadata_files", summary.MetadataFiles)
+	return summary, logging.LogErrorInfo{}
+}
+
+// selectedImages returns the applied images of all saved sets of an item, keyed by their Kometa asset file name.
+// When more than one set has an image of the same kind, the set that was downloaded last wins.
+func selectedImages(savedItem models.DBSavedItem) map[string]models.ImageFile {
+	sets := slices.Clone(savedItem.PosterSets)
+	sort.SliceStable(sets, func(i, j int) bool {
+		return sets[i].LastDownloaded.Before(sets[j].LastDownloaded)
+	})
+
+	images := map[string]models.ImageFile{}
+	for _, set := range sets {
+		for _, image := range set.SelectedImages(savedItem.MediaItem) {
+			fileName := assetFileName(image)
+			if fileName == "" {
+				continue
+			}
+			images[fileName] = image
+		}
+	}
+	return images
+}
+
+// assetFileName returns the Kometa asset file name of an image, or an empty string when Kometa has none
+func assetFileName(image models.ImageFile) string {
+	switch image.Type {
+	case "poster":
+		return "poster.jpg"
+	case "backdrop":
+		return "background.jpg"
+	case "season_poster":
+		if image.SeasonNumber == nil {
+			return ""
+		}
+		return fmt.Sprintf("Season%02d.jpg", *image.SeasonNumber)
+	case "titlecard":
+		if image.SeasonNumber == nil || image.EpisodeNumber == nil {
+			return ""
+		}
+		return fmt.Sprintf("S%02dE%02d.jpg", *image.SeasonNumber, *image.EpisodeNumber)
+	}
+	return ""
+}
+
+// assetFolderName returns the name Kometa looks for in the asset directory.
+// Kometa uses the name of the movie or show folder, so that is used when the path is known.
+func assetFolderName(item models.MediaItem) string {
+	itemPath := ""
+	if item.Movie != nil && item.Movie.File.Path != "" {
+		itemPath = path.Dir(strings.ReplaceAll(item.Movie.File.Path, "\\", "/"))
+	} else if item.Series != nil && item.Series.Location != "" {
+		itemPath = strings.ReplaceAll(item.Series.Location, "\\", "/")
+	}
+	if folder := path.Base(strings.TrimRight(itemPath, "/")); itemPath != "" && folder != "." && folder != "/" {
+		return safeFileName(folder)
+	}
+	return safeFileName(titleWithYear(item))
+}
+
+func titleWithYear(item models.MediaItem) string {
+	if item.Year == 0 {
+		return item.Title
+	}
+	return fmt.Sprintf("%s (%d)", item.Title, item.Year)
+}
+
+// safeFileName replaces the characters that are not allowed in file names on Windows, macOS or Linux
+func safeFileName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		switch r {
+		case '/', '\\', ':', '*', '?', '"', '<', '>', '|':
+			return '_'
+		}
+		return r
+	}, name)
+	return strings.TrimSpace(name)
+}
+
+// exportAssets saves the images in the Kometa asset layout. Images that did not change since the last export are not downloaded again.
+func exportAssets(ctx context.Context, assetDirectory string, items []exportItem, previous exportManifest, current *exportManifest, summary *ExportSummary) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Writing Kometa Asset Directory", logging.LevelInfo)
+	defer logAction.Complete()
+
+	for _, exported := range items {
+		folder := path.Join(assetDirectory, safeFileName(exported.item.LibraryTitle), assetFolderName(exported.item))
+
+		for fileName, image := range exported.images {
+			filePath := path.Join(folder, fileName)
+			version := imageVersion(image)
+
+			if previous.Assets[filePath] == version && utils.CheckFileExists(filePath) {
+				current.Assets[filePath] = version
+				continue
+			}
+
+			Err := writeAsset(ctx, exported.item, image, filePath)
+			if Err.Message != "" {
+				summary.ImagesFailed++
+				// Keep the image of the last export until the new one can be written
+				if oldVersion, exists := previous.Assets[filePath]; exists {
+					current.Assets[filePath] = oldVersion
+				}
+				continue
+			}
+			current.Assets[filePath] = version
+			summary.ImagesWritten++
+		}
+	}
+
+	logAction.AppendResult("images_written", summary.ImagesWritten)
+	logAction.AppendResult("images_failed", summary.ImagesFailed)
+}
+
+func writeAsset(ctx context.Context, item models.MediaItem, image models.ImageFile, filePath string) (Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf(
+		"Writing Kometa Asset %s for %s", path.Base(filePath), utils.MediaItemInfo(item),
+	), logging.LevelDebug)
+	defer logAction.Complete()
+
+	imageData, _, Err := mediux.GetImage(ctx, image.ID, image.Modified.Format("20060102150405"), mediux.ImageQualityOriginal)
+	if Err.Message != "" {
+		return Err
+	}
+
+	if err := os.MkdirAll(path.Dir(filePath), 0755); err != nil {
+		logAction.SetError("Failed to create the Kometa asset folder", "Make sure Kometa.AssetDirectory is writable",
+			map[string]any{
+				"error": err.Error(),
+				"path":  path.Dir(filePath),
+			})
+		return *logAction.Error
+	}
+	if err := os.WriteFile(filePath, imageData, 0644); err != nil {
+		logAction.SetError("Failed to write the Kometa asset", "Make sure Kometa.AssetDirectory is writable",
+			map[string]any{
+				"error": err.Error(),
+				"path":  filePath,
+			})
+		return *logAction.Error
+	}
+	logAction.AppendResult("path", filePath)
+	return logging.LogErrorInfo{}
+}
+
+// imageVersion identifies the MediUX image a file was written from
+func imageVersion(image models.ImageFile) string {
+	return fmt.Sprintf("%s_%s", image.ID, image.Modified.UTC().Format(time.RFC3339))
+}
+
+// removeExportedFile removes a file of an earlier export and up to parentFolders folders above it that are left empty
+func removeExportedFile(filePath string, parentFolders int) bool {
+	if err := os.Remove(filePath); err != nil {
+		return false
+	}
+	folder := path.Dir(filePath)
+	for range parentFolders {
+		if os.Remove(folder) != nil {
+			break
+		}
+		folder = path.Dir(folder)
+	}
+	return true
+}
@@ -0,0 +1,80 @@
+package kometa
+
+import (
+	"aura/models"
+	"testing"
+)
+
+func TestAssetFileName(t *testing.T) {
+	number := func(n int) *int { return &n }
+	tests := []struct {
+		name  string
+		image models.ImageFile
+		want  string
+	}{
+		{name: "poster", image: models.ImageFile{Type: "poster"}, want: "poster.jpg"},
+		{name: "backdrop", image: models.ImageFile{Type: "backdrop"}, want: "background.jpg"},
+		{name: "season poster", image: models.ImageFile{Type: "season_poster", SeasonNumber: number(1)}, want: "Season01.jpg"},
+		{name: "specials poster", image: models.ImageFile{Type: "season_poster", SeasonNumber: number(0)}, want: "Season00.jpg"},
+		{name: "titlecard", image: models.ImageFile{Type: "titlecard", SeasonNumber: number(2), EpisodeNumber: number(10)}, want: "S02E10.jpg"},
+		{name: "season poster without season", image: models.ImageFile{Type: "season_poster"}, want: ""},
+		{name: "titlecard without episode", image: models.ImageFile{Type: "titlecard", SeasonNumber: number(1)}, want: ""},
+		{name: "unknown type", image: models.ImageFile{Type: "logo"}, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := assetFileName(tt.image); got != tt.want {
+				t.Errorf("assetFileName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAssetFolderName(t *testing.T) {
+	tests := []struct {
+		name string
+		item models.MediaItem
+		want string
+	}{
+		{
+			name: "movie folder",
+			item: models.MediaItem{Title: "The Matrix", Year: 1999, Movie: &models.MediaItemMovie{File: models.MediaItemFile{Path: "/data/movies/The Matrix (1999) {tmdb-603}/The Matrix.mkv"}}},
+			want: "The Matrix (1999) {tmdb-603}",
+		},
+		{
+			name: "windows movie folder",
+			item: models.MediaItem{Title: "The Matrix", Year: 1999, Movie: &models.MediaItemMovie{File: models.MediaItemFile{Path: `D:\Movies\The Matrix (1999)\The Matrix.mkv`}}},
+			want: "The Matrix (1999)",
+		},
+		{
+			name: "show folder with trailing slash",
+			item: models.MediaItem{Title: "Game of Thrones", Year: 2011, Series: &models.MediaItemSeries{Location: "/data/tv/Game of Thrones/"}},
+			want: "Game of Thrones",
+		},
+		{
+			name: "title and year without a path",
+			item: models.MediaItem{Title: "Mission: Impossible", Year: 1996},
+			want: "Mission_ Impossible (1996)",
+		},
+		{
+			name: "title without a year",
+			item: models.MediaItem{Title: "Who?"},
+			want: "Who_",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := assetFolderName(tt.item); got != tt.want {
+				t.Errorf("assetFolderName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSafeFileName(t *testing.T) {
+	if got, want := safeFileName(` AC/DC: Live <at> "Donington"? | 1991* `), `AC_DC_ Live _at_ _Donington__ _ 1991_`; got != want {
+		t.Errorf("safeFileName() = %q, want %q", got, want)
+	}
+}
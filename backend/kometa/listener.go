@@ -0,0 +1,59 @@
+package kometa
+
+import (
+	"aura/config"
+	"aura/events"
+	"aura/logging"
+	"context"
+	"sync"
+	"time"
+)
+
+// exportDelay collects the changes of a bulk action (e.g. a set applied to every item of a collection) into one export
+const exportDelay = 30 * time.Second
+
+var listenerOnce sync.Once
+
+// StartSavedSetsListener exports the saved sets again shortly after they change.
+// The listener runs for the lifetime of the app and does nothing while the Kometa export is disabled.
+func StartSavedSetsListener() {
+	listenerOnce.Do(func() {
+		changes, _ := events.Subscribe(events.TypeSavedSetsChanged)
+		go func() {
+			var timer *time.Timer
+			for range changes {
+				if !config.Current.Kometa.Enabled {
+					continue
+				}
+				if timer != nil {
+					timer.Stop()
+				}
+				timer = time.AfterFunc(exportDelay, runExport)
+			}
+		}()
+		logging.LOGGER.Info().Timestamp().Msg("Kometa Export Listener Started")
+	})
+}
+
+func runExport() {
+	defer func() {
+		if r := recover(); r != nil {
+			logging.LOGGER.Error().Timestamp().Msgf("PANIC: in Kometa export: %v", r)
+		}
+	}()
+
+	ctx, ld := logging.CreateLoggingContext(context.Background(), "Kometa Export")
+	logAction := ld.AddAction("Export Changed Saved Sets for Kometa", logging.LevelInfo)
+	ctx = logging.WithCurrentAction(ctx, logAction)
+
+	summary, Err := Export(ctx)
+	if Err.Message == "" {
+		logging.LOGGER.Info().Timestamp().
+			Int("items", summary.Items).
+			Int("images_written", summary.ImagesWritten).
+			Int("images_removed", summary.ImagesRemoved).
+			Msg("Kometa Export Completed")
+	}
+	logAction.Complete()
+	ld.Log()
+}
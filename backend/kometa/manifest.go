@@ -0,0 +1,39 @@
+package kometa
+
+import (
+	"aura/config"
+	"encoding/json"
+	"os"
+	"path"
+)
+
+// exportManifest lists the files aura wrote on the last export.
+// Only these files are ever removed, so other assets in the same folders are left alone.
+type exportManifest struct {
+	Assets        map[string]string `json:"assets"` // File path -> MediUX image the file was written from
+	MetadataFiles []string          `json:"metadata_files"`
+}
+
+func manifestPath() string {
+	return path.Join(config.ConfigPath, "kometa-export.json")
+}
+
+func loadManifest() exportManifest {
+	manifest := exportManifest{Assets: map[string]string{}, MetadataFiles: []string{}}
+	data, err := os.ReadFile(manifestPath())
+	if err != nil {
+		return manifest
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil || manifest.Assets == nil {
+		return exportManifest{Assets: map[string]string{}, MetadataFiles: []string{}}
+	}
+	return manifest
+}
+
+func saveManifest(manifest exportManifest) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(manifestPath(), data, 0644)
+}
@@ -0,0 +1,156 @@
+package kometa
+
+import (
+	"aura/logging"
+	"aura/mediux"
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+const metadataFileHeader = "# Written by aura from the saved sets. Changes to this file are overwritten on the next export.\n"
+
+type metadataFile struct {
+	Metadata map[string]metadataEntry `yaml:"metadata"`
+}
+
+type metadataEntry struct {
+	Match         metadataMatch          `yaml:"match"`
+	URLPoster     string                 `yaml:"url_poster,omitempty"`
+	URLBackground string                 `yaml:"url_background,omitempty"`
+	Seasons       map[int]metadataSeason `yaml:"seasons,omitempty"`
+}
+
+type metadataMatch struct {
+	Title string `yaml:"title"`
+	Year  int    `yaml:"year,omitempty"`
+}
+
+type metadataSeason struct {
+	URLPoster string                  `yaml:"url_poster,omitempty"`
+	Episodes  map[int]metadataEpisode `yaml:"episodes,omitempty"`
+}
+
+type metadataEpisode struct {
+	URLPoster string `yaml:"url_poster"`
+}
+
+// exportMetadataFiles writes a Kometa metadata file for every library with saved sets.
+// Files whose content did not change are not written again, so Kometa does not see a change.
+func exportMetadataFiles(ctx context.Context, metadataDirectory string, items []exportItem, current *exportManifest, summary *ExportSummary) (Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Writing Kometa Metadata Files", logging.LevelInfo)
+	defer logAction.Complete()
+
+	libraries := map[string]*metadataFile{}
+	for _, exported := range items {
+		library, exists := libraries[exported.item.LibraryTitle]
+		if !exists {
+			library = &metadataFile{Metadata: map[string]metadataEntry{}}
+			libraries[exported.item.LibraryTitle] = library
+		}
+
+		entry := metadataEntryForItem(ctx, exported)
+		key := titleWithYear(exported.item)
+		if _, taken := library.Metadata[key]; taken {
+			key = fmt.Sprintf("%s [%s]", key, exported.item.TMDB_ID)
+		}
+		library.Metadata[key] = entry
+	}
+
+	if err := os.MkdirAll(metadataDirectory, 0755); err != nil {
+		logAction.SetError("Failed to create the Kometa metadata folder", "Make sure Kometa.MetadataDirectory is writable",
+			map[string]any{
+				"error": err.Error(),
+				"path":  metadataDirectory,
+			})
+		return *logAction.Error
+	}
+
+	libraryTitles := make([]string, 0, len(libraries))
+	for libraryTitle := range libraries {
+		libraryTitles = append(libraryTitles, libraryTitle)
+	}
+	sort.Strings(libraryTitles)
+
+	for _, libraryTitle := range libraryTitles {
+		filePath := path.Join(metadataDirectory, safeFileName(libraryTitle)+".yml")
+
+		var buf bytes.Buffer
+		buf.WriteString(metadataFileHeader)
+		encoder := yaml.NewEncoder(&buf)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(libraries[libraryTitle]); err != nil {
+			logAction.SetError("Failed to encode the Kometa metadata file", "Try again, this should not happen",
+				map[string]any{
+					"error":   err.Error(),
+					"library": libraryTitle,
+				})
+			return *logAction.Error
+		}
+		encoder.Close()
+
+		current.MetadataFiles = append(current.MetadataFiles, filePath)
+		summary.MetadataFiles = append(summary.MetadataFiles, filePath)
+
+		if existing, err := os.ReadFile(filePath); err == nil && bytes.Equal(existing, buf.Bytes()) {
+			continue
+		}
+		if err := os.WriteFile(filePath, buf.Bytes(), 0644); err != nil {
+			logAction.SetError("Failed to write the Kometa metadata file", "Make sure Kometa.MetadataDirectory is writable",
+				map[string]any{
+					"error": err.Error(),
+					"path":  filePath,
+				})
+			return *logAction.Error
+		}
+	}
+
+	logAction.AppendResult("metadata_files", len(libraryTitles))
+	return logging.LogErrorInfo{}
+}
+
+// metadataEntryForItem builds the metadata of an item with the MediUX URLs of its images.
+// Kometa matches the entry by title and year.
+func metadataEntryForItem(ctx context.Context, exported exportItem) metadataEntry {
+	entry := metadataEntry{
+		Match: metadataMatch{Title: exported.item.Title, Year: exported.item.Year},
+	}
+
+	for _, image := range exported.images {
+		imageURL, Err := mediux.ConstructImageUrl(ctx, image.ID, image.Modified.String(), mediux.ImageQualityOriginal)
+		if Err.Message != "" {
+			continue
+		}
+
+		switch image.Type {
+		case "poster":
+			entry.URLPoster = imageURL
+		case "backdrop":
+			entry.URLBackground = imageURL
+		case "season_poster":
+			season := seasonEntry(&entry, *image.SeasonNumber)
+			season.URLPoster = imageURL
+			entry.Seasons[*image.SeasonNumber] = season
+		case "titlecard":
+			season := seasonEntry(&entry, *image.SeasonNumber)
+			if season.Episodes == nil {
+				season.Episodes = map[int]metadataEpisode{}
+			}
+			season.Episodes[*image.EpisodeNumber] = metadataEpisode{URLPoster: imageURL}
+			entry.Seasons[*image.SeasonNumber] = season
+		}
+	}
+	return entry
+}
+
+func seasonEntry(entry *metadataEntry, seasonNumber int) metadataSeason {
+	if entry.Seasons == nil {
+		entry.Seasons = map[int]metadataSeason{}
+	}
+	return entry.Seasons[seasonNumber]
+}
@@ -7,6 +7,13 @@ func Error_DBClientNotInitialized() LogErrorInfo {
 	}
 }
 
+func Error_AdditionalMediaServerItem() LogErrorInfo {
+	return LogErrorInfo{
+		Message: "Sets can only be saved for items of the primary media server",
+		Help:    "Save the set on the item of the primary media server. It is also applied to the copies on the additional media servers.",
+	}
+}
+
 func Error_BaseUrlParsing(err error) (message, help string, detail map[string]any) {
 	message = "Failed to parse base URL"
 	help = "Ensure the URL is valid"
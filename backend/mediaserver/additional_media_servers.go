@@ -0,0 +1,128 @@
+package mediaserver
+
+import (
+	"aura/cache"
+	"aura/config"
+	"aura/logging"
+	"aura/models"
+	"aura/utils"
+	"context"
+	"fmt"
+)
+
+// ConnectAdditionalMediaServers tests the connection to every additional media server
+// and retrieves the admin user for Emby/Jellyfin servers.
+// A failing additional media server does not stop the application, it is only logged.
+func ConnectAdditionalMediaServers(ctx context.Context) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Connecting to Additional Media Servers", logging.LevelInfo)
+	defer logAction.Complete()
+
+	for i := range config.Current.AdditionalMediaServers {
+		msConfig := &config.Current.AdditionalMediaServers[i]
+
+		connectionOk, serverName, serverVersion, Err := TestConnection(ctx, msConfig)
+		if Err.Message != "" || !connectionOk {
+			logAction.AppendWarning(msConfig.Name, "Failed to connect to media server")
+			logging.LOGGER.Warn().Timestamp().Str("media_server", msConfig.Name).Str("type", msConfig.Type).Msg("Failed to connect to additional media server")
+			continue
+		}
+
+		if msConfig.Type == "Jellyfin" || msConfig.Type == "Emby" {
+			ejUserID, Err := GetAdminUser(ctx, msConfig)
+			if Err.Message != "" || ejUserID == "" {
+				logAction.AppendWarning(msConfig.Name, "Failed to retrieve admin user ID")
+				logging.LOGGER.Warn().Timestamp().Str("media_server", msConfig.Name).Msg("Failed to retrieve admin user ID from additional Emby/Jellyfin server")
+				continue
+			}
+			msConfig.UserID = ejUserID
+		}
+
+		logAction.AppendResult(msConfig.Name, fmt.Sprintf("%s %s", serverName, serverVersion))
+		logging.LOGGER.Info().Timestamp().Str("media_server", msConfig.Name).
+			Str("media_server_name", serverName).
+			Str("media_server_version", serverVersion).
+			Msg("Additional Media Server connection validated successfully")
+	}
+}
+
+// GetMediaItemCopiesOnOtherServers returns the full details of every copy of a Media Item
+// that is held by another configured media server (matched by TMDB ID and type).
+func GetMediaItemCopiesOnOtherServers(ctx context.Context, item models.MediaItem) (copies []models.MediaItem) {
+	copies = []models.MediaItem{}
+	if len(config.Current.AdditionalMediaServers) == 0 {
+		return copies
+	}
+
+	cachedCopies := cache.LibraryStore.GetMediaItemsOnOtherServersByTMDBID(item.Server, item.TMDB_ID, item.Type)
+	if len(cachedCopies) == 0 {
+		return copies
+	}
+
+	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf("Getting copies of %s on other media servers", utils.MediaItemInfo(item)), logging.LevelDebug)
+	defer logAction.Complete()
+
+	for _, cachedCopy := range cachedCopies {
+		found, Err := GetMediaItemDetails(ctx, &cachedCopy)
+		if Err.Message != "" || !found {
+			logAction.AppendWarning(config.MediaServerDisplayName(cachedCopy.Server), fmt.Sprintf("Failed to get details for %s", utils.MediaItemInfo(cachedCopy)))
+			continue
+		}
+		copies = append(copies, cachedCopy)
+	}
+
+	logAction.AppendResult("copies_found", len(copies))
+	return copies
+}
+
+// DownloadApplyImageToServerCopies applies an image to every copy of a Media Item held by another media server.
+// Copies that do not contain the season/episode for the image are skipped.
+func DownloadApplyImageToServerCopies(ctx context.Context, copies []models.MediaItem, imageFile models.ImageFile) (applied int, Err logging.LogErrorInfo) {
+	Err = logging.LogErrorInfo{}
+	for i := range copies {
+		if !mediaItemHasImageTarget(copies[i], imageFile) {
+			continue
+		}
+
+		applyErr := DownloadApplyImageToMediaItem(ctx, &copies[i], imageFile)
+		if applyErr.Message != "" {
+			Err = applyErr
+			Err.Message = fmt.Sprintf("%s: %s", config.MediaServerDisplayName(copies[i].Server), applyErr.Message)
+			continue
+		}
+		applied++
+	}
+	return applied, Err
+}
+
+// mediaItemHasImageTarget checks if the Media Item contains the season/episode an image is meant for
+func mediaItemHasImageTarget(item models.MediaItem, imageFile models.ImageFile) bool {
+	switch imageFile.Type {
+	case "season_poster":
+		if item.Series == nil || imageFile.SeasonNumber == nil {
+			return false
+		}
+		for _, season := range item.Series.Seasons {
+			if season.SeasonNumber == *imageFile.SeasonNumber {
+				return true
+			}
+		}
+		return false
+	case "titlecard":
+		if item.Series == nil || imageFile.SeasonNumber == nil || imageFile.EpisodeNumber == nil {
+			return false
+		}
+		for _, season := range item.Series.Seasons {
+			if season.SeasonNumber != *imageFile.SeasonNumber {
+				continue
+			}
+			for _, episode := range season.Episodes {
+				if episode.EpisodeNumber == *imageFile.EpisodeNumber {
+					return true
+				}
+			}
+		}
+		return false
+	default:
+		return true
+	}
+}
@@ -366,6 +366,7 @@ type EmbyJellyItemContentResponse struct {
 	EndDate           time.Time `json:"EndDate"`
 	IndexNumber       int       `json:"IndexNumber"`
 	ParentIndexNumber int       `json:"ParentIndexNumber"`
+	SeriesID          string    `json:"SeriesId"`
 }
 
 type EmbyJellyItemContentChildResponse struct {
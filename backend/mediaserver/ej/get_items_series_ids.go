@@ -0,0 +1,70 @@
+package ej
+
+import (
+	"aura/logging"
+	"aura/utils/httpx"
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// itemsPerSeriesIDRequest keeps the request URL short when a library scan updates many items at once
+const itemsPerSeriesIDRequest = 100
+
+// GetItemsSeriesIDs maps item IDs to the ID of the movie or show they belong to.
+// Seasons and episodes map to their show, movies and shows map to themselves.
+// Items that are not found (e.g. folders or deleted items) are left out.
+func (e *EJ) GetItemsSeriesIDs(ctx context.Context, itemIDs []string) (seriesIDs map[string]string, Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf(
+		"%s: Resolving %d Items to their Movie or Show", e.Config.Type, len(itemIDs),
+	), logging.LevelDebug)
+	defer logAction.Complete()
+
+	seriesIDs = map[string]string{}
+
+	for start := 0; start < len(itemIDs); start += itemsPerSeriesIDRequest {
+		end := min(start+itemsPerSeriesIDRequest, len(itemIDs))
+
+		// Construct the URL for the EJ server API request
+		u, err := url.Parse(e.Config.URL)
+		if err != nil {
+			logAction.SetError(logging.Error_BaseUrlParsing(err))
+			return seriesIDs, *logAction.Error
+		}
+		u.Path = path.Join(u.Path, "Users", e.Config.UserID, "Items")
+		query := u.Query()
+		query.Set("Ids", strings.Join(itemIDs[start:end], ","))
+		u.RawQuery = query.Encode()
+		URL := u.String()
+
+		// Make the HTTP Request to EJ
+		_, respBody, Err := makeRequest(ctx, e.Config, URL, "GET", nil)
+		if Err.Message != "" {
+			logAction.SetErrorFromInfo(Err)
+			return seriesIDs, *logAction.Error
+		}
+
+		// Decode the Response
+		var ejResp EmbyJellyItemContentChildResponse
+		Err = httpx.DecodeResponseToJSON(ctx, respBody, &ejResp, fmt.Sprintf("%s Items Response", e.Config.Type))
+		if Err.Message != "" {
+			return seriesIDs, Err
+		}
+
+		for _, item := range ejResp.Items {
+			switch item.Type {
+			case "Movie", "Series":
+				seriesIDs[item.ID] = item.ID
+			case "Season", "Episode":
+				if item.SeriesID != "" {
+					seriesIDs[item.ID] = item.SeriesID
+				}
+			}
+		}
+	}
+
+	logAction.AppendResult("items_resolved", len(seriesIDs))
+	return seriesIDs, logging.LogErrorInfo{}
+}
@@ -2,7 +2,6 @@ package ej
 
 import (
 	"aura/cache"
-	"aura/config"
 	"aura/database"
 	"aura/logging"
 	"aura/mediux"
@@ -17,7 +16,7 @@ import (
 
 func (e *EJ) GetMediaItemDetails(ctx context.Context, item *models.MediaItem) (found bool, Err logging.LogErrorInfo) {
 	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf(
-		"%s: Fetching Full Info for %s", config.Current.MediaServer.Type,
+		"%s: Fetching Full Info for %s", e.Config.Type,
 		utils.MediaItemInfo(*item),
 	), logging.LevelDebug)
 	defer logAction.Complete()
@@ -26,12 +25,12 @@ func (e *EJ) GetMediaItemDetails(ctx context.Context, item *models.MediaItem) (f
 	Err = logging.LogErrorInfo{}
 
 	// Construct the URL for the EJ server API request
-	u, err := url.Parse(config.Current.MediaServer.URL)
+	u, err := url.Parse(e.Config.URL)
 	if err != nil {
 		logAction.SetError(logging.Error_BaseUrlParsing(err))
 		return found, *logAction.Error
 	}
-	u.Path = path.Join(u.Path, "Users", config.Current.MediaServer.UserID, "Items", item.RatingKey)
+	u.Path = path.Join(u.Path, "Users", e.Config.UserID, "Items", item.RatingKey)
 	query := u.Query()
 	query.Set("fields", "ShareLevel")
 	query.Set("ExcludeFields", "VideoChapters,VideoMediaSources,MediaStreams")
@@ -40,7 +39,7 @@ func (e *EJ) GetMediaItemDetails(ctx context.Context, item *models.MediaItem) (f
 	URL := u.String()
 
 	// Make the HTTP Request to EJ
-	resp, respBody, Err := makeRequest(ctx, config.Current.MediaServer, URL, "GET", nil)
+	resp, respBody, Err := makeRequest(ctx, e.Config, URL, "GET", nil)
 	if Err.Message != "" {
 		logAction.SetErrorFromInfo(Err)
 		return found, *logAction.Error
@@ -49,7 +48,7 @@ func (e *EJ) GetMediaItemDetails(ctx context.Context, item *models.MediaItem) (f
 
 	// Decode the Response
 	var ejResp EmbyJellyItemContentResponse
-	Err = httpx.DecodeResponseToJSON(ctx, respBody, &ejResp, fmt.Sprintf("%s Media Item Details Response", config.Current.MediaServer.Type))
+	Err = httpx.DecodeResponseToJSON(ctx, respBody, &ejResp, fmt.Sprintf("%s Media Item Details Response", e.Config.Type))
 	if Err.Message != "" {
 		return found, *logAction.Error
 	}
@@ -103,7 +102,7 @@ func (e *EJ) GetMediaItemDetails(ctx context.Context, item *models.MediaItem) (f
 		}
 	}
 	if item.TMDB_ID == "" {
-		logAction.SetError("No TMDB ID found for the media item", fmt.Sprintf("Ensure the media item has a valid TMDB GUID in %s", config.Current.MediaServer.Type),
+		logAction.SetError("No TMDB ID found for the media item", fmt.Sprintf("Ensure the media item has a valid TMDB GUID in %s", e.Config.Type),
 			map[string]any{
 				"rating_key":     item.RatingKey,
 				"library_title":  item.LibraryTitle,
@@ -125,7 +124,7 @@ func (e *EJ) GetMediaItemDetails(ctx context.Context, item *models.MediaItem) (f
 	}
 	if ejResp.Type == "Series" {
 		item.Type = "show"
-		Err := fetchSeasonsForShow(ctx, item)
+		Err := e.fetchSeasonsForShow(ctx, item)
 		if Err.Message != "" {
 			return found, Err
 		}
@@ -161,9 +160,12 @@ func (e *EJ) GetMediaItemDetails(ctx context.Context, item *models.MediaItem) (f
 	}
 
 	// Update the Media Item on Server in the DB
-	updateErr := database.UpdateMediaItemOnServer(ctx, item.TMDB_ID, item.LibraryTitle, true)
-	if updateErr.Message != "" {
-		logAction.AppendWarning("update_on_server_error", updateErr.Message)
+	// Only items from the primary media server are tracked in the DB
+	if item.Server == "" {
+		updateErr := database.UpdateMediaItemOnServer(ctx, item.TMDB_ID, item.LibraryTitle, true)
+		if updateErr.Message != "" {
+			logAction.AppendWarning("update_on_server_error", updateErr.Message)
+		}
 	}
 
 	// Update item in cache
@@ -171,9 +173,9 @@ func (e *EJ) GetMediaItemDetails(ctx context.Context, item *models.MediaItem) (f
 	return found, logging.LogErrorInfo{}
 }
 
-func fetchSeasonsForShow(ctx context.Context, itemInfo *models.MediaItem) (Err logging.LogErrorInfo) {
+func (e *EJ) fetchSeasonsForShow(ctx context.Context, itemInfo *models.MediaItem) (Err logging.LogErrorInfo) {
 	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf(
-		"%s: Fetching Seasons for Show %s", config.Current.MediaServer.Type,
+		"%s: Fetching Seasons for Show %s", e.Config.Type,
 		utils.MediaItemInfo(*itemInfo),
 	), logging.LevelDebug)
 	defer logAction.Complete()
@@ -181,7 +183,7 @@ func fetchSeasonsForShow(ctx context.Context, itemInfo *models.MediaItem) (Err l
 	Err = logging.LogErrorInfo{}
 
 	// Construct the URL for the EJ server API request
-	u, err := url.Parse(config.Current.MediaServer.URL)
+	u, err := url.Parse(e.Config.URL)
 	if err != nil {
 		logAction.SetError(logging.Error_BaseUrlParsing(err))
 		return *logAction.Error
@@ -193,7 +195,7 @@ func fetchSeasonsForShow(ctx context.Context, itemInfo *models.MediaItem) (Err l
 	URL := u.String()
 
 	// Make the HTTP Request to EJ
-	resp, respBody, Err := makeRequest(ctx, config.Current.MediaServer, URL, "GET", nil)
+	resp, respBody, Err := makeRequest(ctx, e.Config, URL, "GET", nil)
 	if Err.Message != "" {
 		logAction.SetErrorFromInfo(Err)
 		return Err
@@ -202,7 +204,7 @@ func fetchSeasonsForShow(ctx context.Context, itemInfo *models.MediaItem) (Err l
 
 	// Decode the Response
 	var ejResp EmbyJellyItemContentChildResponse
-	Err = httpx.DecodeResponseToJSON(ctx, respBody, &ejResp, fmt.Sprintf("%s Show Seasons Response", config.Current.MediaServer.Type))
+	Err = httpx.DecodeResponseToJSON(ctx, respBody, &ejResp, fmt.Sprintf("%s Show Seasons Response", e.Config.Type))
 	if Err.Message != "" {
 		return Err
 	}
@@ -216,7 +218,7 @@ func fetchSeasonsForShow(ctx context.Context, itemInfo *models.MediaItem) (Err l
 			Title:        season.Name,
 			Episodes:     []models.MediaItemEpisode{},
 		}
-		season, Err := fetchEpisodesForSeason(ctx, itemInfo.RatingKey, season)
+		season, Err := e.fetchEpisodesForSeason(ctx, itemInfo.RatingKey, season)
 		if Err.Message != "" {
 			return *logAction.Error
 		}
@@ -237,9 +239,9 @@ func fetchSeasonsForShow(ctx context.Context, itemInfo *models.MediaItem) (Err l
 	return logging.LogErrorInfo{}
 }
 
-func fetchEpisodesForSeason(ctx context.Context, showRatingKey string, season models.MediaItemSeason) (models.MediaItemSeason, logging.LogErrorInfo) {
+func (e *EJ) fetchEpisodesForSeason(ctx context.Context, showRatingKey string, season models.MediaItemSeason) (models.MediaItemSeason, logging.LogErrorInfo) {
 	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf(
-		"%s: Fetching Episodes for Season %d of Show RatingKey %s", config.Current.MediaServer.Type,
+		"%s: Fetching Episodes for Season %d of Show RatingKey %s", e.Config.Type,
 		season.SeasonNumber, showRatingKey,
 	), logging.LevelDebug)
 	defer logAction.Complete()
@@ -247,7 +249,7 @@ func fetchEpisodesForSeason(ctx context.Context, showRatingKey string, season mo
 	Err := logging.LogErrorInfo{}
 
 	// Construct the URL for the EJ server API request
-	u, err := url.Parse(config.Current.MediaServer.URL)
+	u, err := url.Parse(e.Config.URL)
 	if err != nil {
 		logAction.SetError(logging.Error_BaseUrlParsing(err))
 		return season, *logAction.Error
@@ -260,7 +262,7 @@ func fetchEpisodesForSeason(ctx context.Context, showRatingKey string, season mo
 	URL := u.String()
 
 	// Make the HTTP Request to EJ
-	resp, respBody, Err := makeRequest(ctx, config.Current.MediaServer, URL, "GET", nil)
+	resp, respBody, Err := makeRequest(ctx, e.Config, URL, "GET", nil)
 	if Err.Message != "" {
 		logAction.SetErrorFromInfo(Err)
 		return season, *logAction.Error
@@ -269,7 +271,7 @@ func fetchEpisodesForSeason(ctx context.Context, showRatingKey string, season mo
 
 	// Decode the Response
 	var ejResp EmbyJellyItemContentChildResponse
-	Err = httpx.DecodeResponseToJSON(ctx, respBody, &ejResp, fmt.Sprintf("%s Season Episodes Response", config.Current.MediaServer.Type))
+	Err = httpx.DecodeResponseToJSON(ctx, respBody, &ejResp, fmt.Sprintf("%s Season Episodes Response", e.Config.Type))
 	if Err.Message != "" {
 		return season, *logAction.Error
 	}
@@ -1,7 +1,6 @@
 package ej
 
 import (
-	"aura/config"
 	"aura/logging"
 	"aura/models"
 	"aura/utils"
@@ -14,7 +13,7 @@ import (
 func (e *EJ) GetMediaItemImage(ctx context.Context, item *models.MediaItem, imageRatingKey string, imageType string) (imageData []byte, Err logging.LogErrorInfo) {
 	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf(
 		"%s: Fetching Image (%s) for %s",
-		config.Current.MediaServer.Type,
+		e.Config.Type,
 		imageType,
 		utils.MediaItemInfo(*item),
 	), logging.LevelDebug)
@@ -23,7 +22,7 @@ func (e *EJ) GetMediaItemImage(ctx context.Context, item *models.MediaItem, imag
 	imageData = []byte{}
 	Err = logging.LogErrorInfo{}
 
-	respBody, Err := GetImageFromEJ(ctx, imageRatingKey, imageType)
+	respBody, Err := e.GetImageFromEJ(ctx, imageRatingKey, imageType)
 	if Err.Message != "" {
 		return imageData, Err
 	}
@@ -35,7 +34,7 @@ func (e *EJ) GetMediaItemImage(ctx context.Context, item *models.MediaItem, imag
 func (e *EJ) GetCollectionItemImage(ctx context.Context, collection *models.CollectionItem, imageType string) (imageData []byte, Err logging.LogErrorInfo) {
 	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf(
 		"%s: Fetching Image (%s) for Collection '%s' [%s]",
-		config.Current.MediaServer.Type,
+		e.Config.Type,
 		imageType, collection.Title, collection.RatingKey,
 	), logging.LevelDebug)
 	defer logAction.Complete()
@@ -43,7 +42,7 @@ func (e *EJ) GetCollectionItemImage(ctx context.Context, collection *models.Coll
 	imageData = []byte{}
 	Err = logging.LogErrorInfo{}
 
-	respBody, Err := GetImageFromEJ(ctx, collection.RatingKey, imageType)
+	respBody, Err := e.GetImageFromEJ(ctx, collection.RatingKey, imageType)
 	if Err.Message != "" {
 		return imageData, Err
 	}
@@ -52,7 +51,7 @@ func (e *EJ) GetCollectionItemImage(ctx context.Context, collection *models.Coll
 	return imageData, logging.LogErrorInfo{}
 }
 
-func GetImageFromEJ(ctx context.Context, ratingKey string, imageType string) (imageData []byte, Err logging.LogErrorInfo) {
+func (e *EJ) GetImageFromEJ(ctx context.Context, ratingKey string, imageType string) (imageData []byte, Err logging.LogErrorInfo) {
 	switch imageType {
 	case "poster":
 		imageType = "Primary"
@@ -67,7 +66,7 @@ func GetImageFromEJ(ctx context.Context, ratingKey string, imageType string) (im
 	}
 
 	// Construct the URL for the EJ server API request
-	u, err := url.Parse(config.Current.MediaServer.URL)
+	u, err := url.Parse(e.Config.URL)
 	if err != nil {
 		return imageData, logging.LogErrorInfo{
 			Message: "Failed to parse base URL",
@@ -79,7 +78,7 @@ func GetImageFromEJ(ctx context.Context, ratingKey string, imageType string) (im
 	URL := u.String()
 
 	// Make the HTTP Request to EJ
-	resp, respBody, Err := makeRequest(ctx, config.Current.MediaServer, URL, "GET", nil)
+	resp, respBody, Err := makeRequest(ctx, e.Config, URL, "GET", nil)
 	if Err.Message != "" {
 		return imageData, Err
 	}
@@ -1,7 +1,6 @@
 package ej
 
 import (
-	"aura/config"
 	"aura/logging"
 	"aura/models"
 	"aura/utils/httpx"
@@ -11,23 +10,23 @@ import (
 	"path"
 )
 
-func GetMovieCollectionSection(ctx context.Context) (section models.LibrarySection, Err logging.LogErrorInfo) {
-	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf("%s: Get Movie Collection Section", config.Current.MediaServer.Type), logging.LevelInfo)
+func (e *EJ) GetMovieCollectionSection(ctx context.Context) (section models.LibrarySection, Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf("%s: Get Movie Collection Section", e.Config.Type), logging.LevelInfo)
 
 	section = models.LibrarySection{}
 	Err = logging.LogErrorInfo{}
 
 	// Construct the URL for the EJ server API request
-	u, err := url.Parse(config.Current.MediaServer.URL)
+	u, err := url.Parse(e.Config.URL)
 	if err != nil {
 		logAction.SetError(logging.Error_BaseUrlParsing(err))
 		return section, *logAction.Error
 	}
-	u.Path = path.Join(u.Path, "Users", config.Current.MediaServer.UserID, "Items")
+	u.Path = path.Join(u.Path, "Users", e.Config.UserID, "Items")
 	URL := u.String()
 
 	// Make the HTTP Request to EJ
-	resp, respBody, Err := makeRequest(ctx, config.Current.MediaServer, URL, "GET", nil)
+	resp, respBody, Err := makeRequest(ctx, e.Config, URL, "GET", nil)
 	if Err.Message != "" {
 		logAction.SetErrorFromInfo(Err)
 		return section, *logAction.Error
@@ -36,7 +35,7 @@ func GetMovieCollectionSection(ctx context.Context) (section models.LibrarySecti
 
 	// Decode the Response
 	var ejResp EmbyJellyLibrarySectionsResponse
-	Err = httpx.DecodeResponseToJSON(ctx, respBody, &ejResp, fmt.Sprintf("%s Library Sections Response", config.Current.MediaServer.Type))
+	Err = httpx.DecodeResponseToJSON(ctx, respBody, &ejResp, fmt.Sprintf("%s Library Sections Response", e.Config.Type))
 	if Err.Message != "" {
 		return section, *logAction.Error
 	}
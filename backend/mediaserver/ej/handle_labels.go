@@ -1,12 +1,212 @@
 package ej
 
 import (
+	"aura/config"
 	"aura/logging"
 	"aura/models"
+	"aura/utils"
+	"aura/utils/httpx"
 	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
 )
 
-func (ej *EJ) AddLabelToMediaItem(ctx context.Context, item models.MediaItem, selectedTypes models.SelectedTypes) (Err logging.LogErrorInfo) {
-	// Emby does not support labels, so this function will simply return without doing anything
+// AddLabelToMediaItem adds and removes the tags of the LabelsAndTags application for this server type (Emby or Jellyfin)
+func (e *EJ) AddLabelToMediaItem(ctx context.Context, item models.MediaItem, selectedTypes models.SelectedTypes) (Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx,
+		fmt.Sprintf("%s: Adding Tags to %s", e.Config.Type, utils.MediaItemInfo(item)),
+		logging.LevelInfo)
+	defer logAction.Complete()
+
+	if len(config.Current.LabelsAndTags.Applications) == 0 {
+		return logging.LogErrorInfo{}
+	}
+
+	if item.Type != "movie" && item.Type != "show" {
+		logAction.AppendWarning("outcome", "skipped")
+		logAction.AppendWarning("reason", "unsupported_media_type")
+		return logging.LogErrorInfo{}
+	} else if item.RatingKey == "" {
+		logAction.AppendWarning("outcome", "skipped")
+		logAction.AppendWarning("reason", "missing_rating_key")
+		return logging.LogErrorInfo{}
+	}
+
+	for _, app := range config.Current.LabelsAndTags.Applications {
+		if app.Application != e.Config.Type {
+			continue
+		}
+
+		ctx, subAppAction := logging.AddSubActionToContext(ctx, fmt.Sprintf("Processing %s Tags", e.Config.Type), logging.LevelDebug)
+		defer subAppAction.Complete()
+
+		// Check if we are enabled for this application
+		if !app.Enabled {
+			subAppAction.AppendWarning("outcome", "skipped")
+			subAppAction.AppendWarning("reason", "application disabled")
+			continue
+		}
+
+		// Check to see there at least one tag to add or remove
+		if len(app.Add) == 0 && len(app.Remove) == 0 {
+			subAppAction.AppendWarning("outcome", "skipped")
+			subAppAction.AppendWarning("reason", "no tags to add or remove")
+			continue
+		}
+
+		tagsToAdd := app.LabelsToAdd(selectedTypes)
+		tagsToRemove := app.LabelsToRemove(selectedTypes)
+		subAppAction.AppendResult("tags_to_add", tagsToAdd)
+		subAppAction.AppendResult("tags_to_remove", tagsToRemove)
+
+		Err := e.updateItemTags(ctx, item.RatingKey, tagsToAdd, tagsToRemove)
+		if Err.Message != "" {
+			continue
+		}
+
+		subAppAction.AppendResult("outcome", "success")
+	}
+
 	return logging.LogErrorInfo{}
 }
+
+// RemoveLabelsFromMediaItem removes the tags aura added to an item (the Add list and the tags for the selected types)
+func (e *EJ) RemoveLabelsFromMediaItem(ctx context.Context, item models.MediaItem) (Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx,
+		fmt.Sprintf("%s: Removing Tags from %s", e.Config.Type, utils.MediaItemInfo(item)),
+		logging.LevelInfo)
+	defer logAction.Complete()
+
+	if item.Type != "movie" && item.Type != "show" {
+		logAction.AppendWarning("outcome", "skipped")
+		logAction.AppendWarning("reason", "unsupported_media_type")
+		return logging.LogErrorInfo{}
+	} else if item.RatingKey == "" {
+		logAction.AppendWarning("outcome", "skipped")
+		logAction.AppendWarning("reason", "missing_rating_key")
+		return logging.LogErrorInfo{}
+	}
+
+	tags := []string{}
+	for _, app := range config.Current.LabelsAndTags.Applications {
+		if app.Application == e.Config.Type {
+			tags = append(tags, app.AddedLabels()...)
+		}
+	}
+	if len(tags) == 0 {
+		return logging.LogErrorInfo{}
+	}
+	logAction.AppendResult("tags_to_remove", tags)
+
+	Err = e.updateItemTags(ctx, item.RatingKey, nil, tags)
+	if Err.Message != "" {
+		logAction.SetErrorFromInfo(Err)
+		return *logAction.Error
+	}
+
+	logAction.AppendResult("outcome", "success")
+	return logging.LogErrorInfo{}
+}
+
+// updateItemTags adds and removes tags on an item. Tags are compared without case.
+// Emby and Jellyfin have no endpoint for a single tag, so the full item is fetched,
+// its tags are changed and the item is posted back. Every other field is sent back unchanged.
+// Jellyfin keeps the tags in "Tags", Emby in "TagItems".
+func (e *EJ) updateItemTags(ctx context.Context, itemID string, add []string, remove []string) (Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf("%s: Updating Tags of Item '%s'", e.Config.Type, itemID), logging.LevelDebug)
+	defer logAction.Complete()
+
+	// Construct the URL for the EJ server API request
+	u, err := url.Parse(e.Config.URL)
+	if err != nil {
+		logAction.SetError(logging.Error_BaseUrlParsing(err))
+		return *logAction.Error
+	}
+	u.Path = path.Join(u.Path, "Users", e.Config.UserID, "Items", itemID)
+	URL := u.String()
+
+	// Get the full item
+	_, respBody, Err := makeRequest(ctx, e.Config, URL, "GET", nil)
+	if Err.Message != "" {
+		logAction.SetErrorFromInfo(Err)
+		return *logAction.Error
+	}
+	var itemDto map[string]any
+	Err = httpx.DecodeResponseToJSON(ctx, respBody, &itemDto, fmt.Sprintf("%s Item Response", e.Config.Type))
+	if Err.Message != "" {
+		return Err
+	}
+
+	currentTags := []string{}
+	if tags, ok := itemDto["Tags"].([]any); ok {
+		for _, tag := range tags {
+			if name, ok := tag.(string); ok && name != "" {
+				currentTags = append(currentTags, name)
+			}
+		}
+	}
+	if tagItems, ok := itemDto["TagItems"].([]any); ok {
+		for _, tagItem := range tagItems {
+			tagMap, ok := tagItem.(map[string]any)
+			if !ok {
+				continue
+			}
+			if name, ok := tagMap["Name"].(string); ok && name != "" && !containsTag(currentTags, name) {
+				currentTags = append(currentTags, name)
+			}
+		}
+	}
+
+	newTags := []string{}
+	for _, tag := range currentTags {
+		if !containsTag(remove, tag) {
+			newTags = append(newTags, tag)
+		}
+	}
+	for _, tag := range add {
+		if tag != "" && !containsTag(newTags, tag) {
+			newTags = append(newTags, tag)
+		}
+	}
+	logAction.AppendResult("current_tags", currentTags)
+	logAction.AppendResult("new_tags", newTags)
+
+	if slices.Equal(currentTags, newTags) {
+		logAction.AppendResult("outcome", "tags already up to date")
+		return logging.LogErrorInfo{}
+	}
+
+	tagItems := make([]map[string]any, 0, len(newTags))
+	for _, tag := range newTags {
+		tagItems = append(tagItems, map[string]any{"Name": tag})
+	}
+	itemDto["Tags"] = newTags
+	itemDto["TagItems"] = tagItems
+
+	body, err := json.Marshal(itemDto)
+	if err != nil {
+		logAction.SetError("Failed to encode the item", "Try again, this should not happen", map[string]any{"error": err.Error()})
+		return *logAction.Error
+	}
+
+	// Post the item back with the new tags
+	u, _ = url.Parse(e.Config.URL)
+	u.Path = path.Join(u.Path, "Items", itemID)
+	_, _, Err = makeRequest(ctx, e.Config, u.String(), "POST", body)
+	if Err.Message != "" {
+		logAction.SetErrorFromInfo(Err)
+		return *logAction.Error
+	}
+
+	return logging.LogErrorInfo{}
+}
+
+func containsTag(tags []string, tag string) bool {
+	return slices.ContainsFunc(tags, func(t string) bool {
+		return strings.EqualFold(t, tag)
+	})
+}
@@ -1,7 +1,6 @@
 package ej
 
 import (
-	"aura/config"
 	"aura/logging"
 	"aura/models"
 	"aura/utils"
@@ -9,10 +8,10 @@ import (
 	"fmt"
 )
 
-func applyImageToMediaItem(ctx context.Context, item *models.MediaItem, imageFile models.ImageFile, imageData []byte) (Err logging.LogErrorInfo) {
+func (e *EJ) applyImageToMediaItem(ctx context.Context, item *models.MediaItem, imageFile models.ImageFile, imageData []byte) (Err logging.LogErrorInfo) {
 	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf(
 		"%s: Applying %s Image for %s",
-		config.Current.MediaServer.Type, utils.GetFileDownloadName(item.Title, imageFile), utils.MediaItemInfo(*item),
+		e.Config.Type, utils.GetFileDownloadName(item.Title, imageFile), utils.MediaItemInfo(*item),
 	), logging.LevelDebug)
 	defer logAction.Complete()
 
@@ -28,7 +27,7 @@ func applyImageToMediaItem(ctx context.Context, item *models.MediaItem, imageFil
 	// Handling for Backdrops are different than Primary Images
 	if imageFile.Type != "backdrop" {
 		// Apply the Image to the Media Item
-		Err = uploadImage(ctx, item, itemRatingKey, imageFile, imageData)
+		Err = e.uploadImage(ctx, item, itemRatingKey, imageFile, imageData)
 		if Err.Message != "" {
 			return Err
 		}
@@ -40,20 +39,20 @@ func applyImageToMediaItem(ctx context.Context, item *models.MediaItem, imageFil
 		// Then we set the index of that image to 0
 
 		// Get current images
-		currentImages, Err := getCurrentImages(ctx, item, "Current")
+		currentImages, Err := e.getCurrentImages(ctx, item, "Current")
 		if Err.Message != "" {
 			return *logAction.Error
 		}
 
 		// Upload the new image
-		Err = uploadImage(ctx, item, itemRatingKey, imageFile, imageData)
+		Err = e.uploadImage(ctx, item, itemRatingKey, imageFile, imageData)
 		if Err.Message != "" {
 			return *logAction.Error
 		}
 
 		if len(currentImages) != 0 {
 			// Get images after upload
-			updatedImages, Err := getCurrentImages(ctx, item, "Updated")
+			updatedImages, Err := e.getCurrentImages(ctx, item, "Updated")
 			if Err.Message != "" {
 				return *logAction.Error
 			}
@@ -72,7 +71,7 @@ func applyImageToMediaItem(ctx context.Context, item *models.MediaItem, imageFil
 
 			// Now we change the image index to 0, if it's not already 0
 			if newImage.ImageIndex != 0 {
-				err := updateImageIndex(ctx, item, newImage)
+				err := e.updateImageIndex(ctx, item, newImage)
 				if err.Message != "" {
 					return *logAction.Error
 				}
@@ -82,3 +81,8 @@ func applyImageToMediaItem(ctx context.Context, item *models.MediaItem, imageFil
 
 	return Err
 }
+
+// ApplyImageDataToMediaItem uploads an image that is already in memory (e.g. a previous version from the image history)
+func (e *EJ) ApplyImageDataToMediaItem(ctx context.Context, item *models.MediaItem, imageFile models.ImageFile, imageData []byte) (Err logging.LogErrorInfo) {
+	return e.applyImageToMediaItem(ctx, item, imageFile, imageData)
+}
@@ -1,7 +1,6 @@
 package ej
 
 import (
-	"aura/config"
 	"aura/logging"
 	"aura/mediux"
 	"aura/models"
@@ -13,10 +12,10 @@ import (
 	"golang.org/x/text/language"
 )
 
-func (ej *EJ) ApplyCollectionImage(ctx context.Context, collectionItem *models.CollectionItem, imageFile models.ImageFile) (Err logging.LogErrorInfo) {
+func (e *EJ) ApplyCollectionImage(ctx context.Context, collectionItem *models.CollectionItem, imageFile models.ImageFile) (Err logging.LogErrorInfo) {
 	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf(
 		"%s: Applying %s Image to %s",
-		config.Current.MediaServer.Type, cases.Title(language.English).String(imageFile.Type), utils.CollectionItemInfo(*collectionItem),
+		e.Config.Type, cases.Title(language.English).String(imageFile.Type), utils.CollectionItemInfo(*collectionItem),
 	), logging.LevelDebug)
 	defer logAction.Complete()
 
@@ -29,7 +28,7 @@ func (ej *EJ) ApplyCollectionImage(ctx context.Context, collectionItem *models.C
 
 	if imageFile.Type != "collection_backdrop" {
 		// Apply the Image to the Collection
-		Err = uploadCollectionImage(ctx, collectionItem, imageFile, imageData)
+		Err = e.uploadCollectionImage(ctx, collectionItem, imageFile, imageData)
 		if Err.Message != "" {
 			return Err
 		}
@@ -41,20 +40,20 @@ func (ej *EJ) ApplyCollectionImage(ctx context.Context, collectionItem *models.C
 		// Then we set the index of that image to 0
 
 		// Get current images
-		currentImages, Err := getCurrentCollectionImages(ctx, collectionItem, "Current")
+		currentImages, Err := e.getCurrentCollectionImages(ctx, collectionItem, "Current")
 		if Err.Message != "" {
 			return Err
 		}
 
 		// Upload the new image
-		Err = uploadCollectionImage(ctx, collectionItem, imageFile, imageData)
+		Err = e.uploadCollectionImage(ctx, collectionItem, imageFile, imageData)
 		if Err.Message != "" {
 			return Err
 		}
 
 		if len(currentImages) != 0 {
 			// Get images after upload
-			updatedImages, Err := getCurrentCollectionImages(ctx, collectionItem, "Updated")
+			updatedImages, Err := e.getCurrentCollectionImages(ctx, collectionItem, "Updated")
 			if Err.Message != "" {
 				return Err
 			}
@@ -73,7 +72,7 @@ func (ej *EJ) ApplyCollectionImage(ctx context.Context, collectionItem *models.C
 
 			// Now we change the image index to 0, if it's not already 0
 			if newImage.ImageIndex != 0 {
-				err := updateCollectionImageIndex(ctx, collectionItem, newImage)
+				err := e.updateCollectionImageIndex(ctx, collectionItem, newImage)
 				if err.Message != "" {
 					return *logAction.Error
 				}
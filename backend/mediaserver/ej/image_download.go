@@ -3,6 +3,7 @@ package ej
 import (
 	"aura/config"
 	"aura/logging"
+	"aura/mediaserver/localimages"
 	"aura/mediux"
 	"aura/models"
 	"aura/utils"
@@ -10,27 +11,48 @@ import (
 	"fmt"
 )
 
-func (e *EJ) DownloadApplyImageToMediaItem(ctx context.Context, item *models.MediaItem, imageFile models.ImageFile) (Err logging.LogErrorInfo) {
+func (e *EJ) DownloadApplyImageToMediaItem(ctx context.Context, item *models.MediaItem, imageFile models.ImageFile) (imageData []byte, Err logging.LogErrorInfo) {
 	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf(
 		"%s: Downloading and Applying %s Image for %s",
-		config.Current.MediaServer.Type, utils.GetFileDownloadName(item.Title, imageFile), utils.MediaItemInfo(*item),
+		e.Config.Type, utils.GetFileDownloadName(item.Title, imageFile), utils.MediaItemInfo(*item),
 	), logging.LevelDebug)
 	defer logAction.Complete()
 
 	// Get the Image from MediUX
 	// mediux.GetImage will handle checking the temp folder and caching based on config
 	formatDate := imageFile.Modified.Format("20060102150405")
-	imageData, _, Err := mediux.GetImage(ctx, imageFile.ID, formatDate, mediux.ImageQualityOriginal)
+	imageData, _, Err = mediux.GetImage(ctx, imageFile.ID, formatDate, mediux.ImageQualityOriginal)
 	if Err.Message != "" {
-		return Err
+		return nil, Err
+	}
+
+	// Save the Image next to the content before it is uploaded to the media server
+	if config.Current.Images.SaveImagesLocally.Enabled {
+		Err = e.saveImageLocally(ctx, item, imageFile, imageData)
+		if Err.Message != "" {
+			return nil, Err
+		}
 	}
 
 	// Apply the Image to the Media Item
-	Err = applyImageToMediaItem(ctx, item, imageFile, imageData)
+	Err = e.applyImageToMediaItem(ctx, item, imageFile, imageData)
 	if Err.Message != "" {
-		return Err
+		return nil, Err
 	}
 
 	Err = logging.LogErrorInfo{}
+	return imageData, Err
+}
+
+func (e *EJ) saveImageLocally(ctx context.Context, item *models.MediaItem, imageFile models.ImageFile, imageData []byte) (Err logging.LogErrorInfo) {
+	// The file paths of the movie or the episodes are only present in the full details of the item
+	if (item.Type == "movie" && item.Movie == nil) || (item.Type == "show" && item.Series == nil) {
+		_, Err = e.GetMediaItemDetails(ctx, item)
+		if Err.Message != "" {
+			return Err
+		}
+	}
+
+	_, Err = localimages.SaveImage(ctx, item, imageFile, imageData)
 	return Err
 }
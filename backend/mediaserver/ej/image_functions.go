@@ -1,7 +1,6 @@
 package ej
 
 import (
-	"aura/config"
 	"aura/logging"
 	"aura/models"
 	"aura/utils"
@@ -20,10 +19,10 @@ type EmbyJellyItemImagesResponse struct {
 	ImagePath  string `json:"Path,omitempty"` // Used in Emby responses
 }
 
-func getCurrentImages(ctx context.Context, item *models.MediaItem, itr string) ([]EmbyJellyItemImagesResponse, logging.LogErrorInfo) {
+func (e *EJ) getCurrentImages(ctx context.Context, item *models.MediaItem, itr string) ([]EmbyJellyItemImagesResponse, logging.LogErrorInfo) {
 	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf(
 		"%s: Getting %s Images for %s",
-		config.Current.MediaServer.Type, itr, utils.MediaItemInfo(*item),
+		e.Config.Type, itr, utils.MediaItemInfo(*item),
 	), logging.LevelDebug)
 	defer logAction.Complete()
 
@@ -31,7 +30,7 @@ func getCurrentImages(ctx context.Context, item *models.MediaItem, itr string) (
 	Err := logging.LogErrorInfo{}
 
 	// Construct the URL for the EJ server API request
-	u, err := url.Parse(config.Current.MediaServer.URL)
+	u, err := url.Parse(e.Config.URL)
 	if err != nil {
 		logAction.SetError(logging.Error_BaseUrlParsing(err))
 		return images, *logAction.Error
@@ -40,7 +39,7 @@ func getCurrentImages(ctx context.Context, item *models.MediaItem, itr string) (
 	URL := u.String()
 
 	// Make the HTTP Request to EJ
-	resp, respBody, Err := makeRequest(ctx, config.Current.MediaServer, URL, "GET", nil)
+	resp, respBody, Err := makeRequest(ctx, e.Config, URL, "GET", nil)
 	if Err.Message != "" {
 		logAction.SetErrorFromInfo(Err)
 		return images, *logAction.Error
@@ -48,7 +47,7 @@ func getCurrentImages(ctx context.Context, item *models.MediaItem, itr string) (
 	defer resp.Body.Close()
 
 	// Decode the Response
-	Err = httpx.DecodeResponseToJSON(ctx, respBody, &images, fmt.Sprintf("%s Media Item Images Response", config.Current.MediaServer.Type))
+	Err = httpx.DecodeResponseToJSON(ctx, respBody, &images, fmt.Sprintf("%s Media Item Images Response", e.Config.Type))
 	if Err.Message != "" {
 		return images, *logAction.Error
 	}
@@ -58,10 +57,10 @@ func getCurrentImages(ctx context.Context, item *models.MediaItem, itr string) (
 	return images, Err
 }
 
-func getCurrentCollectionImages(ctx context.Context, collectionItem *models.CollectionItem, itr string) ([]EmbyJellyItemImagesResponse, logging.LogErrorInfo) {
+func (e *EJ) getCurrentCollectionImages(ctx context.Context, collectionItem *models.CollectionItem, itr string) ([]EmbyJellyItemImagesResponse, logging.LogErrorInfo) {
 	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf(
 		"%s: Getting %s Images for Collection %s",
-		config.Current.MediaServer.Type, itr, utils.CollectionItemInfo(*collectionItem),
+		e.Config.Type, itr, utils.CollectionItemInfo(*collectionItem),
 	), logging.LevelDebug)
 	defer logAction.Complete()
 
@@ -69,7 +68,7 @@ func getCurrentCollectionImages(ctx context.Context, collectionItem *models.Coll
 	Err := logging.LogErrorInfo{}
 
 	// Construct the URL for the EJ server API request
-	u, err := url.Parse(config.Current.MediaServer.URL)
+	u, err := url.Parse(e.Config.URL)
 	if err != nil {
 		logAction.SetError(logging.Error_BaseUrlParsing(err))
 		return images, *logAction.Error
@@ -78,7 +77,7 @@ func getCurrentCollectionImages(ctx context.Context, collectionItem *models.Coll
 	URL := u.String()
 
 	// Make the HTTP Request to EJ
-	resp, respBody, Err := makeRequest(ctx, config.Current.MediaServer, URL, "GET", nil)
+	resp, respBody, Err := makeRequest(ctx, e.Config, URL, "GET", nil)
 	if Err.Message != "" {
 		logAction.SetErrorFromInfo(Err)
 		return images, *logAction.Error
@@ -86,7 +85,7 @@ func getCurrentCollectionImages(ctx context.Context, collectionItem *models.Coll
 	defer resp.Body.Close()
 
 	// Decode the Response
-	Err = httpx.DecodeResponseToJSON(ctx, respBody, &images, fmt.Sprintf("%s Collection Images Response", config.Current.MediaServer.Type))
+	Err = httpx.DecodeResponseToJSON(ctx, respBody, &images, fmt.Sprintf("%s Collection Images Response", e.Config.Type))
 	if Err.Message != "" {
 		return images, *logAction.Error
 	}
@@ -118,17 +117,17 @@ func findNewImage(ctx context.Context, currentImages, newImages []EmbyJellyItemI
 	return EmbyJellyItemImagesResponse{}
 }
 
-func updateImageIndex(ctx context.Context, item *models.MediaItem, image EmbyJellyItemImagesResponse) (Err logging.LogErrorInfo) {
+func (e *EJ) updateImageIndex(ctx context.Context, item *models.MediaItem, image EmbyJellyItemImagesResponse) (Err logging.LogErrorInfo) {
 	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf(
 		"%s: Updating Image Index for Backdrop Image on %s",
-		config.Current.MediaServer.Type, utils.MediaItemInfo(*item),
+		e.Config.Type, utils.MediaItemInfo(*item),
 	), logging.LevelDebug)
 	defer logAction.Complete()
 
 	Err = logging.LogErrorInfo{}
 
 	// Construct the URL for the EJ server API request
-	u, err := url.Parse(config.Current.MediaServer.URL)
+	u, err := url.Parse(e.Config.URL)
 	if err != nil {
 		logAction.SetError(logging.Error_BaseUrlParsing(err))
 		return *logAction.Error
@@ -140,7 +139,7 @@ func updateImageIndex(ctx context.Context, item *models.MediaItem, image EmbyJel
 	URL := u.String()
 
 	// Make the HTTP Request to EJ
-	resp, _, Err := makeRequest(ctx, config.Current.MediaServer, URL, "POST", nil)
+	resp, _, Err := makeRequest(ctx, e.Config, URL, "POST", nil)
 	if Err.Message != "" {
 		logAction.SetErrorFromInfo(Err)
 		return *logAction.Error
@@ -151,17 +150,17 @@ func updateImageIndex(ctx context.Context, item *models.MediaItem, image EmbyJel
 	return Err
 }
 
-func updateCollectionImageIndex(ctx context.Context, collectionItem *models.CollectionItem, image EmbyJellyItemImagesResponse) (Err logging.LogErrorInfo) {
+func (e *EJ) updateCollectionImageIndex(ctx context.Context, collectionItem *models.CollectionItem, image EmbyJellyItemImagesResponse) (Err logging.LogErrorInfo) {
 	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf(
 		"%s: Updating Image Index for Collection Backdrop Image on Collection %s",
-		config.Current.MediaServer.Type, utils.CollectionItemInfo(*collectionItem),
+		e.Config.Type, utils.CollectionItemInfo(*collectionItem),
 	), logging.LevelDebug)
 	defer logAction.Complete()
 
 	Err = logging.LogErrorInfo{}
 
 	// Construct the URL for the EJ server API request
-	u, err := url.Parse(config.Current.MediaServer.URL)
+	u, err := url.Parse(e.Config.URL)
 	if err != nil {
 		logAction.SetError(logging.Error_BaseUrlParsing(err))
 		return *logAction.Error
@@ -173,7 +172,7 @@ func updateCollectionImageIndex(ctx context.Context, collectionItem *models.Coll
 	URL := u.String()
 
 	// Make the HTTP Request to EJ
-	resp, _, Err := makeRequest(ctx, config.Current.MediaServer, URL, "POST", nil)
+	resp, _, Err := makeRequest(ctx, e.Config, URL, "POST", nil)
 	if Err.Message != "" {
 		logAction.SetErrorFromInfo(Err)
 		return *logAction.Error
@@ -184,10 +183,10 @@ func updateCollectionImageIndex(ctx context.Context, collectionItem *models.Coll
 	return Err
 }
 
-func uploadImage(ctx context.Context, item *models.MediaItem, itemRatingKey string, imageFile models.ImageFile, imageData []byte) (Err logging.LogErrorInfo) {
+func (e *EJ) uploadImage(ctx context.Context, item *models.MediaItem, itemRatingKey string, imageFile models.ImageFile, imageData []byte) (Err logging.LogErrorInfo) {
 	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf(
 		"%s: Uploading %s Image for %s",
-		config.Current.MediaServer.Type, utils.GetFileDownloadName(item.Title, imageFile), utils.MediaItemInfo(*item),
+		e.Config.Type, utils.GetFileDownloadName(item.Title, imageFile), utils.MediaItemInfo(*item),
 	), logging.LevelDebug)
 	defer logAction.Complete()
 	Err = logging.LogErrorInfo{}
@@ -198,7 +197,7 @@ func uploadImage(ctx context.Context, item *models.MediaItem, itemRatingKey stri
 	}
 
 	// Construct the URL for the EJ server API request
-	u, err := url.Parse(config.Current.MediaServer.URL)
+	u, err := url.Parse(e.Config.URL)
 	if err != nil {
 		logAction.SetError(logging.Error_BaseUrlParsing(err))
 		return *logAction.Error
@@ -210,7 +209,7 @@ func uploadImage(ctx context.Context, item *models.MediaItem, itemRatingKey stri
 	base64ImageData := base64.StdEncoding.EncodeToString(imageData)
 
 	// Make the HTTP Request to EJ
-	resp, _, Err := makeRequest(ctx, config.Current.MediaServer, URL, "POST", []byte(base64ImageData))
+	resp, _, Err := makeRequest(ctx, e.Config, URL, "POST", []byte(base64ImageData))
 	if Err.Message != "" {
 		logAction.SetErrorFromInfo(Err)
 		return Err
@@ -220,10 +219,10 @@ func uploadImage(ctx context.Context, item *models.MediaItem, itemRatingKey stri
 	return Err
 }
 
-func uploadCollectionImage(ctx context.Context, collectionItem *models.CollectionItem, imageFile models.ImageFile, imageData []byte) (Err logging.LogErrorInfo) {
+func (e *EJ) uploadCollectionImage(ctx context.Context, collectionItem *models.CollectionItem, imageFile models.ImageFile, imageData []byte) (Err logging.LogErrorInfo) {
 	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf(
 		"%s: Uploading %s Image for Collection %s",
-		config.Current.MediaServer.Type, utils.GetFileDownloadName(collectionItem.Title, imageFile), utils.CollectionItemInfo(*collectionItem),
+		e.Config.Type, utils.GetFileDownloadName(collectionItem.Title, imageFile), utils.CollectionItemInfo(*collectionItem),
 	), logging.LevelDebug)
 	defer logAction.Complete()
 	Err = logging.LogErrorInfo{}
@@ -236,7 +235,7 @@ func uploadCollectionImage(ctx context.Context, collectionItem *models.Collectio
 	}
 
 	// Construct the URL for the EJ server API request
-	u, err := url.Parse(config.Current.MediaServer.URL)
+	u, err := url.Parse(e.Config.URL)
 	if err != nil {
 		logAction.SetError(logging.Error_BaseUrlParsing(err))
 		return *logAction.Error
@@ -248,7 +247,7 @@ func uploadCollectionImage(ctx context.Context, collectionItem *models.Collectio
 	base64ImageData := base64.StdEncoding.EncodeToString(imageData)
 
 	// Make the HTTP Request to EJ
-	resp, _, Err := makeRequest(ctx, config.Current.MediaServer, URL, "POST", []byte(base64ImageData))
+	resp, _, Err := makeRequest(ctx, e.Config, URL, "POST", []byte(base64ImageData))
 	if Err.Message != "" {
 		logAction.SetErrorFromInfo(Err)
 		return *logAction.Error
@@ -0,0 +1,66 @@
+package ej
+
+import (
+	"aura/logging"
+	"aura/models"
+	"aura/utils"
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+)
+
+// ResetImageToDefault deletes the image aura uploaded and refreshes the images of the item,
+// so Emby/Jellyfin downloads the image of its metadata provider again.
+func (e *EJ) ResetImageToDefault(ctx context.Context, item *models.MediaItem, imageFile models.ImageFile) (Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf(
+		"%s: Resetting %s Image for %s",
+		e.Config.Type, utils.GetFileDownloadName(item.Title, imageFile), utils.MediaItemInfo(*item),
+	), logging.LevelDebug)
+	defer logAction.Complete()
+
+	// Determine the Item Rating Key from Emby/Jellyfin
+	itemRatingKey := getItemRatingKeyFromImageFile(*item, imageFile)
+	if itemRatingKey == "" {
+		logAction.SetError("Failed to determine Rating Key for Media Item", "Ensure the Media Item and Image File data are correct", nil)
+		return *logAction.Error
+	}
+
+	// Uploaded backdrops are moved to index 0, every other image type only has one image
+	imageType := "Primary"
+	if imageFile.Type == "backdrop" {
+		imageType = "Backdrop"
+	}
+
+	u, err := url.Parse(e.Config.URL)
+	if err != nil {
+		logAction.SetError(logging.Error_BaseUrlParsing(err))
+		return *logAction.Error
+	}
+	basePath := u.Path
+	u.Path = path.Join(basePath, "Items", itemRatingKey, "Images", imageType, "0")
+	resp, _, Err := makeRequest(ctx, e.Config, u.String(), "DELETE", nil)
+	if Err.Message != "" {
+		logAction.SetErrorFromInfo(Err)
+		return *logAction.Error
+	}
+	defer resp.Body.Close()
+
+	// Only missing images are downloaded, the other images of the item are kept
+	u.Path = path.Join(basePath, "Items", itemRatingKey, "Refresh")
+	query := u.Query()
+	query.Set("Recursive", "false")
+	query.Set("ImageRefreshMode", "FullRefresh")
+	query.Set("MetadataRefreshMode", "Default")
+	query.Set("ReplaceAllImages", "false")
+	query.Set("ReplaceAllMetadata", "false")
+	u.RawQuery = query.Encode()
+	resp, _, Err = makeRequest(ctx, e.Config, u.String(), "POST", nil)
+	if Err.Message != "" {
+		logAction.SetErrorFromInfo(Err)
+		return *logAction.Error
+	}
+	defer resp.Body.Close()
+
+	return logging.LogErrorInfo{}
+}
@@ -1,7 +1,6 @@
 package ej
 
 import (
-	"aura/config"
 	"aura/logging"
 	"aura/models"
 	"aura/utils"
@@ -14,14 +13,14 @@ import (
 func (e *EJ) RefreshMediaItemMetadata(ctx context.Context, item *models.MediaItem, refreshRatingKey string, updateImage bool) (Err logging.LogErrorInfo) {
 	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf(
 		"%s: Refreshing Metadata for %s - Refresh Key: %s",
-		config.Current.MediaServer.Type,
+		e.Config.Type,
 		utils.MediaItemInfo(*item),
 		refreshRatingKey,
 	), logging.LevelDebug)
 	defer logAction.Complete()
 
 	// Construct the URL for the Emby/Jellyfin API request
-	u, err := url.Parse(config.Current.MediaServer.URL)
+	u, err := url.Parse(e.Config.URL)
 	if err != nil {
 		logAction.SetError("Failed to parse base URL", "Ensure the URL is valid", map[string]any{"error": err.Error()})
 		return *logAction.Error
@@ -38,7 +37,7 @@ func (e *EJ) RefreshMediaItemMetadata(ctx context.Context, item *models.MediaIte
 	URL := u.String()
 
 	// Make the HTTP Request to Emby/Jellyfin
-	resp, _, Err := makeRequest(ctx, config.Current.MediaServer, URL, "POST", nil)
+	resp, _, Err := makeRequest(ctx, e.Config, URL, "POST", nil)
 	if Err.Message != "" {
 		logAction.SetErrorFromInfo(Err)
 		return *logAction.Error
@@ -4,6 +4,7 @@ import (
 	"aura/cache"
 	"aura/config"
 	"aura/logging"
+	"aura/models"
 	"context"
 	"sort"
 	"strconv"
@@ -41,10 +42,21 @@ func getAllLibrarySectionsAndItemsImpl(ctx context.Context) (success bool) {
 
 	success = true
 
-	configuredSections := config.Current.MediaServer.Libraries
+	configuredSections := append([]models.LibrarySection{}, config.Current.MediaServer.Libraries...)
 
-	// Sort sections by Title to ensure consistent order
+	// Add the libraries of any additional media servers, keyed by the server name
+	for _, msConfig := range config.Current.AdditionalMediaServers {
+		for _, library := range msConfig.Libraries {
+			library.Server = msConfig.Name
+			configuredSections = append(configuredSections, library)
+		}
+	}
+
+	// Sort sections by Server and Title to ensure consistent order
 	sort.SliceStable(configuredSections, func(i, j int) bool {
+		if configuredSections[i].Server != configuredSections[j].Server {
+			return configuredSections[i].Server < configuredSections[j].Server
+		}
 		return configuredSections[i].Title < configuredSections[j].Title
 	})
 
@@ -59,7 +71,8 @@ func getAllLibrarySectionsAndItemsImpl(ctx context.Context) (success bool) {
 		}
 
 		// Update the collections cache for this section
-		if (section.Type == "movie" || section.Type == "mixed") && !ejRanCollections {
+		// Collections are only tracked for the primary media server
+		if (section.Type == "movie" || section.Type == "mixed") && section.Server == "" && !ejRanCollections {
 			GetMovieCollections(ctx, section)
 			if config.Current.MediaServer.Type == "Emby" || config.Current.MediaServer.Type == "Jellyfin" {
 				ejRanCollections = true
@@ -77,6 +90,7 @@ func getAllLibrarySectionsAndItemsImpl(ctx context.Context) (success bool) {
 				return false
 			}
 			logging.LOGGER.Info().Timestamp().
+				Str("media_server", config.MediaServerDisplayName(section.Server)).
 				Str("section_title", section.Title).
 				Str("section_id", section.ID).
 				Int("fetched_items", len(items)).
@@ -0,0 +1,115 @@
+package mediaserver
+
+import (
+	"aura/database"
+	"aura/mediux"
+	"aura/models"
+	"aura/utils"
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// ImageFingerprintKey is the place of an image on a Media Item, as stored in the ImageFingerprints table.
+// It is empty for image types that are not fingerprinted.
+func ImageFingerprintKey(imageFile models.ImageFile) string {
+	switch imageFile.Type {
+	case "poster", "backdrop":
+		return imageFile.Type
+	case "season_poster":
+		if imageFile.SeasonNumber != nil {
+			return fmt.Sprintf("season_poster:S%02d", *imageFile.SeasonNumber)
+		}
+	case "titlecard":
+		if imageFile.SeasonNumber != nil && imageFile.EpisodeNumber != nil {
+			return fmt.Sprintf("titlecard:S%02dE%02d", *imageFile.SeasonNumber, *imageFile.EpisodeNumber)
+		}
+	}
+	return ""
+}
+
+// fingerprintMaxDistance is the number of differing perceptual hash bits up to which an image counts as unchanged.
+// Re-encoding or a different quality of the same image changes a few bits at most, while a reworked image changes many.
+const fingerprintMaxDistance = 2
+
+// imagePerceptualHash returns the perceptual hash of image data as 16 hex digits
+func imagePerceptualHash(imageData []byte) (string, error) {
+	hash, err := utils.PerceptualHash(imageData)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%016x", hash), nil
+}
+
+// ImageMatchesFingerprint reports whether the MediUX image of imageFile looks the same as the image that was last applied
+// in the same place of the item on its media server.
+// The image is only downloaded when there is a fingerprint to compare it with.
+func ImageMatchesFingerprint(ctx context.Context, item models.MediaItem, imageFile models.ImageFile) bool {
+	key := ImageFingerprintKey(imageFile)
+	if key == "" {
+		return false
+	}
+
+	// A new rating key means the media server no longer has the image that was applied
+	imageRatingKey, _ := ImageRatingKeyAndType(item, imageFile)
+	if imageRatingKey == "" {
+		return false
+	}
+
+	fingerprint, found, Err := database.GetImageFingerprint(ctx, item.Server, item.TMDB_ID, item.LibraryTitle, key)
+	if Err.Message != "" || !found || fingerprint.ImageRatingKey != imageRatingKey {
+		return false
+	}
+	appliedHash, err := strconv.ParseUint(fingerprint.PerceptualHash, 16, 64)
+	if err != nil {
+		return false
+	}
+
+	// The perceptual hash does not depend on the quality, so the smaller optimized image is enough
+	formatDate := imageFile.Modified.Format("20060102150405")
+	imageData, _, Err := mediux.GetImage(ctx, imageFile.ID, formatDate, mediux.ImageQualityOptimized)
+	if Err.Message != "" {
+		return false
+	}
+	newHash, err := utils.PerceptualHash(imageData)
+	if err != nil {
+		return false
+	}
+	return utils.PerceptualHashDistance(appliedHash, newHash) <= fingerprintMaxDistance
+}
+
+// saveImageFingerprint records the perceptual hash of the image data that was applied to the item.
+// Without readable image data (e.g. Plex got the image from MediUX itself) the old fingerprint is removed.
+func saveImageFingerprint(ctx context.Context, item models.MediaItem, imageFile models.ImageFile, imageData []byte) {
+	key := ImageFingerprintKey(imageFile)
+	if key == "" {
+		return
+	}
+	hash, err := imagePerceptualHash(imageData)
+	if err != nil {
+		forgetImageFingerprint(ctx, &item, imageFile)
+		return
+	}
+	imageRatingKey, _ := ImageRatingKeyAndType(item, imageFile)
+	if imageRatingKey == "" {
+		return
+	}
+	database.UpsertImageFingerprint(ctx, models.DBImageFingerprint{
+		Server:         item.Server,
+		TMDB_ID:        item.TMDB_ID,
+		LibraryTitle:   item.LibraryTitle,
+		ImageKey:       key,
+		ImageRatingKey: imageRatingKey,
+		ImageID:        imageFile.ID,
+		PerceptualHash: hash,
+	})
+}
+
+// forgetImageFingerprint removes the fingerprint of an image that was replaced without a known perceptual hash
+func forgetImageFingerprint(ctx context.Context, item *models.MediaItem, imageFile models.ImageFile) {
+	key := ImageFingerprintKey(imageFile)
+	if key == "" {
+		return
+	}
+	database.DeleteImageFingerprint(ctx, item.Server, item.TMDB_ID, item.LibraryTitle, key)
+}
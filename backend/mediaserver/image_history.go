@@ -0,0 +1,204 @@
+package mediaserver
+
+import (
+	"aura/config"
+	"aura/database"
+	"aura/logging"
+	"aura/models"
+	"aura/utils"
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"time"
+)
+
+// ImageHistoryFolder is where the replaced images of the media server are stored
+func ImageHistoryFolder() string {
+	return path.Join(config.ConfigPath, "image-history")
+}
+
+// ImageRatingKeyAndType returns the rating key of the item that holds the image (the season or episode for
+// season posters and titlecards) and the image type to use with GetMediaItemImage.
+// The rating key is empty when the season or episode of the image is not on the media server.
+func ImageRatingKeyAndType(item models.MediaItem, imageFile models.ImageFile) (imageRatingKey string, imageType string) {
+	switch imageFile.Type {
+	case "poster", "backdrop":
+		return item.RatingKey, imageFile.Type
+	case "season_poster", "titlecard":
+	default:
+		return "", ""
+	}
+
+	imageType = "poster"
+	if imageFile.Type == "titlecard" {
+		// Plex keeps titlecards as the thumb of the episode, Emby and Jellyfin as its primary image
+		if msConfig, found := config.GetMediaServerByName(item.Server); found && msConfig.Type == "Plex" {
+			imageType = "thumb"
+		}
+	}
+	if item.Series == nil || imageFile.SeasonNumber == nil {
+		return "", imageType
+	}
+	for _, season := range item.Series.Seasons {
+		if season.SeasonNumber != *imageFile.SeasonNumber {
+			continue
+		}
+		if imageFile.Type == "season_poster" {
+			return season.RatingKey, imageType
+		}
+		for _, episode := range season.Episodes {
+			if imageFile.EpisodeNumber != nil && episode.EpisodeNumber == *imageFile.EpisodeNumber {
+				return episode.RatingKey, imageType
+			}
+		}
+	}
+	return "", imageType
+}
+
+// imageSnapshot is the image the media server had in the place of imageFile before it was replaced
+type imageSnapshot struct {
+	item           models.MediaItem
+	imageFile      models.ImageFile
+	imageRatingKey string
+	imageData      []byte
+}
+
+// snapshotImage gets the image the media server currently has in the place of imageFile.
+// The snapshot is only added to the image history with save once the new image was applied.
+// This is best effort, a failed snapshot never stops the new image from being applied.
+func snapshotImage(ctx context.Context, item *models.MediaItem, imageFile models.ImageFile) *imageSnapshot {
+	if !config.Current.Images.History.Enabled {
+		return nil
+	}
+
+	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf(
+		"Getting Current %s Image of %s for Image History", utils.GetFileDownloadName(item.Title, imageFile), utils.MediaItemInfo(*item)),
+		logging.LevelDebug)
+	defer logAction.Complete()
+
+	imageRatingKey, imageType := ImageRatingKeyAndType(*item, imageFile)
+	if imageRatingKey == "" {
+		logAction.AppendWarning("message", "The media server does not have this image yet")
+		return nil
+	}
+
+	imageData, Err := GetMediaItemImage(ctx, item, imageRatingKey, imageType)
+	if Err.Message != "" || len(imageData) == 0 {
+		logAction.AppendWarning("message", "Failed to get the current image from the media server")
+		return nil
+	}
+
+	return &imageSnapshot{item: *item, imageFile: imageFile, imageRatingKey: imageRatingKey, imageData: imageData}
+}
+
+// save adds the snapshot to the image history, after the image it was taken for has been applied
+func (snapshot *imageSnapshot) save(ctx context.Context) {
+	if snapshot == nil {
+		return
+	}
+	item, imageFile, imageRatingKey := snapshot.item, snapshot.imageFile, snapshot.imageRatingKey
+
+	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf(
+		"Saving Replaced %s Image of %s to Image History", utils.GetFileDownloadName(item.Title, imageFile), utils.MediaItemInfo(item)),
+		logging.LevelDebug)
+	defer logAction.Complete()
+
+	if err := os.MkdirAll(ImageHistoryFolder(), 0755); err != nil {
+		logAction.AppendWarning("message", fmt.Sprintf("Failed to create the image history folder: %s", err.Error()))
+		return
+	}
+	fileName := fmt.Sprintf("%s_%s_%d.jpg", sanitizeFileNamePart(imageRatingKey), imageFile.Type, time.Now().UnixNano())
+	if err := os.WriteFile(path.Join(ImageHistoryFolder(), fileName), snapshot.imageData, 0644); err != nil {
+		logAction.AppendWarning("message", fmt.Sprintf("Failed to save the current image: %s", err.Error()))
+		return
+	}
+
+	_, Err := database.AddImageHistoryEntry(ctx, models.DBImageHistoryEntry{
+		TMDB_ID:        item.TMDB_ID,
+		LibraryTitle:   item.LibraryTitle,
+		Server:         item.Server,
+		Title:          item.Title,
+		RatingKey:      item.RatingKey,
+		ImageRatingKey: imageRatingKey,
+		ImageType:      imageFile.Type,
+		SeasonNumber:   imageFile.SeasonNumber,
+		EpisodeNumber:  imageFile.EpisodeNumber,
+		FileName:       fileName,
+		ReplacedBy:     imageFile.ID,
+	})
+	if Err.Message != "" {
+		os.Remove(path.Join(ImageHistoryFolder(), fileName))
+		return
+	}
+
+	pruneImageHistory(ctx, item.RatingKey, imageRatingKey, imageFile.Type)
+}
+
+// pruneImageHistory removes the oldest versions of an image beyond Images.History.MaxVersions
+func pruneImageHistory(ctx context.Context, ratingKey, imageRatingKey, imageType string) {
+	maxVersions := config.Current.Images.History.MaxVersions
+	if maxVersions <= 0 {
+		return
+	}
+
+	entries, Err := database.GetImageHistory(ctx, models.DBImageHistoryFilter{
+		RatingKey:      ratingKey,
+		ImageRatingKey: imageRatingKey,
+		ImageType:      imageType,
+	})
+	if Err.Message != "" || len(entries) <= maxVersions {
+		return
+	}
+
+	ids := []int64{}
+	for _, entry := range entries[maxVersions:] {
+		ids = append(ids, entry.ID)
+		os.Remove(path.Join(ImageHistoryFolder(), entry.FileName))
+	}
+	database.DeleteImageHistoryEntries(ctx, ids)
+}
+
+// RestoreImageHistoryEntry applies a previous version of an image to the media server.
+// The image that is replaced by the restore is kept in the history as well.
+func RestoreImageHistoryEntry(ctx context.Context, item *models.MediaItem, entry models.DBImageHistoryEntry) (Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf(
+		"Restoring %s Image of %s from Image History", entry.ImageType, utils.MediaItemInfo(*item)),
+		logging.LevelInfo)
+	defer logAction.Complete()
+
+	imageData, err := os.ReadFile(path.Join(ImageHistoryFolder(), entry.FileName))
+	if err != nil {
+		logAction.SetError("Failed to read the saved image", "The image file was removed from the image-history folder", map[string]any{
+			"error":     err.Error(),
+			"file_name": entry.FileName,
+		})
+		return *logAction.Error
+	}
+
+	imageFile := models.ImageFile{
+		ID:            fmt.Sprintf("history-%d", entry.ID),
+		Type:          entry.ImageType,
+		SeasonNumber:  entry.SeasonNumber,
+		EpisodeNumber: entry.EpisodeNumber,
+	}
+
+	snapshot := snapshotImage(ctx, item, imageFile)
+	Err = ApplyImageDataToMediaItem(ctx, item, imageFile, imageData)
+	if Err.Message != "" {
+		return Err
+	}
+	snapshot.save(ctx)
+	return Err
+}
+
+// sanitizeFileNamePart keeps rating keys usable as part of a file name
+func sanitizeFileNamePart(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || r == ':' {
+			return '-'
+		}
+		return r
+	}, s)
+}
@@ -0,0 +1,289 @@
+package localimages
+
+import (
+	"aura/config"
+	"aura/logging"
+	"aura/models"
+	"aura/utils"
+	"context"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+)
+
+const (
+	NamingProfilePlex   = "plex"
+	NamingProfileKodi   = "kodi"
+	NamingProfileEmby   = "emby" // Emby and Jellyfin share the same local artwork naming
+	NamingProfileCustom = "custom"
+)
+
+var episodeCodeRegex = regexp.MustCompile(`(?i)\bS?\d{1,3}[Ex]\d{1,3}\b`)
+
+// NamingProfile returns the configured naming profile, falling back to the naming of the type of the media server
+func NamingProfile(server string) string {
+	profile := config.Current.Images.SaveImagesLocally.NamingProfile
+	if profile != "" {
+		return profile
+	}
+	msConfig, found := config.GetMediaServerByName(server)
+	if found && (msConfig.Type == "Emby" || msConfig.Type == "Jellyfin") {
+		return NamingProfileEmby
+	}
+	return NamingProfilePlex
+}
+
+// FileNames returns the folder next to the content that holds the image and the file names the image is saved as.
+// Some profiles save an image under more than one name (e.g. the Kodi show poster is also the "All Seasons" poster).
+// The item needs its full details (movie file or series episodes) from the media server.
+func FileNames(ctx context.Context, item models.MediaItem, imageFile models.ImageFile) (folder string, fileNames []string, Err logging.LogErrorInfo) {
+	profile := NamingProfile(item.Server)
+	_, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf("Determining File Path for %s (%s)", imageFile.Type, item.Title), logging.LevelTrace)
+	defer logAction.Complete()
+	logAction.AppendResult("naming_profile", profile)
+
+	switch item.Type {
+	case "movie":
+		if item.Movie == nil || item.Movie.File.Path == "" {
+			logAction.SetError("Failed to determine file path for movie",
+				"The media server did not return the file path of the movie",
+				map[string]any{
+					"rating_key": item.RatingKey,
+				})
+			return "", nil, *logAction.Error
+		}
+		folder = path.Dir(item.Movie.File.Path)
+	case "show":
+		if item.Series == nil || item.Series.Location == "" {
+			logAction.SetError("Failed to determine folder of show",
+				"The media server did not return the location of the show",
+				map[string]any{
+					"rating_key": item.RatingKey,
+				})
+			return "", nil, *logAction.Error
+		}
+		folder = item.Series.Location
+	default:
+		logAction.SetError("Unsupported Media Item Type for Poster Update",
+			"Only 'movie' and 'show' types are supported for poster updates",
+			map[string]any{
+				"item_type": item.Type,
+			})
+		return "", nil, *logAction.Error
+	}
+
+	// Titlecards are saved next to the episode file
+	episodePath := ""
+	if imageFile.Type == "titlecard" {
+		episodePath = episodePathFromImageFile(item, imageFile)
+		if episodePath == "" {
+			logAction.SetError("Failed to determine file path for titlecard",
+				"Could not find episode path in the media server data",
+				map[string]any{
+					"rating_key": item.RatingKey,
+				})
+			return "", nil, *logAction.Error
+		}
+		folder = path.Dir(episodePath)
+	}
+
+	ok := true
+	switch profile {
+	case NamingProfileKodi:
+		fileNames = kodiFileNames(item, imageFile, episodePath)
+	case NamingProfileEmby:
+		fileNames = embyFileNames(imageFile, episodePath)
+	case NamingProfileCustom:
+		fileNames, ok = customFileNames(logAction, item, imageFile, episodePath)
+	default:
+		fileNames, ok = plexFileNames(logAction, imageFile, episodePath)
+	}
+	if !ok {
+		return "", nil, *logAction.Error
+	}
+
+	if len(fileNames) == 0 {
+		logAction.SetError("Unsupported Image Type for Local Save",
+			fmt.Sprintf("The '%s' naming profile has no file name for this image type", profile),
+			map[string]any{
+				"image_type": imageFile.Type,
+			})
+		return "", nil, *logAction.Error
+	}
+	logAction.AppendResult("folder", folder)
+	logAction.AppendResult("file_names", fileNames)
+	return folder, fileNames, logging.LogErrorInfo{}
+}
+
+// plexFileNames follows the local asset naming of Plex
+func plexFileNames(logAction *logging.LogAction, imageFile models.ImageFile, episodePath string) (fileNames []string, ok bool) {
+	switch imageFile.Type {
+	case "poster":
+		return []string{"poster.jpg"}, true
+	case "backdrop":
+		return []string{"backdrop.jpg"}, true
+	case "season_poster":
+		return []string{seasonPosterFileName(imageFile)}, true
+	case "special_season_poster":
+		return []string{"season-specials-poster.jpg"}, true
+	case "titlecard":
+		episodeNamingConvention := config.Current.Images.SaveImagesLocally.EpisodeNamingConvention
+		switch episodeNamingConvention {
+		case "match", "":
+			return []string{episodeBaseName(episodePath) + "-thumb.jpg"}, true
+		case "static":
+			return []string{episodeCode(imageFile, episodePath) + ".jpg"}, true
+		default:
+			logAction.SetError("Invalid Episode Naming Convention",
+				"EpisodeNamingConvention must be either 'match' or 'static'",
+				map[string]any{
+					"EpisodeNamingConvention": episodeNamingConvention,
+				})
+			return nil, false
+		}
+	}
+	return nil, true
+}
+
+// kodiFileNames follows the artwork naming of Kodi (and the NFO based tools that write it)
+func kodiFileNames(item models.MediaItem, imageFile models.ImageFile, episodePath string) []string {
+	switch imageFile.Type {
+	case "poster":
+		if item.Type == "show" {
+			return []string{"poster.jpg", "season-all-poster.jpg"}
+		}
+		return []string{"poster.jpg"}
+	case "backdrop":
+		return []string{"fanart.jpg"}
+	case "season_poster":
+		return []string{seasonPosterFileName(imageFile)}
+	case "special_season_poster":
+		return []string{"season-specials-poster.jpg"}
+	case "titlecard":
+		return []string{episodeBaseName(episodePath) + "-thumb.jpg"}
+	}
+	return nil
+}
+
+// embyFileNames follows the local artwork naming of Emby and Jellyfin.
+// Backdrops are also saved as the landscape (Thumb) image, which both servers show in their landscape views.
+func embyFileNames(imageFile models.ImageFile, episodePath string) []string {
+	switch imageFile.Type {
+	case "poster":
+		return []string{"folder.jpg"}
+	case "backdrop":
+		return []string{"backdrop.jpg", "landscape.jpg"}
+	case "season_poster":
+		return []string{seasonPosterFileName(imageFile)}
+	case "special_season_poster":
+		return []string{"season-specials-poster.jpg"}
+	case "titlecard":
+		return []string{episodeBaseName(episodePath) + "-thumb.jpg"}
+	}
+	return nil
+}
+
+// customFileNames renders the user defined NamingTemplate. Image types without a template use the Plex name.
+func customFileNames(logAction *logging.LogAction, item models.MediaItem, imageFile models.ImageFile, episodePath string) (fileNames []string, ok bool) {
+	namingTemplate := config.Current.Images.SaveImagesLocally.NamingTemplate
+	template := ""
+	switch imageFile.Type {
+	case "poster":
+		template = namingTemplate.Poster
+	case "backdrop":
+		template = namingTemplate.Backdrop
+	case "season_poster":
+		template = namingTemplate.SeasonPoster
+	case "special_season_poster":
+		template = namingTemplate.SpecialSeasonPoster
+	case "titlecard":
+		template = namingTemplate.Titlecard
+	}
+	if strings.TrimSpace(template) == "" {
+		return plexFileNames(logAction, imageFile, episodePath)
+	}
+
+	vars := map[string]string{
+		"Title":           item.Title,
+		"Year":            fmt.Sprintf("%d", item.Year),
+		"Season":          "",
+		"Episode":         "",
+		"EpisodeCode":     "",
+		"EpisodeFileName": "",
+	}
+	if imageFile.SeasonNumber != nil {
+		vars["Season"] = utils.FormatIntAsTwoDigitString(*imageFile.SeasonNumber)
+	}
+	if imageFile.EpisodeNumber != nil {
+		vars["Episode"] = utils.FormatIntAsTwoDigitString(*imageFile.EpisodeNumber)
+	}
+	if episodePath != "" {
+		vars["EpisodeCode"] = episodeCode(imageFile, episodePath)
+		vars["EpisodeFileName"] = episodeBaseName(episodePath)
+	}
+
+	fileName := strings.TrimSpace(utils.RenderTemplate(template, vars))
+	if path.Ext(fileName) == "" {
+		fileName += ".jpg"
+	}
+
+	// The rendered name has to stay inside the content folder
+	cleaned := path.Clean(fileName)
+	if path.IsAbs(cleaned) || cleaned == "." || strings.HasPrefix(cleaned, "..") || strings.Contains(cleaned, "{{") {
+		logAction.SetError("Invalid Naming Template",
+			"The NamingTemplate must render to a file name inside the content folder and only use the supported variables",
+			map[string]any{
+				"template":   template,
+				"image_type": imageFile.Type,
+				"file_name":  fileName,
+			})
+		return nil, false
+	}
+	return []string{cleaned}, true
+}
+
+func seasonPosterFileName(imageFile models.ImageFile) string {
+	if imageFile.SeasonNumber == nil {
+		return "season-poster.jpg"
+	}
+	return fmt.Sprintf("season%s-poster.jpg", utils.FormatIntAsTwoDigitString(*imageFile.SeasonNumber))
+}
+
+// episodeBaseName is the file name of the episode without its extension
+func episodeBaseName(episodePath string) string {
+	fileName := path.Base(episodePath)
+	return strings.TrimSuffix(fileName, path.Ext(fileName))
+}
+
+// episodeCode returns the SxxEyy part of the episode file name, or builds it from the image file
+func episodeCode(imageFile models.ImageFile, episodePath string) string {
+	if matchedString := episodeCodeRegex.FindString(episodeBaseName(episodePath)); matchedString != "" {
+		return matchedString
+	}
+	seasonNumber, episodeNumber := 0, 0
+	if imageFile.SeasonNumber != nil {
+		seasonNumber = *imageFile.SeasonNumber
+	}
+	if imageFile.EpisodeNumber != nil {
+		episodeNumber = *imageFile.EpisodeNumber
+	}
+	return fmt.Sprintf("S%02dE%02d", seasonNumber, episodeNumber)
+}
+
+func episodePathFromImageFile(item models.MediaItem, imageFile models.ImageFile) string {
+	if item.Series == nil || imageFile.SeasonNumber == nil || imageFile.EpisodeNumber == nil {
+		return ""
+	}
+	for _, season := range item.Series.Seasons {
+		if season.SeasonNumber != *imageFile.SeasonNumber {
+			continue
+		}
+		for _, episode := range season.Episodes {
+			if episode.EpisodeNumber == *imageFile.EpisodeNumber && episode.SeasonNumber == *imageFile.SeasonNumber {
+				return episode.File.Path
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,213 @@
+package localimages
+
+import (
+	"aura/cache"
+	"aura/config"
+	"aura/logging"
+	"aura/models"
+	"aura/utils"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"time"
+)
+
+// SaveImage saves the image next to the content of the media item, named after the configured naming profile.
+// When SaveImagesLocally.Path is set, the image is saved below that path instead and isCustomLocalPath is true.
+// The item needs its full details (movie file or series episodes) from the media server.
+func SaveImage(ctx context.Context, item *models.MediaItem, imageFile models.ImageFile, imageData []byte) (isCustomLocalPath bool, Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf(
+		"Saving %s Image for %s",
+		utils.GetFileDownloadName(item.Title, imageFile), utils.MediaItemInfo(*item),
+	), logging.LevelDebug)
+	defer logAction.Complete()
+
+	isCustomLocalPath = false
+	Err = logging.LogErrorInfo{}
+
+	newFilePath, newFileNames, Err := FileNames(ctx, *item, imageFile)
+	if Err.Message != "" {
+		return isCustomLocalPath, Err
+	}
+
+	if config.Current.Images.SaveImagesLocally.Path != "" {
+		isCustomLocalPath = true
+		newFilePath, Err = customLocalFolder(ctx, item, imageFile, newFilePath)
+		if Err.Message != "" {
+			return isCustomLocalPath, Err
+		}
+	}
+
+	createFileAction := logAction.AddSubAction("Saving Image to New File Path", logging.LevelDebug)
+	defer createFileAction.Complete()
+	for _, newFileName := range newFileNames {
+		savedFilePath := ConvertWindowsPathToDockerPath(path.Join(newFilePath, newFileName))
+
+		// Ensure the directory exists (a custom template may name a subfolder)
+		err := os.MkdirAll(path.Dir(savedFilePath), os.ModePerm)
+		if err != nil {
+			createFileAction.SetError("Failed to create directory", "Ensure the directory can be created",
+				map[string]any{
+					"error": err.Error(),
+					"path":  path.Dir(savedFilePath),
+				})
+			return isCustomLocalPath, *createFileAction.Error
+		}
+
+		err = os.WriteFile(savedFilePath, imageData, 0644)
+		if err != nil {
+			createFileAction.SetError("Failed to write image data to file", "Ensure the file is writable",
+				map[string]any{
+					"error": err.Error(),
+					"path":  savedFilePath,
+				})
+			return isCustomLocalPath, *createFileAction.Error
+		}
+		createFileAction.AppendResult("saved_file_path", savedFilePath)
+
+		makeFileBytesUnique(savedFilePath)
+	}
+
+	return isCustomLocalPath, Err
+}
+
+// customLocalFolder maps the content folder of the item to the same library structure below SaveImagesLocally.Path
+func customLocalFolder(ctx context.Context, item *models.MediaItem, imageFile models.ImageFile, contentFolder string) (newFilePath string, Err logging.LogErrorInfo) {
+	_, newPathAction := logging.AddSubActionToContext(ctx, "Building New File Path for Local Image Save", logging.LevelDebug)
+	defer newPathAction.Complete()
+
+	// Build newFilePath based on library, content, and config path
+	libraryRoot := ""
+	libSection, exists := cache.LibraryStore.GetServerSectionByTitle(item.Server, item.LibraryTitle)
+	newPathAction.AppendResult("library_title", item.LibraryTitle)
+	if exists && len(libSection.Paths) > 0 {
+		newPathAction.AppendResult("library_found_in_cache", true)
+		// Library exists in cache (e.g. /data/media/movies or /data/media/shows)
+		if len(libSection.Paths) > 1 {
+			newPathAction.AppendResult("multiple_library_paths_found", true)
+			// If there are multiple library paths, we need to determine which one is correct based on the item path
+			itemPath := ""
+			if item.Movie != nil {
+				itemPath = item.Movie.File.Path
+			} else if item.Series != nil {
+				itemPath = item.Series.Location
+			}
+			newPathAction.AppendResult("item_path_for_library_determination", itemPath)
+
+			for _, libPath := range libSection.Paths {
+				if strings.HasPrefix(itemPath, libPath) {
+					libraryRoot = libPath
+					newPathAction.AppendResult("matched_library_path", libPath)
+					break
+				}
+			}
+			if libraryRoot == "" {
+				newPathAction.SetError("Failed to match library path for media item",
+					"Multiple library paths found but none matched the media item's file path",
+					map[string]any{
+						"item_path":     itemPath,
+						"library_paths": libSection.Paths,
+					})
+				return "", *newPathAction.Error
+			}
+		} else {
+			libraryRoot = libSection.Paths[0]
+		}
+		newPathAction.AppendResult("library_root", libraryRoot)
+
+		// Get last part of library root (e.g. "movies" or "shows")
+		libraryPath := path.Base(libraryRoot)
+		newPathAction.AppendResult("library_path", libraryPath)
+
+		// Get path before library name (e.g. /data/media/)
+		remainingLibraryPath := strings.TrimSuffix(libraryRoot, libraryPath)
+		newPathAction.AppendResult("remaining_library_path", remainingLibraryPath)
+
+		// Get relative path from the content folder (e.g. movies/Inception (2020), shows/Breaking Bad/Season 01)
+		relativePath := strings.TrimPrefix(contentFolder, remainingLibraryPath)
+		relativePath = strings.TrimLeft(relativePath, string(os.PathSeparator))
+		newPathAction.AppendResult("relative_path", relativePath)
+
+		// Final path: /local/images/movies/Inception (2020), etc.
+		newFilePath = path.Join(config.Current.Images.SaveImagesLocally.Path, relativePath)
+		newPathAction.AppendResult("final_path", newFilePath)
+		return newFilePath, logging.LogErrorInfo{}
+	}
+
+	newPathAction.AppendResult("library_found_in_cache", false)
+	// Fallback: build path from the media server info
+	if imageFile.Type != "titlecard" {
+		// For movies or posters/backdrops
+		contentPath := path.Base(contentFolder)
+		newPathAction.AppendResult("content_path", contentPath)
+
+		libraryPath := path.Base(path.Dir(contentFolder))
+		newPathAction.AppendResult("library_path", libraryPath)
+
+		// Final path:  /local/images/movies/Inception (2020)
+		//				/local/images/shows/Breaking Bad
+		newFilePath = path.Join(config.Current.Images.SaveImagesLocally.Path, libraryPath, contentPath)
+	} else {
+		// For titlecards in season folders
+		seasonPath := path.Base(contentFolder)
+		newPathAction.AppendResult("season_path", seasonPath)
+
+		contentPath := path.Base(path.Dir(contentFolder))
+		newPathAction.AppendResult("content_path", contentPath)
+
+		libraryPath := path.Base(path.Dir(path.Dir(contentFolder)))
+		newPathAction.AppendResult("library_path", libraryPath)
+
+		// Final path: /local/images/shows/Breaking Bad/Season 01
+		newFilePath = path.Join(config.Current.Images.SaveImagesLocally.Path, libraryPath, contentPath, seasonPath)
+	}
+	newPathAction.AppendResult("final_path", newFilePath)
+	return newFilePath, logging.LogErrorInfo{}
+}
+
+func ConvertWindowsPathToDockerPath(windowsPath string) string {
+	if !config.Current.Images.SaveImagesLocally.RunningOnWindows {
+		return windowsPath
+	} else {
+		logging.LOGGER.Debug().Timestamp().Msg("ConvertWindowsPathToDockerPath called, fixing path for Windows")
+	}
+	// Replace backslashes with forward slashes
+	dockerPath := strings.ReplaceAll(windowsPath, "\\", "/")
+
+	// Handle drive letter conversion (e.g., C:/ to /C/)
+	if len(dockerPath) > 1 && dockerPath[1] == ':' {
+		driveLetter := string(dockerPath[0])
+		dockerPath = "/" + driveLetter + dockerPath[2:]
+	}
+
+	return dockerPath
+}
+
+func makeFileBytesUnique(filePath string) error {
+	f, err := os.OpenFile(filePath, os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	r := make([]byte, 6)
+	if _, err := rand.Read(r); err != nil {
+		return err
+	}
+
+	tag := fmt.Sprintf("\nAURA:%s:%s",
+		time.Now().UTC().Format("20060102T150405.000000000Z"),
+		hex.EncodeToString(r),
+	)
+
+	if _, err := f.Write([]byte(tag)); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	return os.Chtimes(filePath, now, now)
+}
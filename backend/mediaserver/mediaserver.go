@@ -6,6 +6,7 @@ import (
 	"aura/mediaserver/ej"
 	"aura/mediaserver/plex"
 	"aura/models"
+	"aura/utils"
 	"context"
 	"fmt"
 )
@@ -52,16 +53,26 @@ type MediaServerInterface interface {
 	// Refresh the metadata for a specific media item
 	RefreshMediaItemMetadata(ctx context.Context, item *models.MediaItem, refreshRatingKey string, updateImage bool) (Err logging.LogErrorInfo)
 
-	// Handle Labels (Plex Exclusive)
+	// Add the labels (Plex) or tags (Emby/Jellyfin) of the LabelsAndTags application for this server type
 	AddLabelToMediaItem(ctx context.Context, item models.MediaItem, selectedTypes models.SelectedTypes) (Err logging.LogErrorInfo)
 
+	// Remove the labels or tags aura added
+	RemoveLabelsFromMediaItem(ctx context.Context, item models.MediaItem) (Err logging.LogErrorInfo)
+
 	// Rate a specific media item (Plex Exclusive)
 	RateMediaItem(ctx context.Context, item *models.MediaItem, rating float64) (Err logging.LogErrorInfo)
 
 	///////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 
-	// Download an image for a specific Media Item
-	DownloadApplyImageToMediaItem(ctx context.Context, item *models.MediaItem, imageFile models.ImageFile) (Err logging.LogErrorInfo)
+	// Download an image for a specific Media Item.
+	// The downloaded image data is returned, it is nil when the media server got the image from MediUX itself.
+	DownloadApplyImageToMediaItem(ctx context.Context, item *models.MediaItem, imageFile models.ImageFile) (imageData []byte, Err logging.LogErrorInfo)
+
+	// Upload image data to a specific Media Item (e.g. a previous version of the image)
+	ApplyImageDataToMediaItem(ctx context.Context, item *models.MediaItem, imageFile models.ImageFile, imageData []byte) (Err logging.LogErrorInfo)
+
+	// Reset an image to the one provided by the media server's metadata agent
+	ResetImageToDefault(ctx context.Context, item *models.MediaItem, imageFile models.ImageFile) (Err logging.LogErrorInfo)
 
 	// Apply a collection image to a specific Collection Item
 	ApplyCollectionImage(ctx context.Context, collectionItem *models.CollectionItem, imageFile models.ImageFile) (Err logging.LogErrorInfo)
@@ -89,6 +100,18 @@ func NewMediaServerClient(mediaServerConfig *config.Config_MediaServer) (MediaSe
 	}
 }
 
+// newMediaServerClientForServer creates a client for the media server with the given name.
+// An empty name resolves to the primary media server.
+func newMediaServerClientForServer(serverName string) (MediaServerInterface, logging.LogErrorInfo) {
+	cfg, found := config.GetMediaServerByName(serverName)
+	if !found {
+		return nil, logging.LogErrorInfo{
+			Message: fmt.Sprintf("media server not found: %s", serverName),
+		}
+	}
+	return NewMediaServerClient(cfg)
+}
+
 func TestConnection(ctx context.Context, mediaServerConfig *config.Config_MediaServer) (connectionOk bool, serverName string, serverVersion string, Err logging.LogErrorInfo) {
 	msClient, Err := NewMediaServerClient(mediaServerConfig)
 	if Err.Message != "" {
@@ -116,7 +139,7 @@ func GetLibrarySections(ctx context.Context, mediaServerConfig *config.Config_Me
 }
 
 func GetLibrarySectionDetails(ctx context.Context, library *models.LibrarySection) (found bool, Err logging.LogErrorInfo) {
-	msClient, Err := NewMediaServerClient(&config.Current.MediaServer)
+	msClient, Err := newMediaServerClientForServer(library.Server)
 	if Err.Message != "" {
 		return false, Err
 	}
@@ -124,15 +147,19 @@ func GetLibrarySectionDetails(ctx context.Context, library *models.LibrarySectio
 }
 
 func GetLibrarySectionItems(ctx context.Context, section models.LibrarySection, sectionStartIndex string, limit string) ([]models.MediaItem, int, logging.LogErrorInfo) {
-	msClient, Err := NewMediaServerClient(&config.Current.MediaServer)
+	msClient, Err := newMediaServerClientForServer(section.Server)
 	if Err.Message != "" {
 		return nil, 0, Err
 	}
-	return msClient.GetLibrarySectionItems(ctx, section, sectionStartIndex, limit)
+	items, totalSize, Err := msClient.GetLibrarySectionItems(ctx, section, sectionStartIndex, limit)
+	for i := range items {
+		items[i].Server = section.Server
+	}
+	return items, totalSize, Err
 }
 
 func GetMovieCollections(ctx context.Context, section models.LibrarySection) (collections []models.CollectionItem, Err logging.LogErrorInfo) {
-	msClient, Err := NewMediaServerClient(&config.Current.MediaServer)
+	msClient, Err := newMediaServerClientForServer(section.Server)
 	if Err.Message != "" {
 		return nil, Err
 	}
@@ -142,13 +169,15 @@ func GetMovieCollections(ctx context.Context, section models.LibrarySection) (co
 ///////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 
 func GetMediaItemImage(ctx context.Context, item *models.MediaItem, imageRatingKey string, imageType string) (imageData []byte, Err logging.LogErrorInfo) {
-	msClient, Err := NewMediaServerClient(&config.Current.MediaServer)
+	msClient, Err := newMediaServerClientForServer(item.Server)
 	if Err.Message != "" {
 		return nil, Err
 	}
 	return msClient.GetMediaItemImage(ctx, item, imageRatingKey, imageType)
 }
 
+// GetCollectionItemImage gets a collection image from the primary media server.
+// Collections are only loaded from the primary media server, so the collection helpers do not take a server.
 func GetCollectionItemImage(ctx context.Context, item *models.CollectionItem, imageType string) (imageData []byte, Err logging.LogErrorInfo) {
 	msClient, Err := NewMediaServerClient(&config.Current.MediaServer)
 	if Err.Message != "" {
@@ -159,6 +188,7 @@ func GetCollectionItemImage(ctx context.Context, item *models.CollectionItem, im
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 
+// GetCollectionChildrenItems gets the items of a collection from the primary media server
 func GetCollectionChildrenItems(ctx context.Context, collection *models.CollectionItem) (Err logging.LogErrorInfo) {
 	msClient, Err := NewMediaServerClient(&config.Current.MediaServer)
 	if Err.Message != "" {
@@ -170,7 +200,7 @@ func GetCollectionChildrenItems(ctx context.Context, collection *models.Collecti
 ///////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 
 func GetMediaItemDetails(ctx context.Context, item *models.MediaItem) (found bool, Err logging.LogErrorInfo) {
-	msClient, Err := NewMediaServerClient(&config.Current.MediaServer)
+	msClient, Err := newMediaServerClientForServer(item.Server)
 	if Err.Message != "" {
 		return false, Err
 	}
@@ -178,7 +208,7 @@ func GetMediaItemDetails(ctx context.Context, item *models.MediaItem) (found boo
 }
 
 func RefreshMediaItemMetadata(ctx context.Context, item *models.MediaItem, refreshRatingKey string, updateImage bool) (Err logging.LogErrorInfo) {
-	msClient, Err := NewMediaServerClient(&config.Current.MediaServer)
+	msClient, Err := newMediaServerClientForServer(item.Server)
 	if Err.Message != "" {
 		return Err
 	}
@@ -186,23 +216,40 @@ func RefreshMediaItemMetadata(ctx context.Context, item *models.MediaItem, refre
 }
 
 func AddLabelToMediaItem(ctx context.Context, item models.MediaItem, selectedTypes models.SelectedTypes) (Err logging.LogErrorInfo) {
-	if config.Current.MediaServer.Type != "Plex" {
+	msConfig, found := config.GetMediaServerByName(item.Server)
+	if !found {
 		return logging.LogErrorInfo{}
 	} else if len(config.Current.LabelsAndTags.Applications) == 0 {
 		return logging.LogErrorInfo{}
 	}
-	msClient, Err := NewMediaServerClient(&config.Current.MediaServer)
+	msClient, Err := NewMediaServerClient(msConfig)
 	if Err.Message != "" {
 		return Err
 	}
 	return msClient.AddLabelToMediaItem(ctx, item, selectedTypes)
 }
 
+func RemoveLabelsFromMediaItem(ctx context.Context, item models.MediaItem) (Err logging.LogErrorInfo) {
+	msConfig, found := config.GetMediaServerByName(item.Server)
+	if !found {
+		return logging.LogErrorInfo{}
+	} else if len(config.Current.LabelsAndTags.Applications) == 0 {
+		return logging.LogErrorInfo{}
+	}
+	msClient, Err := NewMediaServerClient(msConfig)
+	if Err.Message != "" {
+		return Err
+	}
+	return msClient.RemoveLabelsFromMediaItem(ctx, item)
+}
+
+// RateMediaItem rates the item on the media server that holds it. Only Plex servers support ratings.
 func RateMediaItem(ctx context.Context, item *models.MediaItem, rating float64) (Err logging.LogErrorInfo) {
-	if config.Current.MediaServer.Type != "Plex" {
+	msConfig, found := config.GetMediaServerByName(item.Server)
+	if !found || msConfig.Type != "Plex" {
 		return logging.LogErrorInfo{}
 	}
-	msClient, Err := NewMediaServerClient(&config.Current.MediaServer)
+	msClient, Err := NewMediaServerClient(msConfig)
 	if Err.Message != "" {
 		return Err
 	}
@@ -211,18 +258,80 @@ func RateMediaItem(ctx context.Context, item *models.MediaItem, rating float64)
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 
+// waitForUploadRateLimit blocks until the media server allows another image upload.
+// Every media server has its own limiter, shared by all Download Queue workers.
+func waitForUploadRateLimit(ctx context.Context, serverName string) (Err logging.LogErrorInfo) {
+	err := utils.WaitForRateLimit(ctx, "mediaserver:"+serverName, config.Current.DownloadQueue.MediaServerUploadsPerSecond)
+	if err != nil {
+		return logging.LogErrorInfo{
+			Message: fmt.Sprintf("failed to wait for the media server upload rate limit: %s", err.Error()),
+		}
+	}
+	return logging.LogErrorInfo{}
+}
+
 func DownloadApplyImageToMediaItem(ctx context.Context, item *models.MediaItem, imageFile models.ImageFile) (Err logging.LogErrorInfo) {
-	msClient, Err := NewMediaServerClient(&config.Current.MediaServer)
+	msClient, Err := newMediaServerClientForServer(item.Server)
+	if Err.Message != "" {
+		return Err
+	}
+	if Err := waitForUploadRateLimit(ctx, item.Server); Err.Message != "" {
+		return Err
+	}
+	snapshot := snapshotImage(ctx, item, imageFile)
+	imageData, Err := msClient.DownloadApplyImageToMediaItem(ctx, item, imageFile)
 	if Err.Message != "" {
+		forgetImageFingerprint(ctx, item, imageFile)
 		return Err
 	}
-	return msClient.DownloadApplyImageToMediaItem(ctx, item, imageFile)
+	snapshot.save(ctx)
+	saveImageFingerprint(ctx, *item, imageFile, imageData)
+	return Err
 }
 
+func ApplyImageDataToMediaItem(ctx context.Context, item *models.MediaItem, imageFile models.ImageFile, imageData []byte) (Err logging.LogErrorInfo) {
+	msClient, Err := newMediaServerClientForServer(item.Server)
+	if Err.Message != "" {
+		return Err
+	}
+	if Err := waitForUploadRateLimit(ctx, item.Server); Err.Message != "" {
+		return Err
+	}
+	Err = msClient.ApplyImageDataToMediaItem(ctx, item, imageFile, imageData)
+	if Err.Message != "" {
+		forgetImageFingerprint(ctx, item, imageFile)
+		return Err
+	}
+	saveImageFingerprint(ctx, *item, imageFile, imageData)
+	return Err
+}
+
+func ResetImageToDefault(ctx context.Context, item *models.MediaItem, imageFile models.ImageFile) (Err logging.LogErrorInfo) {
+	msClient, Err := newMediaServerClientForServer(item.Server)
+	if Err.Message != "" {
+		return Err
+	}
+	if Err := waitForUploadRateLimit(ctx, item.Server); Err.Message != "" {
+		return Err
+	}
+	snapshot := snapshotImage(ctx, item, imageFile)
+	forgetImageFingerprint(ctx, item, imageFile)
+	Err = msClient.ResetImageToDefault(ctx, item, imageFile)
+	if Err.Message != "" {
+		return Err
+	}
+	snapshot.save(ctx)
+	return Err
+}
+
+// ApplyCollectionImage applies a collection image on the primary media server
 func ApplyCollectionImage(ctx context.Context, collectionItem *models.CollectionItem, imageFile models.ImageFile) (Err logging.LogErrorInfo) {
 	msClient, Err := NewMediaServerClient(&config.Current.MediaServer)
 	if Err.Message != "" {
 		return Err
 	}
+	if Err := waitForUploadRateLimit(ctx, ""); Err.Message != "" {
+		return Err
+	}
 	return msClient.ApplyCollectionImage(ctx, collectionItem, imageFile)
 }
@@ -2,7 +2,6 @@ package plex
 
 import (
 	"aura/cache"
-	"aura/config"
 	"aura/database"
 	"aura/logging"
 	"aura/mediux"
@@ -32,7 +31,7 @@ func (p *Plex) GetLibrarySectionItems(ctx context.Context, section models.Librar
 	}
 
 	// Construct the URL for the Plex library sections API request
-	u, err := url.Parse(config.Current.MediaServer.URL)
+	u, err := url.Parse(p.Config.URL)
 	if err != nil {
 		logAction.SetError("Failed to parse base URL", "Ensure the URL is valid", map[string]any{"error": err.Error()})
 		return items, totalSize, *logAction.Error
@@ -46,7 +45,7 @@ func (p *Plex) GetLibrarySectionItems(ctx context.Context, section models.Librar
 	URL := u.String()
 
 	// Make the HTTP Request to Plex
-	resp, respBody, Err := makeRequest(ctx, config.Current.MediaServer, URL, "GET", nil)
+	resp, respBody, Err := makeRequest(ctx, p.Config, URL, "GET", nil)
 	if Err.Message != "" {
 		logAction.SetErrorFromInfo(Err)
 		return items, totalSize, *logAction.Error
@@ -69,6 +68,7 @@ func (p *Plex) GetLibrarySectionItems(ctx context.Context, section models.Librar
 		item.Title = metadata.Title
 		item.Year = metadata.Year
 		item.LibraryTitle = plexResp.MediaContainer.LibrarySectionTitle
+		item.Server = section.Server
 		item.UpdatedAt = metadata.UpdatedAt
 		item.AddedAt = metadata.AddedAt
 		item.ContentRating = metadata.ContentRating
@@ -154,9 +154,12 @@ func (p *Plex) GetLibrarySectionItems(ctx context.Context, section models.Librar
 		}
 
 		// Update the Media Item on Server in the DB
-		updateErr := database.UpdateMediaItemOnServer(ctx, item.TMDB_ID, item.LibraryTitle, true)
-		if updateErr.Message != "" {
-			logAction.AppendWarning("update_on_server_error", updateErr.Message)
+		// Only items from the primary media server are tracked in the DB
+		if item.Server == "" {
+			updateErr := database.UpdateMediaItemOnServer(ctx, item.TMDB_ID, item.LibraryTitle, true)
+			if updateErr.Message != "" {
+				logAction.AppendWarning("update_on_server_error", updateErr.Message)
+			}
 		}
 
 		// Check if Media Item exists in MediUX with a set
@@ -165,7 +168,8 @@ func (p *Plex) GetLibrarySectionItems(ctx context.Context, section models.Librar
 		}
 
 		// If the item is a movie, update the movie collections cache
-		if item.Type == "movie" {
+		// Collections are only tracked for the primary media server
+		if item.Type == "movie" && item.Server == "" {
 			if len(metadata.Collections) > 0 {
 				for _, coll := range metadata.Collections {
 					cache.CollectionsStore.UpdateMediaItemInCollectionByTitle(coll.Tag, &item)
@@ -178,8 +182,8 @@ func (p *Plex) GetLibrarySectionItems(ctx context.Context, section models.Librar
 	}
 
 	// For show sections, bulk-fetch all episodes to compute LatestEpisodeAddedAt per show.
-	if section.Type == "show" && config.Current.MediaServer.EnableSortByEpisodeAddedDate {
-		latestEpAdded, fetchErr := fetchLatestEpisodeAddedAtByShow(ctx, section.ID)
+	if section.Type == "show" && p.Config.EnableSortByEpisodeAddedDate {
+		latestEpAdded, fetchErr := p.fetchLatestEpisodeAddedAtByShow(ctx, section.ID)
 		if fetchErr.Message != "" {
 			logAction.AppendWarning("latest_episode_added_at", "Failed to bulk-fetch latest episode addedAt for shows")
 		} else {
@@ -194,7 +198,7 @@ func (p *Plex) GetLibrarySectionItems(ctx context.Context, section models.Librar
 
 // fetchLatestEpisodeAddedAtByShow fetches all episodes for a library section in one bulk
 // request and returns a map of show RatingKey -> latest episode addedAt timestamp.
-func fetchLatestEpisodeAddedAtByShow(ctx context.Context, sectionID string) (map[string]int64, logging.LogErrorInfo) {
+func (p *Plex) fetchLatestEpisodeAddedAtByShow(ctx context.Context, sectionID string) (map[string]int64, logging.LogErrorInfo) {
 	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf(
 		"Plex: Bulk-fetching episode addedAt for section %s", sectionID,
 	), logging.LevelDebug)
@@ -202,7 +206,7 @@ func fetchLatestEpisodeAddedAtByShow(ctx context.Context, sectionID string) (map
 
 	logging.DevMsgf("Bulk-fetching latest episode addedAt for shows in section %s", sectionID)
 
-	u, err := url.Parse(config.Current.MediaServer.URL)
+	u, err := url.Parse(p.Config.URL)
 	if err != nil {
 		logAction.SetError("Failed to parse base URL", "Ensure the URL is valid", map[string]any{"error": err.Error()})
 		return nil, *logAction.Error
@@ -216,7 +220,7 @@ func fetchLatestEpisodeAddedAtByShow(ctx context.Context, sectionID string) (map
 	query.Set("X-Plex-Container-Size", "0")
 	u.RawQuery = query.Encode()
 
-	resp, respBody, Err := makeRequest(ctx, config.Current.MediaServer, u.String(), "GET", nil)
+	resp, respBody, Err := makeRequest(ctx, p.Config, u.String(), "GET", nil)
 	if Err.Message != "" {
 		logAction.SetErrorFromInfo(Err)
 		return nil, *logAction.Error
@@ -237,7 +241,7 @@ func fetchLatestEpisodeAddedAtByShow(ctx context.Context, sectionID string) (map
 	query.Set("X-Plex-Container-Size", fmt.Sprintf("%d", totalEpisodes))
 	u.RawQuery = query.Encode()
 
-	resp, respBody, Err = makeRequest(ctx, config.Current.MediaServer, u.String(), "GET", nil)
+	resp, respBody, Err = makeRequest(ctx, p.Config, u.String(), "GET", nil)
 	if Err.Message != "" {
 		logAction.SetErrorFromInfo(Err)
 		return nil, *logAction.Error
@@ -2,7 +2,6 @@ package plex
 
 import (
 	"aura/cache"
-	"aura/config"
 	"aura/database"
 	"aura/logging"
 	"aura/mediux"
@@ -29,7 +28,7 @@ func (p *Plex) GetMediaItemDetails(ctx context.Context, item *models.MediaItem)
 	Err = logging.LogErrorInfo{}
 
 	// Construct the URL for the Plex API request
-	u, err := url.Parse(config.Current.MediaServer.URL)
+	u, err := url.Parse(p.Config.URL)
 	if err != nil {
 		logAction.SetError("Failed to parse base URL", "Ensure the URL is valid", map[string]any{"error": err.Error()})
 		return found, *logAction.Error
@@ -38,7 +37,7 @@ func (p *Plex) GetMediaItemDetails(ctx context.Context, item *models.MediaItem)
 	URL := u.String()
 
 	// Make the HTTP Request to Plex
-	resp, respBody, Err := makeRequest(ctx, config.Current.MediaServer, URL, "GET", nil)
+	resp, respBody, Err := makeRequest(ctx, p.Config, URL, "GET", nil)
 	if Err.Message != "" {
 		logAction.SetErrorFromInfo(Err)
 		return found, Err
@@ -60,10 +59,11 @@ func (p *Plex) GetMediaItemDetails(ctx context.Context, item *models.MediaItem)
 
 	// Populate the MediaItem details
 	metadata := plexResp.MediaContainer.Metadata[0]
-	extracted, Err := extractMediaItemFromResponse(ctx, metadata)
+	extracted, Err := p.extractMediaItemFromResponse(ctx, metadata)
 	if Err.Message != "" {
 		return found, *logAction.Error
 	}
+	extracted.Server = item.Server
 	*item = *extracted
 
 	// If no TMDB ID found, return an error
@@ -102,9 +102,12 @@ func (p *Plex) GetMediaItemDetails(ctx context.Context, item *models.MediaItem)
 	cache.LibraryStore.UpdateMediaItem(item.LibraryTitle, item)
 
 	// Update the Media Item on Server in the DB
-	updateErr := database.UpdateMediaItemOnServer(ctx, item.TMDB_ID, item.LibraryTitle, true)
-	if updateErr.Message != "" {
-		logAction.AppendWarning("update_on_server_error", updateErr.Message)
+	// Only items from the primary media server are tracked in the DB
+	if item.Server == "" {
+		updateErr := database.UpdateMediaItemOnServer(ctx, item.TMDB_ID, item.LibraryTitle, true)
+		if updateErr.Message != "" {
+			logAction.AppendWarning("update_on_server_error", updateErr.Message)
+		}
 	}
 
 	// Mark as found
@@ -112,7 +115,7 @@ func (p *Plex) GetMediaItemDetails(ctx context.Context, item *models.MediaItem)
 	return found, logging.LogErrorInfo{}
 }
 
-func extractMediaItemFromResponse(ctx context.Context, metadata PlexLibraryItemsMetadata) (item *models.MediaItem, Err logging.LogErrorInfo) {
+func (p *Plex) extractMediaItemFromResponse(ctx context.Context, metadata PlexLibraryItemsMetadata) (item *models.MediaItem, Err logging.LogErrorInfo) {
 	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf("Plex: Extracting Media Item '%s' [RatingKey: %s]", metadata.Title, metadata.RatingKey), logging.LevelDebug)
 	defer logAction.Complete()
 
@@ -155,7 +158,7 @@ func extractMediaItemFromResponse(ctx context.Context, metadata PlexLibraryItems
 		}
 	case "show":
 		// For shows, fetch seasons and episodes
-		Err = fetchSeasonsAndEpisodesForShow(ctx, item)
+		Err = p.fetchSeasonsAndEpisodesForShow(ctx, item)
 		if Err.Message != "" {
 			return item, *logAction.Error
 		}
@@ -205,7 +208,7 @@ func extractMediaItemFromResponse(ctx context.Context, metadata PlexLibraryItems
 	return item, Err
 }
 
-func fetchSeasonsAndEpisodesForShow(ctx context.Context, itemInfo *models.MediaItem) (Err logging.LogErrorInfo) {
+func (p *Plex) fetchSeasonsAndEpisodesForShow(ctx context.Context, itemInfo *models.MediaItem) (Err logging.LogErrorInfo) {
 	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf(
 		"Fetching Seasons and Episodes for Show '%s' (%s | %s | %d)",
 		itemInfo.Title, itemInfo.RatingKey, itemInfo.LibraryTitle, itemInfo.Year,
@@ -213,7 +216,7 @@ func fetchSeasonsAndEpisodesForShow(ctx context.Context, itemInfo *models.MediaI
 	defer logAction.Complete()
 
 	// Construct the URL for the Plex API request
-	u, err := url.Parse(config.Current.MediaServer.URL)
+	u, err := url.Parse(p.Config.URL)
 	if err != nil {
 		logAction.SetError("Failed to parse base URL", "Ensure the URL is valid", map[string]any{"error": err.Error()})
 		return *logAction.Error
@@ -222,7 +225,7 @@ func fetchSeasonsAndEpisodesForShow(ctx context.Context, itemInfo *models.MediaI
 	URL := u.String()
 
 	// Make the HTTP Request to Plex
-	resp, respBody, Err := makeRequest(ctx, config.Current.MediaServer, URL, "GET", nil)
+	resp, respBody, Err := makeRequest(ctx, p.Config, URL, "GET", nil)
 	if Err.Message != "" {
 		logAction.SetErrorFromInfo(Err)
 		return *logAction.Error
@@ -1,7 +1,6 @@
 package plex
 
 import (
-	"aura/config"
 	"aura/logging"
 	"aura/models"
 	"aura/utils"
@@ -22,7 +21,7 @@ func (p *Plex) GetMediaItemImage(ctx context.Context, item *models.MediaItem, im
 	imageData = []byte{}
 	Err = logging.LogErrorInfo{}
 
-	respBody, Err := GetImageFromPlex(ctx, imageRatingKey, imageType)
+	respBody, Err := p.GetImageFromPlex(ctx, imageRatingKey, imageType)
 	if Err.Message != "" {
 		return imageData, Err
 	}
@@ -41,7 +40,7 @@ func (p *Plex) GetCollectionItemImage(ctx context.Context, collection *models.Co
 	imageData = []byte{}
 	Err = logging.LogErrorInfo{}
 
-	respBody, Err := GetImageFromPlex(ctx, collection.RatingKey, imageType)
+	respBody, Err := p.GetImageFromPlex(ctx, collection.RatingKey, imageType)
 	if Err.Message != "" {
 		return imageData, Err
 	}
@@ -50,7 +49,7 @@ func (p *Plex) GetCollectionItemImage(ctx context.Context, collection *models.Co
 	return imageData, Err
 }
 
-func GetImageFromPlex(ctx context.Context, ratingKey string, imageType string) (imageData []byte, Err logging.LogErrorInfo) {
+func (p *Plex) GetImageFromPlex(ctx context.Context, ratingKey string, imageType string) (imageData []byte, Err logging.LogErrorInfo) {
 	width := "600"
 	height := "900"
 	switch imageType {
@@ -66,7 +65,7 @@ func GetImageFromPlex(ctx context.Context, ratingKey string, imageType string) (
 	// Construct the URL for the Plex Image request
 	photoPath := path.Join("/library/metadata", ratingKey, imageType, fmt.Sprintf("%d", time.Now().Unix()))
 	encodedPhotoPath := url.QueryEscape(photoPath)
-	u, err := url.Parse(config.Current.MediaServer.URL)
+	u, err := url.Parse(p.Config.URL)
 	if err != nil {
 		return imageData, logging.LogErrorInfo{
 			Message: "Failed to parse base URL",
@@ -83,7 +82,7 @@ func GetImageFromPlex(ctx context.Context, ratingKey string, imageType string) (
 	URL = fmt.Sprintf("%s&url=%s", URL, encodedPhotoPath)
 
 	// Make the HTTP Request to Plex
-	resp, respBody, Err := makeRequest(ctx, config.Current.MediaServer, URL, "GET", nil)
+	resp, respBody, Err := makeRequest(ctx, p.Config, URL, "GET", nil)
 	if Err.Message != "" {
 		return imageData, Err
 	}
@@ -20,7 +20,7 @@ func (p *Plex) AddLabelToMediaItem(ctx context.Context, item models.MediaItem, s
 	defer logAction.Complete()
 
 	// Do one last check to ensure we are a Plex server
-	if p.Config.Type != "Plex" || config.Current.MediaServer.Type != "Plex" {
+	if p.Config.Type != "Plex" {
 		return logging.LogErrorInfo{}
 	} else if len(config.Current.LabelsAndTags.Applications) == 0 {
 		return logging.LogErrorInfo{}
@@ -68,7 +68,7 @@ func (p *Plex) AddLabelToMediaItem(ctx context.Context, item models.MediaItem, s
 		}
 
 		// Get the library section from the cache
-		librarySection, found := cache.LibraryStore.GetSectionByTitle(item.LibraryTitle)
+		librarySection, found := cache.LibraryStore.GetServerSectionByTitle(item.Server, item.LibraryTitle)
 		if !found {
 			subAppAction.AppendWarning("outcome", "skipped")
 			subAppAction.AppendWarning("reason", "library section not found in cache")
@@ -88,21 +88,7 @@ func (p *Plex) AddLabelToMediaItem(ctx context.Context, item models.MediaItem, s
 		subAppAction.AppendResult("type_number", typeNumber)
 
 		// Make a comma-separated string of labels to remove
-		labelsToRemove := ""
-		if len(app.Remove) > 0 {
-			if config.Current.LabelsAndTags.RemoveOverlayLabelOnlyOnPosterDownload && !selectedTypes.Poster {
-				// If the "RemoveOverlayLabelOnlyOnPosterDownload" setting is enabled and the selected types do not include Poster, filter out "Overlay" from the removal list
-				filteredLabels := []string{}
-				for _, label := range app.Remove {
-					if label != "Overlay" {
-						filteredLabels = append(filteredLabels, label)
-					}
-				}
-				labelsToRemove = strings.Join(filteredLabels, ",")
-			} else {
-				labelsToRemove = strings.Join(app.Remove, ",")
-			}
-		}
+		labelsToRemove := strings.Join(app.LabelsToRemove(selectedTypes), ",")
 
 		// %5B = [
 		// %5D = ]
@@ -112,87 +98,23 @@ func (p *Plex) AddLabelToMediaItem(ctx context.Context, item models.MediaItem, s
 		removalParam := ""
 		if labelsToRemove != "" {
 			removalParam = fmt.Sprintf("label%%5B%%5D.tag.tag-=%s", url.QueryEscape(labelsToRemove))
-			subAppAction.AppendResult("labels_to_remove", app.Remove)
+			subAppAction.AppendResult("labels_to_remove", strings.Split(labelsToRemove, ","))
 		}
 
 		// Construct the addition parameter for the URL
 		// Structure: label%5B{index}%5D.tag.tag={label1},{label2}
 		// Example: label%5B0%5D.tag.tag=Overlay&label%5B0%5D.tag.tag=4K
 		// Note: The index should start at 0 and increment for each label to add
-		labelsToAdd := ""
+		labelsToAdd := app.LabelsToAdd(selectedTypes)
 		additionParams := ""
-		if len(app.Add) > 0 || (app.AddLabelTagForSelectedTypes && (selectedTypes.Poster || selectedTypes.Backdrop || selectedTypes.SeasonPoster || selectedTypes.SpecialSeasonPoster || selectedTypes.Titlecard)) {
-			nextLabelIndex := len(app.Add)
-			for index, label := range app.Add {
-				if index > 0 {
-					additionParams += "&"
-				}
-				additionParams += fmt.Sprintf("label%%5B%d%%5D.tag.tag=%s", index, url.QueryEscape(label))
-				labelsToAdd += label
-				if index < len(app.Add)-1 {
-					labelsToAdd += ","
-				}
-			}
-			if app.AddLabelTagForSelectedTypes && (selectedTypes.Poster || selectedTypes.Backdrop || selectedTypes.SeasonPoster || selectedTypes.SpecialSeasonPoster || selectedTypes.Titlecard) {
-				if selectedTypes.Poster {
-					if labelsToAdd != "" {
-						labelsToAdd += ","
-					}
-					labelsToAdd += "aura-poster"
-					if additionParams != "" {
-						additionParams += "&"
-					}
-					additionParams += fmt.Sprintf("label%%5B%d%%5D.tag.tag=%s", nextLabelIndex, url.QueryEscape("aura-poster"))
-					nextLabelIndex++
-				}
-				if selectedTypes.Backdrop {
-					if labelsToAdd != "" {
-						labelsToAdd += ","
-					}
-					labelsToAdd += "aura-backdrop"
-					if additionParams != "" {
-						additionParams += "&"
-					}
-					additionParams += fmt.Sprintf("label%%5B%d%%5D.tag.tag=%s", nextLabelIndex, url.QueryEscape("aura-backdrop"))
-					nextLabelIndex++
-				}
-				if selectedTypes.SeasonPoster {
-					if labelsToAdd != "" {
-						labelsToAdd += ","
-					}
-					labelsToAdd += "aura-season-poster"
-					if additionParams != "" {
-						additionParams += "&"
-					}
-					additionParams += fmt.Sprintf("label%%5B%d%%5D.tag.tag=%s", nextLabelIndex, url.QueryEscape("aura-season-poster"))
-					nextLabelIndex++
-				}
-				if selectedTypes.SpecialSeasonPoster {
-					if labelsToAdd != "" {
-						labelsToAdd += ","
-					}
-					labelsToAdd += "aura-special-season-poster"
-					if additionParams != "" {
-						additionParams += "&"
-					}
-					additionParams += fmt.Sprintf("label%%5B%d%%5D.tag.tag=%s", nextLabelIndex, url.QueryEscape("aura-special-season-poster"))
-					nextLabelIndex++
-				}
-				if selectedTypes.Titlecard {
-					if labelsToAdd != "" {
-						labelsToAdd += ","
-					}
-					labelsToAdd += "aura-titlecard"
-					if additionParams != "" {
-						additionParams += "&"
-					}
-					additionParams += fmt.Sprintf("label%%5B%d%%5D.tag.tag=%s", nextLabelIndex, url.QueryEscape("aura-titlecard"))
-					nextLabelIndex++
-				}
+		for index, label := range labelsToAdd {
+			if index > 0 {
+				additionParams += "&"
 			}
+			additionParams += fmt.Sprintf("label%%5B%d%%5D.tag.tag=%s", index, url.QueryEscape(label))
 		}
-		if labelsToAdd != "" {
-			subAppAction.AppendResult("labels_to_add", strings.Split(labelsToAdd, ","))
+		if len(labelsToAdd) > 0 {
+			subAppAction.AppendResult("labels_to_add", labelsToAdd)
 		}
 
 		// If no labels to add or remove, return early
@@ -236,7 +158,7 @@ func (p *Plex) AddLabelToMediaItem(ctx context.Context, item models.MediaItem, s
 		URL += "&" + combinedParams
 
 		// Make the API request to add/remove labels
-		_, _, Err := makeRequest(ctx, config.Current.MediaServer, URL, "PUT", nil)
+		_, _, Err := makeRequest(ctx, p.Config, URL, "PUT", nil)
 		if Err.Message != "" {
 			continue
 		}
@@ -246,3 +168,70 @@ func (p *Plex) AddLabelToMediaItem(ctx context.Context, item models.MediaItem, s
 
 	return logging.LogErrorInfo{}
 }
+
+// RemoveLabelsFromMediaItem removes the labels aura added to an item (the Add list and the labels for the selected types)
+func (p *Plex) RemoveLabelsFromMediaItem(ctx context.Context, item models.MediaItem) (Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx,
+		fmt.Sprintf("Plex: Removing Labels from %s", utils.MediaItemInfo(item)),
+		logging.LevelInfo)
+	defer logAction.Complete()
+
+	if item.Type != "movie" && item.Type != "show" {
+		logAction.AppendWarning("outcome", "skipped")
+		logAction.AppendWarning("reason", "unsupported_media_type")
+		return logging.LogErrorInfo{}
+	} else if item.RatingKey == "" {
+		logAction.AppendWarning("outcome", "skipped")
+		logAction.AppendWarning("reason", "missing_rating_key")
+		return logging.LogErrorInfo{}
+	}
+
+	labels := []string{}
+	for _, app := range config.Current.LabelsAndTags.Applications {
+		if app.Application == "Plex" {
+			labels = append(labels, app.AddedLabels()...)
+		}
+	}
+	if len(labels) == 0 {
+		return logging.LogErrorInfo{}
+	}
+	logAction.AppendResult("labels_to_remove", labels)
+
+	// Get the library section from the cache
+	librarySection, found := cache.LibraryStore.GetServerSectionByTitle(item.Server, item.LibraryTitle)
+	if !found || librarySection.ID == "" {
+		logAction.SetError("Library section not found in cache", "Refresh the library sections and try again", map[string]any{
+			"library_title": item.LibraryTitle,
+		})
+		return *logAction.Error
+	}
+
+	typeNumber := 1
+	if item.Type == "show" {
+		typeNumber = 2
+	}
+
+	u, err := url.Parse(p.Config.URL)
+	if err != nil {
+		logAction.SetError("failed to parse Plex URL", "Ensure that the Plex URL in the configuration is valid", map[string]any{
+			"error": err.Error(),
+			"url":   p.Config.URL,
+		})
+		return *logAction.Error
+	}
+	u.Path = path.Join(u.Path, "library", "sections", librarySection.ID, "all")
+	query := u.Query()
+	query.Set("type", fmt.Sprintf("%d", typeNumber))
+	query.Set("id", item.RatingKey)
+	u.RawQuery = query.Encode()
+	URL := u.String() + fmt.Sprintf("&label%%5B%%5D.tag.tag-=%s", url.QueryEscape(strings.Join(labels, ",")))
+
+	_, _, Err = makeRequest(ctx, p.Config, URL, "PUT", nil)
+	if Err.Message != "" {
+		logAction.SetErrorFromInfo(Err)
+		return *logAction.Error
+	}
+
+	logAction.AppendResult("outcome", "success")
+	return logging.LogErrorInfo{}
+}
@@ -1,7 +1,6 @@
 package plex
 
 import (
-	"aura/config"
 	"aura/logging"
 	"aura/mediux"
 	"aura/models"
@@ -17,7 +16,7 @@ import (
 	"golang.org/x/text/language"
 )
 
-func applyImageToMediaItemViaMediuxURL(ctx context.Context, item *models.MediaItem, itemRatingKey string, imageFile models.ImageFile) (Err logging.LogErrorInfo) {
+func (p *Plex) applyImageToMediaItemViaMediuxURL(ctx context.Context, item *models.MediaItem, itemRatingKey string, imageFile models.ImageFile) (Err logging.LogErrorInfo) {
 	fileDownloadName := utils.GetFileDownloadName(item.Title, imageFile)
 	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf(
 		"Plex: Applying '%s' Image via MediUX URL", fileDownloadName), logging.LevelDebug)
@@ -32,10 +31,10 @@ func applyImageToMediaItemViaMediuxURL(ctx context.Context, item *models.MediaIt
 	}
 
 	// Refresh the Plex Item
-	//RefreshItemMetadata(ctx, item, itemRatingKey, false)
+	//p.RefreshItemMetadata(ctx, item, itemRatingKey, false)
 
 	// Set the Poster using the MediUX URL
-	Err = applyImageToMediaItem(ctx, item, itemRatingKey, imageURL, imageFile.Type)
+	Err = p.applyImageToMediaItem(ctx, item, itemRatingKey, imageURL, imageFile.Type)
 	if Err.Message != "" {
 		return Err
 	}
@@ -43,7 +42,7 @@ func applyImageToMediaItemViaMediuxURL(ctx context.Context, item *models.MediaIt
 	return Err
 }
 
-func applyImageToMediaItem(ctx context.Context, item *models.MediaItem, itemRatingKey, imageKey, imageType string) (Err logging.LogErrorInfo) {
+func (p *Plex) applyImageToMediaItem(ctx context.Context, item *models.MediaItem, itemRatingKey, imageKey, imageType string) (Err logging.LogErrorInfo) {
 	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf(
 		"Plex: Applying '%s' Image to %s",
 		cases.Title(language.English).String(imageType), utils.MediaItemInfo(*item),
@@ -81,7 +80,7 @@ func applyImageToMediaItem(ctx context.Context, item *models.MediaItem, itemRati
 	}
 
 	// Construct the URL for the Plex API request
-	u, err := url.Parse(config.Current.MediaServer.URL)
+	u, err := url.Parse(p.Config.URL)
 	if err != nil {
 		logAction.SetError("Failed to parse base URL", "Ensure the URL is valid", map[string]any{"error": err.Error()})
 		return *logAction.Error
@@ -93,7 +92,7 @@ func applyImageToMediaItem(ctx context.Context, item *models.MediaItem, itemRati
 	URL := u.String()
 
 	// Make the HTTP Request to Plex
-	resp, respBody, Err := makeRequest(ctx, config.Current.MediaServer, URL, requestMethod, nil)
+	resp, respBody, Err := makeRequest(ctx, p.Config, URL, requestMethod, nil)
 	if Err.Message != "" {
 		return Err
 	}
@@ -108,3 +107,39 @@ func applyImageToMediaItem(ctx context.Context, item *models.MediaItem, itemRati
 
 	return logging.LogErrorInfo{}
 }
+
+// ApplyImageDataToMediaItem uploads an image that is already in memory (e.g. a previous version from the image history).
+// Plex selects an uploaded image right away.
+func (p *Plex) ApplyImageDataToMediaItem(ctx context.Context, item *models.MediaItem, imageFile models.ImageFile, imageData []byte) (Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf(
+		"Plex: Uploading %s Image for %s", utils.GetFileDownloadName(item.Title, imageFile), utils.MediaItemInfo(*item)),
+		logging.LevelDebug)
+	defer logAction.Complete()
+
+	// Determine the Item Rating Key from Plex
+	itemRatingKey := getItemRatingKeyFromImageFile(*item, imageFile)
+	if itemRatingKey == "" {
+		logAction.SetError("Failed to determine Rating Key for Media Item", "Ensure the Media Item and Image File data are correct", nil)
+		return *logAction.Error
+	}
+
+	imageType := "posters"
+	if imageFile.Type == "backdrop" {
+		imageType = "arts"
+	}
+
+	u, err := url.Parse(p.Config.URL)
+	if err != nil {
+		logAction.SetError("Failed to parse base URL", "Ensure the URL is valid", map[string]any{"error": err.Error()})
+		return *logAction.Error
+	}
+	u.Path = path.Join(u.Path, "library", "metadata", itemRatingKey, imageType)
+
+	resp, _, Err := makeRequest(ctx, p.Config, u.String(), http.MethodPost, imageData)
+	if Err.Message != "" {
+		return Err
+	}
+	defer resp.Body.Close()
+
+	return logging.LogErrorInfo{}
+}
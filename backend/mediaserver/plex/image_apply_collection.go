@@ -1,7 +1,6 @@
 package plex
 
 import (
-	"aura/config"
 	"aura/logging"
 	"aura/mediux"
 	"aura/models"
@@ -37,7 +36,7 @@ func (p *Plex) ApplyCollectionImage(ctx context.Context, collectionItem *models.
 	}
 
 	// Construct the URL for the Plex API request
-	u, err := url.Parse(config.Current.MediaServer.URL)
+	u, err := url.Parse(p.Config.URL)
 	if err != nil {
 		logAction.SetError("Failed to parse base URL", "Ensure the URL is valid", map[string]any{"error": err.Error()})
 		return *logAction.Error
@@ -49,7 +48,7 @@ func (p *Plex) ApplyCollectionImage(ctx context.Context, collectionItem *models.
 	URL := u.String()
 
 	// Make the HTTP Request to Plex
-	resp, _, Err := makeRequest(ctx, config.Current.MediaServer, URL, "POST", nil)
+	resp, _, Err := makeRequest(ctx, p.Config, URL, "POST", nil)
 	if Err.Message != "" {
 		logAction.SetErrorFromInfo(Err)
 		return *logAction.Error
@@ -1,7 +1,6 @@
 package plex
 
 import (
-	"aura/config"
 	"aura/logging"
 	"aura/models"
 	"aura/utils"
@@ -13,7 +12,7 @@ import (
 	"time"
 )
 
-func getAllImages(ctx context.Context, item *models.MediaItem, itemRatingKey string, itr string, imageType string) (images []PlexGetAllImagesMetadata, Err logging.LogErrorInfo) {
+func (p *Plex) getAllImages(ctx context.Context, item *models.MediaItem, itemRatingKey string, itr string, imageType string) (images []PlexGetAllImagesMetadata, Err logging.LogErrorInfo) {
 	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf(
 		"Plex: Getting %s Images for %s", itr, utils.MediaItemInfo(*item)),
 		logging.LevelDebug)
@@ -22,6 +21,35 @@ func getAllImages(ctx context.Context, item *models.MediaItem, itemRatingKey str
 	images = []PlexGetAllImagesMetadata{}
 	Err = logging.LogErrorInfo{}
 
+	allImages, Err := p.fetchImages(ctx, itemRatingKey, imageType)
+	if Err.Message != "" {
+		logAction.SetErrorFromInfo(Err)
+		return images, *logAction.Error
+	}
+
+	for _, img := range allImages {
+		if img.Provider != "local" {
+			continue
+		}
+		images = append(images, img)
+	}
+
+	logAction.AppendResult("image_rating_keys", func() []string {
+		keys := make([]string, len(images))
+		for i, img := range images {
+			keys[i] = img.RatingKey
+		}
+		return keys
+	}())
+	logAction.AppendResult("current_image_count", len(images))
+
+	return images, Err
+}
+
+// fetchImages lists every poster (or art for backdrops) Plex has for an item, including the ones provided by the agent
+func (p *Plex) fetchImages(ctx context.Context, itemRatingKey string, imageType string) (images []PlexGetAllImagesMetadata, Err logging.LogErrorInfo) {
+	images = []PlexGetAllImagesMetadata{}
+
 	if imageType == "backdrop" {
 		imageType = "arts"
 	} else {
@@ -29,52 +57,38 @@ func getAllImages(ctx context.Context, item *models.MediaItem, itemRatingKey str
 	}
 
 	// Construct the URL for the Plex API request
-	u, err := url.Parse(config.Current.MediaServer.URL)
+	u, err := url.Parse(p.Config.URL)
 	if err != nil {
-		logAction.SetError(logging.Error_BaseUrlParsing(err))
-		return images, *logAction.Error
+		return images, logging.LogErrorInfo{
+			Message: "Failed to parse base URL",
+			Help:    "Ensure the URL is valid",
+			Detail:  map[string]any{"error": err.Error()},
+		}
 	}
 	u.Path = path.Join(u.Path, "library", "metadata", itemRatingKey, imageType)
 	URL := u.String()
 
 	// Make the HTTP Request to Plex
-	resp, respBody, Err := makeRequest(ctx, config.Current.MediaServer, URL, "GET", nil)
+	resp, respBody, Err := makeRequest(ctx, p.Config, URL, "GET", nil)
 	if Err.Message != "" {
-		logAction.SetErrorFromInfo(Err)
-		return images, *logAction.Error
+		return images, Err
 	}
 	defer resp.Body.Close()
 
 	// Decode the Response
 	var respData PlexGetAllImagesWrapper
-	Err = httpx.DecodeResponseToJSON(ctx, respBody, &respData, fmt.Sprintf("%s Media Item Images Response", config.Current.MediaServer.Type))
+	Err = httpx.DecodeResponseToJSON(ctx, respBody, &respData, fmt.Sprintf("%s Media Item Images Response", p.Config.Type))
 	if Err.Message != "" {
-		return images, *logAction.Error
+		return images, Err
 	}
 
-	for _, img := range respData.MediaContainer.Metadata {
-		if img.Provider != "local" {
-			continue
-		}
-		images = append(images, img)
-	}
-
-	logAction.AppendResult("image_rating_keys", func() []string {
-		keys := make([]string, len(images))
-		for i, img := range images {
-			keys[i] = img.RatingKey
-		}
-		return keys
-	}())
-	logAction.AppendResult("current_image_count", len(images))
-
-	return images, Err
+	return respData.MediaContainer.Metadata, logging.LogErrorInfo{}
 }
 
 // findNewImage attempts to retrieve a new image for a Plex item by comparing previous and current images.
 // It tries up to 3 times, refreshing the Plex item between attempts if needed.
 // Returns the new image's ratingKey (URL or path) if found, or a StandardError if not.
-func findNewImage(ctx context.Context, item *models.MediaItem, itemRatingKey string, imageType string, previousImages []PlexGetAllImagesMetadata) (newImageRatingKey string, Err logging.LogErrorInfo) {
+func (p *Plex) findNewImage(ctx context.Context, item *models.MediaItem, itemRatingKey string, imageType string, previousImages []PlexGetAllImagesMetadata) (newImageRatingKey string, Err logging.LogErrorInfo) {
 	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf(
 		"Plex: Getting Newest '%s' Image for %s",
 		imageType,
@@ -93,7 +107,7 @@ func findNewImage(ctx context.Context, item *models.MediaItem, itemRatingKey str
 		attemptAction := logAction.AddSubAction(fmt.Sprintf("Attempt %d to fetch new image", attempt), logging.LevelDebug)
 
 		// Get all current images from Plex
-		currentImages, fetchErr := getAllImages(ctx, item, itemRatingKey, "New", imageType)
+		currentImages, fetchErr := p.getAllImages(ctx, item, itemRatingKey, "New", imageType)
 
 		if fetchErr.Message != "" {
 			attemptAction.AppendWarning(fmt.Sprintf("attempt_%d", attempt), map[string]any{"error": fetchErr.Message})
@@ -129,7 +143,7 @@ func findNewImage(ctx context.Context, item *models.MediaItem, itemRatingKey str
 
 		if attempt < 3 {
 			time.Sleep(1 * time.Second)
-			refreshErr := RefreshItemMetadata(ctx, item, itemRatingKey, false)
+			refreshErr := p.RefreshItemMetadata(ctx, item, itemRatingKey, false)
 			if refreshErr.Message != "" {
 				attemptAction.AppendWarning("refresh_error", map[string]any{"error": refreshErr.Message})
 				lastErrorMsg = refreshErr.Message
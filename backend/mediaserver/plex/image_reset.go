@@ -0,0 +1,97 @@
+package plex
+
+import (
+	"aura/logging"
+	"aura/models"
+	"aura/utils"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// ResetImageToDefault selects the image provided by the Plex agent again, replacing the image aura applied.
+// The uploaded images stay in the list of the item, so they can still be selected in Plex.
+func (p *Plex) ResetImageToDefault(ctx context.Context, item *models.MediaItem, imageFile models.ImageFile) (Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf(
+		"Plex: Resetting %s Image for %s", utils.GetFileDownloadName(item.Title, imageFile), utils.MediaItemInfo(*item)),
+		logging.LevelDebug)
+	defer logAction.Complete()
+
+	// Determine the Item Rating Key from Plex
+	itemRatingKey := getItemRatingKeyFromImageFile(*item, imageFile)
+	if itemRatingKey == "" {
+		logAction.SetError("Failed to determine Rating Key for Media Item", "Ensure the Media Item and Image File data are correct", nil)
+		return *logAction.Error
+	}
+
+	agentImage, found, Err := p.findAgentImage(ctx, itemRatingKey, imageFile.Type)
+	if Err.Message != "" {
+		return Err
+	}
+	if !found {
+		// The agent images are only listed after a refresh when the item was matched before they existed
+		Err = p.RefreshItemMetadata(ctx, item, itemRatingKey, false)
+		if Err.Message != "" {
+			return Err
+		}
+		agentImage, found, Err = p.findAgentImage(ctx, itemRatingKey, imageFile.Type)
+		if Err.Message != "" {
+			return Err
+		}
+	}
+	if !found {
+		logAction.SetError("No default image found", "The Plex agent did not provide an image for this item", map[string]any{
+			"rating_key": itemRatingKey,
+			"image_type": imageFile.Type,
+		})
+		return *logAction.Error
+	}
+	logAction.AppendResult("agent_image", agentImage.RatingKey)
+	if agentImage.Selected {
+		logAction.AppendResult("message", "The default image is already selected")
+		return logging.LogErrorInfo{}
+	}
+
+	// PUT requires the singular image type (poster or art)
+	imageType := "poster"
+	if imageFile.Type == "backdrop" {
+		imageType = "art"
+	}
+	u, err := url.Parse(p.Config.URL)
+	if err != nil {
+		logAction.SetError("Failed to parse base URL", "Ensure the URL is valid", map[string]any{"error": err.Error()})
+		return *logAction.Error
+	}
+	u.Path = path.Join(u.Path, "library", "metadata", itemRatingKey, imageType)
+	query := u.Query()
+	query.Set("url", agentImage.RatingKey)
+	u.RawQuery = query.Encode()
+
+	resp, _, Err := makeRequest(ctx, p.Config, u.String(), http.MethodPut, nil)
+	if Err.Message != "" {
+		return Err
+	}
+	defer resp.Body.Close()
+
+	return logging.LogErrorInfo{}
+}
+
+// findAgentImage returns the first image that was provided by a Plex agent.
+// Plex lists the images uploaded by aura with the "local" provider, like the local assets,
+// so both are skipped. Images without a provider can not be traced back to an agent and are skipped as well.
+func (p *Plex) findAgentImage(ctx context.Context, itemRatingKey, imageType string) (image PlexGetAllImagesMetadata, found bool, Err logging.LogErrorInfo) {
+	images, Err := p.fetchImages(ctx, itemRatingKey, imageType)
+	if Err.Message != "" {
+		return image, false, Err
+	}
+	for _, img := range images {
+		if img.Provider == "" || img.Provider == "local" || strings.HasPrefix(img.RatingKey, "upload://") {
+			continue
+		}
+		return img, true, logging.LogErrorInfo{}
+	}
+	return image, false, logging.LogErrorInfo{}
+}
@@ -1,7 +1,6 @@
 package plex
 
 import (
-	"aura/config"
 	"aura/logging"
 	"aura/models"
 	"aura/utils"
@@ -13,10 +12,10 @@ import (
 )
 
 func (p *Plex) RefreshMediaItemMetadata(ctx context.Context, item *models.MediaItem, refreshKey string, updateImage bool) (Err logging.LogErrorInfo) {
-	return RefreshItemMetadata(ctx, item, refreshKey, updateImage)
+	return p.RefreshItemMetadata(ctx, item, refreshKey, updateImage)
 }
 
-func RefreshItemMetadata(ctx context.Context, item *models.MediaItem, refreshKey string, updateImage bool) (Err logging.LogErrorInfo) {
+func (p *Plex) RefreshItemMetadata(ctx context.Context, item *models.MediaItem, refreshKey string, updateImage bool) (Err logging.LogErrorInfo) {
 	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf(
 		"Refreshing Metadata for %s (Refresh Key: %s)",
 		utils.MediaItemInfo(*item),
@@ -25,7 +24,7 @@ func RefreshItemMetadata(ctx context.Context, item *models.MediaItem, refreshKey
 	defer logAction.Complete()
 
 	// Construct the URL for the Plex API request
-	u, err := url.Parse(config.Current.MediaServer.URL)
+	u, err := url.Parse(p.Config.URL)
 	if err != nil {
 		logAction.SetError("Failed to parse base URL", "Ensure the URL is valid", map[string]any{"error": err.Error()})
 		return *logAction.Error
@@ -34,7 +33,7 @@ func RefreshItemMetadata(ctx context.Context, item *models.MediaItem, refreshKey
 	URL := u.String()
 
 	// Make the HTTP Request to Plex
-	resp, _, Err := makeRequest(ctx, config.Current.MediaServer, URL, "PUT", nil)
+	resp, _, Err := makeRequest(ctx, p.Config, URL, "PUT", nil)
 	if Err.Message != "" {
 		logAction.SetErrorFromInfo(Err)
 		return *logAction.Error
@@ -48,7 +47,7 @@ func RefreshItemMetadata(ctx context.Context, item *models.MediaItem, refreshKey
 
 	// When we refresh metadata, we can also update the selected image to be local (if present)
 	// We run this in a separate goroutine to avoid blocking
-	currentImages, Err := getAllImages(ctx, item, refreshKey, "All", "poster")
+	currentImages, Err := p.getAllImages(ctx, item, refreshKey, "All", "poster")
 	if Err.Message != "" {
 		return *logAction.Error
 	}
@@ -66,7 +65,7 @@ func RefreshItemMetadata(ctx context.Context, item *models.MediaItem, refreshKey
 			// If there is no selected image, select a local one if it exists, else select the first one
 			for _, img := range currentImages {
 				if img.Provider == "local" {
-					Err = applyImageToMediaItem(ctx, item, refreshKey, img.RatingKey, "poster")
+					Err = p.applyImageToMediaItem(ctx, item, refreshKey, img.RatingKey, "poster")
 					if Err.Message != "" {
 						return Err
 					}
@@ -75,7 +74,7 @@ func RefreshItemMetadata(ctx context.Context, item *models.MediaItem, refreshKey
 				}
 			}
 			if !hasLocal {
-				Err = applyImageToMediaItem(ctx, item, refreshKey, currentImages[0].RatingKey, "poster")
+				Err = p.applyImageToMediaItem(ctx, item, refreshKey, currentImages[0].RatingKey, "poster")
 				if Err.Message != "" {
 					return Err
 				}
@@ -0,0 +1,76 @@
+package mediaserver
+
+import (
+	"aura/logging"
+	"aura/models"
+	"aura/utils"
+	"context"
+	"fmt"
+)
+
+// ResetMediaItemArtwork resets every image of the selected types to the artwork of the media server's metadata agent.
+// Season posters and titlecards are reset for every season and episode the media server has.
+func ResetMediaItemArtwork(ctx context.Context, item *models.MediaItem, selectedTypes models.SelectedTypes) (reset int, Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf(
+		"Resetting Artwork for %s", utils.MediaItemInfo(*item)),
+		logging.LevelInfo)
+	defer logAction.Complete()
+
+	targets := resetTargets(*item, selectedTypes)
+	if len(targets) == 0 {
+		logAction.SetError("No images to reset", "Select at least one image type that the item has", map[string]any{
+			"selected_types": selectedTypes,
+		})
+		return 0, *logAction.Error
+	}
+
+	failed := 0
+	for _, imageFile := range targets {
+		resetErr := ResetImageToDefault(ctx, item, imageFile)
+		if resetErr.Message != "" {
+			logAction.AppendWarning(utils.GetFileDownloadName(item.Title, imageFile), resetErr.Message)
+			failed++
+			continue
+		}
+		reset++
+	}
+	logAction.AppendResult("reset", reset)
+	logAction.AppendResult("failed", failed)
+
+	if reset == 0 {
+		logAction.SetError("Failed to reset any image", "Check the warnings for the error of each image", map[string]any{
+			"images": len(targets),
+		})
+		return 0, *logAction.Error
+	}
+	return reset, logging.LogErrorInfo{}
+}
+
+// resetTargets lists the images of the item for the selected types
+func resetTargets(item models.MediaItem, selectedTypes models.SelectedTypes) []models.ImageFile {
+	targets := []models.ImageFile{}
+	if selectedTypes.Poster {
+		targets = append(targets, models.ImageFile{Type: "poster"})
+	}
+	if selectedTypes.Backdrop {
+		targets = append(targets, models.ImageFile{Type: "backdrop"})
+	}
+	if item.Type != "show" || item.Series == nil {
+		return targets
+	}
+
+	for _, season := range item.Series.Seasons {
+		seasonNumber := season.SeasonNumber
+		if (seasonNumber == 0 && selectedTypes.SpecialSeasonPoster) || (seasonNumber != 0 && selectedTypes.SeasonPoster) {
+			targets = append(targets, models.ImageFile{Type: "season_poster", SeasonNumber: &seasonNumber})
+		}
+		if !selectedTypes.Titlecard {
+			continue
+		}
+		for _, episode := range season.Episodes {
+			episodeNumber := episode.EpisodeNumber
+			targets = append(targets, models.ImageFile{Type: "titlecard", SeasonNumber: &seasonNumber, EpisodeNumber: &episodeNumber})
+		}
+	}
+	return targets
+}
@@ -0,0 +1,79 @@
+package mediux
+
+import (
+	"aura/cache"
+	"aura/logging"
+	"aura/models"
+	"aura/tmdb"
+	"context"
+)
+
+// GetItemSets returns the show sets of a show, or the movie sets and collection sets of a movie
+func GetItemSets(ctx context.Context, tmdbID string, itemType string, itemLibraryTitle string) (sets []models.SetRef, includedItems map[string]models.IncludedItem, Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Get Item Sets", logging.LevelDebug)
+	defer logAction.Complete()
+
+	if !tmdb.FallbackEnabled() {
+		return getMediuxItemSets(ctx, tmdbID, itemType, itemLibraryTitle)
+	}
+
+	// Items without MediUX sets get their sets from TMDB instead
+	hasSets, known := hasMediuxSets(ctx, itemType, tmdbID)
+	if known && !hasSets {
+		logAction.AppendResult("source", "TMDB")
+		return tmdb.GetItemSets(ctx, tmdbID, itemType, itemLibraryTitle)
+	}
+
+	sets, includedItems, Err = getMediuxItemSets(ctx, tmdbID, itemType, itemLibraryTitle)
+	if !known && Err.Message == "" && len(sets) == 0 {
+		// The MediUX item list could not be loaded, so MediUX was asked directly
+		logAction.AppendWarning("mediux_items", "The list of MediUX items with sets is not loaded, TMDB sets are used because MediUX returned no sets")
+		logAction.AppendResult("source", "TMDB")
+		return tmdb.GetItemSets(ctx, tmdbID, itemType, itemLibraryTitle)
+	}
+	return sets, includedItems, Err
+}
+
+func getMediuxItemSets(ctx context.Context, tmdbID string, itemType string, itemLibraryTitle string) (sets []models.SetRef, includedItems map[string]models.IncludedItem, Err logging.LogErrorInfo) {
+	sets = []models.SetRef{}
+	includedItems = map[string]models.IncludedItem{}
+
+	switch itemType {
+	case "show":
+		// For Shows, we get just Show Sets
+		return GetShowItemSets(ctx, tmdbID, itemLibraryTitle)
+	case "movie":
+		// For Movies, we get Movie Sets and Movie Collection Sets
+		movieSets, Err := GetMovieItemSets(ctx, tmdbID, itemLibraryTitle, &includedItems)
+		if Err.Message != "" {
+			return sets, includedItems, Err
+		}
+		collectionSets, Err := GetMovieItemCollectionSets(ctx, tmdbID, itemLibraryTitle, &includedItems)
+		if Err.Message != "" {
+			return sets, includedItems, Err
+		}
+		sets = append(movieSets, collectionSets...)
+		return sets, includedItems, Err
+	default:
+		_, logAction := logging.AddSubActionToContext(ctx, "Get MediUX Item Sets", logging.LevelDebug)
+		defer logAction.Complete()
+		logAction.SetError("Invalid Item Type", "The provided item type is not valid", map[string]any{
+			"item_type": itemType,
+		})
+		return sets, includedItems, *logAction.Error
+	}
+}
+
+// hasMediuxSets reports whether MediUX has sets for the item.
+// The MediUX items are loaded when they were not loaded yet. When they still can not be loaded, known is false.
+func hasMediuxSets(ctx context.Context, itemType string, tmdbID string) (hasSets bool, known bool) {
+	movieCount, showCount := cache.MediuxItems.GetCountMediuxItems()
+	if movieCount == 0 && showCount == 0 {
+		PreLoadMediuxItemsWithSets(ctx)
+		movieCount, showCount = cache.MediuxItems.GetCountMediuxItems()
+		if movieCount == 0 && showCount == 0 {
+			return false, false
+		}
+	}
+	return cache.MediuxItems.CheckItemExists(itemType, tmdbID), true
+}
@@ -4,6 +4,7 @@ import (
 	"aura/cache"
 	"aura/logging"
 	"aura/models"
+	"aura/tmdb"
 	"aura/utils/httpx"
 	"context"
 	_ "embed"
@@ -27,6 +28,11 @@ type movieSetBySetID_MovieSetsByID struct {
 }
 
 func GetMovieSetByID(ctx context.Context, setID string, itemLibraryTitle string) (set models.SetRef, includedItems map[string]models.IncludedItem, Err logging.LogErrorInfo) {
+	// Sets built from TMDB images are not MediUX sets
+	if tmdb.IsSetID(setID) {
+		return tmdb.GetSetByID(ctx, setID, itemLibraryTitle)
+	}
+
 	ctx, logAction := logging.AddSubActionToContext(ctx, "Get Movie Set By ID", logging.LevelInfo)
 	defer logAction.Complete()
 
@@ -3,6 +3,7 @@ package mediux
 import (
 	"aura/config"
 	"aura/logging"
+	"aura/tmdb"
 	"aura/utils"
 	"context"
 	"fmt"
@@ -131,6 +132,16 @@ func GetImage(ctx context.Context, assetID string, formatDate string, imageQuali
 		return imageData, imageType, Err
 	}
 
+	// Wait for the MediUX rate limit, shared by all Download Queue workers
+	// TMDB images are served by TMDB, which does not count towards the MediUX rate limit
+	if err := waitForImageRateLimit(ctx, assetID); err != nil {
+		logAction.SetError("Failed to wait for the MediUX rate limit", err.Error(), map[string]any{
+			"error": err.Error(),
+			"URL":   mediuxURL,
+		})
+		return imageData, imageType, *logAction.Error
+	}
+
 	// Make the HTTP Request to MediUX
 	resp, respBody, Err := makeRequest(ctx, mediuxURL, "GET", nil, "", false)
 	if Err.Message != "" {
@@ -170,6 +181,13 @@ func GetImage(ctx context.Context, assetID string, formatDate string, imageQuali
 	return imageData, imageType, Err
 }
 
+func waitForImageRateLimit(ctx context.Context, assetID string) error {
+	if tmdb.IsImageID(assetID) {
+		return nil
+	}
+	return utils.WaitForRateLimit(ctx, "mediux", config.Current.DownloadQueue.MediuxRequestsPerSecond)
+}
+
 func GetAvatarImage(ctx context.Context, avatarID string) (imageData []byte, imageType string, Err logging.LogErrorInfo) {
 	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf("MediUX: Getting Avatar Image for Avatar ID '%s'", avatarID), logging.LevelDebug)
 	defer logAction.Complete()
@@ -3,6 +3,7 @@ package mediux
 import (
 	"aura/config"
 	"aura/logging"
+	"aura/tmdb"
 	"aura/utils"
 	"context"
 	"fmt"
@@ -32,6 +33,18 @@ func ConstructImageUrl(ctx context.Context, assetID, dateTimeString string, imag
 		return "", *logAction.Error
 	}
 
+	// TMDB images are served by TMDB
+	if tmdb.IsImageID(assetID) {
+		tmdbSize := "original"
+		if imageQuality == ImageQualityThumb {
+			tmdbSize = "w500"
+		}
+		URL := tmdb.ImageURL(assetID, tmdbSize)
+		logAction.AppendResult("url", URL)
+		logAction.AppendResult("imageQuality", imageQuality)
+		return URL, logging.LogErrorInfo{}
+	}
+
 	// Format the date to YYYYMMDDHHMMSS
 	dateTime := utils.ConvertDateStringToTime(dateTimeString)
 	dateTimeFormatted := dateTime.Format("20060102150405")
@@ -90,6 +103,9 @@ func GetImageURLFromSrc(src string) string {
 	if src == "" {
 		return ""
 	}
+	if tmdb.IsImageID(src) {
+		return tmdb.ImageURL(src, "w500")
+	}
 
 	var MediuxURL string
 	if strings.HasPrefix(src, "---") {
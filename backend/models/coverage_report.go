@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+// Coverage states of a Media Item
+const (
+	CoverageStatusSaved         = "saved"                 // At least one set is saved for the item
+	CoverageStatusMediuxNoSaved = "mediux_sets_not_saved" // MediUX has sets for the item, but none is saved
+	CoverageStatusIgnored       = "ignored"               // The item is ignored
+	CoverageStatusNoMediuxSets  = "no_mediux_sets"        // MediUX has no sets for the item
+)
+
+// CoverageReport shows how much of the libraries is covered by MediUX artwork
+type CoverageReport struct {
+	GeneratedAt time.Time         `json:"generated_at"`
+	Libraries   []CoverageLibrary `json:"libraries"`
+}
+
+// CoverageLibrary is the coverage of a single library of the primary media server
+type CoverageLibrary struct {
+	LibraryTitle         string         `json:"library_title"`
+	Type                 string         `json:"type"` // "movie" or "show"
+	TotalItems           int            `json:"total_items"`
+	Saved                int            `json:"saved"`
+	MediuxSetsNotSaved   int            `json:"mediux_sets_not_saved"`
+	Ignored              int            `json:"ignored"`
+	NoMediuxSets         int            `json:"no_mediux_sets"`
+	MissingSeasonPosters int            `json:"missing_season_posters"` // Seasons of shows with a saved set that have no season poster
+	MissingTitlecards    int            `json:"missing_titlecards"`     // Episodes of shows with a saved set that have no titlecard
+	Items                []CoverageItem `json:"items"`
+}
+
+// CoverageItem is the coverage of a single Media Item
+type CoverageItem struct {
+	TMDB_ID              string   `json:"tmdb_id"`
+	Title                string   `json:"title"`
+	Year                 int      `json:"year"`
+	Type                 string   `json:"type"`
+	Status               string   `json:"status"`                           // saved, mediux_sets_not_saved, ignored or no_mediux_sets
+	IgnoredMode          string   `json:"ignored_mode,omitempty"`           // Present when the item is ignored
+	SavedSets            []string `json:"saved_sets,omitempty"`             // IDs of the saved sets
+	MissingSeasonPosters []int    `json:"missing_season_posters,omitempty"` // Season numbers without a season poster (shows with a saved set)
+	MissingTitlecards    []string `json:"missing_titlecards,omitempty"`     // Episodes without a titlecard, as S01E01 (shows with a saved set)
+}
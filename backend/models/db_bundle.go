@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// Current version of the saved sets export bundle
+// Increase this when the bundle layout changes in a way older versions can not read
+const DBBundleVersion = 1
+
+// DBBundle is a portable export of the saved sets and ignored items in the database.
+// It is used to move saved sets between instances, independent of the database type.
+type DBBundle struct {
+	Version      int             `json:"version"`       // Bundle format version (DBBundleVersion)
+	AppVersion   string          `json:"app_version"`   // Version of the application that created the bundle
+	DBVersion    int             `json:"db_version"`    // Database version of the instance that created the bundle
+	ExportedAt   time.Time       `json:"exported_at"`   // When the bundle was created
+	SavedItems   []DBSavedItem   `json:"saved_items"`   // Saved Media Items with their Poster Sets
+	IgnoredItems []DBIgnoredItem `json:"ignored_items"` // Ignored Media Items
+}
+
+// DBIgnoredItem is a single entry of the IgnoredItems table
+type DBIgnoredItem struct {
+	TMDB_ID      string   `json:"tmdb_id"`
+	LibraryTitle string   `json:"library_title"`
+	Mode         string   `json:"mode"`         // "always", "until-set-available" or "until-new-set-available"
+	CurrentSets  []string `json:"current_sets"` // Set IDs that were present when the item was ignored
+}
@@ -19,11 +19,35 @@ type DBPosterSetDetail struct {
 	ToDelete                  bool          `json:"to_delete"` // Flag to indicate if the poster set should be deleted (Not used in DB)
 }
 
+// SelectedImages returns the images of the set that have a selected type and belong to the item.
+// Images of the other items in a collection set are left out.
+func (set DBPosterSetDetail) SelectedImages(item MediaItem) []ImageFile {
+	images := []ImageFile{}
+	for _, image := range set.Images {
+		if image.ItemTMDB_ID != "" && image.ItemTMDB_ID != item.TMDB_ID {
+			continue
+		}
+		if set.SelectedTypes.Includes(image) {
+			images = append(images, image)
+		}
+	}
+	return images
+}
+
 type PosterSet struct {
 	BaseSetInfo
 	Images []ImageFile `json:"images"`
 }
 
+// ContainedTypes returns the image types that the set has at least one image of
+func (set PosterSet) ContainedTypes() SelectedTypes {
+	contained := SelectedTypes{}
+	for _, image := range set.Images {
+		contained.Add(image)
+	}
+	return contained
+}
+
 type DBFilter struct {
 	ItemTMDB_ID       string   `json:"item_tmdb_id"`
 	ItemLibraryTitle  string   `json:"item_library_title"`
@@ -0,0 +1,53 @@
+package models
+
+import "time"
+
+// States of a Download Queue entry
+const (
+	DownloadQueueStatusPending    = "pending"
+	DownloadQueueStatusProcessing = "processing"
+	DownloadQueueStatusSucceeded  = "succeeded"
+	DownloadQueueStatusWarning    = "warning"
+	DownloadQueueStatusFailed     = "failed"
+)
+
+// DBDownloadQueueEntry is a single entry of the DownloadQueue table
+type DBDownloadQueueEntry struct {
+	ID            int64                      `json:"id"`
+	Status        string                     `json:"status"`          // pending, processing, succeeded, warning or failed
+	Attempts      int                        `json:"attempts"`        // Number of times the entry has been processed
+	Item          DBSavedItem                `json:"item"`            // Media Item and Poster Sets to download
+	Results       []DownloadQueueImageResult `json:"results"`         // Result of every image, images applied in an earlier attempt are not applied again
+	Errors        []string                   `json:"errors"`          // Errors of the last attempt
+	Warnings      []string                   `json:"warnings"`        // Warnings of the last attempt
+	CreatedAt     time.Time                  `json:"created_at"`      // When the entry was added to the queue
+	UpdatedAt     time.Time                  `json:"updated_at"`      // When the entry was last changed
+	StartedAt     *time.Time                 `json:"started_at"`      // When the last attempt started
+	FinishedAt    *time.Time                 `json:"finished_at"`     // When the last attempt finished
+	NextAttemptAt *time.Time                 `json:"next_attempt_at"` // When a pending entry is retried after a transient failure
+}
+
+// DownloadQueueImageResult is the outcome of applying a single image from a Download Queue entry
+type DownloadQueueImageResult struct {
+	SetID               string `json:"set_id"`
+	ImageID             string `json:"image_id"`
+	ImageType           string `json:"image_type"`
+	SeasonNumber        *int   `json:"season_number,omitempty"`
+	EpisodeNumber       *int   `json:"episode_number,omitempty"`
+	Success             bool   `json:"success"`                         // Applied to the Media Item on its own media server
+	ServerCopiesApplied int    `json:"server_copies_applied,omitempty"` // Number of copies on other media servers the image was applied to
+	Message             string `json:"message,omitempty"`
+}
+
+// DBDownloadQueueFilter selects entries of the DownloadQueue table.
+// Empty fields are not used in the filter.
+type DBDownloadQueueFilter struct {
+	ID           int64      `json:"id"`
+	Statuses     []string   `json:"statuses"`
+	TMDB_ID      string     `json:"tmdb_id"`
+	LibraryTitle string     `json:"library_title"`
+	Server       *string    `json:"server,omitempty"` // Empty string for the primary media server
+	DueBy        *time.Time `json:"due_by,omitempty"` // Only entries without a retry scheduled after this time
+	NewestFirst  bool       `json:"newest_first"`
+	Limit        int        `json:"limit"`
+}
@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// DriftReport is the result of the last Drift Detection check
+type DriftReport struct {
+	CheckedAt time.Time      `json:"checked_at"`
+	Checked   int            `json:"checked"` // Number of applied images that were compared
+	Drifted   []DriftedImage `json:"drifted"`
+}
+
+// DriftedImage is an applied image that no longer matches the image of its saved set
+type DriftedImage struct {
+	TMDB_ID       string `json:"tmdb_id"`
+	LibraryTitle  string `json:"library_title"`
+	Title         string `json:"title"`
+	RatingKey     string `json:"rating_key"`
+	SetID         string `json:"set_id"`
+	ImageID       string `json:"image_id"`
+	ImageType     string `json:"image_type"`
+	SeasonNumber  *int   `json:"season_number,omitempty"`
+	EpisodeNumber *int   `json:"episode_number,omitempty"`
+	Distance      int    `json:"distance"`        // Number of differing bits between the perceptual hashes (0-64)
+	Reapplied     bool   `json:"reapplied"`       // Whether the image of the saved set was applied again
+	Error         string `json:"error,omitempty"` // Present when re-applying the image failed
+}
@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// DBImageFingerprint is the perceptual hash of the MediUX image that was last applied in a place of a Media Item
+type DBImageFingerprint struct {
+	Server         string    `json:"server"` // Name of the media server, empty for the primary media server
+	TMDB_ID        string    `json:"tmdb_id"`
+	LibraryTitle   string    `json:"library_title"`
+	ImageKey       string    `json:"image_key"`        // Place of the image: poster, backdrop, season_poster:S01 or titlecard:S01E01
+	ImageRatingKey string    `json:"image_rating_key"` // Rating Key of the item the image was applied to (season or episode for season posters and titlecards)
+	ImageID        string    `json:"image_id"`         // ID of the MediUX image
+	PerceptualHash string    `json:"perceptual_hash"`  // Perceptual hash (dHash) of the image data as 16 hex digits
+	AppliedAt      time.Time `json:"applied_at"`
+}
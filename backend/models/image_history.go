@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+// DBImageHistoryEntry is an image that was on the media server before aura replaced it.
+// The image itself is stored as a file in the image-history folder of the config directory.
+type DBImageHistoryEntry struct {
+	ID             int64     `json:"id"`
+	TMDB_ID        string    `json:"tmdb_id"`
+	LibraryTitle   string    `json:"library_title"`
+	Server         string    `json:"server,omitempty"`
+	Title          string    `json:"title"`
+	RatingKey      string    `json:"rating_key"`               // Rating Key of the Media Item
+	ImageRatingKey string    `json:"image_rating_key"`         // Rating Key of the item the image belongs to (season or episode for season posters and titlecards)
+	ImageType      string    `json:"image_type"`               // poster, backdrop, season_poster or titlecard
+	SeasonNumber   *int      `json:"season_number,omitempty"`  // Present for Season Posters and Titlecards
+	EpisodeNumber  *int      `json:"episode_number,omitempty"` // Present for Titlecards
+	FileName       string    `json:"file_name"`                // Name of the snapshot file in the image-history folder
+	ReplacedBy     string    `json:"replaced_by"`              // ID of the MediUX image that replaced this image
+	CreatedAt      time.Time `json:"created_at"`               // When the image was replaced
+}
+
+// DBImageHistoryFilter selects entries of the ImageHistory table.
+// Empty fields are not used in the filter.
+type DBImageHistoryFilter struct {
+	ID             int64  `json:"id"`
+	TMDB_ID        string `json:"tmdb_id"`
+	LibraryTitle   string `json:"library_title"`
+	Server         string `json:"server"`
+	RatingKey      string `json:"rating_key"`
+	ImageRatingKey string `json:"image_rating_key"`
+	ImageType      string `json:"image_type"`
+	Limit          int    `json:"limit"`
+}
@@ -9,6 +9,7 @@ type LibrarySectionBase struct {
 
 type LibrarySection struct {
 	LibrarySectionBase `yaml:",inline" mapstructure:",squash"`
+	Server             string      `json:"server,omitempty" yaml:"-" mapstructure:"-"` // Name of the media server the section belongs to (empty for the primary media server)
 	TotalSize          int         `json:"total_size" yaml:"TotalSize,omitempty" mapstructure:"TotalSize"`
 	MediaItems         []MediaItem `json:"media_items" yaml:"MediaItems,omitempty" mapstructure:"MediaItems"`
 }
@@ -3,6 +3,7 @@ package models
 type MediaItem struct {
 	TMDB_ID      string           `json:"tmdb_id"`          // TMDB ID of the media item
 	LibraryTitle string           `json:"library_title"`    // Title of the library/section the item belongs to
+	Server       string           `json:"server,omitempty"` // Name of the media server the item belongs to (empty for the primary media server)
 	RatingKey    string           `json:"rating_key"`       // RatingKey is the internal ID from the media server
 	Type         string           `json:"type"`             // "movie" or "show"
 	Title        string           `json:"title"`            // Title of the media item
@@ -84,6 +85,49 @@ type SelectedTypes struct {
 	Titlecard           bool `json:"titlecard"`
 }
 
+// SelectedTypeKey returns the SelectedTypes key of an image.
+// Posters of season 0 are "special_season_poster", the other season posters are "season_poster".
+func (image ImageFile) SelectedTypeKey() string {
+	if image.Type == "season_poster" && image.SeasonNumber != nil && *image.SeasonNumber == 0 {
+		return "special_season_poster"
+	}
+	return image.Type
+}
+
+// Includes reports whether the type of the image is selected
+func (selectedTypes SelectedTypes) Includes(image ImageFile) bool {
+	selected := selectedTypes.typeOf(image)
+	return selected != nil && *selected
+}
+
+// Add selects the type of the image
+func (selectedTypes *SelectedTypes) Add(image ImageFile) {
+	if selected := selectedTypes.typeOf(image); selected != nil {
+		*selected = true
+	}
+}
+
+// Any reports whether at least one image type is selected
+func (selectedTypes SelectedTypes) Any() bool {
+	return selectedTypes != SelectedTypes{}
+}
+
+func (selectedTypes *SelectedTypes) typeOf(image ImageFile) *bool {
+	switch image.SelectedTypeKey() {
+	case "poster":
+		return &selectedTypes.Poster
+	case "backdrop":
+		return &selectedTypes.Backdrop
+	case "season_poster":
+		return &selectedTypes.SeasonPoster
+	case "special_season_poster":
+		return &selectedTypes.SpecialSeasonPoster
+	case "titlecard":
+		return &selectedTypes.Titlecard
+	}
+	return nil
+}
+
 type CollectionItem struct {
 	RatingKey    string      `json:"rating_key"`
 	Index        string      `json:"index"` // Unique identifier for the collection in Plex
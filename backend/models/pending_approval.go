@@ -0,0 +1,52 @@
+package models
+
+import "time"
+
+// States of a Pending Approval
+const (
+	PendingApprovalStatusPending  = "pending"
+	PendingApprovalStatusApproved = "approved"
+	PendingApprovalStatusRejected = "rejected"
+)
+
+// Kinds of Pending Approvals
+const (
+	PendingApprovalKindCreatorSet  = "creator_set"  // New set of a followed MediUX creator for an item without a saved set
+	PendingApprovalKindImageUpdate = "image_update" // Images of a saved set that AutoDownload or a Sonarr/Radarr webhook would apply
+)
+
+// DBPendingApproval is a single entry of the PendingApprovals table
+type DBPendingApproval struct {
+	ID        int64       `json:"id"`
+	Kind      string      `json:"kind"`       // What is waiting for approval (e.g. creator_set)
+	Status    string      `json:"status"`     // pending, approved or rejected
+	Item      DBSavedItem `json:"item"`       // Media Item and Poster Set with the images that are applied when approved
+	Reason    string      `json:"reason"`     // Why the change was suggested
+	CreatedAt time.Time   `json:"created_at"` // When the approval was staged
+	DecidedAt *time.Time  `json:"decided_at"` // When the approval was approved or rejected
+
+	Images []PendingApprovalImage `json:"images,omitempty"` // Current and new version of every image (Not stored in DB)
+}
+
+// PendingApprovalImage is an image that is replaced when an approval is approved
+type PendingApprovalImage struct {
+	ImageID       string `json:"image_id"`
+	Type          string `json:"type"`
+	SeasonNumber  *int   `json:"season_number,omitempty"`
+	EpisodeNumber *int   `json:"episode_number,omitempty"`
+	BeforeURL     string `json:"before_url"` // Current image on the media server, empty when the media server has no image for it yet
+	AfterURL      string `json:"after_url"`  // New image on MediUX
+}
+
+// DBPendingApprovalFilter selects entries of the PendingApprovals table.
+// Empty fields are not used in the filter.
+type DBPendingApprovalFilter struct {
+	ID           int64    `json:"id"`
+	Kinds        []string `json:"kinds"`
+	Statuses     []string `json:"statuses"`
+	TMDB_ID      string   `json:"tmdb_id"`
+	LibraryTitle string   `json:"library_title"`
+	SetID        string   `json:"set_id"`
+	NewestFirst  bool     `json:"newest_first"`
+	Limit        int      `json:"limit"`
+}
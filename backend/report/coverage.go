@@ -0,0 +1,197 @@
+package report
+
+import (
+	"aura/cache"
+	"aura/database"
+	"aura/events"
+	"aura/logging"
+	"aura/mediaserver"
+	"aura/models"
+	"aura/utils"
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+var (
+	coverageMu     sync.Mutex
+	latestMu       sync.RWMutex
+	latestCoverage *models.CoverageReport
+)
+
+// LatestCoverageReport returns the last Coverage Report that was built since the app started
+func LatestCoverageReport() (models.CoverageReport, bool) {
+	latestMu.RLock()
+	defer latestMu.RUnlock()
+	if latestCoverage == nil {
+		return models.CoverageReport{}, false
+	}
+	return *latestCoverage, true
+}
+
+// BuildCoverageReport walks every item of the primary media server and reports per library
+// which items have a saved set, which could have one, which are ignored and which have no MediUX sets.
+// Shows with a saved set also report the seasons and episodes that have no season poster or titlecard.
+func BuildCoverageReport(ctx context.Context) (report models.CoverageReport, Err logging.LogErrorInfo) {
+	coverageMu.Lock()
+	defer coverageMu.Unlock()
+
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Building Coverage Report", logging.LevelInfo)
+	defer logAction.Complete()
+
+	mediaserver.GetAllLibrarySectionsAndItems(ctx, false)
+	if cache.LibraryStore.IsEmpty() {
+		logAction.SetError("No media items found", "Make sure the media server libraries are loaded", nil)
+		return report, *logAction.Error
+	}
+
+	savedSets, Err := database.GetAllSavedSets(ctx, models.DBFilter{ItemsPerPage: -1})
+	if Err.Message != "" {
+		return report, Err
+	}
+	savedByItem := map[string][]models.DBPosterSetDetail{}
+	for _, savedItem := range savedSets.Items {
+		key := savedItem.MediaItem.TMDB_ID + "|" + savedItem.MediaItem.LibraryTitle
+		savedByItem[key] = append(savedByItem[key], savedItem.PosterSets...)
+	}
+
+	ignoredItems, Err := database.GetAllIgnoredItems(ctx)
+	if Err.Message != "" {
+		return report, Err
+	}
+	ignoredByItem := map[string]models.DBIgnoredItem{}
+	for _, ignored := range ignoredItems {
+		ignoredByItem[ignored.TMDB_ID+"|"+ignored.LibraryTitle] = ignored
+	}
+
+	// Copies on the additional media servers share the saved sets of the primary media server
+	items := []models.MediaItem{}
+	for _, item := range cache.LibraryStore.GetAllMediaItems() {
+		if item.Server == "" {
+			items = append(items, item)
+		}
+	}
+
+	progress := events.JobProgress{Job: "Coverage Report", Total: len(items)}
+	events.Publish(events.TypeJobStarted, progress)
+
+	libraries := map[string]*models.CoverageLibrary{}
+	for _, item := range items {
+		library, ok := libraries[item.LibraryTitle]
+		if !ok {
+			library = &models.CoverageLibrary{LibraryTitle: item.LibraryTitle, Type: item.Type, Items: []models.CoverageItem{}}
+			libraries[item.LibraryTitle] = library
+		}
+
+		key := item.TMDB_ID + "|" + item.LibraryTitle
+		coverageItem := models.CoverageItem{
+			TMDB_ID: item.TMDB_ID,
+			Title:   item.Title,
+			Year:    item.Year,
+			Type:    item.Type,
+		}
+		sets := savedByItem[key]
+		for _, set := range sets {
+			coverageItem.SavedSets = append(coverageItem.SavedSets, set.ID)
+		}
+
+		if ignored, isIgnored := ignoredByItem[key]; isIgnored {
+			coverageItem.Status = models.CoverageStatusIgnored
+			coverageItem.IgnoredMode = ignored.Mode
+			library.Ignored++
+		} else if len(sets) > 0 {
+			coverageItem.Status = models.CoverageStatusSaved
+			library.Saved++
+		} else if cache.MediuxItems.CheckItemExists(item.Type, item.TMDB_ID) {
+			coverageItem.Status = models.CoverageStatusMediuxNoSaved
+			library.MediuxSetsNotSaved++
+		} else {
+			coverageItem.Status = models.CoverageStatusNoMediuxSets
+			library.NoMediuxSets++
+		}
+
+		if item.Type == "show" && len(sets) > 0 {
+			addMissingShowImages(ctx, item, sets, &coverageItem)
+			library.MissingSeasonPosters += len(coverageItem.MissingSeasonPosters)
+			library.MissingTitlecards += len(coverageItem.MissingTitlecards)
+		}
+
+		library.TotalItems++
+		library.Items = append(library.Items, coverageItem)
+
+		progress.Processed++
+		progress.SuccessCount++
+		progress.Item = utils.MediaItemInfo(item)
+		progress.Result = coverageItem.Status
+		events.Publish(events.TypeJobProgress, progress)
+	}
+
+	report.GeneratedAt = time.Now()
+	report.Libraries = []models.CoverageLibrary{}
+	for _, library := range libraries {
+		sort.SliceStable(library.Items, func(i, j int) bool {
+			return library.Items[i].Title < library.Items[j].Title
+		})
+		report.Libraries = append(report.Libraries, *library)
+	}
+	sort.Slice(report.Libraries, func(i, j int) bool {
+		return report.Libraries[i].LibraryTitle < report.Libraries[j].LibraryTitle
+	})
+
+	progress.Item = ""
+	progress.Result = ""
+	events.Publish(events.TypeJobFinished, progress)
+
+	latestMu.Lock()
+	latestCoverage = &report
+	latestMu.Unlock()
+
+	logAction.AppendResult("libraries", len(report.Libraries))
+	logAction.AppendResult("items", len(items))
+	return report, Err
+}
+
+// addMissingShowImages lists the seasons and episodes of a show that none of its saved sets has an image for
+func addMissingShowImages(ctx context.Context, item models.MediaItem, sets []models.DBPosterSetDetail, coverageItem *models.CoverageItem) {
+	// Seasons and episodes are only present in the full details of a show
+	found, Err := mediaserver.GetMediaItemDetails(ctx, &item)
+	if Err.Message != "" || !found || item.Series == nil {
+		return
+	}
+
+	seasonPosters := map[int]bool{}
+	titlecards := map[string]bool{}
+	for _, set := range sets {
+		for _, image := range set.SelectedImages(item) {
+			if image.SeasonNumber == nil {
+				continue
+			}
+			switch image.Type {
+			case "season_poster":
+				seasonPosters[*image.SeasonNumber] = true
+			case "titlecard":
+				if image.EpisodeNumber != nil {
+					titlecards[episodeCode(*image.SeasonNumber, *image.EpisodeNumber)] = true
+				}
+			}
+		}
+	}
+
+	for _, season := range item.Series.Seasons {
+		if !seasonPosters[season.SeasonNumber] {
+			coverageItem.MissingSeasonPosters = append(coverageItem.MissingSeasonPosters, season.SeasonNumber)
+		}
+		for _, episode := range season.Episodes {
+			code := episodeCode(episode.SeasonNumber, episode.EpisodeNumber)
+			if !titlecards[code] {
+				coverageItem.MissingTitlecards = append(coverageItem.MissingTitlecards, code)
+			}
+		}
+	}
+}
+
+func episodeCode(seasonNumber, episodeNumber int) string {
+	return fmt.Sprintf("S%02dE%02d", seasonNumber, episodeNumber)
+}
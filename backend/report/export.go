@@ -0,0 +1,92 @@
+package report
+
+import (
+	"aura/config"
+	"aura/models"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// ReportsFolder is where the reports of the scheduled jobs are stored
+func ReportsFolder() string {
+	return path.Join(config.ConfigPath, "reports")
+}
+
+// CoverageReportJSON returns the Coverage Report as indented JSON
+func CoverageReportJSON(report models.CoverageReport) ([]byte, error) {
+	return json.MarshalIndent(report, "", "  ")
+}
+
+// CoverageReportCSV returns the Coverage Report as CSV with one row per Media Item
+func CoverageReportCSV(report models.CoverageReport) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+
+	if err := writer.Write([]string{
+		"library_title", "tmdb_id", "title", "year", "type", "status", "ignored_mode",
+		"saved_sets", "missing_season_posters", "missing_titlecards",
+	}); err != nil {
+		return nil, err
+	}
+
+	for _, library := range report.Libraries {
+		for _, item := range library.Items {
+			seasons := make([]string, 0, len(item.MissingSeasonPosters))
+			for _, seasonNumber := range item.MissingSeasonPosters {
+				seasons = append(seasons, strconv.Itoa(seasonNumber))
+			}
+			if err := writer.Write([]string{
+				library.LibraryTitle,
+				item.TMDB_ID,
+				item.Title,
+				strconv.Itoa(item.Year),
+				item.Type,
+				item.Status,
+				item.IgnoredMode,
+				strings.Join(item.SavedSets, ";"),
+				strings.Join(seasons, ";"),
+				strings.Join(item.MissingTitlecards, ";"),
+			}); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// SaveCoverageReport writes the Coverage Report as JSON and CSV to the reports folder.
+// It returns the base file name without extension.
+func SaveCoverageReport(report models.CoverageReport) (string, error) {
+	if err := os.MkdirAll(ReportsFolder(), 0755); err != nil {
+		return "", err
+	}
+	baseName := fmt.Sprintf("coverage_%s", report.GeneratedAt.Format("2006-01-02_15-04-05"))
+
+	jsonData, err := CoverageReportJSON(report)
+	if err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(path.Join(ReportsFolder(), baseName+".json"), jsonData, 0644); err != nil {
+		return "", err
+	}
+
+	csvData, err := CoverageReportCSV(report)
+	if err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(path.Join(ReportsFolder(), baseName+".csv"), csvData, 0644); err != nil {
+		return "", err
+	}
+	return baseName, nil
+}
@@ -0,0 +1,130 @@
+package routes_approvals
+
+import (
+	"aura/download/approvals"
+	"aura/logging"
+	"aura/models"
+	"aura/utils/httpx"
+	"net/http"
+	"strconv"
+)
+
+type DecideApproval_Response struct {
+	Approval models.DBPendingApproval `json:"approval"`
+}
+
+// ApproveApproval godoc
+// @Summary      Approvals - Approve
+// @Description  Approve a pending change. For a creator set, the Media Item and its Poster Set are added to the download queue and saved once the download worker has processed them. For an image update, the images are applied to the media server right away and stored with the saved set.
+// @Tags         Approvals
+// @Accept       json
+// @Produce      json
+// @Param        id  query     int  true  "ID of the approval"
+// @Security 	 BearerAuth
+// @Failure      401  {object}  httpx.UnauthorizedResponse "Unauthorized (only when Auth.Enabled=true)"
+// @Success      200  {object}  httpx.JSONResponse{data=DecideApproval_Response}
+// @Failure      500           {object}  httpx.JSONResponse "Internal Server Error"
+// @Router       /api/approvals/approve [post]
+func ApproveApproval(w http.ResponseWriter, r *http.Request) {
+	ctx, ld := logging.CreateLoggingContext(r.Context(), r.URL.Path)
+	logAction := ld.AddAction("Approvals - Approve", logging.LevelInfo)
+	ctx = logging.WithCurrentAction(ctx, logAction)
+
+	var response DecideApproval_Response
+
+	id, ok := parseApprovalID(r, logAction)
+	if !ok {
+		httpx.SendResponse(w, ld, response)
+		return
+	}
+
+	approval, Err := approvals.Approve(ctx, id)
+	if Err.Message != "" {
+		httpx.SendResponse(w, ld, response)
+		return
+	}
+
+	response.Approval = approval
+	httpx.SendResponse(w, ld, response)
+}
+
+// RejectApproval godoc
+// @Summary      Approvals - Reject
+// @Description  Reject a pending change. Nothing is downloaded, and a rejected creator set is not suggested again for the same item. The images of a rejected image update are stored with the saved set without applying them, so they are not suggested again.
+// @Tags         Approvals
+// @Accept       json
+// @Produce      json
+// @Param        id  query     int  true  "ID of the approval"
+// @Security 	 BearerAuth
+// @Failure      401  {object}  httpx.UnauthorizedResponse "Unauthorized (only when Auth.Enabled=true)"
+// @Success      200  {object}  httpx.JSONResponse{data=DecideApproval_Response}
+// @Failure      500           {object}  httpx.JSONResponse "Internal Server Error"
+// @Router       /api/approvals/reject [post]
+func RejectApproval(w http.ResponseWriter, r *http.Request) {
+	ctx, ld := logging.CreateLoggingContext(r.Context(), r.URL.Path)
+	logAction := ld.AddAction("Approvals - Reject", logging.LevelInfo)
+	ctx = logging.WithCurrentAction(ctx, logAction)
+
+	var response DecideApproval_Response
+
+	id, ok := parseApprovalID(r, logAction)
+	if !ok {
+		httpx.SendResponse(w, ld, response)
+		return
+	}
+
+	approval, Err := approvals.Reject(ctx, id)
+	if Err.Message != "" {
+		httpx.SendResponse(w, ld, response)
+		return
+	}
+
+	response.Approval = approval
+	httpx.SendResponse(w, ld, response)
+}
+
+type ApproveAllApprovals_Response struct {
+	Approved int `json:"approved"`
+	Failed   int `json:"failed"`
+}
+
+// ApproveAllApprovals godoc
+// @Summary      Approvals - Approve All
+// @Description  Approve every pending change, oldest first. Changes that fail to apply stay pending and are counted as failed.
+// @Tags         Approvals
+// @Accept       json
+// @Produce      json
+// @Security 	 BearerAuth
+// @Failure      401  {object}  httpx.UnauthorizedResponse "Unauthorized (only when Auth.Enabled=true)"
+// @Success      200  {object}  httpx.JSONResponse{data=ApproveAllApprovals_Response}
+// @Failure      500           {object}  httpx.JSONResponse "Internal Server Error"
+// @Router       /api/approvals/approve-all [post]
+func ApproveAllApprovals(w http.ResponseWriter, r *http.Request) {
+	ctx, ld := logging.CreateLoggingContext(r.Context(), r.URL.Path)
+	logAction := ld.AddAction("Approvals - Approve All", logging.LevelInfo)
+	ctx = logging.WithCurrentAction(ctx, logAction)
+
+	var response ApproveAllApprovals_Response
+
+	approved, failed, Err := approvals.ApproveAll(ctx)
+	if Err.Message != "" {
+		httpx.SendResponse(w, ld, response)
+		return
+	}
+
+	response.Approved = approved
+	response.Failed = failed
+	httpx.SendResponse(w, ld, response)
+}
+
+func parseApprovalID(r *http.Request, logAction *logging.LogAction) (id int64, ok bool) {
+	idStr := r.URL.Query().Get("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil || id <= 0 {
+		logAction.SetError("Missing or invalid query parameter", "A valid approval ID is required", map[string]any{
+			"id": idStr,
+		})
+		return 0, false
+	}
+	return id, true
+}
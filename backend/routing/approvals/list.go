@@ -0,0 +1,84 @@
+package routes_approvals
+
+import (
+	"aura/download/approvals"
+	"aura/logging"
+	"aura/models"
+	"aura/utils/httpx"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+type ListApprovals_Response struct {
+	Approvals []models.DBPendingApproval `json:"approvals"`
+}
+
+// ListApprovals godoc
+// @Summary      Approvals - List
+// @Description  Retrieve the changes waiting for approval, newest first. Every approval contains the Media Item and the Poster Set that is applied when it is approved, the reason it was suggested, and for every image a link to the current image on the media server (before_url) and the new image on MediUX (after_url).
+// @Tags         Approvals
+// @Accept       json
+// @Produce      json
+// @Param        status  query     string  false  "Comma separated list of states to return (pending, approved, rejected). Defaults to pending."
+// @Param        kind    query     string  false  "Comma separated list of kinds to return (creator_set, image_update). All kinds are returned when empty."
+// @Param        limit   query     int     false  "Maximum number of approvals to return (default 100)"
+// @Security 	 BearerAuth
+// @Failure      401  {object}  httpx.UnauthorizedResponse "Unauthorized (only when Auth.Enabled=true)"
+// @Success      200  {object}  httpx.JSONResponse{data=ListApprovals_Response}
+// @Failure      500           {object}  httpx.JSONResponse "Internal Server Error"
+// @Router       /api/approvals [get]
+func ListApprovals(w http.ResponseWriter, r *http.Request) {
+	ctx, ld := logging.CreateLoggingContext(r.Context(), r.URL.Path)
+	logAction := ld.AddAction("Approvals - List", logging.LevelInfo)
+	ctx = logging.WithCurrentAction(ctx, logAction)
+
+	var response ListApprovals_Response
+
+	validStatuses := []string{
+		models.PendingApprovalStatusPending,
+		models.PendingApprovalStatusApproved,
+		models.PendingApprovalStatusRejected,
+	}
+	statuses := []string{models.PendingApprovalStatusPending}
+	if statusStr := r.URL.Query().Get("status"); statusStr != "" {
+		statuses = []string{}
+		for status := range strings.SplitSeq(statusStr, ",") {
+			status = strings.ToLower(strings.TrimSpace(status))
+			if !slices.Contains(validStatuses, status) {
+				logAction.SetError("Invalid status filter", "Use one or more of: pending, approved, rejected", map[string]any{
+					"status": status,
+				})
+				httpx.SendResponse(w, ld, response)
+				return
+			}
+			statuses = append(statuses, status)
+		}
+	}
+
+	kinds := []string{}
+	if kindStr := r.URL.Query().Get("kind"); kindStr != "" {
+		for kind := range strings.SplitSeq(kindStr, ",") {
+			if kind = strings.ToLower(strings.TrimSpace(kind)); kind != "" {
+				kinds = append(kinds, kind)
+			}
+		}
+	}
+
+	limit := 100
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if val, err := strconv.Atoi(limitStr); err == nil && val > 0 {
+			limit = val
+		}
+	}
+
+	list, Err := approvals.List(ctx, statuses, kinds, limit)
+	if Err.Message != "" {
+		httpx.SendResponse(w, ld, response)
+		return
+	}
+
+	response.Approvals = list
+	httpx.SendResponse(w, ld, response)
+}
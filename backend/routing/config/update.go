@@ -59,8 +59,15 @@ func UpdateAppConfig(w http.ResponseWriter, r *http.Request) {
 	authChanged, authValid := checkConfigDifferences_Auth(ctx, config.Current.Auth, &newConfig.Auth)
 	loggingChanged, loggingValid := checkConfigDifferences_Logging(ctx, config.Current.Logging, &newConfig.Logging)
 	mediaServerChanged, mediaServerValid, newMediaServerName := checkConfigDifferences_MediaServer(ctx, config.Current.MediaServer, &newConfig.MediaServer)
+	additionalMediaServersChanged, additionalMediaServersValid := checkConfigDifferences_AdditionalMediaServers(ctx, newConfig.MediaServer, config.Current.AdditionalMediaServers, newConfig.AdditionalMediaServers)
 	mediuxChanged, mediuxValid := checkConfigDifferences_Mediux(ctx, config.Current.Mediux, &newConfig.Mediux)
 	autoDownloadChanged, autoDownloadValid := checkConfigDifferences_Autodownload(ctx, config.Current.AutoDownload, &newConfig.AutoDownload)
+	downloadQueueChanged, downloadQueueValid := checkConfigDifferences_DownloadQueue(ctx, config.Current.DownloadQueue, &newConfig.DownloadQueue)
+	subscribedCreatorsChanged, subscribedCreatorsValid := checkConfigDifferences_SubscribedCreators(ctx, config.Current.SubscribedCreators, &newConfig.SubscribedCreators)
+	autoSelectChanged, autoSelectValid := checkConfigDifferences_AutoSelect(ctx, config.Current.AutoSelect, &newConfig.AutoSelect)
+	coverageReportChanged, coverageReportValid := checkConfigDifferences_CoverageReport(ctx, config.Current.CoverageReport, &newConfig.CoverageReport)
+	driftDetectionChanged, driftDetectionValid := checkConfigDifferences_DriftDetection(ctx, config.Current.DriftDetection, &newConfig.DriftDetection)
+	kometaChanged, kometaValid := checkConfigDifferences_Kometa(ctx, config.Current.Kometa, &newConfig.Kometa)
 	imagesChanged, imagesValid := checkConfigDifferences_Images(ctx, config.Current.Images, &newConfig.Images, newConfig.MediaServer)
 	tmdbChanged, tmdbValid := checkConfigDifferences_TMDB(ctx, config.Current.TMDB, &newConfig.TMDB)
 	labelsAndTagsChanged, labelsAndTagsValid := checkConfigDifferences_LabelsAndTags(ctx, config.Current.LabelsAndTags, &newConfig.LabelsAndTags)
@@ -68,28 +75,35 @@ func UpdateAppConfig(w http.ResponseWriter, r *http.Request) {
 	sonarrRadarrChanged, sonarrRadarrValid := checkConfigDifferences_SonarrRadarr(ctx, config.Current.SonarrRadarr, &newConfig.SonarrRadarr, newConfig.MediaServer)
 	databaseChanged, databaseValid := checkConfigDifferences_Database(ctx, config.Current.Database, &newConfig.Database)
 
-	if !authValid || !loggingValid || !mediaServerValid || !mediuxValid || !autoDownloadValid || !imagesValid || !tmdbValid || !labelsAndTagsValid || !notificationsValid || !sonarrRadarrValid || !databaseValid {
+	if !authValid || !loggingValid || !mediaServerValid || !additionalMediaServersValid || !mediuxValid || !autoDownloadValid || !downloadQueueValid || !subscribedCreatorsValid || !autoSelectValid || !coverageReportValid || !driftDetectionValid || !kometaValid || !imagesValid || !tmdbValid || !labelsAndTagsValid || !notificationsValid || !sonarrRadarrValid || !databaseValid {
 		ld.Status = logging.StatusError
 		logAction.SetError("Invalid configuration", "The provided configuration is invalid. Check the results for details.", map[string]any{
-			"auth_valid":            authValid,
-			"logging_valid":         loggingValid,
-			"media_server_valid":    mediaServerValid,
-			"mediux_valid":          mediuxValid,
-			"auto_download_valid":   autoDownloadValid,
-			"images_valid":          imagesValid,
-			"tmdb_valid":            tmdbValid,
-			"labels_and_tags_valid": labelsAndTagsValid,
-			"notifications_valid":   notificationsValid,
-			"sonarr_radarr_valid":   sonarrRadarrValid,
-			"database_valid":        databaseValid,
+			"auth_valid":                     authValid,
+			"logging_valid":                  loggingValid,
+			"media_server_valid":             mediaServerValid,
+			"additional_media_servers_valid": additionalMediaServersValid,
+			"mediux_valid":                   mediuxValid,
+			"auto_download_valid":            autoDownloadValid,
+			"download_queue_valid":           downloadQueueValid,
+			"subscribed_creators_valid":      subscribedCreatorsValid,
+			"auto_select_valid":              autoSelectValid,
+			"coverage_report_valid":          coverageReportValid,
+			"drift_detection_valid":          driftDetectionValid,
+			"kometa_valid":                   kometaValid,
+			"images_valid":                   imagesValid,
+			"tmdb_valid":                     tmdbValid,
+			"labels_and_tags_valid":          labelsAndTagsValid,
+			"notifications_valid":            notificationsValid,
+			"sonarr_radarr_valid":            sonarrRadarrValid,
+			"database_valid":                 databaseValid,
 		})
 		response.Message = "Invalid configuration. Check the results for details."
 		httpx.SendResponse(w, ld, response)
 		return
 	}
 
-	if !authChanged && !loggingChanged && !mediaServerChanged && !mediuxChanged &&
-		!autoDownloadChanged && !imagesChanged && !tmdbChanged && !labelsAndTagsChanged &&
+	if !authChanged && !loggingChanged && !mediaServerChanged && !additionalMediaServersChanged && !mediuxChanged &&
+		!autoDownloadChanged && !downloadQueueChanged && !subscribedCreatorsChanged && !autoSelectChanged && !coverageReportChanged && !driftDetectionChanged && !kometaChanged && !imagesChanged && !tmdbChanged && !labelsAndTagsChanged &&
 		!notificationsChanged && !sonarrRadarrChanged && !databaseChanged {
 		// If nothing has changed AND the config is valid, log a warning
 		if config.Valid {
@@ -136,8 +150,33 @@ func UpdateAppConfig(w http.ResponseWriter, r *http.Request) {
 		jobs.StartAutoDownloadJob()
 	}
 
+	if subscribedCreatorsChanged {
+		jobs.StartSubscribedCreatorsJob()
+	}
+
+	if coverageReportChanged {
+		jobs.StartCoverageReportJob()
+	}
+
+	if driftDetectionChanged {
+		jobs.StartDriftDetectionJob()
+	}
+
+	if kometaChanged {
+		jobs.StartKometaExportJob()
+	}
+
+	if databaseChanged {
+		jobs.StartDatabaseBackupJob()
+	}
+
+	if mediuxChanged {
+		autodownload.StartOrStopMediuxWebSocketClient()
+	}
+
 	if mediaServerChanged {
 		autodownload.StartOrRestartPlexWebSocketClient()
+		autodownload.StartOrRestartEJWebSocketClient()
 	}
 
 	response.Status = AppConfigStatus{
@@ -278,6 +317,38 @@ func checkConfigDifferences_MediaServer(ctx context.Context, oldMediaServer conf
 				Msg("MediaServer.PlexEventListener.Enabled changed")
 			changed = true
 		}
+
+		if oldMediaServer.EnableEmbyJellyfinEventListener != newMediaServer.EnableEmbyJellyfinEventListener {
+			logAction.AppendResult("MediaServer.EnableEmbyJellyfinEventListener changed", fmt.Sprintf("from '%v' to '%v'", oldMediaServer.EnableEmbyJellyfinEventListener, newMediaServer.EnableEmbyJellyfinEventListener))
+			logging.LOGGER.Info().
+				Timestamp().
+				Bool("old_enabled", oldMediaServer.EnableEmbyJellyfinEventListener).
+				Bool("new_enabled", newMediaServer.EnableEmbyJellyfinEventListener).
+				Msg("MediaServer.EnableEmbyJellyfinEventListener changed")
+			changed = true
+		}
+
+		if oldMediaServer.EnablePlexWebhook != newMediaServer.EnablePlexWebhook {
+			logAction.AppendResult("MediaServer.EnablePlexWebhook changed", fmt.Sprintf("from '%v' to '%v'", oldMediaServer.EnablePlexWebhook, newMediaServer.EnablePlexWebhook))
+			logging.LOGGER.Info().
+				Timestamp().
+				Bool("old_enabled", oldMediaServer.EnablePlexWebhook).
+				Bool("new_enabled", newMediaServer.EnablePlexWebhook).
+				Msg("MediaServer.EnablePlexWebhook changed")
+			changed = true
+		}
+
+		if oldMediaServer.PlexWebhookToken != newMediaServer.PlexWebhookToken {
+			if !strings.HasPrefix(newMediaServer.PlexWebhookToken, "***") {
+				logAction.AppendResult("MediaServer.PlexWebhookToken changed", "the token was updated")
+				logging.LOGGER.Info().
+					Timestamp().
+					Msg("MediaServer.PlexWebhookToken changed")
+				changed = true
+			} else {
+				newMediaServer.PlexWebhookToken = oldMediaServer.PlexWebhookToken
+			}
+		}
 	}
 	newValid = config.ValidateMediaServer(ctx, newMediaServer)
 	// If the Media Server config doesn't pass validation, return early
@@ -294,6 +365,67 @@ func checkConfigDifferences_MediaServer(ctx context.Context, oldMediaServer conf
 	return changed, newValid, serverName
 }
 
+// checkConfigDifferences_AdditionalMediaServers compares old and new additional MediaServer configurations.
+func checkConfigDifferences_AdditionalMediaServers(ctx context.Context, primary config.Config_MediaServer, oldMediaServers []config.Config_MediaServer, newMediaServers []config.Config_MediaServer) (changed, newValid bool) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Check Config Differences: AdditionalMediaServers", logging.LevelTrace)
+	defer logAction.Complete()
+
+	changed = false
+
+	// Masked API tokens are sent back from the frontend, so restore them from the old config by server name
+	oldByName := make(map[string]config.Config_MediaServer, len(oldMediaServers))
+	for _, ms := range oldMediaServers {
+		oldByName[ms.Name] = ms
+	}
+	for i := range newMediaServers {
+		if old, exists := oldByName[newMediaServers[i].Name]; exists && strings.HasPrefix(newMediaServers[i].ApiToken, "***") {
+			newMediaServers[i].ApiToken = old.ApiToken
+		}
+	}
+
+	if !reflect.DeepEqual(oldMediaServers, newMediaServers) {
+		oldNames := make([]string, 0, len(oldMediaServers))
+		for _, ms := range oldMediaServers {
+			oldNames = append(oldNames, ms.Name)
+		}
+		newNames := make([]string, 0, len(newMediaServers))
+		for _, ms := range newMediaServers {
+			newNames = append(newNames, ms.Name)
+		}
+		logAction.AppendResult("AdditionalMediaServers changed", fmt.Sprintf("from '%s' to '%s'", joinNonEmptyComma(oldNames), joinNonEmptyComma(newNames)))
+		changed = true
+		logging.LOGGER.Info().Timestamp().
+			Str("old_servers", joinNonEmptyComma(oldNames)).
+			Str("new_servers", joinNonEmptyComma(newNames)).
+			Msg("AdditionalMediaServers changed")
+	}
+
+	newValid = config.ValidateAdditionalMediaServers(ctx, primary, newMediaServers)
+	// If the additional Media Servers don't pass validation, return early
+	if !newValid {
+		return changed, newValid
+	}
+
+	// Check to see if we can connect to each additional Media Server with the new config
+	for i := range newMediaServers {
+		connectionOk, _, _, msErr := mediaserver.TestConnection(ctx, &newMediaServers[i])
+		if msErr.Message != "" || !connectionOk {
+			newValid = false
+			continue
+		}
+		if newMediaServers[i].Type == "Jellyfin" || newMediaServers[i].Type == "Emby" {
+			ejUserID, userErr := mediaserver.GetAdminUser(ctx, &newMediaServers[i])
+			if userErr.Message != "" || ejUserID == "" {
+				newValid = false
+				continue
+			}
+			newMediaServers[i].UserID = ejUserID
+		}
+	}
+
+	return changed, newValid
+}
+
 // checkConfigDifferences_Mediux compares old and new MediUX configurations.
 func checkConfigDifferences_Mediux(ctx context.Context, oldMediux config.Config_Mediux, newMediux *config.Config_Mediux) (changed, newValid bool) {
 	ctx, logAction := logging.AddSubActionToContext(ctx, "Check Config Differences: MediUX", logging.LevelTrace)
@@ -324,6 +456,16 @@ func checkConfigDifferences_Mediux(ctx context.Context, oldMediux config.Config_
 				Msg("Mediux.DownloadQuality changed")
 			changed = true
 		}
+
+		if oldMediux.EnableEventListener != newMediux.EnableEventListener {
+			logAction.AppendResult("Mediux.EnableEventListener changed", fmt.Sprintf("from '%v' to '%v'", oldMediux.EnableEventListener, newMediux.EnableEventListener))
+			logging.LOGGER.Info().
+				Timestamp().
+				Bool("old_enabled", oldMediux.EnableEventListener).
+				Bool("new_enabled", newMediux.EnableEventListener).
+				Msg("Mediux.EnableEventListener changed")
+			changed = true
+		}
 	}
 	newValid = config.ValidateMediux(ctx, newMediux)
 	// If the MediUX config doesn't pass validation, return early
@@ -366,11 +508,145 @@ func checkConfigDifferences_Autodownload(ctx context.Context, oldAutoDownload co
 				Msg("Autodownload.Cron changed")
 			changed = true
 		}
+
+		if oldAutoDownload.DryRun != newAutoDownload.DryRun {
+			logAction.AppendResult("Autodownload.DryRun changed", fmt.Sprintf("from '%v' to '%v'", oldAutoDownload.DryRun, newAutoDownload.DryRun))
+			logging.LOGGER.Info().
+				Timestamp().
+				Bool("old_dry_run", oldAutoDownload.DryRun).
+				Bool("new_dry_run", newAutoDownload.DryRun).
+				Msg("Autodownload.DryRun changed")
+			changed = true
+		}
+
+		if oldAutoDownload.RequireApproval != newAutoDownload.RequireApproval {
+			logAction.AppendResult("Autodownload.RequireApproval changed", fmt.Sprintf("from '%v' to '%v'", oldAutoDownload.RequireApproval, newAutoDownload.RequireApproval))
+			logging.LOGGER.Info().
+				Timestamp().
+				Bool("old_require_approval", oldAutoDownload.RequireApproval).
+				Bool("new_require_approval", newAutoDownload.RequireApproval).
+				Msg("Autodownload.RequireApproval changed")
+			changed = true
+		}
 	}
 	newValid = config.ValidateAutoDownload(ctx, newAutoDownload)
 	return changed, newValid
 }
 
+// checkConfigDifferences_DownloadQueue compares old and new DownloadQueue configurations.
+func checkConfigDifferences_DownloadQueue(ctx context.Context, oldDownloadQueue config.Config_DownloadQueue, newDownloadQueue *config.Config_DownloadQueue) (changed, newValid bool) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Check Config Differences: DownloadQueue", logging.LevelTrace)
+	defer logAction.Complete()
+	changed = false
+	newValid = false
+	if oldDownloadQueue != *newDownloadQueue {
+		logAction.AppendResult("DownloadQueue changed", fmt.Sprintf("from '%+v' to '%+v'", oldDownloadQueue, *newDownloadQueue))
+		logging.LOGGER.Info().
+			Timestamp().
+			Interface("old_download_queue", oldDownloadQueue).
+			Interface("new_download_queue", *newDownloadQueue).
+			Msg("DownloadQueue changed")
+		changed = true
+	}
+	newValid = config.ValidateDownloadQueue(ctx, newDownloadQueue)
+	return changed, newValid
+}
+
+// checkConfigDifferences_SubscribedCreators compares old and new SubscribedCreators configurations.
+func checkConfigDifferences_SubscribedCreators(ctx context.Context, oldSubscribedCreators config.Config_SubscribedCreators, newSubscribedCreators *config.Config_SubscribedCreators) (changed, newValid bool) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Check Config Differences: SubscribedCreators", logging.LevelTrace)
+	defer logAction.Complete()
+	changed = false
+	newValid = false
+	if oldSubscribedCreators != *newSubscribedCreators {
+		logAction.AppendResult("SubscribedCreators changed", fmt.Sprintf("from '%+v' to '%+v'", oldSubscribedCreators, *newSubscribedCreators))
+		logging.LOGGER.Info().
+			Timestamp().
+			Interface("old_subscribed_creators", oldSubscribedCreators).
+			Interface("new_subscribed_creators", *newSubscribedCreators).
+			Msg("SubscribedCreators changed")
+		changed = true
+	}
+	newValid = config.ValidateSubscribedCreators(ctx, newSubscribedCreators)
+	return changed, newValid
+}
+
+// checkConfigDifferences_CoverageReport compares old and new CoverageReport configurations.
+func checkConfigDifferences_CoverageReport(ctx context.Context, oldCoverageReport config.Config_CoverageReport, newCoverageReport *config.Config_CoverageReport) (changed, newValid bool) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Check Config Differences: CoverageReport", logging.LevelTrace)
+	defer logAction.Complete()
+	changed = false
+	newValid = false
+	if oldCoverageReport != *newCoverageReport {
+		logAction.AppendResult("CoverageReport changed", fmt.Sprintf("from '%+v' to '%+v'", oldCoverageReport, *newCoverageReport))
+		logging.LOGGER.Info().
+			Timestamp().
+			Interface("old_coverage_report", oldCoverageReport).
+			Interface("new_coverage_report", *newCoverageReport).
+			Msg("CoverageReport changed")
+		changed = true
+	}
+	newValid = config.ValidateCoverageReport(ctx, newCoverageReport)
+	return changed, newValid
+}
+
+// checkConfigDifferences_DriftDetection compares old and new DriftDetection configurations.
+func checkConfigDifferences_DriftDetection(ctx context.Context, oldDriftDetection config.Config_DriftDetection, newDriftDetection *config.Config_DriftDetection) (changed, newValid bool) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Check Config Differences: DriftDetection", logging.LevelTrace)
+	defer logAction.Complete()
+	changed = false
+	newValid = false
+	if oldDriftDetection != *newDriftDetection {
+		logAction.AppendResult("DriftDetection changed", fmt.Sprintf("from '%+v' to '%+v'", oldDriftDetection, *newDriftDetection))
+		logging.LOGGER.Info().
+			Timestamp().
+			Interface("old_drift_detection", oldDriftDetection).
+			Interface("new_drift_detection", *newDriftDetection).
+			Msg("DriftDetection changed")
+		changed = true
+	}
+	newValid = config.ValidateDriftDetection(ctx, newDriftDetection)
+	return changed, newValid
+}
+
+// checkConfigDifferences_Kometa compares old and new Kometa configurations.
+func checkConfigDifferences_Kometa(ctx context.Context, oldKometa config.Config_Kometa, newKometa *config.Config_Kometa) (changed, newValid bool) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Check Config Differences: Kometa", logging.LevelTrace)
+	defer logAction.Complete()
+	changed = false
+	newValid = false
+	if oldKometa != *newKometa {
+		logAction.AppendResult("Kometa changed", fmt.Sprintf("from '%+v' to '%+v'", oldKometa, *newKometa))
+		logging.LOGGER.Info().
+			Timestamp().
+			Interface("old_kometa", oldKometa).
+			Interface("new_kometa", *newKometa).
+			Msg("Kometa changed")
+		changed = true
+	}
+	newValid = config.ValidateKometa(ctx, newKometa)
+	return changed, newValid
+}
+
+// checkConfigDifferences_AutoSelect compares old and new AutoSelect configurations.
+func checkConfigDifferences_AutoSelect(ctx context.Context, oldAutoSelect config.Config_AutoSelect, newAutoSelect *config.Config_AutoSelect) (changed, newValid bool) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Check Config Differences: AutoSelect", logging.LevelTrace)
+	defer logAction.Complete()
+	changed = false
+	newValid = false
+	if !reflect.DeepEqual(oldAutoSelect, *newAutoSelect) {
+		logAction.AppendResult("AutoSelect changed", fmt.Sprintf("from '%+v' to '%+v'", oldAutoSelect, *newAutoSelect))
+		logging.LOGGER.Info().
+			Timestamp().
+			Interface("old_auto_select", oldAutoSelect).
+			Interface("new_auto_select", *newAutoSelect).
+			Msg("AutoSelect changed")
+		changed = true
+	}
+	newValid = config.ValidateAutoSelect(ctx, newAutoSelect)
+	return changed, newValid
+}
+
 // checkConfigDifferences_Images compares old and new Images configurations.
 func checkConfigDifferences_Images(ctx context.Context, oldImages config.Config_Images, newImages *config.Config_Images, msConfig config.Config_MediaServer) (changed, newValid bool) {
 	ctx, logAction := logging.AddSubActionToContext(ctx, "Check Config Differences: Images", logging.LevelTrace)
@@ -417,6 +693,46 @@ func checkConfigDifferences_Images(ctx context.Context, oldImages config.Config_
 				Msg("Images.SaveImagesLocally.EpisodeNamingConvention changed")
 			changed = true
 		}
+
+		if oldImages.SaveImagesLocally.NamingProfile != newImages.SaveImagesLocally.NamingProfile {
+			logAction.AppendResult("Images.SaveImagesLocally.NamingProfile changed", fmt.Sprintf("from '%s' to '%s'", oldImages.SaveImagesLocally.NamingProfile, newImages.SaveImagesLocally.NamingProfile))
+			logging.LOGGER.Info().
+				Timestamp().
+				Str("old_naming_profile", oldImages.SaveImagesLocally.NamingProfile).
+				Str("new_naming_profile", newImages.SaveImagesLocally.NamingProfile).
+				Msg("Images.SaveImagesLocally.NamingProfile changed")
+			changed = true
+		}
+
+		if oldImages.SaveImagesLocally.NamingTemplate != newImages.SaveImagesLocally.NamingTemplate {
+			logAction.AppendResult("Images.SaveImagesLocally.NamingTemplate changed", fmt.Sprintf("from '%+v' to '%+v'", oldImages.SaveImagesLocally.NamingTemplate, newImages.SaveImagesLocally.NamingTemplate))
+			logging.LOGGER.Info().
+				Timestamp().
+				Interface("old_naming_template", oldImages.SaveImagesLocally.NamingTemplate).
+				Interface("new_naming_template", newImages.SaveImagesLocally.NamingTemplate).
+				Msg("Images.SaveImagesLocally.NamingTemplate changed")
+			changed = true
+		}
+
+		if oldImages.History.Enabled != newImages.History.Enabled {
+			logAction.AppendResult("Images.History.Enabled changed", fmt.Sprintf("from '%v' to '%v'", oldImages.History.Enabled, newImages.History.Enabled))
+			logging.LOGGER.Info().
+				Timestamp().
+				Bool("old_enabled", oldImages.History.Enabled).
+				Bool("new_enabled", newImages.History.Enabled).
+				Msg("Images.History.Enabled changed")
+			changed = true
+		}
+
+		if oldImages.History.MaxVersions != newImages.History.MaxVersions {
+			logAction.AppendResult("Images.History.MaxVersions changed", fmt.Sprintf("from '%d' to '%d'", oldImages.History.MaxVersions, newImages.History.MaxVersions))
+			logging.LOGGER.Info().
+				Timestamp().
+				Int("old_max_versions", oldImages.History.MaxVersions).
+				Int("new_max_versions", newImages.History.MaxVersions).
+				Msg("Images.History.MaxVersions changed")
+			changed = true
+		}
 	}
 	newValid = config.ValidateImages(ctx, newImages, msConfig)
 	return changed, newValid
@@ -442,7 +758,48 @@ func checkConfigDifferences_TMDB(ctx context.Context, oldTMDB config.Config_TMDB
 				newTMDB.ApiToken = oldTMDB.ApiToken
 			}
 		}
+
+		if oldTMDB.FallbackEnabled != newTMDB.FallbackEnabled {
+			logAction.AppendResult("TMDB.FallbackEnabled changed", fmt.Sprintf("from '%v' to '%v'", oldTMDB.FallbackEnabled, newTMDB.FallbackEnabled))
+			logging.LOGGER.Info().
+				Timestamp().
+				Bool("old_fallback_enabled", oldTMDB.FallbackEnabled).
+				Bool("new_fallback_enabled", newTMDB.FallbackEnabled).
+				Msg("TMDB.FallbackEnabled changed")
+			changed = true
+		}
+
+		if !reflect.DeepEqual(oldTMDB.Languages, newTMDB.Languages) {
+			logAction.AppendResult("TMDB.Languages changed", fmt.Sprintf("from '%v' to '%v'", oldTMDB.Languages, newTMDB.Languages))
+			logging.LOGGER.Info().
+				Timestamp().
+				Strs("old_languages", oldTMDB.Languages).
+				Strs("new_languages", newTMDB.Languages).
+				Msg("TMDB.Languages changed")
+			changed = true
+		}
+
+		if oldTMDB.MinVoteAverage != newTMDB.MinVoteAverage {
+			logAction.AppendResult("TMDB.MinVoteAverage changed", fmt.Sprintf("from '%v' to '%v'", oldTMDB.MinVoteAverage, newTMDB.MinVoteAverage))
+			logging.LOGGER.Info().
+				Timestamp().
+				Float64("old_min_vote_average", oldTMDB.MinVoteAverage).
+				Float64("new_min_vote_average", newTMDB.MinVoteAverage).
+				Msg("TMDB.MinVoteAverage changed")
+			changed = true
+		}
+
+		if oldTMDB.MaxSets != newTMDB.MaxSets {
+			logAction.AppendResult("TMDB.MaxSets changed", fmt.Sprintf("from '%d' to '%d'", oldTMDB.MaxSets, newTMDB.MaxSets))
+			logging.LOGGER.Info().
+				Timestamp().
+				Int("old_max_sets", oldTMDB.MaxSets).
+				Int("new_max_sets", newTMDB.MaxSets).
+				Msg("TMDB.MaxSets changed")
+			changed = true
+		}
 	}
+	newValid = config.ValidateTMDB(ctx, newTMDB)
 	return changed, newValid
 }
 
@@ -881,6 +1238,7 @@ func diffNotificationTemplates(oldT, newT config.Config_NotificationTemplate) []
 		"new_sets_available_for_ignored_items": oldT.NewSetsAvailableForIgnoredItems,
 		"check_for_media_item_changes_job":     oldT.CheckForMediaItemChangesJob,
 		"sonarr_notification":                  oldT.SonarrNotification,
+		"radarr_notification":                  oldT.RadarrNotification,
 	}
 	newMap := map[string]config.Config_CustomNotification{
 		"app_startup":                          newT.AppStartup,
@@ -890,6 +1248,7 @@ func diffNotificationTemplates(oldT, newT config.Config_NotificationTemplate) []
 		"new_sets_available_for_ignored_items": newT.NewSetsAvailableForIgnoredItems,
 		"check_for_media_item_changes_job":     newT.CheckForMediaItemChangesJob,
 		"sonarr_notification":                  newT.SonarrNotification,
+		"radarr_notification":                  newT.RadarrNotification,
 	}
 
 	diffs := make([]notificationTemplateDiff, 0)
@@ -1051,7 +1410,13 @@ func checkConfigDifferences_Database(ctx context.Context, oldDB config.Config_Da
 	defer logAction.Complete()
 	changed = false
 	newValid = false
-	if !reflect.DeepEqual(oldDB, newDB) {
+
+	// If the password is masked, keep the old one
+	if strings.HasPrefix(newDB.Password, "***") {
+		newDB.Password = oldDB.Password
+	}
+
+	if !reflect.DeepEqual(oldDB, *newDB) {
 		if oldDB.Type != newDB.Type {
 			logAction.AppendResult("Database.Type changed", fmt.Sprintf("from '%s' to '%s'", oldDB.Type, newDB.Type))
 			logging.LOGGER.Info().
@@ -1071,6 +1436,34 @@ func checkConfigDifferences_Database(ctx context.Context, oldDB config.Config_Da
 				Msg("Database.ConnectionString changed")
 			changed = true
 		}
+
+		if oldDB.Host != newDB.Host || oldDB.Port != newDB.Port || oldDB.Name != newDB.Name {
+			logAction.AppendResult("Database.Server changed", fmt.Sprintf("from '%s:%d/%s' to '%s:%d/%s'", oldDB.Host, oldDB.Port, oldDB.Name, newDB.Host, newDB.Port, newDB.Name))
+			logging.LOGGER.Info().
+				Timestamp().
+				Str("old_server", fmt.Sprintf("%s:%d/%s", oldDB.Host, oldDB.Port, oldDB.Name)).
+				Str("new_server", fmt.Sprintf("%s:%d/%s", newDB.Host, newDB.Port, newDB.Name)).
+				Msg("Database.Server changed")
+			changed = true
+		}
+
+		if oldDB.User != newDB.User || oldDB.Password != newDB.Password {
+			logAction.AppendResult("Database.Credentials changed", "")
+			logging.LOGGER.Info().
+				Timestamp().
+				Msg("Database.Credentials changed")
+			changed = true
+		}
+
+		if oldDB.Backup != newDB.Backup {
+			logAction.AppendResult("Database.Backup changed", fmt.Sprintf("from '%+v' to '%+v'", oldDB.Backup, newDB.Backup))
+			logging.LOGGER.Info().
+				Timestamp().
+				Interface("old_backup", oldDB.Backup).
+				Interface("new_backup", newDB.Backup).
+				Msg("Database.Backup changed")
+			changed = true
+		}
 	}
 	newValid = config.ValidateDatabase(ctx, newDB)
 	return changed, newValid
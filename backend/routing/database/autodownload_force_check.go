@@ -13,6 +13,7 @@ import (
 type autodownloadForceCheckRequest struct {
 	Item     models.DBSavedItem `json:"item"`
 	Complete bool               `json:"complete"` // Whether the provided data is complete or if we need to fetch missing information
+	DryRun   bool               `json:"dry_run"`  // Only return which images would be downloaded and why, without changing the media server or database
 }
 
 type autodownloadForceCheckResponse struct {
@@ -21,7 +22,7 @@ type autodownloadForceCheckResponse struct {
 
 // AutoDownloadForceCheck godoc
 // @Summary      Auto Download - Force Check
-// @Description  Force a check to see if any of the images need to be re-downloaded for a given Media Item and its associated Poster Sets.
+// @Description  Force a check to see if any of the images need to be re-downloaded for a given Media Item and its associated Poster Sets. With dry_run set, the result contains the images that would be downloaded and why, without changing the media server or database.
 // @Tags         Database
 // @Accept       json
 // @Produce      json
@@ -134,7 +135,7 @@ func AutoDownloadForceCheck(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Perform the Force Check
-	result := autodownload.CheckItem(ctx, saveItem)
+	result := autodownload.CheckItem(ctx, saveItem, req.DryRun)
 	response.Result = result
 	httpx.SendResponse(w, ld, response)
 }
@@ -0,0 +1,84 @@
+package routes_db
+
+import (
+	"aura/database"
+	"aura/logging"
+	"aura/utils/httpx"
+	"net/http"
+)
+
+type listBackupsResponse struct {
+	BackupDir string                `json:"backup_dir"`
+	Backups   []database.BackupInfo `json:"backups"`
+}
+
+type restoreBackupResponse struct {
+	Restored     string              `json:"restored"`      // Name of the backup that was restored
+	SafetyBackup database.BackupInfo `json:"safety_backup"` // Backup of the database taken before restoring
+}
+
+// ListBackups godoc
+// @Summary      List Database Backups
+// @Description  List the scheduled database backups, newest first.
+// @Tags         Database
+// @Produce      json
+// @Security 	 BearerAuth
+// @Failure      401  {object}  httpx.UnauthorizedResponse "Unauthorized (only when Auth.Enabled=true)"
+// @Success      200  {object}  httpx.JSONResponse{data=listBackupsResponse}
+// @Failure      500  {object}  httpx.JSONResponse "Internal Server Error"
+// @Router       /api/db/backups [get]
+func ListBackups(w http.ResponseWriter, r *http.Request) {
+	ctx, ld := logging.CreateLoggingContext(r.Context(), r.URL.Path)
+	logAction := ld.AddAction("List Database Backups", logging.LevelDebug)
+	ctx = logging.WithCurrentAction(ctx, logAction)
+
+	var response listBackupsResponse
+	response.BackupDir = database.GetBackupDir()
+
+	backups, Err := database.ListBackups(ctx)
+	if Err.Message != "" {
+		httpx.SendResponse(w, ld, response)
+		return
+	}
+	response.Backups = backups
+
+	httpx.SendResponse(w, ld, response)
+}
+
+// RestoreBackup godoc
+// @Summary      Restore Database Backup
+// @Description  Replace the database with one of the scheduled backups. A backup of the current database is taken first, so the restore can be rolled back.
+// @Tags         Database
+// @Produce      json
+// @Param        name  query     string  true  "Name of the backup to restore"
+// @Security 	 BearerAuth
+// @Failure      401  {object}  httpx.UnauthorizedResponse "Unauthorized (only when Auth.Enabled=true)"
+// @Success      200  {object}  httpx.JSONResponse{data=restoreBackupResponse}
+// @Failure      500  {object}  httpx.JSONResponse "Internal Server Error"
+// @Router       /api/db/backups/restore [post]
+func RestoreBackup(w http.ResponseWriter, r *http.Request) {
+	ctx, ld := logging.CreateLoggingContext(r.Context(), r.URL.Path)
+	logAction := ld.AddAction("Restore Database Backup", logging.LevelInfo)
+	ctx = logging.WithCurrentAction(ctx, logAction)
+
+	var response restoreBackupResponse
+
+	backupName := r.URL.Query().Get("name")
+	if backupName == "" {
+		logAction.SetError("Missing required query parameters", "Backup name is required", map[string]any{
+			"name": backupName,
+		})
+		httpx.SendResponse(w, ld, response)
+		return
+	}
+
+	safetyBackup, Err := database.RestoreBackup(ctx, backupName)
+	if Err.Message != "" {
+		httpx.SendResponse(w, ld, response)
+		return
+	}
+
+	response.Restored = backupName
+	response.SafetyBackup = safetyBackup
+	httpx.SendResponse(w, ld, response)
+}
@@ -0,0 +1,146 @@
+package routes_db
+
+import (
+	"aura/config"
+	"aura/database"
+	"aura/logging"
+	"aura/models"
+	"aura/utils/httpx"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ExportDB godoc
+// @Summary      Export Saved Sets
+// @Description  Export every saved Media Item (with its Poster Sets, selected types and autodownload flags) and every ignored item as a versioned bundle. The bundle can be imported into another instance using the import endpoint.
+// @Tags         Database
+// @Produce      json
+// @Produce      application/yaml
+// @Param        format  query     string  false  "Bundle format ('json' or 'yaml'). Defaults to 'json'"
+// @Security 	 BearerAuth
+// @Failure      401  {object}  httpx.UnauthorizedResponse "Unauthorized (only when Auth.Enabled=true)"
+// @Success      200  {object}  models.DBBundle
+// @Failure      500  {object}  httpx.JSONResponse "Internal Server Error"
+// @Router       /api/db/export [get]
+func ExportDB(w http.ResponseWriter, r *http.Request) {
+	ctx, ld := logging.CreateLoggingContext(r.Context(), r.URL.Path)
+	logAction := ld.AddAction("Export Saved Sets", logging.LevelInfo)
+	ctx = logging.WithCurrentAction(ctx, logAction)
+
+	format, ok := getBundleFormat(r)
+	if !ok {
+		logAction.SetError("Invalid format parameter", "Format must be either 'json' or 'yaml'", map[string]any{
+			"format": r.URL.Query().Get("format"),
+		})
+		httpx.SendResponse(w, ld, nil)
+		return
+	}
+
+	dbVersion, Err := database.GetCurrentVersion(ctx)
+	if Err.Message != "" {
+		httpx.SendResponse(w, ld, nil)
+		return
+	}
+
+	// Get every saved item (-1 means no paging)
+	savedItems, Err := database.GetAllSavedSets(ctx, models.DBFilter{ItemsPerPage: -1})
+	if Err.Message != "" {
+		httpx.SendResponse(w, ld, nil)
+		return
+	}
+
+	ignoredItems, Err := database.GetAllIgnoredItems(ctx)
+	if Err.Message != "" {
+		httpx.SendResponse(w, ld, nil)
+		return
+	}
+
+	bundle := models.DBBundle{
+		Version:      models.DBBundleVersion,
+		AppVersion:   config.AppVersion,
+		DBVersion:    dbVersion,
+		ExportedAt:   time.Now().UTC(),
+		SavedItems:   savedItems.Items,
+		IgnoredItems: ignoredItems,
+	}
+	if bundle.SavedItems == nil {
+		bundle.SavedItems = []models.DBSavedItem{}
+	}
+
+	data, err := encodeBundle(bundle, format)
+	if err != nil {
+		logAction.SetError("Failed to encode export bundle", err.Error(), map[string]any{
+			"format": format,
+		})
+		httpx.SendResponse(w, ld, nil)
+		return
+	}
+
+	logAction.AppendResult("saved_items", len(bundle.SavedItems))
+	logAction.AppendResult("ignored_items", len(bundle.IgnoredItems))
+
+	contentType := "application/json"
+	if format == "yaml" {
+		contentType = "application/yaml"
+	}
+	fileName := fmt.Sprintf("aura_export_%s.%s", bundle.ExportedAt.Format("20060102_150405"), format)
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fileName))
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// getBundleFormat returns the requested bundle format ("json" or "yaml").
+// The "format" query parameter takes precedence over the Content-Type header.
+func getBundleFormat(r *http.Request) (format string, ok bool) {
+	format = strings.ToLower(strings.TrimSpace(r.URL.Query().Get("format")))
+	switch format {
+	case "json":
+		return "json", true
+	case "yaml", "yml":
+		return "yaml", true
+	case "":
+		if strings.Contains(strings.ToLower(r.Header.Get("Content-Type")), "yaml") {
+			return "yaml", true
+		}
+		return "json", true
+	default:
+		return format, false
+	}
+}
+
+// encodeBundle encodes the bundle in the requested format.
+// YAML is converted from the JSON encoding so both formats use the same keys.
+func encodeBundle(bundle models.DBBundle, format string) ([]byte, error) {
+	data, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil || format != "yaml" {
+		return data, err
+	}
+
+	var doc any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(doc)
+}
+
+// decodeBundle decodes a bundle in the given format
+func decodeBundle(data []byte, format string) (bundle models.DBBundle, err error) {
+	if format == "yaml" {
+		var doc any
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return bundle, err
+		}
+		data, err = json.Marshal(doc)
+		if err != nil {
+			return bundle, err
+		}
+	}
+	err = json.Unmarshal(data, &bundle)
+	return bundle, err
+}
@@ -0,0 +1,183 @@
+package routes_db
+
+import (
+	"aura/cache"
+	"aura/database"
+	"aura/logging"
+	"aura/mediaserver"
+	"aura/models"
+	"aura/utils/httpx"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+type importDBResponse struct {
+	Version              int                   `json:"version"`                // Version of the imported bundle
+	SavedItemsImported   int                   `json:"saved_items_imported"`   // Number of saved items added/updated
+	IgnoredItemsImported int                   `json:"ignored_items_imported"` // Number of ignored items added/updated
+	Unmatched            []importUnmatchedItem `json:"unmatched"`              // Items that could not be matched to a Media Item in the library
+	Failed               []importUnmatchedItem `json:"failed"`                 // Items that were matched but could not be saved
+}
+
+type importUnmatchedItem struct {
+	TMDB_ID      string `json:"tmdb_id"`
+	LibraryTitle string `json:"library_title"`
+	Title        string `json:"title,omitempty"`
+	Year         int    `json:"year,omitempty"`
+	Ignored      bool   `json:"ignored"` // Whether the entry came from the ignored items
+	Reason       string `json:"reason"`
+}
+
+// ImportDB godoc
+// @Summary      Import Saved Sets
+// @Description  Import a bundle created by the export endpoint. Each item is re-matched by TMDB ID and Library Title against the library cache of this instance. Items that can not be matched are reported and skipped.
+// @Tags         Database
+// @Accept       json
+// @Accept       application/yaml
+// @Produce      json
+// @Param        format  query     string  false  "Bundle format ('json' or 'yaml'). Defaults to the Content-Type header, then 'json'"
+// @Param        req     body      models.DBBundle  true  "Export Bundle"
+// @Security 	 BearerAuth
+// @Failure      401  {object}  httpx.UnauthorizedResponse "Unauthorized (only when Auth.Enabled=true)"
+// @Success      200  {object}  httpx.JSONResponse{data=importDBResponse}
+// @Failure      500  {object}  httpx.JSONResponse "Internal Server Error"
+// @Router       /api/db/import [post]
+func ImportDB(w http.ResponseWriter, r *http.Request) {
+	ctx, ld := logging.CreateLoggingContext(r.Context(), r.URL.Path)
+	logAction := ld.AddAction("Import Saved Sets", logging.LevelInfo)
+	ctx = logging.WithCurrentAction(ctx, logAction)
+
+	response := importDBResponse{
+		Unmatched: []importUnmatchedItem{},
+		Failed:    []importUnmatchedItem{},
+	}
+
+	format, ok := getBundleFormat(r)
+	if !ok {
+		logAction.SetError("Invalid format parameter", "Format must be either 'json' or 'yaml'", map[string]any{
+			"format": r.URL.Query().Get("format"),
+		})
+		httpx.SendResponse(w, ld, response)
+		return
+	}
+
+	// Decode the bundle
+	actionDecode := logAction.AddSubAction("Decode Import Bundle", logging.LevelDebug)
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		actionDecode.SetError("Failed to read request body", err.Error(), map[string]any{
+			"error": err.Error(),
+		})
+		httpx.SendResponse(w, ld, response)
+		return
+	}
+	bundle, err := decodeBundle(body, format)
+	if err != nil {
+		actionDecode.SetError("Failed to decode import bundle", "Make sure the bundle was created by the export endpoint and the format is correct", map[string]any{
+			"error":  err.Error(),
+			"format": format,
+		})
+		httpx.SendResponse(w, ld, response)
+		return
+	}
+	if bundle.Version < 1 || bundle.Version > models.DBBundleVersion {
+		actionDecode.SetError("Unsupported bundle version", fmt.Sprintf("Supported bundle versions are 1 to %d", models.DBBundleVersion), map[string]any{
+			"version": bundle.Version,
+		})
+		httpx.SendResponse(w, ld, response)
+		return
+	}
+	actionDecode.Complete()
+	response.Version = bundle.Version
+
+	// Saved Items
+	for _, bundleItem := range bundle.SavedItems {
+		reported := importUnmatchedItem{
+			TMDB_ID:      bundleItem.MediaItem.TMDB_ID,
+			LibraryTitle: bundleItem.MediaItem.LibraryTitle,
+			Title:        bundleItem.MediaItem.Title,
+			Year:         bundleItem.MediaItem.Year,
+		}
+
+		if len(bundleItem.PosterSets) == 0 {
+			reported.Reason = "No poster sets in bundle"
+			response.Failed = append(response.Failed, reported)
+			continue
+		}
+
+		// Re-match the Media Item against the library of this instance
+		cachedItem, found := cache.LibraryStore.GetMediaItemFromSectionByTMDBID(bundleItem.MediaItem.LibraryTitle, bundleItem.MediaItem.TMDB_ID)
+		if !found {
+			reported.Reason = "Not found in library"
+			response.Unmatched = append(response.Unmatched, reported)
+			continue
+		}
+
+		// The bundle contains the file details of the exporting instance, so get them again
+		mediaItem := *cachedItem
+		found, Err := mediaserver.GetMediaItemDetails(ctx, &mediaItem)
+		if Err.Message != "" || !found {
+			reported.Reason = "Failed to get details from media server"
+			if Err.Message != "" {
+				reported.Reason = Err.Message
+			}
+			response.Unmatched = append(response.Unmatched, reported)
+			continue
+		}
+
+		posterSets := make([]models.DBPosterSetDetail, 0, len(bundleItem.PosterSets))
+		for _, ps := range bundleItem.PosterSets {
+			ps.ToDelete = false
+			posterSets = append(posterSets, ps)
+		}
+
+		Err = database.UpsertSavedItem(ctx, models.DBSavedItem{
+			MediaItem:  mediaItem,
+			PosterSets: posterSets,
+		})
+		if Err.Message != "" {
+			reported.Reason = Err.Message
+			response.Failed = append(response.Failed, reported)
+			continue
+		}
+		response.SavedItemsImported++
+	}
+
+	// Ignored Items
+	for _, ignoredItem := range bundle.IgnoredItems {
+		reported := importUnmatchedItem{
+			TMDB_ID:      ignoredItem.TMDB_ID,
+			LibraryTitle: ignoredItem.LibraryTitle,
+			Ignored:      true,
+		}
+
+		cachedItem, found := cache.LibraryStore.GetMediaItemFromSectionByTMDBID(ignoredItem.LibraryTitle, ignoredItem.TMDB_ID)
+		if !found {
+			reported.Reason = "Not found in library"
+			response.Unmatched = append(response.Unmatched, reported)
+			continue
+		}
+		reported.Title = cachedItem.Title
+		reported.Year = cachedItem.Year
+
+		Err := database.IgnoreMediaItem(ctx, ignoredItem.TMDB_ID, ignoredItem.LibraryTitle, ignoredItem.Mode, strings.Join(ignoredItem.CurrentSets, ","))
+		if Err.Message != "" {
+			reported.Reason = Err.Message
+			response.Failed = append(response.Failed, reported)
+			continue
+		}
+		response.IgnoredItemsImported++
+	}
+
+	for _, item := range append(response.Unmatched, response.Failed...) {
+		logAction.AppendWarning(fmt.Sprintf("%s | %s", item.LibraryTitle, item.TMDB_ID), item.Reason)
+	}
+	logAction.AppendResult("saved_items_imported", response.SavedItemsImported)
+	logAction.AppendResult("ignored_items_imported", response.IgnoredItemsImported)
+	logAction.AppendResult("unmatched", len(response.Unmatched))
+	logAction.AppendResult("failed", len(response.Failed))
+
+	httpx.SendResponse(w, ld, response)
+}
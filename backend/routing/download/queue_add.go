@@ -18,7 +18,7 @@ type AddItemToDownloadQueue_Response struct {
 
 // AddItemToDownloadQueue godoc
 // @Summary      Download Queue - Add Item
-// @Description  Add a Media Item and its associated Poster Sets to the download queue. The item will be processed by the download worker and removed from the queue once completed.
+// @Description  Add a Media Item and its associated Poster Sets to the download queue. The item will be processed by the download worker, and its result is kept in the download queue history.
 // @Tags         Download
 // @Accept       json
 // @Produce      json
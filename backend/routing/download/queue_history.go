@@ -0,0 +1,75 @@
+package routes_download
+
+import (
+	downloadqueue "aura/download/queue"
+	"aura/logging"
+	"aura/models"
+	"aura/utils/httpx"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+type GetDownloadQueueHistory_Response struct {
+	Entries []models.DBDownloadQueueEntry `json:"entries"`
+}
+
+// GetDownloadQueueHistory godoc
+// @Summary      Download Queue - Get History
+// @Description  Retrieve the entries of the download queue, newest first, including their state, number of attempts, timestamps and the result of every image from the last attempt.
+// @Tags         Download
+// @Accept       json
+// @Produce      json
+// @Param        status  query     string  false  "Comma separated list of states to return (pending, processing, succeeded, warning, failed)"
+// @Param        limit   query     int     false  "Maximum number of entries to return (default 100)"
+// @Security 	 BearerAuth
+// @Failure      401  {object}  httpx.UnauthorizedResponse "Unauthorized (only when Auth.Enabled=true)"
+// @Success      200  {object}  httpx.JSONResponse{data=GetDownloadQueueHistory_Response}
+// @Failure      500           {object}  httpx.JSONResponse "Internal Server Error"
+// @Router       /api/download/queue/history [get]
+func GetDownloadQueueHistory(w http.ResponseWriter, r *http.Request) {
+	ctx, ld := logging.CreateLoggingContext(r.Context(), r.URL.Path)
+	logAction := ld.AddAction("Download Queue - Get History", logging.LevelInfo)
+	ctx = logging.WithCurrentAction(ctx, logAction)
+
+	var response GetDownloadQueueHistory_Response
+
+	validStatuses := []string{
+		models.DownloadQueueStatusPending,
+		models.DownloadQueueStatusProcessing,
+		models.DownloadQueueStatusSucceeded,
+		models.DownloadQueueStatusWarning,
+		models.DownloadQueueStatusFailed,
+	}
+	statuses := []string{}
+	if statusStr := r.URL.Query().Get("status"); statusStr != "" {
+		for status := range strings.SplitSeq(statusStr, ",") {
+			status = strings.ToLower(strings.TrimSpace(status))
+			if !slices.Contains(validStatuses, status) {
+				logAction.SetError("Invalid status filter", "Use one or more of: pending, processing, succeeded, warning, failed", map[string]any{
+					"status": status,
+				})
+				httpx.SendResponse(w, ld, response)
+				return
+			}
+			statuses = append(statuses, status)
+		}
+	}
+
+	limit := 100
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if val, err := strconv.Atoi(limitStr); err == nil && val > 0 {
+			limit = val
+		}
+	}
+
+	entries, Err := downloadqueue.GetQueueHistory(ctx, statuses, limit)
+	if Err.Message != "" {
+		httpx.SendResponse(w, ld, response)
+		return
+	}
+
+	response.Entries = entries
+	httpx.SendResponse(w, ld, response)
+}
@@ -0,0 +1,53 @@
+package routes_download
+
+import (
+	downloadqueue "aura/download/queue"
+	"aura/logging"
+	"aura/models"
+	"aura/utils/httpx"
+	"net/http"
+	"strconv"
+)
+
+type RetryDownloadQueueEntry_Response struct {
+	Entry models.DBDownloadQueueEntry `json:"entry"`
+}
+
+// RetryDownloadQueueEntry godoc
+// @Summary      Download Queue - Retry Entry
+// @Description  Queue a failed or warning entry of the download queue again, or skip the remaining backoff delay of an entry waiting for a retry. The entry is processed by the next run of the download worker.
+// @Tags         Download
+// @Accept       json
+// @Produce      json
+// @Param        id  query     int  true  "ID of the download queue entry"
+// @Security 	 BearerAuth
+// @Failure      401  {object}  httpx.UnauthorizedResponse "Unauthorized (only when Auth.Enabled=true)"
+// @Success      200  {object}  httpx.JSONResponse{data=RetryDownloadQueueEntry_Response}
+// @Failure      500           {object}  httpx.JSONResponse "Internal Server Error"
+// @Router       /api/download/queue/item/retry [post]
+func RetryDownloadQueueEntry(w http.ResponseWriter, r *http.Request) {
+	ctx, ld := logging.CreateLoggingContext(r.Context(), r.URL.Path)
+	logAction := ld.AddAction("Download Queue - Retry Entry", logging.LevelInfo)
+	ctx = logging.WithCurrentAction(ctx, logAction)
+
+	var response RetryDownloadQueueEntry_Response
+
+	idStr := r.URL.Query().Get("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil || id <= 0 {
+		logAction.SetError("Missing or invalid query parameter", "A valid download queue entry ID is required", map[string]any{
+			"id": idStr,
+		})
+		httpx.SendResponse(w, ld, response)
+		return
+	}
+
+	entry, Err := downloadqueue.RetryQueueEntry(ctx, id)
+	if Err.Message != "" {
+		httpx.SendResponse(w, ld, response)
+		return
+	}
+
+	response.Entry = entry
+	httpx.SendResponse(w, ld, response)
+}
@@ -1,6 +1,7 @@
 package routes_download
 
 import (
+	"aura/config"
 	downloadqueue "aura/download/queue"
 	"aura/logging"
 	"aura/utils/httpx"
@@ -14,11 +15,24 @@ type GetDownloadQueueStatus_Response struct {
 	Message  string               `json:"message"`
 	Warnings []string             `json:"warnings"`
 	Errors   []string             `json:"errors"`
+	Retries  []DownloadQueueRetry `json:"retries"`
+}
+
+// DownloadQueueRetry is an entry waiting for a retry after a transient failure
+type DownloadQueueRetry struct {
+	ID            int64     `json:"id"`
+	Title         string    `json:"title"`
+	LibraryTitle  string    `json:"library_title"`
+	Server        string    `json:"server,omitempty"`
+	Attempts      int       `json:"attempts"`
+	MaxAttempts   int       `json:"max_attempts"`
+	NextAttemptAt time.Time `json:"next_attempt_at"`
+	Errors        []string  `json:"errors"`
 }
 
 // GetDownloadQueueStatus godoc
 // @Summary      Download Queue - Get Status
-// @Description  Retrieve the current status of the download queue, including the latest status message, any warnings or errors, and the timestamp of the last update. This endpoint provides insight into the overall health and activity of the download queue.
+// @Description  Retrieve the current status of the download queue, including the latest status message, any warnings or errors, the timestamp of the last update and the entries waiting for a retry after a transient failure. This endpoint provides insight into the overall health and activity of the download queue.
 // @Tags         Download
 // @Accept       json
 // @Produce      json
@@ -32,12 +46,31 @@ func GetDownloadQueueStatus(w http.ResponseWriter, r *http.Request) {
 	logAction := ld.AddAction("Download Queue - Get Status", logging.LevelInfo)
 	ctx = logging.WithCurrentAction(ctx, logAction)
 
+	latestInfo := downloadqueue.GetLatestInfo()
 	var response GetDownloadQueueStatus_Response
-	response.Time = downloadqueue.LatestInfo.Time
-	response.Status = downloadqueue.LatestInfo.Status
-	response.Message = downloadqueue.LatestInfo.Message
-	response.Warnings = downloadqueue.LatestInfo.Warnings
-	response.Errors = downloadqueue.LatestInfo.Errors
+	response.Time = latestInfo.Time
+	response.Status = latestInfo.Status
+	response.Message = latestInfo.Message
+	response.Warnings = latestInfo.Warnings
+	response.Errors = latestInfo.Errors
+	response.Retries = []DownloadQueueRetry{}
+
+	// Entries waiting for a retry, the status is still returned if they can not be read
+	retries, Err := downloadqueue.GetScheduledRetries(ctx)
+	if Err.Message == "" {
+		for _, entry := range retries {
+			response.Retries = append(response.Retries, DownloadQueueRetry{
+				ID:            entry.ID,
+				Title:         entry.Item.MediaItem.Title,
+				LibraryTitle:  entry.Item.MediaItem.LibraryTitle,
+				Server:        entry.Item.MediaItem.Server,
+				Attempts:      entry.Attempts,
+				MaxAttempts:   config.Current.DownloadQueue.MaxAttempts,
+				NextAttemptAt: *entry.NextAttemptAt,
+				Errors:        entry.Errors,
+			})
+		}
+	}
 
 	httpx.SendResponse(w, ld, response)
 }
@@ -0,0 +1,106 @@
+package routes_events
+
+import (
+	downloadqueue "aura/download/queue"
+	"aura/events"
+	"aura/logging"
+	"aura/utils/httpx"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// keepAliveInterval keeps proxies from closing idle event streams
+const keepAliveInterval = 30 * time.Second
+
+// StreamEvents godoc
+// @Summary      Stream Events
+// @Description  Stream live events as Server-Sent Events. Every event is sent with its type as the SSE event name and a JSON object with the type, time and data. Available events: download_queue.status, download_queue.item_started, download_queue.image_result, download_queue.item_finished, job.started, job.progress, job.finished and saved_sets.changed. The current download queue status is sent right after connecting. The stream requires the Authorization header, so it can not be opened with the browser EventSource API when authentication is enabled.
+// @Tags         Events
+// @Produce      text/event-stream
+// @Param        types  query     string  false  "Comma separated list of event type prefixes to stream (e.g. download_queue,job). All events are streamed when empty."
+// @Security 	 BearerAuth
+// @Failure      401  {object}  httpx.UnauthorizedResponse "Unauthorized (only when Auth.Enabled=true)"
+// @Success      200  {object}  events.Event
+// @Failure      500  {object}  httpx.JSONResponse "Internal Server Error"
+// @Router       /api/events [get]
+func StreamEvents(w http.ResponseWriter, r *http.Request) {
+	ctx, ld := logging.CreateLoggingContext(r.Context(), r.URL.Path)
+	logAction := ld.AddAction("Events - Stream", logging.LevelInfo)
+	ctx = logging.WithCurrentAction(ctx, logAction)
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		logAction.SetError("Streaming is not supported", "The connection does not support Server-Sent Events", nil)
+		httpx.SendResponse(w, ld, nil)
+		return
+	}
+
+	prefixes := []string{}
+	for prefix := range strings.SplitSeq(r.URL.Query().Get("types"), ",") {
+		if prefix = strings.TrimSpace(prefix); prefix != "" {
+			prefixes = append(prefixes, prefix)
+		}
+	}
+
+	stream, unsubscribe := events.Subscribe(prefixes...)
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	// Send the current status first, so clients do not have to wait for the next change
+	initial := events.Event{Type: events.TypeDownloadQueueStatus, Time: time.Now(), Data: downloadqueue.GetLatestInfo()}
+	if len(prefixes) == 0 || matchesAny(initial.Type, prefixes) {
+		if err := writeEvent(w, initial); err != nil {
+			return
+		}
+	}
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(keepAliveInterval)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		case event, ok := <-stream:
+			if !ok {
+				return
+			}
+			if err := writeEvent(w, event); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+func writeEvent(w http.ResponseWriter, event events.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data)
+	return err
+}
+
+func matchesAny(eventType string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(eventType, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,48 @@
+package routes_images
+
+import (
+	"aura/download/drift"
+	"aura/logging"
+	"aura/models"
+	"aura/utils/httpx"
+	"net/http"
+)
+
+type GetImageDrift_Response struct {
+	Report models.DriftReport `json:"report"`
+	Found  bool               `json:"found"` // False when no drift check has run since aura started
+}
+
+// GetImageDrift godoc
+// @Summary      Image Drift - Get
+// @Description  Get the applied images that no longer match their saved set, as found by the last drift check. With refresh set, a new check is run first. When DriftDetection.Mode is "reapply", drifted images are applied again by the check.
+// @Tags         Images
+// @Produce      json
+// @Param        refresh  query     bool  false  "Run a new drift check before returning the result"
+// @Security 	 BearerAuth
+// @Failure      401  {object}  httpx.UnauthorizedResponse "Unauthorized (only when Auth.Enabled=true)"
+// @Success      200  {object}  httpx.JSONResponse{data=GetImageDrift_Response}
+// @Failure      500           {object}  httpx.JSONResponse "Internal Server Error"
+// @Router       /api/images/drift [get]
+func GetImageDrift(w http.ResponseWriter, r *http.Request) {
+	ctx, ld := logging.CreateLoggingContext(r.Context(), r.URL.Path)
+	logAction := ld.AddAction("Image Drift - Get", logging.LevelInfo)
+	ctx = logging.WithCurrentAction(ctx, logAction)
+
+	var response GetImageDrift_Response
+
+	if r.URL.Query().Get("refresh") == "true" {
+		report, Err := drift.CheckImageDrift(ctx)
+		if Err.Message != "" {
+			httpx.SendResponse(w, ld, response)
+			return
+		}
+		response.Report = report
+		response.Found = true
+		httpx.SendResponse(w, ld, response)
+		return
+	}
+
+	response.Report, response.Found = drift.LatestDriftReport()
+	httpx.SendResponse(w, ld, response)
+}
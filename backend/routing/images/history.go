@@ -0,0 +1,192 @@
+package routes_images
+
+import (
+	"aura/cache"
+	"aura/database"
+	"aura/logging"
+	"aura/mediaserver"
+	"aura/models"
+	"aura/utils/httpx"
+	"context"
+	"net/http"
+	"os"
+	"path"
+	"strconv"
+)
+
+type ListImageHistory_Response struct {
+	Entries []models.DBImageHistoryEntry `json:"entries"`
+}
+
+type RestoreImageHistory_Response struct {
+	Entry models.DBImageHistoryEntry `json:"entry"`
+}
+
+// ListImageHistory godoc
+// @Summary      Image History - List
+// @Description  Retrieve the images that were on the media server before aura replaced them, newest first. Images are only kept when Images.History.Enabled is true.
+// @Tags         Images
+// @Produce      json
+// @Param        tmdb_id           query     string  false  "TMDB ID of the media item"
+// @Param        library_title     query     string  false  "Library title of the media item"
+// @Param        rating_key        query     string  false  "Rating Key of the media item"
+// @Param        image_rating_key  query     string  false  "Rating Key of the season or episode the image belongs to"
+// @Param        image_type        query     string  false  "Type of image (poster, backdrop, season_poster or titlecard)"
+// @Param        limit             query     int     false  "Maximum number of entries to return (default 100)"
+// @Security 	 BearerAuth
+// @Failure      401  {object}  httpx.UnauthorizedResponse "Unauthorized (only when Auth.Enabled=true)"
+// @Success      200  {object}  httpx.JSONResponse{data=ListImageHistory_Response}
+// @Failure      500           {object}  httpx.JSONResponse "Internal Server Error"
+// @Router       /api/images/history [get]
+func ListImageHistory(w http.ResponseWriter, r *http.Request) {
+	ctx, ld := logging.CreateLoggingContext(r.Context(), r.URL.Path)
+	logAction := ld.AddAction("Image History - List", logging.LevelInfo)
+	ctx = logging.WithCurrentAction(ctx, logAction)
+
+	var response ListImageHistory_Response
+
+	query := r.URL.Query()
+	filter := models.DBImageHistoryFilter{
+		TMDB_ID:        query.Get("tmdb_id"),
+		LibraryTitle:   query.Get("library_title"),
+		RatingKey:      query.Get("rating_key"),
+		ImageRatingKey: query.Get("image_rating_key"),
+		ImageType:      query.Get("image_type"),
+		Limit:          100,
+	}
+	if limitStr := query.Get("limit"); limitStr != "" {
+		if val, err := strconv.Atoi(limitStr); err == nil && val > 0 {
+			filter.Limit = val
+		}
+	}
+
+	entries, Err := database.GetImageHistory(ctx, filter)
+	if Err.Message != "" {
+		httpx.SendResponse(w, ld, response)
+		return
+	}
+
+	response.Entries = entries
+	httpx.SendResponse(w, ld, response)
+}
+
+// GetImageHistoryFile godoc
+// @Summary      Image History - Get Image
+// @Description  Get the saved image of an Image History entry
+// @Tags         Images
+// @Produce      image/jpeg
+// @Param        id  query     int  true  "ID of the Image History entry"
+// @Security 	 BearerAuth
+// @Failure      401  {object}  httpx.UnauthorizedResponse "Unauthorized (only when Auth.Enabled=true)"
+// @Success      200  {string}  string "Image data in JPEG format"
+// @Failure      500           {object}  httpx.JSONResponse "Internal Server Error"
+// @Router       /api/images/history/file [get]
+func GetImageHistoryFile(w http.ResponseWriter, r *http.Request) {
+	ctx, ld := logging.CreateLoggingContext(r.Context(), r.URL.Path)
+	logAction := ld.AddAction("Image History - Get Image", logging.LevelInfo)
+	ctx = logging.WithCurrentAction(ctx, logAction)
+
+	entry, ok := getImageHistoryEntry(ctx, r, logAction)
+	if !ok {
+		httpx.SendResponse(w, ld, nil)
+		return
+	}
+
+	imageData, err := os.ReadFile(path.Join(mediaserver.ImageHistoryFolder(), entry.FileName))
+	if err != nil {
+		logAction.SetError("Failed to read the saved image", "The image file was removed from the image-history folder", map[string]any{
+			"error":     err.Error(),
+			"file_name": entry.FileName,
+		})
+		httpx.SendResponse(w, ld, nil)
+		return
+	}
+
+	w.Header().Set("Content-Type", "image/jpeg")
+	w.WriteHeader(http.StatusOK)
+	w.Write(imageData)
+}
+
+// RestoreImageHistory godoc
+// @Summary      Image History - Restore
+// @Description  Apply a previous version of an image to the media server. The image that is replaced by the restore is added to the history as well (when Images.History.Enabled is true), so a restore can be undone.
+// @Tags         Images
+// @Produce      json
+// @Param        id  query     int  true  "ID of the Image History entry"
+// @Security 	 BearerAuth
+// @Failure      401  {object}  httpx.UnauthorizedResponse "Unauthorized (only when Auth.Enabled=true)"
+// @Success      200  {object}  httpx.JSONResponse{data=RestoreImageHistory_Response}
+// @Failure      500           {object}  httpx.JSONResponse "Internal Server Error"
+// @Router       /api/images/history/restore [post]
+func RestoreImageHistory(w http.ResponseWriter, r *http.Request) {
+	ctx, ld := logging.CreateLoggingContext(r.Context(), r.URL.Path)
+	logAction := ld.AddAction("Image History - Restore", logging.LevelInfo)
+	ctx = logging.WithCurrentAction(ctx, logAction)
+
+	var response RestoreImageHistory_Response
+
+	entry, ok := getImageHistoryEntry(ctx, r, logAction)
+	if !ok {
+		httpx.SendResponse(w, ld, response)
+		return
+	}
+	response.Entry = entry
+
+	// Get the matching media item from the cache
+	item, found := cache.LibraryStore.GetMediaItemFromServerSectionByTMDBID(entry.Server, entry.LibraryTitle, entry.TMDB_ID)
+	if !found {
+		logAction.SetError("Media Item Not Found", "The media item of this image is no longer in the library", map[string]any{
+			"tmdb_id":       entry.TMDB_ID,
+			"library_title": entry.LibraryTitle,
+			"server":        entry.Server,
+		})
+		httpx.SendResponse(w, ld, response)
+		return
+	}
+
+	// Seasons and episodes are only present in the full details of a show
+	if item.Type == "show" {
+		found, Err := mediaserver.GetMediaItemDetails(ctx, item)
+		if Err.Message != "" {
+			httpx.SendResponse(w, ld, response)
+			return
+		} else if !found {
+			logAction.SetError("Media Item Not Found", "The media server no longer has this item", map[string]any{
+				"rating_key": item.RatingKey,
+			})
+			httpx.SendResponse(w, ld, response)
+			return
+		}
+	}
+
+	Err := mediaserver.RestoreImageHistoryEntry(ctx, item, entry)
+	if Err.Message != "" {
+		httpx.SendResponse(w, ld, response)
+		return
+	}
+
+	httpx.SendResponse(w, ld, response)
+}
+
+// getImageHistoryEntry reads the id query parameter and gets the matching Image History entry
+func getImageHistoryEntry(ctx context.Context, r *http.Request, logAction *logging.LogAction) (entry models.DBImageHistoryEntry, ok bool) {
+	id, err := strconv.ParseInt(r.URL.Query().Get("id"), 10, 64)
+	if err != nil || id <= 0 {
+		logAction.SetError("Invalid ID", "Provide the ID of the Image History entry", map[string]any{
+			"id": r.URL.Query().Get("id"),
+		})
+		return entry, false
+	}
+
+	entries, Err := database.GetImageHistory(ctx, models.DBImageHistoryFilter{ID: id})
+	if Err.Message != "" {
+		return entry, false
+	}
+	if len(entries) == 0 {
+		logAction.SetError("Image History entry not found", "The entry was removed from the history", map[string]any{
+			"id": id,
+		})
+		return entry, false
+	}
+	return entries[0], true
+}
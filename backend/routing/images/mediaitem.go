@@ -16,6 +16,7 @@ import (
 // @Param        rating_key   query     string  true  "Rating Key of the media item"
 // @Param        image_rating_key   query     string  false  "Rating Key of the specific image to fetch (if different from the media item rating key)"
 // @Param        image_type   query     string  true  "Type of image to fetch (poster, backdrop or thumb)"
+// @Param        server       query     string  false  "Name of the media server that holds the item (default is the primary media server)"
 // @Success      200  {string}  string "Image data in JPEG format"
 // @Failure      500           {object}  httpx.JSONResponse "Internal Server Error"
 // @Router       /api/images/media/item [get]
@@ -28,6 +29,7 @@ func GetMediaItemImage(w http.ResponseWriter, r *http.Request) {
 	ratingKey := r.URL.Query().Get("rating_key")
 	imageRatingKey := r.URL.Query().Get("image_rating_key")
 	imageType := r.URL.Query().Get("image_type")
+	server := r.URL.Query().Get("server")
 	if ratingKey == "" || imageType == "" {
 		actionGetQueryParams.SetError("Missing Query Parameters", "One or more required query parameters are missing",
 			map[string]any{
@@ -53,7 +55,7 @@ func GetMediaItemImage(w http.ResponseWriter, r *http.Request) {
 	actionGetQueryParams.Complete()
 
 	// Get the matching media item from the cache
-	item, found := cache.LibraryStore.GetMediaItemByRatingKey(ratingKey)
+	item, found := cache.LibraryStore.GetServerMediaItemByRatingKey(server, ratingKey)
 	if !found {
 		logAction.SetError("Media Item Not Found", "No media item found matching the provided rating key",
 			map[string]any{
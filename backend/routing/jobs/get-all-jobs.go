@@ -1,6 +1,7 @@
 package routes_jobs
 
 import (
+	autodownload "aura/download/auto"
 	"aura/jobs"
 	"aura/logging"
 	"aura/utils/httpx"
@@ -8,12 +9,13 @@ import (
 )
 
 type GetAllJobs_Response struct {
-	Jobs []jobs.JobInfo `json:"jobs"`
+	Jobs            []jobs.JobInfo                     `json:"jobs"`
+	MediuxWebSocket autodownload.MediuxWebSocketStatus `json:"mediux_websocket"` // Connection state of the MediUX set update listener
 }
 
 // GetAllJobs godoc
 // @Summary      Get All Scheduled Jobs
-// @Description  Retrieve a list of all scheduled jobs in the system, including their name, description, schedule, and next run time. This endpoint provides insight into the background tasks that are set up to run at specific intervals or times.
+// @Description  Retrieve a list of all scheduled jobs in the system, including their name, description, schedule, and next run time. This endpoint provides insight into the background tasks that are set up to run at specific intervals or times. It also returns the connection state of the MediUX WebSocket listener that checks saved items as soon as their show, movie or collection set is updated.
 // @Tags         Jobs
 // @Produce      json
 // @Security 	 BearerAuth
@@ -27,5 +29,6 @@ func GetAllJobs(w http.ResponseWriter, r *http.Request) {
 	ctx = logging.WithCurrentAction(ctx, logAction)
 	var response GetAllJobs_Response
 	response.Jobs = jobs.GetListOfJobs()
+	response.MediuxWebSocket = autodownload.GetMediuxWebSocketStatus()
 	httpx.SendResponse(w, ld, response)
 }
@@ -41,6 +41,10 @@ var possible_actions_paths = map[string]structActionLabelSection{
 		Label:   "Handle Sonarr Webhook",
 		Section: "SONARR/RADARR",
 	},
+	"POST:/api/plex/webhook": {
+		Label:   "Handle Plex Webhook",
+		Section: "MEDIA SERVER",
+	},
 
 	// Login & Auth
 	"POST:/api/login": {
@@ -48,6 +52,24 @@ var possible_actions_paths = map[string]structActionLabelSection{
 		Section: "AUTH",
 	},
 
+	// Approval Routes
+	"GET:/api/approvals": {
+		Label:   "List Approvals",
+		Section: "APPROVALS",
+	},
+	"POST:/api/approvals/approve": {
+		Label:   "Approve Change",
+		Section: "APPROVALS",
+	},
+	"POST:/api/approvals/approve-all": {
+		Label:   "Approve All Changes",
+		Section: "APPROVALS",
+	},
+	"POST:/api/approvals/reject": {
+		Label:   "Reject Change",
+		Section: "APPROVALS",
+	},
+
 	// Config Routes
 	"GET:/api/config": {
 		Label:   "Get Config",
@@ -129,6 +151,20 @@ var possible_actions_paths = map[string]structActionLabelSection{
 		Label:   "Remove Item from Download Queue",
 		Section: "DOWNLOAD",
 	},
+	"POST:/api/download/queue/item/retry": {
+		Label:   "Retry Download Queue Entry",
+		Section: "DOWNLOAD",
+	},
+	"GET:/api/download/queue/history": {
+		Label:   "Get Download Queue History",
+		Section: "DOWNLOAD",
+	},
+
+	// Events Routes
+	"GET:/api/events": {
+		Label:   "Stream Events",
+		Section: "EVENTS",
+	},
 
 	// Image Routes
 	"GET:/api/images/media/item": {
@@ -151,6 +187,22 @@ var possible_actions_paths = map[string]structActionLabelSection{
 		Label:   "Delete Temp Images",
 		Section: "IMAGES",
 	},
+	"GET:/api/images/history": {
+		Label:   "List Image History",
+		Section: "IMAGES",
+	},
+	"GET:/api/images/history/file": {
+		Label:   "Get Image History Image",
+		Section: "IMAGES",
+	},
+	"POST:/api/images/history/restore": {
+		Label:   "Restore Image From History",
+		Section: "IMAGES",
+	},
+	"GET:/api/images/drift": {
+		Label:   "Get Image Drift",
+		Section: "IMAGES",
+	},
 
 	// Labels & Tags Route
 	"POST:/api/labels-tags": {
@@ -201,6 +253,10 @@ var possible_actions_paths = map[string]structActionLabelSection{
 		Label:   "Refresh Media Item Metadata",
 		Section: "MEDIA SERVER",
 	},
+	"POST:/api/mediaserver/reset-artwork": {
+		Label:   "Reset Media Item Artwork",
+		Section: "MEDIA SERVER",
+	},
 
 	// Plex OAuth Routes
 	"GET:/api/oauth/plex": {
@@ -230,6 +286,12 @@ var possible_actions_paths = map[string]structActionLabelSection{
 		Section: "MEDIUX",
 	},
 
+	// Report Routes
+	"GET:/api/reports/coverage": {
+		Label:   "Get Coverage Report",
+		Section: "REPORTS",
+	},
+
 	// Validation Routes
 	"POST:/api/validate/mediux": {
 		Label:   "Validate Mediux Info",
@@ -606,7 +668,7 @@ func inferRouteSection(path string) string {
 		return "CONFIG"
 	case strings.HasPrefix(path, "/api/logs"):
 		return "LOGS"
-	case strings.HasPrefix(path, "/api/mediaserver"), strings.HasPrefix(path, "/api/oauth/plex"):
+	case strings.HasPrefix(path, "/api/mediaserver"), strings.HasPrefix(path, "/api/oauth/plex"), strings.HasPrefix(path, "/api/plex"):
 		return "MEDIA SERVER"
 	case strings.HasPrefix(path, "/api/download"):
 		return "DOWNLOAD"
@@ -26,6 +26,7 @@ type GetMediaItemDetails_Response struct {
 // @Produce      json
 // @Param        rating_key query string true "Rating Key of the Media Item"
 // @Param        return_type query string false "Return Type (full or item, default is full)"
+// @Param        server query string false "Name of the media server that holds the item (default is the primary media server)"
 // @Security 	 BearerAuth
 // @Failure      401  {object}  httpx.UnauthorizedResponse "Unauthorized (only when Auth.Enabled=true)"
 // @Success      200  {object}  httpx.JSONResponse{data=GetMediaItemDetails_Response}
@@ -40,6 +41,7 @@ func GetMediaItemDetails(w http.ResponseWriter, r *http.Request) {
 	actionGetQueryParams := logAction.AddSubAction("Get Query Params", logging.LevelTrace)
 	ratingKey := r.URL.Query().Get("rating_key")
 	returnType := r.URL.Query().Get("return_type")
+	server := r.URL.Query().Get("server")
 	if ratingKey == "" {
 		actionGetQueryParams.SetError("Missing query parameter: rating_key", "Make sure to provide a valid rating_key", nil)
 		httpx.SendResponse(w, ld, response)
@@ -50,7 +52,7 @@ func GetMediaItemDetails(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Get the Media Item from the cache
-	mediaItem, found := cache.LibraryStore.GetMediaItemByRatingKey(ratingKey)
+	mediaItem, found := cache.LibraryStore.GetServerMediaItemByRatingKey(server, ratingKey)
 	if !found {
 		actionGetQueryParams.SetError("Media item not found in cache", "Make sure the rating_key is correct and the media server is connected", map[string]any{
 			"rating_key": ratingKey,
@@ -74,7 +76,9 @@ func GetMediaItemDetails(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response.ServerType = config.Current.MediaServer.Type
+	if msConfig, found := config.GetMediaServerByName(mediaItem.Server); found {
+		response.ServerType = msConfig.Type
+	}
 	response.MediaItem = *mediaItem
 
 	// If the return type is item, return only the media item details
@@ -124,7 +124,8 @@ func getEmbyJellyfinMovieCollections(ctx context.Context) (collections []models.
 	collections = []models.CollectionItem{}
 
 	// Get the Media Server Collection Section
-	collectionSection, Err := ej.GetMovieCollectionSection(ctx)
+	ejClient := ej.EJ{Config: config.Current.MediaServer}
+	collectionSection, Err := ejClient.GetMovieCollectionSection(ctx)
 	if Err.Message != "" {
 		return collections, Err
 	}
@@ -17,12 +17,13 @@ type RateMediaItem_Response struct {
 
 // RateMediaItem godoc
 // @Summary      Rate Media Item
-// @Description  Rate a media item on the media server. This endpoint allows clients to submit a user rating for a specific media item, which will be sent to the media server (currently only supported for Plex). The rating should be a number between 0 and 5, and it will be converted to the appropriate scale for the media server before being submitted.
+// @Description  Rate a media item on the media server. This endpoint allows clients to submit a user rating for a specific media item, which will be sent to the media server that holds the item (only supported for Plex servers). The rating should be a number between 0 and 5, and it will be converted to the appropriate scale for the media server before being submitted.
 // @Tags         MediaServer
 // @Accept       json
 // @Produce      json
 // @Param        rating_key   query     string  true  "The rating key of the media item to rate"
 // @Param        rating       query     string  true  "The user rating for the media item (0-5)"
+// @Param        server       query     string  false "Name of the media server that holds the item (default is the primary media server)"
 // @Security 	 BearerAuth
 // @Failure      401  {object}  httpx.UnauthorizedResponse "Unauthorized (only when Auth.Enabled=true)"
 // @Success      200  {object}  httpx.JSONResponse{data=RateMediaItem_Response}
@@ -34,15 +35,11 @@ func RateMediaItem(w http.ResponseWriter, r *http.Request) {
 	ctx = logging.WithCurrentAction(ctx, logAction)
 	var response RateMediaItem_Response
 
-	if config.Current.MediaServer.Type != "Plex" {
-		httpx.SendResponse(w, ld, response)
-		return
-	}
-
 	// Get the query parameters
 	actionGetQueryParams := logAction.AddSubAction("Get Query Parameters", logging.LevelTrace)
 	ratingKey := r.URL.Query().Get("rating_key")
 	userRatingStr := r.URL.Query().Get("rating")
+	server := r.URL.Query().Get("server")
 	if ratingKey == "" || userRatingStr == "" {
 		actionGetQueryParams.SetError("Missing query parameters: rating_key or rating", "Make sure to provide a valid rating_key and rating", map[string]any{
 			"rating_key": ratingKey,
@@ -69,7 +66,7 @@ func RateMediaItem(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Get the Media Item from the cache
-	mediaItem, found := cache.LibraryStore.GetMediaItemByRatingKey(ratingKey)
+	mediaItem, found := cache.LibraryStore.GetServerMediaItemByRatingKey(server, ratingKey)
 	if !found {
 		actionGetQueryParams.SetError("Media item not found in cache", "Make sure the rating_key is correct and the media server is connected", map[string]any{
 			"rating_key": ratingKey,
@@ -78,6 +75,12 @@ func RateMediaItem(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Only Plex servers support ratings
+	if msConfig, found := config.GetMediaServerByName(mediaItem.Server); !found || msConfig.Type != "Plex" {
+		httpx.SendResponse(w, ld, response)
+		return
+	}
+
 	// Convert rating to scale of 10 for Plex
 	userRating = userRating * 2
 
@@ -20,6 +20,7 @@ type RefreshMediaItemMetadata_Response struct {
 // @Produce      json
 // @Param        rating_key query string true "Rating Key of the media item to refresh"
 // @Param        refresh_rating_key query string true "Rating Key to specify which metadata entry to refresh"
+// @Param        server query string false "Name of the media server that holds the item (default is the primary media server)"
 // @Security 	 BearerAuth
 // @Failure      401  {object}  httpx.UnauthorizedResponse "Unauthorized (only when Auth.Enabled=true)"
 // @Success      200  {object}  httpx.JSONResponse{data=RefreshMediaItemMetadata_Response}
@@ -36,6 +37,7 @@ func RefreshMediaItemMetadata(w http.ResponseWriter, r *http.Request) {
 	actionGetQueryParams := logAction.AddSubAction("Get Query Parameters", logging.LevelTrace)
 	ratingKey := r.URL.Query().Get("rating_key")
 	refreshRatingKey := r.URL.Query().Get("refresh_rating_key")
+	server := r.URL.Query().Get("server")
 	if ratingKey == "" || refreshRatingKey == "" {
 		actionGetQueryParams.SetError("Query parameter 'rating_key' and 'refresh_rating_key' are required", "Make sure to provide valid rating_key and refresh_rating_key", map[string]any{
 			"rating_key":         ratingKey,
@@ -46,7 +48,7 @@ func RefreshMediaItemMetadata(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Get the Media Item from the cache
-	mediaItem, found := cache.LibraryStore.GetMediaItemByRatingKey(ratingKey)
+	mediaItem, found := cache.LibraryStore.GetServerMediaItemByRatingKey(server, ratingKey)
 	if !found {
 		actionGetQueryParams.SetError("Media item not found in cache",
 			"Make sure the rating_key is correct and the media server is connected",
@@ -0,0 +1,170 @@
+package routes_ms
+
+import (
+	"aura/cache"
+	"aura/config"
+	"aura/database"
+	"aura/logging"
+	"aura/mediaserver"
+	"aura/models"
+	sonarr_radarr "aura/sonarr-radarr"
+	"aura/utils/httpx"
+	"context"
+	"net/http"
+)
+
+type ResetMediaItemArtwork_Request struct {
+	RatingKey           string               `json:"rating_key"`
+	SelectedTypes       models.SelectedTypes `json:"selected_types"`
+	RemoveSavedSets     bool                 `json:"remove_saved_sets"`
+	RemoveLabelsAndTags bool                 `json:"remove_labels_and_tags"`
+}
+
+type ResetMediaItemArtwork_Response struct {
+	Reset             int  `json:"reset"`
+	RemovedSavedSets  bool `json:"removed_saved_sets"`
+	UpdatedSavedSets  int  `json:"updated_saved_sets"` // Saved sets the reset image types were deselected in
+	RemovedLabelsTags bool `json:"removed_labels_and_tags"`
+}
+
+// ResetMediaItemArtwork godoc
+// @Summary      Reset Media Item Artwork
+// @Description  Reset the images of a media item to the artwork of the media server's metadata agent. On Plex the agent image is selected again, on Emby/Jellyfin the uploaded image is deleted and the item is refreshed. The images are reset on every media server that has a copy of the item. Optionally the saved sets of the item and the labels/tags aura added are removed as well. Saved sets that are kept have the reset image types deselected, so AutoDownload, the event listeners, the Plex webhook and Drift Detection do not apply them again. Like any saved set without a selected image type, a set that has nothing left is removed.
+// @Tags         MediaServer
+// @Accept       json
+// @Produce      json
+// @Param        req  body      ResetMediaItemArtwork_Request  true  "Reset Media Item Artwork Request"
+// @Security 	 BearerAuth
+// @Failure      401  {object}  httpx.UnauthorizedResponse "Unauthorized (only when Auth.Enabled=true)"
+// @Success      200  {object}  httpx.JSONResponse{data=ResetMediaItemArtwork_Response}
+// @Failure      500  {object}  httpx.JSONResponse "Internal Server Error"
+// @Router       /api/mediaserver/reset-artwork [post]
+func ResetMediaItemArtwork(w http.ResponseWriter, r *http.Request) {
+	ctx, ld := logging.CreateLoggingContext(r.Context(), r.URL.Path)
+	logAction := ld.AddAction("Reset Media Item Artwork", logging.LevelInfo)
+	ctx = logging.WithCurrentAction(ctx, logAction)
+	var req ResetMediaItemArtwork_Request
+	var response ResetMediaItemArtwork_Response
+
+	Err := httpx.DecodeRequestBodyToJSON(ctx, r.Body, &req, "Reset Media Item Artwork - Decode Request Body")
+	if Err.Message != "" {
+		httpx.SendResponse(w, ld, response)
+		return
+	}
+	if req.RatingKey == "" {
+		logAction.SetError("Missing rating_key", "Provide the rating_key of the media item to reset", nil)
+		httpx.SendResponse(w, ld, response)
+		return
+	}
+
+	// Get the Media Item from the cache
+	cachedItem, found := cache.LibraryStore.GetMediaItemByRatingKey(req.RatingKey)
+	if !found {
+		logAction.SetError("Media item not found in cache",
+			"Make sure the rating_key is correct and the media server is connected",
+			map[string]any{
+				"rating_key": req.RatingKey,
+			})
+		httpx.SendResponse(w, ld, response)
+		return
+	}
+	mediaItem := *cachedItem
+
+	// Seasons and episodes are only present in the full details of a show
+	found, Err = mediaserver.GetMediaItemDetails(ctx, &mediaItem)
+	if Err.Message != "" {
+		httpx.SendResponse(w, ld, response)
+		return
+	} else if !found {
+		logAction.SetError("Media item not found on the media server", "The item may have been removed from the media server", map[string]any{
+			"rating_key": req.RatingKey,
+		})
+		httpx.SendResponse(w, ld, response)
+		return
+	}
+
+	response.Reset, Err = mediaserver.ResetMediaItemArtwork(ctx, &mediaItem, req.SelectedTypes)
+	if Err.Message != "" {
+		httpx.SendResponse(w, ld, response)
+		return
+	}
+	for _, serverCopy := range mediaserver.GetMediaItemCopiesOnOtherServers(ctx, mediaItem) {
+		copyReset, copyErr := mediaserver.ResetMediaItemArtwork(ctx, &serverCopy, req.SelectedTypes)
+		if copyErr.Message != "" {
+			logAction.AppendWarning(config.MediaServerDisplayName(serverCopy.Server), copyErr.Message)
+		}
+		response.Reset += copyReset
+	}
+
+	if req.RemoveSavedSets {
+		Err = database.DeleteAllPosterSetsForMediaItem(ctx, mediaItem.TMDB_ID, mediaItem.LibraryTitle)
+		if Err.Message != "" {
+			httpx.SendResponse(w, ld, response)
+			return
+		}
+		response.RemovedSavedSets = true
+	} else {
+		response.UpdatedSavedSets, Err = deselectResetTypes(ctx, mediaItem, req.SelectedTypes)
+		if Err.Message != "" {
+			httpx.SendResponse(w, ld, response)
+			return
+		}
+	}
+
+	if req.RemoveLabelsAndTags {
+		Err = mediaserver.RemoveLabelsFromMediaItem(ctx, mediaItem)
+		if Err.Message != "" {
+			httpx.SendResponse(w, ld, response)
+			return
+		}
+		Err = sonarr_radarr.RemoveTags(ctx, mediaItem)
+		if Err.Message != "" {
+			httpx.SendResponse(w, ld, response)
+			return
+		}
+		response.RemovedLabelsTags = true
+	}
+
+	httpx.SendResponse(w, ld, response)
+}
+
+// deselectResetTypes deselects the reset image types in the saved sets of the item, so the saved images are not applied again.
+// The database removes the sets that have no selected image type left.
+func deselectResetTypes(ctx context.Context, mediaItem models.MediaItem, resetTypes models.SelectedTypes) (updated int, Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Deselecting Reset Image Types in Saved Sets", logging.LevelDebug)
+	defer logAction.Complete()
+
+	out, Err := database.GetAllSavedSets(ctx, models.DBFilter{
+		ItemTMDB_ID:      mediaItem.TMDB_ID,
+		ItemLibraryTitle: mediaItem.LibraryTitle,
+	})
+	if Err.Message != "" || len(out.Items) == 0 {
+		return 0, Err
+	}
+
+	dbItem := out.Items[0]
+	for i, set := range dbItem.PosterSets {
+		selected := models.SelectedTypes{
+			Poster:              set.SelectedTypes.Poster && !resetTypes.Poster,
+			Backdrop:            set.SelectedTypes.Backdrop && !resetTypes.Backdrop,
+			SeasonPoster:        set.SelectedTypes.SeasonPoster && !resetTypes.SeasonPoster,
+			SpecialSeasonPoster: set.SelectedTypes.SpecialSeasonPoster && !resetTypes.SpecialSeasonPoster,
+			Titlecard:           set.SelectedTypes.Titlecard && !resetTypes.Titlecard,
+		}
+		if selected == set.SelectedTypes {
+			continue
+		}
+		dbItem.PosterSets[i].SelectedTypes = selected
+		updated++
+	}
+	if updated == 0 {
+		return 0, Err
+	}
+
+	Err = database.UpsertSavedItem(ctx, dbItem)
+	if Err.Message != "" {
+		return 0, Err
+	}
+	logAction.AppendResult("updated_sets", updated)
+	return updated, Err
+}
@@ -0,0 +1,96 @@
+package routes_plex
+
+import (
+	"aura/config"
+	autodownload "aura/download/auto"
+	"aura/logging"
+	"aura/utils/httpx"
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+)
+
+// plexWebhookMaxMemory is enough for the payload and the thumbnail that Plex attaches to library.new events
+const plexWebhookMaxMemory = 10 << 20
+
+// PlexWebhookHandler godoc
+// @Summary      Handle Plex Webhook
+// @Description  Receive a Plex Pass webhook (multipart/form-data with a JSON 'payload' field). For library.new events the saved images of the new movie, show, season or episode are re-applied, and new items are passed to AutoSelect. Other events are ignored. This endpoint is public so Plex can reach it, and only acts when MediaServer.EnablePlexWebhook is true. When MediaServer.PlexWebhookToken is set, the request must send it as the token query parameter.
+// @Tags         Plex
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        token    query     string  false  "Value of MediaServer.PlexWebhookToken"
+// @Param        payload  formData  string  true  "Plex webhook payload (JSON)"
+// @Success      200  "OK"
+// @Failure      400  {string}  string "Bad Request"
+// @Failure      401  {string}  string "Unauthorized"
+// @Router       /api/plex/webhook [post]
+func PlexWebhookHandler(w http.ResponseWriter, r *http.Request) {
+	_, ld := logging.CreateLoggingContext(r.Context(), r.URL.Path)
+	logAction := ld.AddAction("Handle Plex Webhook", logging.LevelInfo)
+
+	// The token is a secret, so it is not written to the log with the other query parameters
+	if ld.Route != nil && len(ld.Route.Params["token"]) > 0 {
+		ld.Route.Params["token"] = []string{config.MaskToken(r.URL.Query().Get("token"))}
+	}
+
+	if config.Current.MediaServer.Type != "Plex" || !config.Current.MediaServer.EnablePlexWebhook {
+		logAction.AppendResult("skipped", "Plex webhook is not enabled")
+		httpx.SendResponse(w, ld, nil)
+		return
+	}
+
+	if token := config.Current.MediaServer.PlexWebhookToken; token != "" &&
+		subtle.ConstantTimeCompare([]byte(r.URL.Query().Get("token")), []byte(token)) != 1 {
+		logAction.SetError("Invalid Plex webhook token", "Add the value of MediaServer.PlexWebhookToken as the token query parameter of the webhook URL in Plex", nil)
+		sendPlexWebhookError(w, ld, http.StatusUnauthorized)
+		return
+	}
+
+	if err := r.ParseMultipartForm(plexWebhookMaxMemory); err != nil {
+		logAction.SetError("Invalid Plex webhook payload", "Plex webhooks are sent as multipart/form-data", map[string]any{
+			"error": err.Error(),
+		})
+		sendPlexWebhookError(w, ld, http.StatusBadRequest)
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	var payload autodownload.PlexWebhookPayload
+	if err := json.Unmarshal([]byte(r.FormValue("payload")), &payload); err != nil {
+		logAction.SetError("Invalid Plex webhook payload", "The payload field must hold the JSON of the Plex event", map[string]any{
+			"error": err.Error(),
+		})
+		sendPlexWebhookError(w, ld, http.StatusBadRequest)
+		return
+	}
+
+	logAction.AppendResult("event", payload.Event)
+	logAction.AppendResult("item_type", payload.Metadata.Type)
+	logAction.AppendResult("title", payload.Metadata.Title)
+
+	// Only new media is handled, play and rating events are ignored
+	if payload.Event != "library.new" {
+		httpx.SendResponse(w, ld, nil)
+		return
+	}
+
+	queued, reason := autodownload.QueuePlexLibraryNewEvent(payload.Metadata)
+	logAction.AppendResult("queued", queued)
+	if !queued {
+		logAction.AppendResult("reason", reason)
+	}
+
+	// Respond to Plex immediately, the event is handled in the background
+	httpx.SendResponse(w, ld, nil)
+}
+
+// sendPlexWebhookError completes the log actions and answers with the status code of the error,
+// httpx.SendResponse would answer every error with 500
+func sendPlexWebhookError(w http.ResponseWriter, ld *logging.LogData, statusCode int) {
+	for _, action := range ld.Actions {
+		action.Complete()
+	}
+	ld.Status = logging.StatusError
+	http.Error(w, http.StatusText(statusCode), statusCode)
+}
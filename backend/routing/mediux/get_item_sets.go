@@ -58,46 +58,20 @@ func GetItemSets(w http.ResponseWriter, r *http.Request) {
 	}
 	actionCheckCache.Complete()
 
-	switch itemType {
-	case "show":
-		// For Shows, we get just Show Sets
-		showSets, showItems, Err := mediux.GetShowItemSets(ctx, tmdbID, itemLibraryTitle)
-		if Err.Message != "" {
-			httpx.SendResponse(w, ld, response)
-			return
-		}
-		response.Sets = showSets
-		response.IncludedItems = showItems
-	case "movie":
-		setItems := map[string]models.IncludedItem{}
-		// For Movies, we get Movie Sets and Movie Collection Sets
-		movieSets, Err := mediux.GetMovieItemSets(ctx, tmdbID, itemLibraryTitle, &setItems)
-		if Err.Message != "" {
-			httpx.SendResponse(w, ld, response)
-			return
-		}
-		response.Sets = movieSets
-		collectionSets, Err := mediux.GetMovieItemCollectionSets(ctx, tmdbID, itemLibraryTitle, &setItems)
-		if Err.Message != "" {
-			httpx.SendResponse(w, ld, response)
-			return
-		}
-		response.Sets = append(response.Sets, collectionSets...)
-		if len(movieSets) == 0 && len(collectionSets) == 0 && len(setItems) == 0 {
-			logAction.SetError("No Sets Found", "No movie sets or collection sets found for the provided TMDB ID", map[string]any{
-				"tmdb_id": tmdbID,
-			})
-			httpx.SendResponse(w, ld, response)
-			return
-		}
-		response.IncludedItems = setItems
-	default:
-		logAction.SetError("Invalid Item Type", "The provided item type is not valid", map[string]any{
-			"item_type": itemType,
+	sets, includedItems, Err := mediux.GetItemSets(ctx, tmdbID, itemType, itemLibraryTitle)
+	if Err.Message != "" {
+		httpx.SendResponse(w, ld, response)
+		return
+	}
+	if itemType == "movie" && len(sets) == 0 && len(includedItems) == 0 {
+		logAction.SetError("No Sets Found", "No movie sets or collection sets found for the provided TMDB ID", map[string]any{
+			"tmdb_id": tmdbID,
 		})
 		httpx.SendResponse(w, ld, response)
 		return
 	}
+	response.Sets = sets
+	response.IncludedItems = includedItems
 
 	httpx.SendResponse(w, ld, response)
 }
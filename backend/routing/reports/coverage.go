@@ -0,0 +1,86 @@
+package routes_reports
+
+import (
+	"aura/logging"
+	"aura/models"
+	"aura/report"
+	"aura/utils/httpx"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+type GetCoverageReport_Response struct {
+	Report models.CoverageReport `json:"report"`
+}
+
+// GetCoverageReport godoc
+// @Summary      Coverage Report
+// @Description  Report per library which items have a saved set, which have MediUX sets but no saved set, which are ignored and which have no MediUX sets. Shows with a saved set also list the seasons and episodes without a season poster or titlecard. The last report is returned unless refresh is true or no report was built yet. Use format to download the report as a file.
+// @Tags         Reports
+// @Produce      json
+// @Produce      text/csv
+// @Param        refresh  query     bool    false  "Build a new report instead of returning the last one"
+// @Param        format   query     string  false  "Download the report as a file ('json' or 'csv')"
+// @Security 	 BearerAuth
+// @Failure      401  {object}  httpx.UnauthorizedResponse "Unauthorized (only when Auth.Enabled=true)"
+// @Success      200  {object}  httpx.JSONResponse{data=GetCoverageReport_Response}
+// @Failure      500  {object}  httpx.JSONResponse "Internal Server Error"
+// @Router       /api/reports/coverage [get]
+func GetCoverageReport(w http.ResponseWriter, r *http.Request) {
+	ctx, ld := logging.CreateLoggingContext(r.Context(), r.URL.Path)
+	logAction := ld.AddAction("Get Coverage Report", logging.LevelInfo)
+	ctx = logging.WithCurrentAction(ctx, logAction)
+
+	var response GetCoverageReport_Response
+
+	format := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("format")))
+	switch format {
+	case "", "json", "csv":
+	default:
+		logAction.SetError("Invalid format parameter", "Format must be either 'json' or 'csv'", map[string]any{
+			"format": r.URL.Query().Get("format"),
+		})
+		httpx.SendResponse(w, ld, response)
+		return
+	}
+
+	coverage, found := report.LatestCoverageReport()
+	if !found || r.URL.Query().Get("refresh") == "true" {
+		var Err logging.LogErrorInfo
+		coverage, Err = report.BuildCoverageReport(ctx)
+		if Err.Message != "" {
+			httpx.SendResponse(w, ld, response)
+			return
+		}
+	}
+
+	if format == "" {
+		response.Report = coverage
+		httpx.SendResponse(w, ld, response)
+		return
+	}
+
+	var data []byte
+	var err error
+	contentType := "application/json"
+	if format == "csv" {
+		contentType = "text/csv"
+		data, err = report.CoverageReportCSV(coverage)
+	} else {
+		data, err = report.CoverageReportJSON(coverage)
+	}
+	if err != nil {
+		logAction.SetError("Failed to encode Coverage Report", err.Error(), map[string]any{
+			"format": format,
+		})
+		httpx.SendResponse(w, ld, response)
+		return
+	}
+
+	fileName := fmt.Sprintf("aura_coverage_%s.%s", coverage.GeneratedAt.Format("20060102_150405"), format)
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fileName))
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
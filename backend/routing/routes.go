@@ -3,11 +3,13 @@ package routing
 import (
 	"aura/config"
 	"aura/logging"
+	routes_approvals "aura/routing/approvals"
 	routes_auth "aura/routing/auth"
 	routes_base "aura/routing/base"
 	routes_config "aura/routing/config"
 	routes_db "aura/routing/database"
 	routes_download "aura/routing/download"
+	routes_events "aura/routing/events"
 	routes_images "aura/routing/images"
 	routes_jobs "aura/routing/jobs"
 	routes_labels_tags "aura/routing/labels-tags"
@@ -16,6 +18,7 @@ import (
 	routes_plex "aura/routing/mediaserver/plex"
 	routes_mediux "aura/routing/mediux"
 	"aura/routing/middleware"
+	routes_reports "aura/routing/reports"
 	routes_search "aura/routing/search"
 	routes_sonarr_radarr "aura/routing/sonarr-radarr"
 	routes_validation "aura/routing/validation"
@@ -61,8 +64,12 @@ func AddRoutes(r *chi.Mux) {
 		// Search - Public Search Endpoint (Media Items, Saved Sets and MediUX Users)
 		r.Get("/search", routes_search.HandleSearch)
 
-		// Sonarr Webhook Routes - Public since Sonarr/Radarr need to access it without authentication
+		// Sonarr/Radarr Webhook Routes - Public since Sonarr/Radarr need to access it without authentication
 		r.Post("/sonarr/webhook", routes_sonarr_radarr.SonarrWebhookHandler)
+		r.Post("/radarr/webhook", routes_sonarr_radarr.RadarrWebhookHandler)
+
+		// Plex Webhook Route - Public since Plex needs to access it without authentication
+		r.Post("/plex/webhook", routes_plex.PlexWebhookHandler)
 
 		/////////////////////
 		// Protected Routes
@@ -72,6 +79,14 @@ func AddRoutes(r *chi.Mux) {
 			r.Use(jwtauth.Verifier(routes_auth.TokenAuth))
 			r.Use(middleware.Authenticator)
 
+			// Approval Routes
+			r.Route("/approvals", func(r chi.Router) {
+				r.Get("/", routes_approvals.ListApprovals)
+				r.Post("/approve", routes_approvals.ApproveApproval)
+				r.Post("/approve-all", routes_approvals.ApproveAllApprovals)
+				r.Post("/reject", routes_approvals.RejectApproval)
+			})
+
 			// Config Routes
 			r.Route("/config", func(r chi.Router) {
 				r.Get("/", routes_config.GetAppConfigStatus)
@@ -89,6 +104,10 @@ func AddRoutes(r *chi.Mux) {
 				r.Patch("/ignore", routes_db.IgnoreItemInDB)
 				r.Patch("/ignore/stop", routes_db.StopIgnoringItemInDB)
 				r.Post("/force-check", routes_db.AutoDownloadForceCheck)
+				r.Get("/export", routes_db.ExportDB)
+				r.Post("/import", routes_db.ImportDB)
+				r.Get("/backups", routes_db.ListBackups)
+				r.Post("/backups/restore", routes_db.RestoreBackup)
 			})
 
 			// Download Routes
@@ -103,9 +122,14 @@ func AddRoutes(r *chi.Mux) {
 					r.Get("/item", routes_download.GetAllDownloadQueueItems)
 					r.Post("/item", routes_download.AddItemToDownloadQueue)
 					r.Delete("/item", routes_download.RemoveItemFromDownloadQueue)
+					r.Post("/item/retry", routes_download.RetryDownloadQueueEntry)
+					r.Get("/history", routes_download.GetDownloadQueueHistory)
 				})
 			})
 
+			// Events Route - Server-Sent Events for live queue and job progress
+			r.Get("/events", routes_events.StreamEvents)
+
 			// Image Routes
 			r.Route("/images", func(r chi.Router) {
 				r.Get("/media/item", routes_images.GetMediaItemImage)
@@ -113,6 +137,10 @@ func AddRoutes(r *chi.Mux) {
 				r.Get("/mediux/item", routes_images.GetMediuxImage)
 				r.Get("/mediux/avatar", routes_images.GetMediuxAvatarImage)
 				r.Delete("/temp", routes_images.DeleteTempImages)
+				r.Get("/history", routes_images.ListImageHistory)
+				r.Get("/history/file", routes_images.GetImageHistoryFile)
+				r.Post("/history/restore", routes_images.RestoreImageHistory)
+				r.Get("/drift", routes_images.GetImageDrift)
 			})
 
 			// Jobs Routes
@@ -144,6 +172,7 @@ func AddRoutes(r *chi.Mux) {
 				r.Get("/collections/item", routes_ms.GetAllCollectionChildrenItems)
 				r.Patch("/rate", routes_ms.RateMediaItem)
 				r.Post("/refresh", routes_ms.RefreshMediaItemMetadata)
+				r.Post("/reset-artwork", routes_ms.ResetMediaItemArtwork)
 			})
 
 			// MediUX Routes
@@ -154,6 +183,11 @@ func AddRoutes(r *chi.Mux) {
 				r.Get("/sets/user", routes_mediux.GetAllUserSets)
 			})
 
+			// Report Routes
+			r.Route("/reports", func(r chi.Router) {
+				r.Get("/coverage", routes_reports.GetCoverageReport)
+			})
+
 			// Validation Routes
 			r.Route("/validate", func(r chi.Router) {
 				r.Post("/mediux", routes_validation.ValidateMediuxInfo)
@@ -0,0 +1,36 @@
+package routes_sonarr_radarr
+
+import (
+	"aura/download/approvals"
+	"aura/logging"
+	"aura/models"
+	"aura/utils"
+	"context"
+)
+
+// stageForApproval stages the images of a webhook event for approval instead of applying them
+func stageForApproval(ctx context.Context, mediaItem models.MediaItem, dbSet models.DBPosterSetDetail, images []models.ImageFile, reason string) {
+	item := models.DBSavedItem{
+		MediaItem: mediaItem,
+		PosterSets: []models.DBPosterSetDetail{{
+			PosterSet: models.PosterSet{
+				BaseSetInfo: dbSet.BaseSetInfo,
+				Images:      images,
+			},
+			SelectedTypes:             dbSet.SelectedTypes,
+			AutoDownload:              dbSet.AutoDownload,
+			AutoAddNewCollectionItems: dbSet.AutoAddNewCollectionItems,
+		}},
+	}
+
+	id, Err := approvals.Stage(ctx, models.PendingApprovalKindImageUpdate, item, reason)
+	if Err.Message != "" {
+		logging.LOGGER.Error().Timestamp().Str("item", utils.MediaItemInfo(mediaItem)).Str("set_id", dbSet.ID).Str("error", Err.Message).Msg("Failed to stage webhook images for approval")
+		return
+	}
+	if id == 0 {
+		logging.LOGGER.Info().Timestamp().Str("item", utils.MediaItemInfo(mediaItem)).Str("set_id", dbSet.ID).Msg("Webhook images were rejected before, not staging them again")
+		return
+	}
+	logging.LOGGER.Info().Timestamp().Str("item", utils.MediaItemInfo(mediaItem)).Str("set_id", dbSet.ID).Int64("approval_id", id).Int("images", len(images)).Msg("Staged webhook images for approval")
+}
@@ -3,11 +3,11 @@ package routes_sonarr_radarr
 import (
 	"aura/config"
 	"aura/logging"
+	"aura/mediux"
 	"aura/models"
 	"aura/notification"
 	"aura/utils"
 	"context"
-	"fmt"
 )
 
 func sendFileDownloadNotification(mediaItem models.MediaItem, set models.DBPosterSetDetail, image models.ImageFile, isUpgrade bool, result string) {
@@ -41,13 +41,50 @@ func sendFileDownloadNotification(mediaItem models.MediaItem, set models.DBPoste
 	message := utils.RenderTemplate(config.Current.Notifications.NotificationTemplate.SonarrNotification.Message, vars)
 	imageURL := ""
 	if config.Current.Notifications.NotificationTemplate.SonarrNotification.IncludeImage {
-		imageURL = fmt.Sprintf("%s/%s?v=%s&key=jpg",
-			"https://images.mediux.io/assets",
-			image.ID,
-			image.Modified.Format("20060102150405"),
-		)
+		imageURL = mediux.GetImageURLFromSrc(image.Src)
 	}
 
+	sendToAllProviders(title, message, imageURL)
+}
+
+func sendRadarrFileDownloadNotification(mediaItem models.MediaItem, set models.DBPosterSetDetail, image models.ImageFile, isUpgrade bool, result string) {
+	// If notifications are disabled, skip
+	if !config.Current.Notifications.Enabled {
+		logging.LOGGER.Debug().Timestamp().Msg("Notifications are disabled, skipping Radarr notification")
+		return
+	}
+
+	// If notification providers are not configured, skip
+	if len(config.Current.Notifications.Providers) == 0 {
+		logging.LOGGER.Debug().Timestamp().Msg("No notification providers configured, skipping Radarr notification")
+		return
+	}
+
+	// If Radarr notification is disabled, skip
+	if !config.Current.Notifications.NotificationTemplate.RadarrNotification.Enabled {
+		logging.LOGGER.Debug().Timestamp().Msg("Radarr notification is disabled, skipping Radarr notification")
+		return
+	}
+
+	reasonTitle := "New Download"
+	reason := "The movie file was downloaded via Radarr for this media item."
+	if isUpgrade {
+		reasonTitle = "Upgrade"
+		reason = "The movie file was upgraded via Radarr for this media item."
+	}
+
+	vars := utils.TemplateVars_RadarrNotification(mediaItem, set, image, reasonTitle, reason, result)
+	title := utils.RenderTemplate(config.Current.Notifications.NotificationTemplate.RadarrNotification.Title, vars)
+	message := utils.RenderTemplate(config.Current.Notifications.NotificationTemplate.RadarrNotification.Message, vars)
+	imageURL := ""
+	if config.Current.Notifications.NotificationTemplate.RadarrNotification.IncludeImage {
+		imageURL = mediux.GetImageURLFromSrc(image.Src)
+	}
+
+	sendToAllProviders(title, message, imageURL)
+}
+
+func sendToAllProviders(title, message, imageURL string) {
 	ctx, ld := logging.CreateLoggingContext(context.Background(), "Notification - Send File Download Message")
 	logAction := ld.AddAction("Sending File Download Notification", logging.LevelInfo)
 	ctx = logging.WithCurrentAction(ctx, logAction)
@@ -0,0 +1,21 @@
+package routes_sonarr_radarr
+
+import (
+	"aura/logging"
+	"aura/mediaserver"
+	"aura/models"
+	"aura/utils"
+	"context"
+)
+
+// applyToServerCopies applies an image that was applied to the item on the primary media server
+// to the copies of the item on the other media servers
+func applyToServerCopies(ctx context.Context, serverCopies []models.MediaItem, image models.ImageFile) {
+	if len(serverCopies) == 0 {
+		return
+	}
+	_, Err := mediaserver.DownloadApplyImageToServerCopies(ctx, serverCopies, image)
+	if Err.Message != "" {
+		logging.LOGGER.Error().Timestamp().Str("item", utils.MediaItemInfo(serverCopies[0])).Str("image_id", image.ID).Str("error", Err.Message).Msg("Failed to apply webhook image to the copies on the other media servers")
+	}
+}
@@ -0,0 +1,318 @@
+package routes_sonarr_radarr
+
+import (
+	"aura/cache"
+	"aura/config"
+	"aura/database"
+	"aura/logging"
+	"aura/mediaserver"
+	"aura/models"
+	"aura/utils"
+	"aura/utils/httpx"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type RadarrWebHookOnDownloadPayload struct {
+	DeletedFiles []RadarrFile `json:"deletedFiles"`
+	EventType    string       `json:"eventType"`
+	InstanceName string       `json:"instanceName"`
+	IsUpgrade    bool         `json:"isUpgrade"`
+	Movie        RadarrMovie  `json:"movie"`
+	MovieFile    RadarrFile   `json:"movieFile"`
+}
+
+type RadarrFile struct {
+	Path         string `json:"path"`
+	RelativePath string `json:"relativePath"`
+}
+
+type RadarrMovie struct {
+	FolderPath string `json:"folderPath"`
+	Title      string `json:"title"`
+	Year       int    `json:"year"`
+	TmdbID     int    `json:"tmdbId"`
+}
+
+func RadarrWebhookHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, ld := logging.CreateLoggingContext(r.Context(), r.URL.Path)
+	logAction := ld.AddAction("Handle Radarr Webhook", logging.LevelInfo)
+	ctx = logging.WithCurrentAction(ctx, logAction)
+
+	// Get the Library from the URL params
+	libraryTitle := r.URL.Query().Get("library")
+	if libraryTitle == "" {
+		logAction.SetError("Missing library parameter", "The 'library' URL parameter is required", nil)
+		httpx.SendResponse(w, ld, nil)
+		return
+	}
+
+	// Decode into typed struct
+	var payload RadarrWebHookOnDownloadPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		http.Error(w, "Invalid payload", http.StatusBadRequest)
+		return
+	}
+
+	// Run Validation on Payload to determine if we could/should proceed
+	// We only want to run this when EventType is Download (new downloads and upgrades)
+	if payload.EventType != "Download" {
+		logAction.AppendResult("event_type", payload.EventType)
+		w.WriteHeader(http.StatusOK)
+		return
+	} else if payload.Movie == (RadarrMovie{}) || payload.Movie.TmdbID == 0 {
+		logAction.AppendResult("movie_info", "missing or invalid")
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// Now we want to check if this TMDB ID + Library Title exists in the Aura DB
+	dbFilter := models.DBFilter{
+		ItemTMDB_ID:      strconv.Itoa(payload.Movie.TmdbID),
+		ItemLibraryTitle: libraryTitle,
+	}
+
+	db, Err := database.GetAllSavedSets(ctx, dbFilter)
+	if Err.Message != "" {
+		httpx.SendResponse(w, ld, nil)
+		return
+	}
+
+	if len(db.Items) == 0 {
+		logAction.AppendResult("items_found", 0)
+		w.WriteHeader(http.StatusOK)
+		return
+	} else if len(db.Items) > 1 {
+		logAction.SetError("Multiple DB items found", "Multiple items matched the TMDB ID and Library Title. This should not happen.", map[string]any{
+			"items_found":   len(db.Items),
+			"library_title": libraryTitle,
+			"tmdb_id":       payload.Movie.TmdbID,
+		})
+		httpx.SendResponse(w, ld, nil)
+		return
+	}
+
+	dbItem := db.Items[0]
+
+	// Validate the DB Item Media Item details to ensure it has the necessary info to proceed
+	if dbItem.MediaItem.TMDB_ID == "" || dbItem.MediaItem.Title == "" || dbItem.MediaItem.LibraryTitle == "" || dbItem.MediaItem.RatingKey == "" {
+		logAction.SetError("DB item is missing necessary media item info", "The DB item must have a MediaItem with TMDB_ID, Title, LibraryTitle, and RatingKey", map[string]any{
+			"db_item": dbItem,
+		})
+		httpx.SendResponse(w, ld, nil)
+		return
+	}
+
+	// Validate the DB Item Poster Sets to ensure it has the necessary info to proceed
+	if len(dbItem.PosterSets) == 0 {
+		logAction.SetError("DB item is missing poster sets", "The DB item must have at least one poster set to proceed", map[string]any{
+			"db_item": dbItem,
+		})
+		httpx.SendResponse(w, ld, nil)
+		return
+	}
+
+	// Respond to Radarr immediately, it only cares about the response status code
+	w.WriteHeader(http.StatusOK)
+
+	// Check to see if Posters or Backdrops are selected in any of the Poster Sets
+	// If they are not, then we can skip the background processing since there is nothing to re-apply
+	imagesSelected := false
+	for _, posterSet := range dbItem.PosterSets {
+		if posterSet.SelectedTypes.Poster || posterSet.SelectedTypes.Backdrop {
+			imagesSelected = true
+			break
+		}
+	}
+	if !imagesSelected {
+		logAction.AppendResult("background_processing_skipped", true)
+		logAction.AppendResult("reason", "No posters or backdrops selected in any poster set")
+		return
+	}
+
+	go func(
+		dbItem models.DBSavedItem,
+		payload RadarrWebHookOnDownloadPayload,
+	) {
+		eventTypeStr := "Download"
+		if payload.IsUpgrade {
+			eventTypeStr = "Upgrade"
+		}
+
+		bgCtx, bgLd := logging.CreateLoggingContext(context.Background(), "Handle Radarr Webhook Background Task")
+		bgAction := bgLd.AddAction(fmt.Sprintf("Radarr Webhook: Processing %s for %s", eventTypeStr, utils.MediaItemInfo(dbItem.MediaItem)), logging.LevelInfo)
+		bgCtx = logging.WithCurrentAction(bgCtx, bgAction)
+
+		// Handle Panic to prevent crashing the app since this is running in the background
+		defer func() {
+			if r := recover(); r != nil {
+				logging.LOGGER.Error().Timestamp().Msgf("PANIC: in RadarrWebhookHandler background processing: %v", r)
+			}
+		}()
+
+		processRadarrDownloadEvent(bgCtx, payload, dbItem)
+		bgAction.Complete()
+		bgLd.Log()
+	}(dbItem, payload)
+}
+
+func processRadarrDownloadEvent(ctx context.Context, payload RadarrWebHookOnDownloadPayload, dbItem models.DBSavedItem) {
+	// Initial wait to give media server time to ingest the new file.
+	initialSleep := 10 * time.Second
+	_, sleepAction := logging.AddSubActionToContext(ctx, fmt.Sprintf("Sleeping for %v to give time for media server to update", initialSleep), logging.LevelTrace)
+	time.Sleep(initialSleep)
+	sleepAction.Complete()
+
+	// Get the Movie Media Item from the cache
+	_, actionGetFromCache := logging.AddSubActionToContext(ctx, fmt.Sprintf("Getting %s Item from cache", utils.MediaItemInfo(dbItem.MediaItem)), logging.LevelTrace)
+	mediaItem, found := cache.LibraryStore.GetMediaItemFromServerSectionByTMDBID(dbItem.MediaItem.Server, dbItem.MediaItem.LibraryTitle, dbItem.MediaItem.TMDB_ID)
+	if !found || mediaItem == nil {
+		actionGetFromCache.SetError("Media Item not found in cache", "Try refreshing the cache if this issue persists", nil)
+		actionGetFromCache.Complete()
+		return
+	}
+	actionGetFromCache.Complete()
+
+	_, actionGetFromMediaServer := logging.AddSubActionToContext(
+		ctx,
+		fmt.Sprintf("Getting %s Item details from MediaServer", utils.MediaItemInfo(dbItem.MediaItem)),
+		logging.LevelTrace,
+	)
+
+	// Wait until the media server reports the new file from Radarr
+	retrySleep := 10 * time.Second
+	maxRetries := 6
+	var Err logging.LogErrorInfo
+
+	for attempt := 1; attempt <= maxRetries; attempt++ {
+		found, Err = mediaserver.GetMediaItemDetails(ctx, mediaItem)
+		fileUpdated := payload.MovieFile.Path == "" || (mediaItem.Movie != nil && mediaItem.Movie.File.Path == payload.MovieFile.Path)
+		if Err.Message == "" && found && fileUpdated {
+			actionGetFromMediaServer.AppendResult("attempt", attempt)
+			actionGetFromMediaServer.Complete()
+			break
+		}
+
+		if Err.Message != "" {
+			actionGetFromMediaServer.AppendWarning(
+				fmt.Sprintf("attempt_%d_error", attempt),
+				Err.Message,
+			)
+		} else if !found {
+			actionGetFromMediaServer.AppendWarning(
+				fmt.Sprintf("attempt_%d_not_found", attempt),
+				"Media item not found yet",
+			)
+		} else {
+			actionGetFromMediaServer.AppendWarning(
+				fmt.Sprintf("attempt_%d_file_not_updated", attempt),
+				"Media server does not have the new file yet",
+			)
+		}
+
+		// No more retries left
+		if attempt == maxRetries {
+			// The path reported by Radarr may differ from the media server (e.g. Docker mounts), so continue if the item was found
+			if Err.Message == "" && found {
+				actionGetFromMediaServer.AppendWarning("file_path", "Media server file path does not match Radarr file path, continuing anyway")
+				actionGetFromMediaServer.Complete()
+				break
+			}
+			actionGetFromMediaServer.SetError(
+				"Media item not found after retries",
+				"The media server did not return the item within retry window",
+				map[string]any{
+					"max_retries": maxRetries,
+					"retry_sleep": retrySleep.String(),
+					"item":        utils.MediaItemInfo(*mediaItem),
+				},
+			)
+			actionGetFromMediaServer.Complete()
+			return
+		}
+
+		_, retrySleepAction := logging.AddSubActionToContext(
+			ctx,
+			fmt.Sprintf("Media item not ready; sleeping %v before retry %d/%d", retrySleep, attempt, maxRetries),
+			logging.LevelTrace,
+		)
+		time.Sleep(retrySleep)
+		retrySleepAction.Complete()
+	}
+
+	dbUpdateRequired := false
+	var serverCopies []models.MediaItem
+	for idx, dbSet := range dbItem.PosterSets {
+		if !dbSet.SelectedTypes.Poster && !dbSet.SelectedTypes.Backdrop {
+			continue
+		}
+
+		// Re-apply the saved images of the selected types
+		var imagesToApply []models.ImageFile
+		for _, image := range dbSet.Images {
+			if image.ItemTMDB_ID != "" && image.ItemTMDB_ID != mediaItem.TMDB_ID {
+				continue
+			}
+			if (image.Type == "poster" && dbSet.SelectedTypes.Poster) || (image.Type == "backdrop" && dbSet.SelectedTypes.Backdrop) {
+				imagesToApply = append(imagesToApply, image)
+			}
+		}
+
+		if len(imagesToApply) == 0 {
+			logging.LOGGER.Info().Timestamp().Msgf("No saved posters or backdrops for set ID %s, skipping", dbSet.ID)
+			continue
+		}
+
+		// When approvals are required, the images wait in the approval list instead of being applied
+		if config.Current.AutoDownload.RequireApproval {
+			reason := "Radarr: New Download"
+			if payload.IsUpgrade {
+				reason = "Radarr: Upgrade"
+			}
+			stageForApproval(ctx, *mediaItem, dbSet, imagesToApply, reason)
+			continue
+		}
+
+		// Copies of this movie held by the other media servers get the same images
+		if serverCopies == nil {
+			serverCopies = mediaserver.GetMediaItemCopiesOnOtherServers(ctx, *mediaItem)
+		}
+
+		setApplied := false
+		for _, image := range imagesToApply {
+			result := ""
+			Err := mediaserver.DownloadApplyImageToMediaItem(ctx, mediaItem, image)
+			if Err.Message != "" {
+				logging.LOGGER.Error().Timestamp().Msgf("Error downloading/applying image from set ID %s to media item %s: %s", dbSet.ID, utils.MediaItemInfo(*mediaItem), Err.Message)
+				result = "Error: " + Err.Message
+			} else {
+				setApplied = true
+				result = "Success"
+				applyToServerCopies(ctx, serverCopies, image)
+			}
+
+			go func(set models.DBPosterSetDetail, image models.ImageFile, result string) {
+				sendRadarrFileDownloadNotification(*mediaItem, set, image, payload.IsUpgrade, result)
+			}(dbSet, image, result)
+		}
+
+		if setApplied {
+			dbItem.PosterSets[idx].LastDownloaded = time.Now()
+			dbItem.PosterSets[idx].ToDelete = false
+			dbUpdateRequired = true
+		}
+	}
+
+	// Store the new file details so the AutoDownload check does not see the upgrade as a change
+	if dbUpdateRequired {
+		dbItem.MediaItem = *mediaItem
+		Err = database.UpsertSavedItem(ctx, dbItem)
+		if Err.Message != "" {
+			logging.LOGGER.Error().Timestamp().Str("item", utils.MediaItemInfo(*mediaItem)).Str("error", Err.Message).Msg("Failed to update DB item after re-applying images for Radarr Webhook")
+		}
+	}
+}
@@ -2,6 +2,7 @@ package routes_sonarr_radarr
 
 import (
 	"aura/cache"
+	"aura/config"
 	"aura/database"
 	"aura/logging"
 	"aura/mediaserver"
@@ -212,7 +213,7 @@ func processSonarrDownloadEvent(ctx context.Context, payload SonarrWebHookOnUpgr
 
 	// Get the base Show Media Item from the cache
 	_, actionGetFromCache := logging.AddSubActionToContext(ctx, fmt.Sprintf("Getting %s Item from cache", utils.MediaItemInfo(dbItem.MediaItem)), logging.LevelTrace)
-	mediaItem, found := cache.LibraryStore.GetMediaItemFromSectionByTMDBID(dbItem.MediaItem.LibraryTitle, dbItem.MediaItem.TMDB_ID)
+	mediaItem, found := cache.LibraryStore.GetMediaItemFromServerSectionByTMDBID(dbItem.MediaItem.Server, dbItem.MediaItem.LibraryTitle, dbItem.MediaItem.TMDB_ID)
 	if !found || mediaItem == nil {
 		actionGetFromCache.SetError("Media Item not found in cache", "Try refreshing the cache if this issue persists", nil)
 		actionGetFromCache.Complete()
@@ -274,6 +275,7 @@ func processSonarrDownloadEvent(ctx context.Context, payload SonarrWebHookOnUpgr
 		retrySleepAction.Complete()
 	}
 
+	var serverCopies []models.MediaItem
 	for _, dbSet := range dbItem.PosterSets {
 		if !dbSet.SelectedTypes.SeasonPoster && !dbSet.SelectedTypes.SpecialSeasonPoster && !dbSet.SelectedTypes.Titlecard {
 			continue
@@ -369,6 +371,21 @@ func processSonarrDownloadEvent(ctx context.Context, payload SonarrWebHookOnUpgr
 		actionCheck.AppendResult(fmt.Sprintf("images_to_download_for_set_%s", dbSet.ID), len(imagesToDownload))
 		actionCheck.Complete()
 
+		// When approvals are required, the images wait in the approval list instead of being downloaded
+		if config.Current.AutoDownload.RequireApproval {
+			reason := "Sonarr: New Download"
+			if payload.IsUpgrade {
+				reason = "Sonarr: Upgrade"
+			}
+			stageForApproval(ctx, *mediaItem, dbSet, imagesToDownload, reason)
+			continue
+		}
+
+		// Copies of this show held by the other media servers get the same images
+		if serverCopies == nil {
+			serverCopies = mediaserver.GetMediaItemCopiesOnOtherServers(ctx, *mediaItem)
+		}
+
 		dbUpdateRequired := false
 		for _, image := range imagesToDownload {
 			result := ""
@@ -379,6 +396,7 @@ func processSonarrDownloadEvent(ctx context.Context, payload SonarrWebHookOnUpgr
 			} else {
 				dbUpdateRequired = true
 				result = "Success"
+				applyToServerCopies(ctx, serverCopies, image)
 			}
 
 			go func(image models.ImageFile, result string) {
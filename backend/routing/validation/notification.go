@@ -198,6 +198,30 @@ func SendTestNotification(w http.ResponseWriter, r *http.Request) {
 		title = utils.RenderTemplate(req.Template.Title, vars)
 		message = utils.RenderTemplate(req.Template.Message, vars)
 		imageURL = ""
+	case config.TemplateTypeRadarrNotification:
+		vars = utils.MergeTemplateVars(
+			utils.BaseTemplateVars(),
+			map[string]string{
+				"MediaItemTitle":        "Inception",
+				"MediaItemYear":         "2010",
+				"MediaItemTMDBID":       "27205",
+				"MediaItemLibraryTitle": "Movies",
+				"MediaItemRatingKey":    "5678",
+				"MediaItemType":         "movie",
+				"SetID":                 "12345",
+				"SetTitle":              "Inception (2010) Set",
+				"SetType":               "movie",
+				"SetCreator":            sampleSet.UserCreated,
+				"ImageName":             "Poster",
+				"ImageType":             "poster",
+				"ReasonTitle":           "Upgrade",
+				"Reason":                "The movie file was upgraded via Radarr for this media item.",
+				"Result":                "Success",
+			},
+		)
+		title = utils.RenderTemplate(req.Template.Title, vars)
+		message = utils.RenderTemplate(req.Template.Message, vars)
+		imageURL = ""
 	default:
 		logAction.SetError("Unsupported template type", fmt.Sprintf("The template type '%s' is not supported", req.TemplateType), nil)
 		httpx.SendResponse(w, ld, response)
@@ -0,0 +1,124 @@
+package sonarr_radarr
+
+import (
+	"aura/config"
+	"aura/logging"
+	"aura/models"
+	"aura/utils"
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+)
+
+// RemoveTags removes the tags aura added to an item (the Add list and the tags for the selected types)
+// from every Sonarr/Radarr application of the item's library
+func RemoveTags(ctx context.Context, item models.MediaItem) (Err logging.LogErrorInfo) {
+	Err = logging.LogErrorInfo{}
+	if len(config.Current.SonarrRadarr.Applications) == 0 {
+		return Err
+	}
+
+	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf("Removing Tags from %s", utils.MediaItemInfo(item)), logging.LevelInfo)
+	defer logAction.Complete()
+
+	if item.Type != "movie" && item.Type != "show" {
+		return Err
+	}
+
+	for _, srApp := range config.Current.SonarrRadarr.Applications {
+		if MakeSureAllAppInfoPresent(ctx, &srApp).Message != "" {
+			continue
+		}
+		if (item.Type == "movie" && srApp.Type != "Radarr") || (item.Type == "show" && srApp.Type != "Sonarr") {
+			continue
+		}
+		if srApp.Library != item.LibraryTitle {
+			continue
+		}
+
+		appErr := srRemoveTags(ctx, srApp, item)
+		if appErr.Message != "" {
+			Err = appErr
+		}
+	}
+
+	return Err
+}
+
+func srRemoveTags(ctx context.Context, app config.Config_SonarrRadarrApp, item models.MediaItem) (Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf("Removing tags for %s in %s", utils.MediaItemInfo(item), app.Type), logging.LevelInfo)
+	defer logAction.Complete()
+
+	labels := []string{}
+	for _, labelApp := range config.Current.LabelsAndTags.Applications {
+		if labelApp.Application == app.Type {
+			labels = append(labels, labelApp.AddedLabels()...)
+		}
+	}
+	if len(labels) == 0 {
+		return logging.LogErrorInfo{}
+	}
+
+	tmdbIDInt, _ := strconv.Atoi(item.TMDB_ID)
+	srItem, Err := GetItemInfoFromTMDBID(ctx, app, tmdbIDInt)
+	if Err.Message != "" {
+		return Err
+	}
+
+	allAvailableTags, Err := GetAllTags(ctx, app)
+	if Err.Message != "" {
+		return Err
+	}
+	removeIDs := []int64{}
+	for _, tag := range allAvailableTags {
+		if slices.Contains(labels, tag.Label) {
+			removeIDs = append(removeIDs, int64(tag.ID))
+		}
+	}
+
+	keep := func(tagIDs []int64) []int64 {
+		kept := []int64{}
+		for _, id := range tagIDs {
+			if !slices.Contains(removeIDs, id) {
+				kept = append(kept, id)
+			}
+		}
+		return kept
+	}
+
+	switch app.Type {
+	case "Sonarr":
+		srSonarrItem, ok := srItem.(SR_SonarrItem)
+		if !ok {
+			logAction.SetError("Type Assertion Failed", "Failed to assert type to SR_SonarrItem", nil)
+			return *logAction.Error
+		}
+		kept := keep(srSonarrItem.Tags)
+		if len(kept) == len(srSonarrItem.Tags) {
+			return logging.LogErrorInfo{}
+		}
+		srSonarrItem.Tags = kept
+		srItem = srSonarrItem
+	case "Radarr":
+		srRadarrItem, ok := srItem.(SR_RadarrItem)
+		if !ok {
+			logAction.SetError("Type Assertion Failed", "Failed to assert type to SR_RadarrItem", nil)
+			return *logAction.Error
+		}
+		kept := keep(srRadarrItem.Tags)
+		if len(kept) == len(srRadarrItem.Tags) {
+			return logging.LogErrorInfo{}
+		}
+		srRadarrItem.Tags = kept
+		srItem = srRadarrItem
+	}
+
+	Err = UpdateItemInfo(ctx, app, srItem)
+	if Err.Message != "" {
+		return Err
+	}
+
+	logAction.AppendResult("removed_tags", labels)
+	return logging.LogErrorInfo{}
+}
@@ -8,6 +8,7 @@ import (
 	autodownload "aura/download/auto"
 	downloadqueue "aura/download/queue"
 	"aura/jobs"
+	"aura/kometa"
 	"aura/logging"
 	"aura/mediaserver"
 	"aura/mediux"
@@ -97,6 +98,12 @@ func runPreFlight() (success bool) {
 		Msg("Media Server connection validated successfully")
 	config.MediaServerValid = true
 
+	// Validate Additional Media Server Connections
+	if len(config.Current.AdditionalMediaServers) > 0 {
+		config.AppLoadingStep = "Validating Additional Media Server Connections"
+		mediaserver.ConnectAdditionalMediaServers(ctx)
+	}
+
 	// Validate MediUX Token
 	config.AppLoadingStep = "Validating MediUX Token"
 	mediuxTokenValid, mediuxErr := mediux.ValidateToken(ctx, config.Current.Mediux.ApiToken)
@@ -137,6 +144,9 @@ func runWarmup() (success bool) {
 	}
 	logging.LOGGER.Info().Timestamp().Bool("new_database", newDB).Msg("Database initialized")
 
+	// Database-Migration: Restored backups of an older version are migrated as well
+	database.RestoreMigrations = migration.MigrateToLatest
+
 	// Database-Migration: If not a new DB, run migrations
 	if !newDB {
 		config.AppLoadingStep = "Running Database Migrations"
@@ -144,6 +154,10 @@ func runWarmup() (success bool) {
 		logging.LOGGER.Info().Timestamp().Msgf("%d database migrations performed", migrationsCompleted)
 	}
 
+	// Download Queue: Requeue interrupted entries and import the old queue files
+	config.AppLoadingStep = "Initializing Download Queue"
+	downloadqueue.Init(ctx)
+
 	// Cache: Add all media server sections and items
 	config.AppLoadingStep = "Preloading Media Server Data into Cache"
 	_ = mediaserver.GetAllLibrarySectionsAndItems(ctx, false)
@@ -166,18 +180,52 @@ func runWarmup() (success bool) {
 	config.AppLoadingStep = "Starting Background Jobs"
 	jobs.StartAutoDownloadJob()
 
+	// Cronjob: Database Backup
+	err := jobs.StartDatabaseBackupJob()
+	if err != nil {
+		logging.LOGGER.Error().Timestamp().Err(err).Msg("Failed to schedule Database Backup cron job")
+	}
+
+	// Cronjob: Subscribed Creators
+	err = jobs.StartSubscribedCreatorsJob()
+	if err != nil {
+		logging.LOGGER.Error().Timestamp().Err(err).Msg("Failed to schedule Subscribed Creators cron job")
+	}
+
+	// Cronjob: Coverage Report
+	err = jobs.StartCoverageReportJob()
+	if err != nil {
+		logging.LOGGER.Error().Timestamp().Err(err).Msg("Failed to schedule Coverage Report cron job")
+	}
+
+	// Cronjob: Drift Detection
+	err = jobs.StartDriftDetectionJob()
+	if err != nil {
+		logging.LOGGER.Error().Timestamp().Err(err).Msg("Failed to schedule Drift Detection cron job")
+	}
+
+	// Cronjob: Kometa Export
+	err = jobs.StartKometaExportJob()
+	if err != nil {
+		logging.LOGGER.Error().Timestamp().Err(err).Msg("Failed to schedule Kometa Export cron job")
+	}
+
 	// Cronjob: Download Queue Processing
-	err := jobs.StartDownloadQueueJob()
+	err = jobs.StartDownloadQueueJob()
 	if err != nil {
 		logging.LOGGER.Error().Timestamp().Err(err).Msg("Failed to schedule Download Queue Processing cron job")
-		downloadqueue.LatestInfo.Time = time.Now()
-		downloadqueue.LatestInfo.Status = downloadqueue.LAST_STATUS_ERROR
-		downloadqueue.LatestInfo.Message = "Failed to schedule Download Queue Processing"
-		downloadqueue.LatestInfo.Errors = []string{err.Error()}
-		downloadqueue.LatestInfo.Warnings = []string{}
+		downloadqueue.SetLatestInfo(downloadqueue.QueueInfo{
+			Time:     time.Now(),
+			Status:   downloadqueue.LAST_STATUS_ERROR,
+			Message:  "Failed to schedule Download Queue Processing",
+			Errors:   []string{err.Error()},
+			Warnings: []string{},
+		})
 	} else {
-		downloadqueue.LatestInfo.Time = time.Now()
-		downloadqueue.LatestInfo.Status = downloadqueue.LAST_STATUS_IDLE
+		downloadqueue.SetLatestInfo(downloadqueue.QueueInfo{
+			Time:   time.Now(),
+			Status: downloadqueue.LAST_STATUS_IDLE,
+		})
 	}
 
 	// Cronjob: Refresh Media Items and Collections
@@ -217,11 +265,15 @@ func runWarmup() (success bool) {
 	config.AppLoadingStep = "Checking MediUX Site Link Availability"
 	mediux.CheckSiteLinkAvailability()
 
-	// Initialize MediUX WebSocket Listener
-	//go autodownload.StartMediuxWebSocketClient()
+	// Export the saved sets for Kometa again whenever they change
+	kometa.StartSavedSetsListener()
+
+	// Initialize MediUX WebSocket Listener (show, movie and collection set updates, if enabled)
+	autodownload.StartOrStopMediuxWebSocketClient()
 
 	// Initialize Media Server WebSocket Listener (if supported)
 	autodownload.StartOrRestartPlexWebSocketClient()
+	autodownload.StartOrRestartEJWebSocketClient()
 
 	success = true
 	return success
@@ -0,0 +1,154 @@
+package tmdb
+
+import (
+	"aura/config"
+	"aura/logging"
+	"aura/utils/httpx"
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"sort"
+	"strings"
+)
+
+type tmdbImage struct {
+	FilePath    string  `json:"file_path"`
+	Language    *string `json:"iso_639_1"`
+	VoteAverage float64 `json:"vote_average"`
+	VoteCount   int     `json:"vote_count"`
+	Width       int     `json:"width"`
+	Height      int     `json:"height"`
+}
+
+type tmdbImagesResponse struct {
+	Posters   []tmdbImage `json:"posters"`
+	Backdrops []tmdbImage `json:"backdrops"`
+}
+
+type tmdbItemResponse struct {
+	ID           int                `json:"id"`
+	Title        string             `json:"title"` // Movies
+	Name         string             `json:"name"`  // Shows
+	Tagline      string             `json:"tagline"`
+	Status       string             `json:"status"`
+	ReleaseDate  string             `json:"release_date"`   // Movies
+	FirstAirDate string             `json:"first_air_date"` // Shows
+	PosterPath   string             `json:"poster_path"`
+	BackdropPath string             `json:"backdrop_path"`
+	ImdbID       string             `json:"imdb_id"`
+	Seasons      []tmdbSeason       `json:"seasons"`
+	Images       tmdbImagesResponse `json:"images"`
+}
+
+type tmdbSeason struct {
+	SeasonNumber int `json:"season_number"`
+}
+
+// getItemWithImages gets the details and images of a movie or show in one request
+func getItemWithImages(ctx context.Context, tmdbID string, itemType string) (item tmdbItemResponse, Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf("TMDB: Getting Images for %s '%s'", itemType, tmdbID), logging.LevelDebug)
+	defer logAction.Complete()
+
+	apiType := "movie"
+	if itemType == "show" {
+		apiType = "tv"
+	}
+
+	query := url.Values{}
+	query.Set("append_to_response", "images")
+	query.Set("include_image_language", imageLanguageParam())
+	_, respBody, Err := makeRequest(ctx, fmt.Sprintf("/%s/%s", apiType, url.PathEscape(tmdbID)), query)
+	if Err.Message != "" {
+		logAction.SetErrorFromInfo(Err)
+		return item, *logAction.Error
+	}
+
+	Err = httpx.DecodeResponseToJSON(ctx, respBody, &item, "TMDB Item Response")
+	if Err.Message != "" {
+		return item, Err
+	}
+
+	logAction.AppendResult("posters", len(item.Images.Posters))
+	logAction.AppendResult("backdrops", len(item.Images.Backdrops))
+	return item, logging.LogErrorInfo{}
+}
+
+// getSeasonPosters gets the posters of a season of a show
+func getSeasonPosters(ctx context.Context, tmdbID string, seasonNumber int) (posters []tmdbImage, Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf("TMDB: Getting Season %d Posters for show '%s'", seasonNumber, tmdbID), logging.LevelTrace)
+	defer logAction.Complete()
+
+	query := url.Values{}
+	query.Set("include_image_language", imageLanguageParam())
+	_, respBody, Err := makeRequest(ctx, fmt.Sprintf("/tv/%s/season/%d/images", url.PathEscape(tmdbID), seasonNumber), query)
+	if Err.Message != "" {
+		logAction.SetErrorFromInfo(Err)
+		return nil, *logAction.Error
+	}
+
+	var resp tmdbImagesResponse
+	Err = httpx.DecodeResponseToJSON(ctx, respBody, &resp, "TMDB Season Images Response")
+	if Err.Message != "" {
+		return nil, Err
+	}
+	return resp.Posters, logging.LogErrorInfo{}
+}
+
+// imageLanguageParam is the include_image_language value for the configured languages.
+// Images without text ("null") are always included, they are used for backdrops.
+func imageLanguageParam() string {
+	languages := slices.Clone(config.Current.TMDB.Languages)
+	if len(languages) == 0 {
+		languages = []string{"en"}
+	}
+	if !slices.Contains(languages, "null") {
+		languages = append(languages, "null")
+	}
+	return strings.Join(languages, ",")
+}
+
+// rankImages removes the images below TMDB.MinVoteAverage and orders the rest by preference.
+// Posters prefer the configured languages in their order, backdrops prefer images without text.
+// Within the same language the images are ordered by vote average and vote count.
+func rankImages(images []tmdbImage, preferTextless bool) []tmdbImage {
+	languages := config.Current.TMDB.Languages
+	if len(languages) == 0 {
+		languages = []string{"en"}
+	}
+
+	languageRank := func(img tmdbImage) int {
+		lang := "null"
+		if img.Language != nil && *img.Language != "" {
+			lang = *img.Language
+		}
+		if preferTextless && lang == "null" {
+			return -1
+		}
+		if idx := slices.Index(languages, lang); idx >= 0 {
+			return idx
+		}
+		// Textless posters come after the configured languages
+		return len(languages)
+	}
+
+	ranked := []tmdbImage{}
+	for _, img := range images {
+		if img.FilePath == "" || img.VoteAverage < config.Current.TMDB.MinVoteAverage {
+			continue
+		}
+		ranked = append(ranked, img)
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		ri, rj := languageRank(ranked[i]), languageRank(ranked[j])
+		if ri != rj {
+			return ri < rj
+		}
+		if ranked[i].VoteAverage != ranked[j].VoteAverage {
+			return ranked[i].VoteAverage > ranked[j].VoteAverage
+		}
+		return ranked[i].VoteCount > ranked[j].VoteCount
+	})
+	return ranked
+}
@@ -0,0 +1,68 @@
+package tmdb
+
+import (
+	"aura/config"
+	"aura/logging"
+	"aura/utils"
+	"aura/utils/httpx"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+var TMDBApiURL string = "https://api.themoviedb.org/3"
+var TMDBImageURL string = "https://image.tmdb.org/t/p"
+
+// tmdbRequestsPerSecond stays well below the TMDB limit of about 40 requests per second
+const tmdbRequestsPerSecond = 20
+
+func makeRequest(ctx context.Context, apiPath string, query url.Values) (resp *http.Response, respBody []byte, Err logging.LogErrorInfo) {
+	u, err := url.Parse(TMDBApiURL)
+	if err != nil {
+		return nil, nil, logging.LogErrorInfo{
+			Message: "Failed to parse TMDB base URL",
+			Help:    "Ensure the URL is valid",
+			Detail:  map[string]any{"error": err.Error()},
+		}
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/") + apiPath
+	u.RawQuery = query.Encode()
+
+	// Make the HTTP Headers for this request
+	headers := makeAuthHeader(config.Current.TMDB.ApiToken)
+
+	if err := utils.WaitForRateLimit(ctx, "tmdb", tmdbRequestsPerSecond); err != nil {
+		return nil, nil, logging.LogErrorInfo{
+			Message: "Failed to wait for the TMDB rate limit",
+			Help:    err.Error(),
+			Detail:  map[string]any{"error": err.Error()},
+		}
+	}
+
+	// Make the HTTP request to TMDB
+	resp, respBody, Err = httpx.MakeHTTPRequest(ctx, u.String(), "GET", headers, 30, nil, "TMDB")
+	if Err.Message != "" {
+		return nil, nil, Err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, nil, logging.LogErrorInfo{
+			Message: "TMDB returned a non-success status code",
+			Help:    "Check the response from TMDB for more details",
+			Detail:  map[string]any{"status_code": resp.StatusCode, "error_body": string(respBody)},
+		}
+	}
+
+	return resp, respBody, logging.LogErrorInfo{}
+}
+
+// makeAuthHeader uses the API Read Access Token, so the token never ends up in a logged URL
+func makeAuthHeader(token string) (headers map[string]string) {
+	headers = make(map[string]string)
+	headers["Authorization"] = fmt.Sprintf("Bearer %s", token)
+	headers["Accept"] = "application/json"
+	return headers
+}
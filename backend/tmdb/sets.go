@@ -0,0 +1,261 @@
+package tmdb
+
+import (
+	"aura/cache"
+	"aura/config"
+	"aura/database"
+	"aura/logging"
+	"aura/models"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/language/display"
+)
+
+// SetIDPrefix marks the ID of a set that aura built from TMDB images (e.g. "tmdb-show-1399-1")
+const SetIDPrefix = "tmdb-"
+
+// ImageIDPrefix marks the ID of a TMDB image (e.g. "tmdb_kqjL17yufvn9OVLyXYpvtyrFfak.jpg")
+const ImageIDPrefix = "tmdb_"
+
+// SetCreator is used as the creator of TMDB sets
+const SetCreator = "TMDB"
+
+// FallbackEnabled reports whether TMDB sets are offered for items without MediUX sets
+func FallbackEnabled() bool {
+	return config.Current.TMDB.FallbackEnabled && config.Current.TMDB.ApiToken != ""
+}
+
+func IsSetID(setID string) bool {
+	return strings.HasPrefix(setID, SetIDPrefix)
+}
+
+func IsImageID(imageID string) bool {
+	return strings.HasPrefix(imageID, ImageIDPrefix)
+}
+
+// ImageURL returns the TMDB URL of an image. Size is a TMDB image size such as "w500" or "original".
+func ImageURL(imageID string, size string) string {
+	return fmt.Sprintf("%s/%s/%s", TMDBImageURL, size, strings.TrimPrefix(imageID, ImageIDPrefix))
+}
+
+// GetItemSets lists the TMDB images of a movie or show as sets that work like MediUX sets.
+// Set n holds the n-th best poster, backdrop and season posters, so up to TMDB.MaxSets sets are returned.
+func GetItemSets(ctx context.Context, tmdbID string, itemType string, itemLibraryTitle string) (sets []models.SetRef, includedItems map[string]models.IncludedItem, Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "TMDB: Get Item Sets", logging.LevelInfo)
+	defer logAction.Complete()
+
+	sets = []models.SetRef{}
+	includedItems = map[string]models.IncludedItem{}
+
+	if itemType != "movie" && itemType != "show" {
+		logAction.SetError("Invalid Item Type", "The provided item type is not valid", map[string]any{
+			"item_type": itemType,
+		})
+		return sets, includedItems, *logAction.Error
+	}
+
+	item, Err := getItemWithImages(ctx, tmdbID, itemType)
+	if Err.Message != "" {
+		return sets, includedItems, Err
+	}
+
+	// 1) Populate included items with the item info
+	includedItem := convertTMDBItemToIncludedItem(item, itemType, tmdbID, itemLibraryTitle)
+	includedItems[tmdbID] = includedItem
+
+	// 2) Rank the images
+	posters := rankImages(item.Images.Posters, false)
+	backdrops := rankImages(item.Images.Backdrops, true)
+	seasonPosters := map[int][]tmdbImage{}
+	if itemType == "show" {
+		for _, season := range item.Seasons {
+			images, Err := getSeasonPosters(ctx, tmdbID, season.SeasonNumber)
+			if Err.Message != "" {
+				logAction.AppendWarning(fmt.Sprintf("season_%d", season.SeasonNumber), "Failed to get the season posters from TMDB")
+				continue
+			}
+			seasonPosters[season.SeasonNumber] = rankImages(images, false)
+		}
+	}
+
+	// 3) Build the sets (one SetRef per rank)
+	maxSets := config.Current.TMDB.MaxSets
+	if maxSets <= 0 {
+		maxSets = 5
+	}
+	for rank := 1; rank <= maxSets; rank++ {
+		images := []models.ImageFile{}
+		if img, ok := imageAtRank(posters, rank); ok {
+			images = append(images, convertTMDBImageToImageFile(img, "poster", tmdbID, nil))
+		}
+		if img, ok := imageAtRank(backdrops, rank); ok {
+			images = append(images, convertTMDBImageToImageFile(img, "backdrop", tmdbID, nil))
+		}
+		for _, season := range item.Seasons {
+			if img, ok := imageAtRank(seasonPosters[season.SeasonNumber], rank); ok {
+				seasonNumber := season.SeasonNumber
+				images = append(images, convertTMDBImageToImageFile(img, "season_poster", tmdbID, &seasonNumber))
+			}
+		}
+		if len(images) == 0 {
+			break
+		}
+
+		sets = append(sets, models.SetRef{
+			PosterSet: models.PosterSet{
+				BaseSetInfo: models.BaseSetInfo{
+					ID:          fmt.Sprintf("%s%s-%s-%d", SetIDPrefix, itemType, tmdbID, rank),
+					Title:       fmt.Sprintf("%s - TMDB #%d", includedItem.MediuxInfo.Title, rank),
+					Type:        itemType,
+					UserCreated: SetCreator,
+				},
+				Images: images,
+			},
+			ItemIDs: []string{tmdbID},
+		})
+	}
+
+	logAction.AppendResult("sets_returned", len(sets))
+	return sets, includedItems, logging.LogErrorInfo{}
+}
+
+// GetSetByID gets a TMDB set by the ID that GetItemSets gave it.
+// The ID only holds the rank of the images, so a saved set is returned with the images that were saved.
+// Ranking the images again would give a saved set other images whenever the TMDB votes change.
+func GetSetByID(ctx context.Context, setID string, itemLibraryTitle string) (set models.SetRef, includedItems map[string]models.IncludedItem, Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf("TMDB: Get Set By ID '%s'", setID), logging.LevelInfo)
+	defer logAction.Complete()
+
+	includedItems = map[string]models.IncludedItem{}
+
+	// The set ID is built as tmdb-<item type>-<tmdb id>-<rank>
+	parts := strings.Split(strings.TrimPrefix(setID, SetIDPrefix), "-")
+	if len(parts) != 3 {
+		logAction.SetError("Invalid TMDB Set ID", "The set ID is not a TMDB set ID", map[string]any{
+			"set_id": setID,
+		})
+		return set, includedItems, *logAction.Error
+	}
+	if _, err := strconv.Atoi(parts[2]); err != nil {
+		logAction.SetError("Invalid TMDB Set ID", "The set ID is not a TMDB set ID", map[string]any{
+			"set_id": setID,
+		})
+		return set, includedItems, *logAction.Error
+	}
+
+	savedSet, saved, Err := getSavedSet(ctx, setID, parts[1], itemLibraryTitle)
+	if Err.Message != "" {
+		return set, includedItems, Err
+	}
+	if saved {
+		item, Err := getItemWithImages(ctx, parts[1], parts[0])
+		if Err.Message != "" {
+			return set, includedItems, Err
+		}
+		includedItems[parts[1]] = convertTMDBItemToIncludedItem(item, parts[0], parts[1], itemLibraryTitle)
+		logAction.AppendResult("saved_set", true)
+		return models.SetRef{PosterSet: savedSet, ItemIDs: []string{parts[1]}}, includedItems, logging.LogErrorInfo{}
+	}
+
+	var sets []models.SetRef
+	sets, includedItems, Err = GetItemSets(ctx, parts[1], parts[0], itemLibraryTitle)
+	if Err.Message != "" {
+		return set, includedItems, Err
+	}
+	for _, s := range sets {
+		if s.ID == setID {
+			return s, includedItems, logging.LogErrorInfo{}
+		}
+	}
+
+	logAction.SetError("TMDB Set Not Found", "TMDB no longer has enough images for this set", map[string]any{
+		"set_id":     setID,
+		"sets_found": len(sets),
+	})
+	return set, includedItems, *logAction.Error
+}
+
+// getSavedSet gets the set as it was saved for the item
+func getSavedSet(ctx context.Context, setID string, tmdbID string, itemLibraryTitle string) (set models.PosterSet, found bool, Err logging.LogErrorInfo) {
+	savedItems, Err := database.GetAllSavedSets(ctx, models.DBFilter{
+		ItemTMDB_ID:      tmdbID,
+		ItemLibraryTitle: itemLibraryTitle,
+		SetID:            setID,
+		ItemsPerPage:     -1,
+	})
+	if Err.Message != "" {
+		return set, false, Err
+	}
+	for _, savedItem := range savedItems.Items {
+		for _, savedSet := range savedItem.PosterSets {
+			if savedSet.ID == setID && len(savedSet.Images) > 0 {
+				return savedSet.PosterSet, true, logging.LogErrorInfo{}
+			}
+		}
+	}
+	return set, false, logging.LogErrorInfo{}
+}
+
+func imageAtRank(images []tmdbImage, rank int) (tmdbImage, bool) {
+	if rank > len(images) {
+		return tmdbImage{}, false
+	}
+	return images[rank-1], true
+}
+
+// Convert TMDB item details to Set Response BaseItemInfo
+func convertTMDBItemToResponseBaseItem(item tmdbItemResponse, itemType string) models.BaseMediuxItemInfo {
+	baseItem := models.BaseMediuxItemInfo{
+		TMDB_ID:           strconv.Itoa(item.ID),
+		Type:              itemType,
+		Status:            item.Status,
+		Title:             item.Title,
+		Tagline:           item.Tagline,
+		ReleaseDate:       item.ReleaseDate,
+		ImdbID:            item.ImdbID,
+		TMDB_PosterPath:   item.PosterPath,
+		TMDB_BackdropPath: item.BackdropPath,
+	}
+	if itemType == "show" {
+		baseItem.Title = item.Name
+		baseItem.ReleaseDate = item.FirstAirDate
+	}
+	return baseItem
+}
+
+// Convert TMDB item details to the included item, with the media item when it is in the library
+func convertTMDBItemToIncludedItem(item tmdbItemResponse, itemType string, tmdbID string, itemLibraryTitle string) models.IncludedItem {
+	includedItem := models.IncludedItem{MediuxInfo: convertTMDBItemToResponseBaseItem(item, itemType)}
+	if mediaItem, found := cache.LibraryStore.GetMediaItemFromSectionByTMDBID(itemLibraryTitle, tmdbID); found {
+		includedItem.MediaItem = *mediaItem
+	}
+	return includedItem
+}
+
+// Convert a TMDB image to Response ImageFile
+func convertTMDBImageToImageFile(img tmdbImage, imageType string, tmdbID string, seasonNumber *int) models.ImageFile {
+	imageID := ImageIDPrefix + strings.TrimPrefix(img.FilePath, "/")
+	return models.ImageFile{
+		ID:           imageID,
+		Type:         imageType,
+		Src:          imageID,
+		Language:     languageDisplayName(img.Language),
+		ItemTMDB_ID:  tmdbID,
+		SeasonNumber: seasonNumber,
+	}
+}
+
+func languageDisplayName(code *string) string {
+	if code == nil || *code == "" {
+		return "Textless"
+	}
+	tag, err := language.Parse(*code)
+	if err != nil {
+		return *code
+	}
+	return display.English.Languages().Name(tag)
+}
@@ -0,0 +1,68 @@
+package utils
+
+import (
+	"bytes"
+	"image"
+	"math/bits"
+
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+)
+
+// PerceptualHash returns the difference hash (dHash) of an image.
+// The image is shrunk to 9x8 grayscale pixels and every bit tells whether a pixel is brighter than its right neighbour,
+// so the hash stays the same when an image is resized or recompressed by the media server.
+func PerceptualHash(imageData []byte) (uint64, error) {
+	img, _, err := image.Decode(bytes.NewReader(imageData))
+	if err != nil {
+		return 0, err
+	}
+
+	const width, height = 9, 8
+	bounds := img.Bounds()
+	if bounds.Dx() < width || bounds.Dy() < height {
+		return 0, image.ErrFormat
+	}
+
+	// Average the pixels of every cell of a 9x8 grid.
+	// Large cells are sampled with a step of at most 16 samples per side to keep big images fast.
+	var gray [height][width]float64
+	for y := range height {
+		y0 := bounds.Min.Y + y*bounds.Dy()/height
+		y1 := bounds.Min.Y + (y+1)*bounds.Dy()/height
+		for x := range width {
+			x0 := bounds.Min.X + x*bounds.Dx()/width
+			x1 := bounds.Min.X + (x+1)*bounds.Dx()/width
+			stepX := max(1, (x1-x0)/16)
+			stepY := max(1, (y1-y0)/16)
+			var sum float64
+			var count int
+			for py := y0; py < y1; py += stepY {
+				for px := x0; px < x1; px += stepX {
+					r, g, b, _ := img.At(px, py).RGBA()
+					sum += 0.299*float64(r) + 0.587*float64(g) + 0.114*float64(b)
+					count++
+				}
+			}
+			gray[y][x] = sum / float64(count)
+		}
+	}
+
+	var hash uint64
+	for y := range height {
+		for x := range width - 1 {
+			hash <<= 1
+			if gray[y][x] > gray[y][x+1] {
+				hash |= 1
+			}
+		}
+	}
+	return hash, nil
+}
+
+// PerceptualHashDistance returns the number of bits that differ between two perceptual hashes.
+// 0 means the images look the same, 64 means they have nothing in common.
+func PerceptualHashDistance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
@@ -0,0 +1,115 @@
+package utils
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+// testImage draws a poster-like image with a light left half, a dark right half and a diagonal band
+func testImage(width, height int, invert bool) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := range height {
+		for x := range width {
+			v := uint8(220)
+			if x > width/2 {
+				v = 40
+			}
+			if d := x*height/width - y; d > -height/8 && d < height/8 {
+				v = 128
+			}
+			v += uint8(x * 30 / width)
+			if invert {
+				v = 255 - v
+			}
+			img.Set(x, y, color.RGBA{R: v, G: v, B: v, A: 255})
+		}
+	}
+	return img
+}
+
+func encodePNG(t *testing.T, img image.Image) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("failed to encode png: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func encodeJPEG(t *testing.T, img image.Image, quality int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: quality}); err != nil {
+		t.Fatalf("failed to encode jpeg: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func mustPerceptualHash(t *testing.T, data []byte) uint64 {
+	t.Helper()
+	hash, err := PerceptualHash(data)
+	if err != nil {
+		t.Fatalf("PerceptualHash() failed: %v", err)
+	}
+	return hash
+}
+
+func TestPerceptualHashIgnoresResizingAndRecompression(t *testing.T) {
+	original := mustPerceptualHash(t, encodePNG(t, testImage(600, 900, false)))
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{name: "same image", data: encodePNG(t, testImage(600, 900, false))},
+		{name: "smaller", data: encodePNG(t, testImage(300, 450, false))},
+		{name: "low quality jpeg", data: encodeJPEG(t, testImage(600, 900, false), 40)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if distance := PerceptualHashDistance(original, mustPerceptualHash(t, tt.data)); distance > 2 {
+				t.Errorf("distance = %d, want at most 2", distance)
+			}
+		})
+	}
+}
+
+func TestPerceptualHashDetectsADifferentImage(t *testing.T) {
+	original := mustPerceptualHash(t, encodePNG(t, testImage(600, 900, false)))
+	inverted := mustPerceptualHash(t, encodePNG(t, testImage(600, 900, true)))
+
+	if distance := PerceptualHashDistance(original, inverted); distance < 20 {
+		t.Errorf("distance = %d, want at least 20", distance)
+	}
+}
+
+func TestPerceptualHashRejectsUnreadableImages(t *testing.T) {
+	if _, err := PerceptualHash([]byte("not an image")); err == nil {
+		t.Error("PerceptualHash() of invalid data returned no error")
+	}
+	if _, err := PerceptualHash(encodePNG(t, testImage(4, 4, false))); err == nil {
+		t.Error("PerceptualHash() of an image smaller than 9x8 returned no error")
+	}
+}
+
+func TestPerceptualHashDistance(t *testing.T) {
+	tests := []struct {
+		a, b uint64
+		want int
+	}{
+		{a: 0, b: 0, want: 0},
+		{a: 0b1011, b: 0b0001, want: 2},
+		{a: 0, b: ^uint64(0), want: 64},
+	}
+
+	for _, tt := range tests {
+		if got := PerceptualHashDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("PerceptualHashDistance(%b, %b) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
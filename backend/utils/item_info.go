@@ -27,6 +27,9 @@ func MediaItemInfo(item models.MediaItem) string {
 	if libraryTitle == "" {
 		libraryTitle = "<no library>"
 	}
+	if item.Server != "" {
+		libraryTitle = fmt.Sprintf("%s/%s", item.Server, libraryTitle)
+	}
 	if tmdbID == "" {
 		tmdbID = "<no tmdb>"
 	}
@@ -0,0 +1,35 @@
+package utils
+
+import (
+	"context"
+	"sync"
+
+	"golang.org/x/time/rate"
+)
+
+var (
+	rateLimitersMu sync.Mutex
+	rateLimiters   = map[string]*rate.Limiter{}
+)
+
+// WaitForRateLimit blocks until the service identified by key allows another request.
+// The limiter of a key is shared by all callers and follows changes of perSecond.
+// A perSecond of 0 or lower disables the limit.
+func WaitForRateLimit(ctx context.Context, key string, perSecond int) error {
+	if perSecond <= 0 {
+		return nil
+	}
+
+	rateLimitersMu.Lock()
+	limiter, ok := rateLimiters[key]
+	if !ok {
+		limiter = rate.NewLimiter(rate.Limit(perSecond), perSecond)
+		rateLimiters[key] = limiter
+	} else if limiter.Limit() != rate.Limit(perSecond) {
+		limiter.SetLimit(rate.Limit(perSecond))
+		limiter.SetBurst(perSecond)
+	}
+	rateLimitersMu.Unlock()
+
+	return limiter.Wait(ctx)
+}
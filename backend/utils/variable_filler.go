@@ -184,3 +184,26 @@ func TemplateVars_SonarrNotification(mediaItem models.MediaItem, setItem models.
 		},
 	)
 }
+
+func TemplateVars_RadarrNotification(mediaItem models.MediaItem, setItem models.DBPosterSetDetail, image models.ImageFile, reasonTitle string, reason string, result string) map[string]string {
+	return MergeTemplateVars(
+		BaseTemplateVars(),
+		map[string]string{
+			"MediaItemTitle":        mediaItem.Title,
+			"MediaItemYear":         fmt.Sprintf("%d", mediaItem.Year),
+			"MediaItemTMDBID":       mediaItem.TMDB_ID,
+			"MediaItemLibraryTitle": mediaItem.LibraryTitle,
+			"MediaItemRatingKey":    mediaItem.RatingKey,
+			"MediaItemType":         mediaItem.Type,
+			"SetID":                 setItem.ID,
+			"SetTitle":              setItem.Title,
+			"SetType":               setItem.Type,
+			"SetCreator":            setItem.UserCreated,
+			"ImageName":             GetFileDownloadName(mediaItem.Title, image),
+			"ImageType":             image.Type,
+			"ReasonTitle":           reasonTitle,
+			"Reason":                reason,
+			"Result":                result,
+		},
+	)
+}
@@ -24,9 +24,17 @@ import { Switch } from "@/components/ui/switch";
 
 import { cn } from "@/lib/cn";
 
-import type { AppConfigImages } from "@/types/config/config";
+import type { AppConfigImages, AppConfigLocalImageNamingTemplate } from "@/types/config/config";
 
 const EPISODE_NAMING_CONVENTION_OPTIONS = ["match", "static"];
+const NAMING_PROFILE_OPTIONS = ["plex", "kodi", "emby", "custom"];
+const NAMING_TEMPLATE_FIELDS: { key: keyof AppConfigLocalImageNamingTemplate; label: string; placeholder: string }[] = [
+  { key: "poster", label: "Poster", placeholder: "poster.jpg" },
+  { key: "backdrop", label: "Backdrop", placeholder: "backdrop.jpg" },
+  { key: "season_poster", label: "Season Poster", placeholder: "season{{Season}}-poster.jpg" },
+  { key: "special_season_poster", label: "Special Season Poster", placeholder: "season-specials-poster.jpg" },
+  { key: "titlecard", label: "Titlecard", placeholder: "{{EpisodeFileName}}-thumb.jpg" },
+];
 
 interface ConfigSectionImagesProps {
   value: AppConfigImages;
@@ -37,6 +45,8 @@ interface ConfigSectionImagesProps {
       enabled?: boolean;
       path?: boolean;
       episode_naming_convention?: boolean;
+      naming_profile?: boolean;
+      naming_template?: boolean;
     };
   };
   onChange: <K extends keyof AppConfigImages, F extends keyof AppConfigImages[K]>(
@@ -72,11 +82,14 @@ export const ConfigSectionImages: React.FC<ConfigSectionImagesProps> = ({
     }
   };
 
+  const defaultNamingProfile = mediaServerType === "Emby" || mediaServerType === "Jellyfin" ? "emby" : "plex";
+  const namingProfile = value.save_images_locally.naming_profile || defaultNamingProfile;
+
   const errors = React.useMemo<Partial<Record<keyof AppConfigImages, string>>>(() => {
     const errs: Partial<Record<keyof AppConfigImages, string>> = {};
 
-    // If Media Server Type is Plex, validate SaveImagesLocally.EpisodeNamingConvention
-    if (mediaServerType && mediaServerType === "Plex" && value.save_images_locally.enabled) {
+    // If the Plex naming profile is used, validate SaveImagesLocally.EpisodeNamingConvention
+    if (namingProfile === "plex" && value.save_images_locally.enabled) {
       if (!value.save_images_locally.episode_naming_convention) {
         errs.save_images_locally = "Episode naming convention is required.";
       } else {
@@ -87,7 +100,7 @@ export const ConfigSectionImages: React.FC<ConfigSectionImagesProps> = ({
     }
 
     return errs;
-  }, [mediaServerType, value.save_images_locally.enabled, value.save_images_locally.episode_naming_convention]);
+  }, [namingProfile, value.save_images_locally.enabled, value.save_images_locally.episode_naming_convention]);
 
   // Emit errors upward
   useEffect(() => {
@@ -139,7 +152,7 @@ export const ConfigSectionImages: React.FC<ConfigSectionImagesProps> = ({
       </div>
 
       {/* Save Images Locally */}
-      {mediaServerType === "Plex" && (
+      {mediaServerType && (
         <div
           className={cn(
             "border rounded-md p-3 transition",
@@ -198,7 +211,91 @@ export const ConfigSectionImages: React.FC<ConfigSectionImagesProps> = ({
             </div>
           )}
 
-          {mediaServerType === "Plex" && value.save_images_locally.enabled && (
+          {value.save_images_locally.enabled && (
+            <div className={cn("space-y-1 mt-4")}>
+              <div className="flex items-center justify-between">
+                <Label>Naming Profile</Label>
+                {editing && (
+                  <PopoverHelp ariaLabel="help-images-naming-profile">
+                    <div className="space-y-3">
+                      <p className="font-medium mb-1">Naming Profile</p>
+                      <ul className="space-y-1">
+                        <li>
+                          <span className="font-mono">plex</span>: poster.jpg, backdrop.jpg, season01-poster.jpg
+                        </li>
+                        <li>
+                          <span className="font-mono">kodi</span>: poster.jpg, fanart.jpg, season-all-poster.jpg,
+                          episode-thumb.jpg
+                        </li>
+                        <li>
+                          <span className="font-mono">emby</span>: folder.jpg, backdrop.jpg, landscape.jpg,
+                          episode-thumb.jpg
+                        </li>
+                        <li>
+                          <span className="font-mono">custom</span>: your own file names
+                        </li>
+                      </ul>
+                    </div>
+                  </PopoverHelp>
+                )}
+              </div>
+              <Select
+                disabled={!editing}
+                value={namingProfile}
+                onValueChange={(v) => onChange("save_images_locally", "naming_profile", v)}
+              >
+                <SelectTrigger
+                  id="images-naming-profile-trigger"
+                  className={cn("w-full", dirtyFields.save_images_locally?.naming_profile && "border-amber-500")}
+                >
+                  <SelectValue placeholder="Select profile..." />
+                </SelectTrigger>
+                <SelectContent>
+                  {NAMING_PROFILE_OPTIONS.map((o) => (
+                    <SelectItem key={o} value={o}>
+                      {o}
+                    </SelectItem>
+                  ))}
+                </SelectContent>
+              </Select>
+            </div>
+          )}
+
+          {value.save_images_locally.enabled && namingProfile === "custom" && (
+            <div className={cn("space-y-2 mt-4")}>
+              <div className="flex items-center justify-between">
+                <Label>Naming Template</Label>
+                {editing && (
+                  <PopoverHelp ariaLabel="help-images-naming-template">
+                    <p>
+                      Use {"{{Title}}"}, {"{{Year}}"}, {"{{Season}}"}, {"{{Episode}}"}, {"{{EpisodeCode}}"} and{" "}
+                      {"{{EpisodeFileName}}"}. Empty fields use the Plex file name.
+                    </p>
+                  </PopoverHelp>
+                )}
+              </div>
+              {NAMING_TEMPLATE_FIELDS.map((field) => (
+                <div key={field.key} className="space-y-1">
+                  <Label className="text-sm text-muted-foreground">{field.label}</Label>
+                  <Input
+                    type="text"
+                    disabled={!editing}
+                    value={value.save_images_locally.naming_template?.[field.key] || ""}
+                    onChange={(e) =>
+                      onChange("save_images_locally", "naming_template", {
+                        ...value.save_images_locally.naming_template,
+                        [field.key]: e.target.value,
+                      })
+                    }
+                    className={cn("w-full", dirtyFields.save_images_locally?.naming_template && "border-amber-500")}
+                    placeholder={field.placeholder}
+                  />
+                </div>
+              ))}
+            </div>
+          )}
+
+          {namingProfile === "plex" && value.save_images_locally.enabled && (
             <div className={cn("space-y-1 mt-4")}>
               <div className="flex items-center justify-between">
                 <Label>Episode Naming Convention</Label>
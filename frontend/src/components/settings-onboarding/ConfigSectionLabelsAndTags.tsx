@@ -55,8 +55,8 @@ export const ConfigSectionLabelsAndTags: React.FC<ConfigSectionLabelsAndTagsProp
 
   const APPLICATION_TYPES = React.useMemo(() => {
     let appTypes = [];
-    if (mediaServerType === "Plex") {
-      appTypes.push("Plex");
+    if (mediaServerType === "Plex" || mediaServerType === "Emby" || mediaServerType === "Jellyfin") {
+      appTypes.push(mediaServerType);
     }
     if (Array.isArray(srOptions) && srOptions.length > 0) {
       appTypes = appTypes.concat(srOptions);
@@ -107,9 +107,9 @@ export const ConfigSectionLabelsAndTags: React.FC<ConfigSectionLabelsAndTagsProp
     if (!editing || providerExists) return;
     const type = newApplicationType;
     let newEntry: AppConfigLabelsAndTagsApplication;
-    if (type === "Plex") {
+    if (type === "Plex" || type === "Emby" || type === "Jellyfin") {
       newEntry = {
-        application: "Plex",
+        application: type,
         enabled: true,
         add: [],
         remove: [],
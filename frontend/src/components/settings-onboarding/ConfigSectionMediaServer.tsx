@@ -680,6 +680,71 @@ export const ConfigSectionMediaServer: React.FC<ConfigSectionMediaServerProps> =
           </div>
         </div>
       )}
+
+      {/* Plex Webhook */}
+      {value.type === "Plex" && (
+        <div
+          className={cn(
+            "flex items-center justify-between border rounded-md p-3 transition",
+            "border-muted",
+            dirtyFields.enable_plex_webhook && "border-amber-500"
+          )}
+        >
+          <Label>Enable Plex Webhook</Label>
+          <div className="flex items-center gap-2">
+            <Switch
+              disabled={!editing}
+              checked={value.enable_plex_webhook}
+              onCheckedChange={(c) => onChange("enable_plex_webhook", c)}
+            />
+            {editing && (
+              <PopoverHelp ariaLabel="help-plex-webhook">
+                <p className="mb-2">
+                  When enabled, Aura handles the <b>library.new</b> webhooks that Plex sends to{" "}
+                  <code>/api/plex/webhook</code>. Saved images are reapplied to new episodes and seasons, and new items
+                  are passed to AutoSelect.
+                </p>
+                <p className="text-muted-foreground">
+                  Add the webhook URL under Settings → Webhooks in Plex (requires Plex Pass). Use this instead of the
+                  websocket listener if Aura can only be reached through a reverse proxy.
+                </p>
+              </PopoverHelp>
+            )}
+          </div>
+        </div>
+      )}
+
+      {/* Emby/Jellyfin Websocket Listener */}
+      {(value.type === "Emby" || value.type === "Jellyfin") && (
+        <div
+          className={cn(
+            "flex items-center justify-between border rounded-md p-3 transition",
+            "border-muted",
+            dirtyFields.enable_emby_jellyfin_event_listener && "border-amber-500"
+          )}
+        >
+          <Label>Enable {value.type} Websocket Listener</Label>
+          <div className="flex items-center gap-2">
+            <Switch
+              disabled={!editing}
+              checked={value.enable_emby_jellyfin_event_listener}
+              onCheckedChange={(c) => onChange("enable_emby_jellyfin_event_listener", c)}
+            />
+            {editing && (
+              <PopoverHelp ariaLabel="help-emby-jellyfin-websocket-listener">
+                <p className="mb-2">
+                  When enabled, Aura will listen for {value.type} metadata refresh events and reapply saved images that
+                  the refresh replaced.
+                </p>
+                <p className="text-muted-foreground">
+                  Only sets with auto download enabled are reapplied. This requires an extra websocket connection from
+                  Aura to your {value.type} server.
+                </p>
+              </PopoverHelp>
+            )}
+          </div>
+        </div>
+      )}
     </Card>
   );
 };
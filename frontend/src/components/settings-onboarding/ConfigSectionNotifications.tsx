@@ -72,6 +72,7 @@ const TEMPLATE_TITLES: Partial<Record<keyof AppConfigNotificationTemplate, strin
   new_sets_available_for_ignored_items: "New Sets Available for Ignored Item",
   check_for_media_item_changes_job: "Check For Media Item Changes Job",
   sonarr_notification: "Sonarr Notification",
+  radarr_notification: "Radarr Notification",
 };
 
 const TEMPLATE_SUPPORTS_IMAGE: Partial<Record<keyof AppConfigNotificationTemplate, boolean>> = {
@@ -82,6 +83,7 @@ const TEMPLATE_SUPPORTS_IMAGE: Partial<Record<keyof AppConfigNotificationTemplat
   new_sets_available_for_ignored_items: true,
   check_for_media_item_changes_job: false,
   sonarr_notification: true,
+  radarr_notification: true,
 };
 
 const TEMPLATE_VAR_REGEX = /\{\{\s*([a-zA-Z0-9_.-]+)\s*\}\}/g;
@@ -92,6 +92,13 @@ export const defaultAppConfig = (): AppConfig =>
             "{{MediaItemTitle}}{{NewLine}}{{ImageName}}{{NewLine}}Set ID: {{SetID}}{{NewLine}}Reason:{{NewLine}}{{Reason}}{{NewLine}}{{Result}}",
           include_image: true,
         },
+        radarr_notification: {
+          enabled: true,
+          title: "Radarr Notification | {{ReasonTitle}}",
+          message:
+            "{{MediaItemTitle}}{{NewLine}}{{ImageName}}{{NewLine}}Set ID: {{SetID}}{{NewLine}}Reason:{{NewLine}}{{Reason}}{{NewLine}}{{Result}}",
+          include_image: true,
+        },
       },
     },
     sonarr_radarr: {
@@ -4,6 +4,9 @@ export interface AppConfig {
   media_server: AppConfigMediaServer; // Media server integration settings
   mediux: AppConfigMediux; // MediUX integration settings
   auto_download: AppConfigAutoDownload; // Auto-download settings
+  coverage_report?: AppConfigCoverageReport; // Scheduled library coverage report settings
+  drift_detection?: AppConfigDriftDetection; // Settings for checking that applied images are still on the media server
+  kometa?: AppConfigKometa; // Settings for exporting the saved sets for Kometa
   images: AppConfigImages;
   tmdb: AppConfigTMDB; // TMDB (The Movie Database) integration settings
   labels_and_tags: AppConfigLabelsAndTags; // Labels and tags management settings
@@ -29,6 +32,9 @@ export interface AppConfigMediaServer {
   user_id?: string; // User ID for accessing the media server (optional for Emby/Jellyfin)
   enable_sort_by_episode_added_date?: boolean; // Whether to enable sorting shows by latest episode added date (Plex only)
   enable_plex_event_listener?: boolean; // Whether to enable the Plex event listener for reapplying images on refresh (Plex only)
+  enable_emby_jellyfin_event_listener?: boolean; // Whether to enable the Emby/Jellyfin event listener for reapplying images that a refresh replaced (Emby/Jellyfin only)
+  enable_plex_webhook?: boolean; // Whether to handle Plex Pass webhooks sent to /api/plex/webhook (Plex only)
+  plex_webhook_token?: string; // Optional secret that Plex webhooks must send as the token query parameter (Plex only)
 }
 
 export interface AppConfigMediaServerLibrary {
@@ -41,35 +47,77 @@ export interface AppConfigMediaServerLibrary {
 export interface AppConfigMediux {
   api_token: string; // Authentication token for accessing MediUX services
   download_quality: string; // Preferred download quality (e.g., "original", "optimized")
+  enable_event_listener?: boolean; // Whether to listen for MediUX set updates and run the AutoDownload check for them
 }
 
 export interface AppConfigAutoDownload {
   enabled: boolean; // Whether auto-download is enabled
   cron: string; // Cron expression for scheduling auto-downloads
+  dry_run?: boolean; // Only log which images would be downloaded
+  require_approval?: boolean; // Stage the images that would be applied until they are approved
+}
+
+export interface AppConfigCoverageReport {
+  enabled: boolean; // Whether the coverage report is built on a schedule
+  cron?: string; // Cron expression for building the coverage report
+}
+
+export interface AppConfigDriftDetection {
+  enabled: boolean; // Whether the applied images are checked on a schedule
+  cron?: string; // Cron expression for the drift check
+  mode?: string; // "flag" or "reapply"
+  threshold?: number; // Number of differing perceptual hash bits above which an image counts as drifted
+}
+
+export interface AppConfigKometa {
+  enabled: boolean; // Whether the saved sets are exported for Kometa
+  asset_directory?: string; // Folder the images are saved to in the Kometa asset layout
+  metadata_directory?: string; // Folder a Kometa metadata file is written to for every library
+  cron?: string; // Cron expression for a full export
 }
 
 export interface AppConfigImages {
   cache_images: AppConfigCacheImages;
   save_images_locally: AppConfigSaveImagesLocally;
+  history?: AppConfigImageHistory;
 }
 
 export interface AppConfigCacheImages {
   enabled: boolean; // Whether to enable caching of images.
 }
 
+export interface AppConfigImageHistory {
+  enabled: boolean; // Whether to keep a copy of the media server image before it is replaced.
+  max_versions: number; // Number of replaced versions kept per image.
+}
+
 export interface AppConfigSaveImagesLocally {
   enabled: boolean; // Whether to save images locally.
   path: string; // Path to save images locally. If empty, images will be saved next to content.
   episode_naming_convention: string; // Naming convention for episode images.
+  naming_profile?: string; // File naming of the saved images: "plex", "kodi", "emby" or "custom".
+  naming_template?: AppConfigLocalImageNamingTemplate; // File names used by the "custom" naming profile.
+}
+
+export interface AppConfigLocalImageNamingTemplate {
+  poster?: string;
+  backdrop?: string;
+  season_poster?: string;
+  special_season_poster?: string;
+  titlecard?: string;
 }
 
 export interface AppConfigTMDB {
   api_token: string; // API key for accessing TMDB services
+  fallback_enabled?: boolean; // Whether TMDB images are offered as sets for items without MediUX sets
+  languages?: string[]; // ISO 639-1 codes of the poster languages, in order of preference
+  min_vote_average?: number; // Images with a lower TMDB vote average are left out
+  max_sets?: number; // Maximum number of TMDB sets offered per item
 }
 
 export interface AppConfigLabelsAndTags {
   applications: AppConfigLabelsAndTagsApplication[];
-  remove_overlay_label_only_on_poster_download: boolean; // Whether to remove the "Overlay" label from media items after downloading a poster image. This is to allow Kometa to reprocess the image and apply the overlays. This is a Plex exclusive feature and should be set to true if you have "Overlay" in your "Remove" list for the Plex application under "LabelsAndTags".
+  remove_overlay_label_only_on_poster_download: boolean; // Whether to remove the "Overlay" label from media items after downloading a poster image. This is to allow Kometa to reprocess the image and apply the overlays. This applies to Plex, Emby and Jellyfin and should be set to true if you have "Overlay" in your "Remove" list for one of these applications under "LabelsAndTags".
 }
 
 export interface AppConfigLabelsAndTagsApplication {
@@ -126,6 +174,7 @@ export interface AppConfigNotificationTemplate {
   new_sets_available_for_ignored_items: AppConfigNotificationCustomNotification;
   check_for_media_item_changes_job: AppConfigNotificationCustomNotification;
   sonarr_notification: AppConfigNotificationCustomNotification;
+  radarr_notification: AppConfigNotificationCustomNotification;
 }
 
 export interface NotificationTemplateVariablesCatalog {